@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/format"
 	"io/fs"
@@ -16,6 +17,7 @@ import (
 	"github.com/gaarutyunov/guix/internal/cache"
 	"github.com/gaarutyunov/guix/pkg/codegen"
 	"github.com/gaarutyunov/guix/pkg/parser"
+	"github.com/gaarutyunov/guix/pkg/visitors"
 	"github.com/urfave/cli/v2"
 )
 
@@ -56,6 +58,22 @@ func main() {
 						Name:  "verbose-logs",
 						Usage: "Generate verbose logging statements in code (for debugging)",
 					},
+					&cli.BoolFlag{
+						Name:  "ssr",
+						Usage: "Also generate *_ssr_gen.go files with RenderHTML for server-side rendering",
+					},
+					&cli.BoolFlag{
+						Name:  "bench",
+						Usage: "Also generate *_bench_gen_test.go render benchmarks (implies --ssr)",
+					},
+					&cli.BoolFlag{
+						Name:  "optimize-size",
+						Usage: "Prefer strconv over fmt in generated code to reduce WASM binary size",
+					},
+					&cli.BoolFlag{
+						Name:  "line-directives",
+						Usage: "Write //line directives so compiler errors point at .gx source lines",
+					},
 				},
 				Action: runGenerate,
 			},
@@ -72,6 +90,12 @@ func main() {
 				},
 				Action: runClean,
 			},
+			{
+				Name:      "inspect",
+				Usage:     "Print the AST and semantic diagnostics of .gx files without generating code",
+				ArgsUsage: "<file.gx>...",
+				Action:    runInspect,
+			},
 		},
 	}
 
@@ -123,6 +147,10 @@ func runGenerate(c *cli.Context) error {
 	lazy := c.Bool("lazy")
 	verbose := c.Bool("verbose")
 	verboseLogs := c.Bool("verbose-logs")
+	bench := c.Bool("bench")
+	ssr := c.Bool("ssr") || bench
+	optimizeSize := c.Bool("optimize-size")
+	lineDirectives := c.Bool("line-directives")
 
 	// Load or create cache
 	var genCache *cache.Cache
@@ -143,7 +171,7 @@ func runGenerate(c *cli.Context) error {
 	}
 
 	// Generate all files initially
-	if err := generateAll(path, genCache, verbose, verboseLogs); err != nil {
+	if err := generateAll(path, genCache, verbose, verboseLogs, ssr, bench, optimizeSize, lineDirectives); err != nil {
 		return err
 	}
 
@@ -156,13 +184,13 @@ func runGenerate(c *cli.Context) error {
 
 	// Watch mode
 	if watchMode {
-		return watchFiles(path, genCache, verbose, verboseLogs, lazy)
+		return watchFiles(path, genCache, verbose, verboseLogs, ssr, bench, optimizeSize, lineDirectives, lazy)
 	}
 
 	return nil
 }
 
-func generateAll(root string, genCache *cache.Cache, verbose bool, verboseLogs bool) error {
+func generateAll(root string, genCache *cache.Cache, verbose bool, verboseLogs bool, ssr bool, bench bool, optimizeSize bool, lineDirectives bool) error {
 	p, err := parser.New()
 	if err != nil {
 		return fmt.Errorf("failed to create parser: %w", err)
@@ -201,7 +229,12 @@ func generateAll(root string, genCache *cache.Cache, verbose bool, verboseLogs b
 			}
 		}
 
-		if err := generateFile(path, p, verbose, verboseLogs); err != nil {
+		if err := generateFile(path, p, verbose, verboseLogs, ssr, bench, optimizeSize, lineDirectives); err != nil {
+			// Syntax errors already start with the file and position
+			var parseErr *parser.ParseError
+			if errors.As(err, &parseErr) {
+				return err
+			}
 			return fmt.Errorf("failed to generate %s: %w", path, err)
 		}
 
@@ -241,7 +274,7 @@ func formatFile(path string) error {
 	return nil
 }
 
-func generateFile(srcPath string, p *parser.Parser, verbose bool, verboseLogs bool) error {
+func generateFile(srcPath string, p *parser.Parser, verbose bool, verboseLogs bool, ssr bool, bench bool, optimizeSize bool, lineDirectives bool) error {
 	if verbose {
 		log.Printf("Generating %s", srcPath)
 	}
@@ -266,6 +299,8 @@ func generateFile(srcPath string, p *parser.Parser, verbose bool, verboseLogs bo
 	// Generate Go code
 	gen := codegen.New(file.Package)
 	gen.SetVerbose(verboseLogs)
+	gen.SetOptimizeSize(optimizeSize)
+	gen.SetEmitLineDirectives(lineDirectives)
 	output, err := gen.Generate(file)
 	if err != nil {
 		return err
@@ -286,10 +321,52 @@ func generateFile(srcPath string, p *parser.Parser, verbose bool, verboseLogs bo
 		log.Printf("Generated %s", outPath)
 	}
 
+	if !ssr {
+		return nil
+	}
+
+	// Generate the server-side rendering variant
+	ssrOutput, err := codegen.New(file.Package).GenerateSSR(file)
+	if err != nil {
+		return err
+	}
+
+	ssrPath := strings.TrimSuffix(srcPath, guixExt) + "_ssr_gen.go"
+	if err := os.WriteFile(ssrPath, ssrOutput, 0644); err != nil {
+		return err
+	}
+
+	if verbose {
+		log.Printf("Generated %s", ssrPath)
+	}
+
+	if !bench {
+		return nil
+	}
+
+	// Generate render benchmarks for the server-side rendering variant
+	benchOutput, err := codegen.New(file.Package).GenerateBenchmarks(file)
+	if err != nil {
+		return err
+	}
+
+	benchPath := strings.TrimSuffix(srcPath, guixExt) + "_bench_gen_test.go"
+	if err := os.WriteFile(benchPath, benchOutput, 0644); err != nil {
+		return err
+	}
+
+	if err := formatFile(benchPath); err != nil {
+		log.Printf("Warning: failed to format %s: %v", benchPath, err)
+	}
+
+	if verbose {
+		log.Printf("Generated %s", benchPath)
+	}
+
 	return nil
 }
 
-func watchFiles(root string, genCache *cache.Cache, verbose bool, verboseLogs bool, lazy bool) error {
+func watchFiles(root string, genCache *cache.Cache, verbose bool, verboseLogs bool, ssr bool, bench bool, optimizeSize bool, lineDirectives bool, lazy bool) error {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return fmt.Errorf("failed to create watcher: %w", err)
@@ -357,7 +434,7 @@ func watchFiles(root string, genCache *cache.Cache, verbose bool, verboseLogs bo
 
 					log.Printf("File changed: %s", event.Name)
 
-					if err := generateFile(event.Name, p, verbose, verboseLogs); err != nil {
+					if err := generateFile(event.Name, p, verbose, verboseLogs, ssr, bench, optimizeSize, lineDirectives); err != nil {
 						log.Printf("Error generating %s: %v", event.Name, err)
 					} else {
 						log.Printf("Successfully regenerated %s", event.Name)
@@ -372,12 +449,14 @@ func watchFiles(root string, genCache *cache.Cache, verbose bool, verboseLogs bo
 						}
 					}
 				} else if event.Op&fsnotify.Remove == fsnotify.Remove {
-					// Remove generated file
-					outPath := strings.TrimSuffix(event.Name, guixExt) + "_gen.go"
-					if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
-						log.Printf("Warning: failed to remove %s: %v", outPath, err)
-					} else {
-						log.Printf("Removed %s", outPath)
+					// Remove generated files
+					base := strings.TrimSuffix(event.Name, guixExt)
+					for _, outPath := range []string{base + "_gen.go", base + "_ssr_gen.go", base + "_bench_gen_test.go"} {
+						if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
+							log.Printf("Warning: failed to remove %s: %v", outPath, err)
+						} else if err == nil {
+							log.Printf("Removed %s", outPath)
+						}
 					}
 
 					// Remove from cache
@@ -410,7 +489,7 @@ func runClean(c *cli.Context) error {
 		}
 
 		// Remove generated files
-		if strings.HasSuffix(p, "_gen.go") {
+		if strings.HasSuffix(p, "_gen.go") || strings.HasSuffix(p, "_gen_test.go") {
 			if err := os.Remove(p); err != nil {
 				log.Printf("Warning: failed to remove %s: %v", p, err)
 			} else {
@@ -439,3 +518,35 @@ func runClean(c *cli.Context) error {
 	log.Printf("Cleaned %d generated files", count)
 	return nil
 }
+
+func runInspect(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return fmt.Errorf("no .gx files given")
+	}
+
+	var errorCount int
+	for _, path := range c.Args().Slice() {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		dump, diags, err := visitors.Analyze(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		fmt.Print(dump)
+		for _, diag := range diags {
+			fmt.Fprintf(os.Stderr, "%s:%s\n", path, diag)
+			if diag.Severity == visitors.SeverityError {
+				errorCount++
+			}
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%d semantic errors", errorCount)
+	}
+	return nil
+}
@@ -3,12 +3,11 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
-	"syscall/js"
 	"time"
 
+	"github.com/gaarutyunov/guix/pkg/runtime"
 	"github.com/gaarutyunov/guix/pkg/runtime/chart"
 )
 
@@ -31,69 +30,18 @@ func FetchBinanceDataWithEndTime(symbol, interval string, limit int, endTime int
 		url += fmt.Sprintf("&endTime=%d", endTime)
 	}
 
-	// Create a promise to fetch data
-	promise := js.Global().Call("fetch", url)
-
-	// Wait for the promise to resolve
-	resultChan := make(chan js.Value, 1)
-	errorChan := make(chan error, 1)
-
-	onSuccess := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		response := args[0]
-
-		// Check if response is OK
-		if !response.Get("ok").Bool() {
-			errorChan <- fmt.Errorf("HTTP error: %d", response.Get("status").Int())
-			return nil
-		}
-
-		// Parse JSON
-		jsonPromise := response.Call("json")
-		onJSONSuccess := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			resultChan <- args[0]
-			return nil
-		})
-		defer onJSONSuccess.Release()
-
-		onJSONError := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			errorChan <- fmt.Errorf("JSON parse error")
-			return nil
-		})
-		defer onJSONError.Release()
-
-		jsonPromise.Call("then", onJSONSuccess).Call("catch", onJSONError)
-		return nil
-	})
-	defer onSuccess.Release()
-
-	onError := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		// CORS or network error - silently fail and use fallback
-		errorChan <- fmt.Errorf("network error")
-		return nil
-	})
-	defer onError.Release()
-
-	promise.Call("then", onSuccess).Call("catch", onError)
-
-	// Wait for result or error with timeout (reduced to 2s since CORS fails immediately)
-	select {
-	case result := <-resultChan:
-		return parseBinanceKlines(result)
-	case err := <-errorChan:
+	// CORS fails immediately, so a short timeout only bounds a slow API
+	resp, err := runtime.Fetch(url, runtime.FetchOptions{Timeout: 2 * time.Second})
+	if err != nil {
 		return nil, err
-	case <-time.After(2 * time.Second):
-		return nil, fmt.Errorf("timeout")
 	}
+	return parseBinanceKlines(resp)
 }
 
-// parseBinanceKlines converts Binance kline data to OHLCV format
-func parseBinanceKlines(data js.Value) ([]chart.OHLCV, error) {
-	// Convert js.Value to JSON string
-	jsonStr := js.Global().Get("JSON").Call("stringify", data).String()
-
-	// Parse JSON
+// parseBinanceKlines converts a Binance kline response to OHLCV format
+func parseBinanceKlines(resp *runtime.Response) ([]chart.OHLCV, error) {
 	var rawKlines [][]interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &rawKlines); err != nil {
+	if err := resp.JSON(&rawKlines); err != nil {
 		return nil, fmt.Errorf("failed to parse kline data: %w", err)
 	}
 
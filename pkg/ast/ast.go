@@ -2,57 +2,91 @@
 package ast
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
 )
 
 // File represents a complete .gx file
+// Type definitions, components and methods may appear in any order after
+// the imports.
 type File struct {
 	Pos        lexer.Position
 	Package    string       `"package" @Ident`
-	Imports    []*Import    `@@*`
-	Types      []*TypeDef   `@@*`
-	Components []*Component `@@*`
-	Methods    []*Method    `@@*`
+	Imports    []*Import    `("import" ("(" @@* ")" | @@))*`
+	Types      []*TypeDef   `( @@`
+	Components []*Component `| @@`
+	Methods    []*Method    `| @@ )*`
 }
 
 // TypeDef represents a type definition
 type TypeDef struct {
 	Pos    lexer.Position
+	JSON   *JSONTags   `@@?` // @json adds json tags to the struct fields
 	Name   string      `"type" @Ident`
 	Struct *StructType `@@`
 }
 
-// StructType represents a struct type definition
+// JSONTags is the @json directive of a type definition. Fields are named
+// in camelCase, or in snake_case with @json(snake).
+// Example: @json(snake) type User struct { UserID int }
+type JSONTags struct {
+	Pos   lexer.Position
+	Style string `"@json" ("(" @("camel" | "snake") ")")?`
+}
+
+// StructType represents a struct type definition, named or inline
+// Example: struct { X, Y float64; Label string }
 type StructType struct {
 	Pos    lexer.Position
-	Fields []*StructField `"struct" "{" @@* "}"`
+	Fields []*StructField `"struct" "{" (@@ ";"?)* "}"`
+}
+
+// MapType represents a map type
+// Example: map[string]int
+type MapType struct {
+	Pos   lexer.Position
+	Key   *Type `"map" "[" @@ "]"`
+	Value *Type `@@`
 }
 
 // StructField represents a field in a struct
+// Fields sharing a type list extra names in MoreNames: X, Y float64
 type StructField struct {
-	Pos  lexer.Position
-	Name string `@Ident`
-	Type *Type  `@@`
+	Pos       lexer.Position
+	Name      string   `@Ident`
+	MoreNames []string `("," @Ident)*`
+	Type      *Type    `@@`
+}
+
+// Names returns all names declared by the field
+func (f *StructField) Names() []string {
+	return append([]string{f.Name}, f.MoreNames...)
 }
 
-// Import represents an import statement
-// Supports both single imports (import "path") and grouped imports (import ( "path1" "path2" ))
+// Import represents an imported package with an optional alias
+// Single imports (import r "path") and each entry of a grouped import
+// (import ( "path1"; r "path2" )) become one Import. Path keeps its quotes.
 type Import struct {
 	Pos   lexer.Position
-	Paths []string `"import" ( "(" @String+ ")" | @String )`
+	Alias string `@(Ident | ".")?`
+	Path  string `@String ";"?`
 }
 
 // Component represents a component or function definition
 // If Results is non-empty, it's a component function that returns Component interface
 // If Results is empty, it's a regular helper function
 type Component struct {
-	Pos       lexer.Position
-	AutoProps bool         `@"@props"?`
-	Name      string       `"func" @Ident`
-	Params    []*Parameter `"(" (@@ ("," @@)*)? ")"`
-	Results   []*Type      `("(" (@@ ("," @@)*)? ")")?`
-	Body      *Body        `@@`
+	Pos         lexer.Position
+	AutoProps   bool         `@"@props"?`
+	Memo        bool         `@"@memo"?`              // @memo skips re-rendering while primitive props are unchanged
+	KeysChannel string       `("@keys" "->" @Ident)?` // @keys -> ch sends key state changes to ch
+	Name        string       `"func" @Ident`
+	Params      []*Parameter `"(" (@@ ("," @@)*)? ")"`
+	Results     []*Type      `("(" (@@ ("," @@)*)? ")")?`
+	Body        *Body        `@@`
 }
 
 // Method represents a method with a receiver (e.g., func (c *MyType) String() string)
@@ -76,22 +110,33 @@ type Receiver struct {
 // Parameter represents a component parameter with name and type
 type Parameter struct {
 	Pos        lexer.Position
+	Required   bool   `@"@required"?` // @required takes the prop positionally in New<Name>Required
 	Name       string `@Ident`
 	IsVariadic bool   `@"..."?`
 	Type       *Type  `@@`
 }
 
+// IsChildren returns true if the parameter receives the nodes nested inside
+// the component's element, like children in Card(children Children)
+func (p *Parameter) IsChildren() bool {
+	t := p.Type
+	return !p.IsVariadic && t != nil && t.Name == "Children" && !t.IsChannel && !t.IsChan &&
+		!t.IsSlice && !t.IsPointer && t.Generic == nil
+}
+
 // Type represents a type specification
 type Type struct {
 	Pos         lexer.Position
-	IsChannel   bool   `@("<-")?`
-	IsChan      bool   `@("chan")?`
-	IsSlice     bool   `@("[" "]")?`
-	IsPointer   bool   `@("*")?`
-	IsInterface bool   `@("interface" "{" "}")?` // Empty interface type
-	Name        string `@Ident?`
-	Generic     *Type  `("[" @@ "]")?`
-	IsFunc      bool   `@("func")?`
+	IsChannel   bool        `@("<-")?`
+	IsChan      bool        `@("chan")?`
+	IsSlice     bool        `@("[" "]")?`
+	IsPointer   bool        `@("*")?`
+	IsInterface bool        `@("interface" "{" "}")?` // Empty interface type
+	Struct      *StructType `@@?`                     // Anonymous struct type
+	Map         *MapType    `@@?`                     // Map type
+	Name        string      `@Ident?`
+	Generic     *Type       `("[" @@ "]")?`
+	IsFunc      bool        `@("func")?`
 	FuncParams  []*Type
 	FuncResults []*Type
 }
@@ -99,7 +144,8 @@ type Type struct {
 // Body represents a component body with optional variable declarations, statements, and UI tree
 type Body struct {
 	Pos        lexer.Position
-	VarDecls   []*VarDecl       `"{" @@*`
+	ConstDecls []*ConstDecl     `"{" @@*`
+	VarDecls   []*VarDecl       `@@*`
 	Statements []*BodyStatement `@@*`
 	Children   []*Node          `@@* "}"`
 }
@@ -112,6 +158,7 @@ type BodyStatement struct {
 	VarDecl    *VarDecl        `@@`
 	AssignStmt *AssignmentStmt `| @@`
 	Return     *Return         `| @@`
+	Branch     *BranchStmt     `| @@` // break or continue
 	If         *IfStmt         `| @@`
 	For        *ForLoop        `| @@`
 	Switch     *SwitchStmt     `| @@` // Switch statement
@@ -129,6 +176,7 @@ type Node struct {
 	IfExpr      *IfExpr      `| @@`
 	ForLoop     *ForLoop     `| @@`
 	ChannelRecv *ChannelRecv `| @@`
+	Slot        *Slot        `| @@`
 	Element     *Element     `| @@`
 	ExprStmt    *ExprStmt    `| @@`
 }
@@ -151,10 +199,34 @@ type Element struct {
 
 // Prop represents a property or event handler
 // Props are function calls like Class("value") or Background(0.1, 0.1, 0.15, 1.0)
+// A bare identifier without parentheses passes a value as-is, e.g. ErrorText(errChannel)
 type Prop struct {
-	Pos  lexer.Position
-	Name string  `@Ident`
-	Args []*Expr `"(" (@@ ("," @@)*)? ")"`
+	Pos       lexer.Position
+	Name      string  `@Ident`
+	HasParens bool    `(@"("`
+	Args      []*Expr `(@@ ("," @@)*)? ")")?`
+}
+
+// IsBare returns true if the prop is a bare identifier rather than a call
+func (p *Prop) IsBare() bool {
+	return !p.HasParens && len(p.Args) == 0
+}
+
+// IsBind returns true if the prop is a two-way binding like Bind(state.Display)
+func (p *Prop) IsBind() bool {
+	return p.Name == "Bind" && len(p.Args) == 1
+}
+
+// BindTarget returns the bound expression with an optional leading & removed
+func (p *Prop) BindTarget() *Expr {
+	if !p.IsBind() {
+		return nil
+	}
+	target := p.Args[0]
+	if target.Left != nil && target.Left.Unary != nil && target.Left.Unary.Op == "&" && len(target.BinOps) == 0 {
+		return &Expr{Pos: target.Pos, Left: target.Left.Unary.Right}
+	}
+	return target
 }
 
 // Expr represents an expression with optional binary operations
@@ -162,6 +234,16 @@ type Expr struct {
 	Pos    lexer.Position
 	Left   *Primary    `@@`
 	BinOps []*BinaryOp `@@*`
+	Cond   *CondExpr   `@@?`
+}
+
+// CondExpr represents the branches of a conditional expression; the
+// expression before it is the condition
+// Example: active ? "on" : "off"
+type CondExpr struct {
+	Pos  lexer.Position
+	Then *Expr `"?" @@`
+	Else *Expr `":" @@`
 }
 
 // BinaryOp represents a binary operation (operator and right operand)
@@ -202,19 +284,42 @@ type SliceExpr struct {
 	High *Expr `":" @@?`
 }
 
-// CompositeLit represents a composite literal (struct initialization)
+// CompositeLit represents a composite literal: a struct initialization
+// with keyed fields, or a slice or map literal
 // Example: CalculatorState{Display: "0", PreviousValue: 0}
+// Example: []Point{{X: 1, Y: 2}, origin}
+// Example: map[string]int{"a": 1}
 type CompositeLit struct {
 	Pos      lexer.Position
-	Type     string      `@Ident`
-	Elements []*KeyValue `"{" (@@ ("," @@)*)? ","? "}"`
+	SliceOf  *Type       `(  ( "[" "]" @@`
+	Map      *MapType    `   | @@ )`
+	Values   []*LitElem  `   "{" (@@ ("," @@)*)? ","? "}"`
+	Type     string      `| @Ident`
+	Elements []*KeyValue `  "{" (@@ ("," @@)*)? ","? "}" )`
 }
 
-// KeyValue represents a key-value pair in a composite literal
+// KeyValue represents a key-value pair in a composite literal, keyed by a
+// field name or, for a map type such as CSS, a literal
+// Example: CSS{"margin-top": "4px"}
 type KeyValue struct {
-	Pos   lexer.Position
-	Key   string `@Ident ":"`
-	Value *Expr  `@@`
+	Pos    lexer.Position
+	Key    string   `( @Ident`
+	LitKey *Literal `| @@ ) ":"`
+	Value  *Expr    `@@`
+}
+
+// LitElem represents an element of a slice or map literal. Map entries are
+// keyed by an identifier or literal. A value in braces is a composite
+// literal of the element type with the type elided, whose elements are
+// struct fields keyed by name, or slice or map elements.
+// Example: "origin": {X: 0, Y: 0}
+type LitElem struct {
+	Pos      lexer.Position
+	Key      string     `( @Ident ":"`
+	LitKey   *Literal   `| @@ ":" )?`
+	Elided   bool       `(  @"{"`
+	Elements []*LitElem `   (@@ ("," @@)*)? ","? "}"`
+	Value    *Expr      `| @@ )`
 }
 
 // UnaryExpr represents a unary expression (e.g., !x, -x)
@@ -261,15 +366,17 @@ type Call struct {
 }
 
 // MakeCall represents a make() function call with type argument
-// Example: make(chan int, 10) or make([]string, 0)
+// Example: make(chan int, 10), make([]string, 0) or make(map[string]int)
 type MakeCall struct {
 	Pos       lexer.Position
-	Func      string `@"make"`
-	ChanType  *Type  `"(" ("chan" @@`
-	ChanSize  *Expr  `("," @@)? ")"`
-	SliceType *Type  `| "[" "]" @@`
-	SliceLen  *Expr  `"," @@`
-	SliceCap  *Expr  `("," @@)? ")")`
+	Func      string   `@"make"`
+	ChanType  *Type    `"(" ("chan" @@`
+	ChanSize  *Expr    `("," @@)? ")"`
+	SliceType *Type    `| "[" "]" @@`
+	SliceLen  *Expr    `"," @@`
+	SliceCap  *Expr    `("," @@)? ")"`
+	MapType   *MapType `| @@`
+	MapSize   *Expr    `("," @@)? ")")`
 }
 
 // FuncLit represents a function literal
@@ -290,10 +397,12 @@ type FuncBody struct {
 // Statement represents a statement in a function body
 type Statement struct {
 	Pos        lexer.Position
-	CallStmt   *CallStmt       `@@` // Function call statement
+	ConstDecl  *ConstDecl      `@@`
+	CallStmt   *CallStmt       `| @@` // Function call statement
 	VarDecl    *VarDecl        `| @@`
 	AssignStmt *AssignmentStmt `| @@` // Assignment statement
 	Return     *Return         `| @@`
+	Branch     *BranchStmt     `| @@` // break or continue
 	If         *IfStmt         `| @@`
 	For        *ForLoop        `| @@`
 	Switch     *SwitchStmt     `| @@` // Switch statement
@@ -312,60 +421,77 @@ var RuntimeComponents = map[string]bool{
 	"Table": true, "Tr": true, "Td": true, "Th": true, "Thead": true, "Tbody": true,
 	"Header": true, "Footer": true, "Nav": true, "Main": true, "Section": true, "Article": true,
 	"Aside": true, "Select": true, "Option": true, "Textarea": true, "Label": true,
+	"Output": true, "Progress": true, "Meter": true, "ErrorText": true,
+	// Layout
+	"HStack": true, "VStack": true, "Grid": true,
+	"Gap": true, "Align": true, "Justify": true, "Columns": true,
+	// Widgets
+	"ListBox": true, "Items": true, "Selected": true, "EmptyState": true, "Image": true,
+	"Accordion": true, "Active": true, "Panel": true, "Tooltip": true, "Placement": true,
+	"Sortable": true, "SortableItems": true, "OnReorder": true, "CopyButton": true,
+	"Portal": true,
 	// WebGPU Canvas
 	"Canvas": true, "GPUScene": true,
 	// Runtime helpers
 	"Fragment": true, "Text": true, "El": true,
 	// Props and Attributes
 	"Class": true, "ID": true, "ClassAttr": true, "Href": true, "Src": true,
+	"Classes": true, "ClassIf": true,
 	"Type": true, "Placeholder": true, "Value": true, "Disabled": true, "Checked": true,
 	"Name": true, "For": true, "Alt": true, "Title": true, "Style": true,
 	"Min": true, "Max": true, "Step": true, "TabIndex": true,
+	"Low": true, "High": true, "Optimum": true, "Bind": true, "Key": true, "Role": true,
+	"SrcSet": true, "Sizes": true, "Lazy": true,
 	// Event Handlers
 	"OnClick": true, "OnInput": true, "OnChange": true, "OnSubmit": true,
 	"OnKeyDown": true, "OnKeyUp": true, "OnKeyPress": true,
 	"OnMouseOver": true, "OnMouseOut": true, "OnMouseEnter": true, "OnMouseLeave": true,
-	"OnFocus": true, "OnBlur": true,
+	"OnWheel": true, "OnScroll": true, "OnContextMenu": true,
+	"OnFocus": true, "OnBlur": true, "OnLoad": true,
+	// Lifecycle hooks
+	"OnMount": true, "OnBeforeUnmount": true,
 	// WebGPU Elements
-	"Scene": true, "Mesh": true, "Group": true,
+	"Scene": true, "Mesh": true, "InstancedMesh": true, "Group": true,
 	"PerspectiveCamera": true, "OrthographicCamera": true,
-	"AmbientLight": true, "DirectionalLight": true, "PointLight": true,
+	"AmbientLight": true, "DirectionalLight": true, "PointLight": true, "SpotLight": true,
+	"ParticleSystem": true,
 	// Chart Elements
 	"Chart": true, "XAxis": true, "YAxis": true,
 	"CandlestickSeries": true, "LineSeries": true,
 	// WebGPU Properties
 	"Position": true, "Rotation": true, "ScaleValue": true,
-	"Color": true, "Metalness": true, "Roughness": true,
-	"Intensity": true, "FOV": true, "Near": true, "Far": true,
+	"Color": true, "Metalness": true, "Roughness": true, "Transparent": true,
+	"Intensity": true, "Direction": true, "ConeAngle": true, "FOV": true, "Near": true, "Far": true,
+	"Count": true, "Shader": true, "ParticleSize": true,
 	"LookAtPos": true, "Background": true,
 	"Width": true, "Height": true,
 	"GeometryProp": true, "MaterialProp": true, "GPURenderUpdate": true,
 	// Chart Properties
-	"ChartBackground": true, "ChartPadding": true, "ChartInteractive": true,
-	"AxisPosition": true, "TimeScale": true, "GridLines": true, "GridColor": true,
+	"ChartBackground": true, "ChartPadding": true, "ChartInteractive": true, "ChartDepth": true,
+	"ChartIndexedLines": true, "AxisPosition": true, "TimeScale": true, "GridLines": true, "GridColor": true,
 	"ChartData": true,
 	"UpColor":   true, "DownColor": true, "WickColor": true, "BarWidth": true,
-	"StrokeColor": true, "StrokeWidth": true, "FillColor": true, "FillEnabled": true,
+	"StrokeColor": true, "StrokeWidth": true, "FillColor": true, "FillEnabled": true, "ZIndex": true,
+	"StrokeStyle": true, "DashPattern": true,
 	// WebGPU Geometry Constructors
 	"NewBoxGeometry": true, "NewSphereGeometry": true, "NewPlaneGeometry": true,
 	// WebGPU Material Constructors
 	"StandardMaterial": true,
 }
 
-// NonRuntimeIdent is a custom type that only captures identifiers that are NOT runtime components
+// NonRuntimeIdent is a custom type that only matches identifiers that are NOT runtime components
 type NonRuntimeIdent string
 
-// Capture implements participle's Capture interface to reject runtime component names
-func (n *NonRuntimeIdent) Capture(values []string) error {
-	if len(values) == 0 {
-		return nil
-	}
-	ident := values[0]
-	if RuntimeComponents[ident] {
-		// Reject this parse - it's a runtime component
+// Parse implements participle's Parseable interface. A runtime component name
+// is rejected before it is consumed, so Div(...) { ... } is never parsed as a
+// call statement whose arguments, however long, would exhaust the lookahead.
+func (n *NonRuntimeIdent) Parse(lex *lexer.PeekingLexer) error {
+	tok := lex.Peek()
+	if RuntimeComponents[tok.Value] {
 		return participle.NextMatch
 	}
-	*n = NonRuntimeIdent(ident)
+	lex.Next()
+	*n = NonRuntimeIdent(tok.Value)
 	return nil
 }
 
@@ -374,9 +500,9 @@ func (n *NonRuntimeIdent) Capture(values []string) error {
 // Runtime component names (Div, Span, etc.) are excluded via NonRuntimeIdent
 type CallStmt struct {
 	Pos    lexer.Position
-	Base   NonRuntimeIdent `@Ident`
+	Base   NonRuntimeIdent `(?= Ident) @@`
 	Fields []string        `("." @Ident)*`
-	Args   []*Expr         `"(" (@@ ("," @@)*)? ")"` // Required parentheses
+	Args   []*Expr         `"(" (@@ ("," @@)*)? ")" (?! "{")` // Required parentheses; a "{" after them starts a component's children
 }
 
 // IsRuntimeComponent returns true if the identifier is a known runtime component
@@ -390,9 +516,16 @@ type AssignmentStmt struct {
 	Pos    lexer.Position
 	Base   string   `@Ident`
 	Fields []string `("." @Ident)*`
-	Index  *Expr    `("[" @@ "]")?`                                    // Optional index for array/slice assignment
-	Op     string   `@("<-" | ":=" | "=" | "+=" | "-=" | "*=" | "/=")` // Required operator
-	Right  *Expr    `@@`                                               // Required right side
+	Index  *Expr    `("[" @@ "]")?`                                      // Optional index for array/slice assignment
+	IncDec string   `( @("++" | "--")`                                   // i++ or i--, without operator and right side
+	Op     string   `| @("<-" | ":=" | "=" | "+=" | "-=" | "*=" | "/=")` // Operator
+	Right  *Expr    `@@ )`                                               // Right side
+}
+
+// BranchStmt represents a break or continue statement inside a for loop
+type BranchStmt struct {
+	Pos lexer.Position
+	Tok string `@("break" | "continue")`
 }
 
 // Assignment represents an assignment statement (DEPRECATED - use ExpressionStmt)
@@ -426,7 +559,7 @@ type CaseClause struct {
 	Pos        lexer.Position
 	Values     []*Expr      `("case" @@ ("," @@)*`
 	Statements []*Statement `":" @@*)`
-	Default    bool         `| ("default"`
+	Default    bool         `| (@"default"`
 	DefStmts   []*Statement `":" @@*)`
 }
 
@@ -442,7 +575,7 @@ type CommClause struct {
 	Pos        lexer.Position
 	Comm       *CommCase    `("case" @@`
 	Statements []*Statement `":" @@*)`
-	Default    bool         `| ("default"`
+	Default    bool         `| (@"default"`
 	DefStmts   []*Statement `":" @@*)`
 }
 
@@ -492,11 +625,28 @@ type Else struct {
 
 // VarDecl represents a variable declaration
 // Example: counter := make(chan int) or n, err := strconv.Atoi(value)
+// A computed declaration (computed total := <-a + <-b) is recomputed
+// whenever one of the channels it receives from sends a value.
 type VarDecl struct {
-	Pos    lexer.Position
-	Names  []string `@Ident ("," @Ident)*`
-	Op     string   `@":="`
-	Values []*Expr  `@@ ("," @@)*`
+	Pos      lexer.Position
+	Computed bool     `(@"computed" (?= Ident))?`
+	Names    []string `@Ident ("," @Ident)*`
+	Op       string   `@":="`
+	Values   []*Expr  `@@ ("," @@)*`
+}
+
+// ConstDecl represents a constant declaration, single or grouped
+// Example: const maxItems = 10 or const ( a = 1 b = "x" )
+type ConstDecl struct {
+	Pos   lexer.Position
+	Specs []*ConstSpec `"const" ( "(" @@* ")" | @@ )`
+}
+
+// ConstSpec represents a single name = value pair of a const declaration
+type ConstSpec struct {
+	Pos   lexer.Position
+	Name  string `@Ident`
+	Value *Expr  `"=" @@`
 }
 
 // TextNode represents plain text
@@ -513,19 +663,47 @@ type Template struct {
 }
 
 // Fragment represents part of a template (text or expression)
+// An expression may end with a format spec: `{price:.2f}`
 type Fragment struct {
-	Pos  lexer.Position
-	Text string `@TemplateText`
-	Expr *Expr  `| ("{" @@ "}")`
+	Pos    lexer.Position
+	Text   string     `@TemplateText`
+	Expr   *Expr      `| ("{" @@`
+	Format FormatSpec `(":" @("-" | "+" | FormatFlag | "." | Number | Ident)+)? "}")`
+}
+
+// FormatSpec is the format of an interpolated value: a fmt verb with its
+// flags, width and precision, like .2f or 05d, or time or date for a Unix
+// timestamp. It is empty for the default formatting.
+type FormatSpec string
+
+// Capture implements participle's Capture interface, joining the tokens
+// of the spec: .2f is lexed as ".", "2" and "f"
+func (f *FormatSpec) Capture(values []string) error {
+	*f += FormatSpec(strings.Join(values, ""))
+	return nil
+}
+
+// formatVerb matches a fmt verb with optional flags, width and precision
+var formatVerb = regexp.MustCompile(`^[-+# 0]*[0-9]*(\.[0-9]+)?[bcdeEfFgGoOqstTvxXU]$`)
+
+// IsTime returns true if the spec formats a Unix timestamp
+func (f FormatSpec) IsTime() bool {
+	return f == "time" || f == "date"
+}
+
+// Valid returns true if the spec is empty, a time spec or a fmt verb
+func (f FormatSpec) Valid() bool {
+	return f == "" || f.IsTime() || formatVerb.MatchString(string(f))
 }
 
 // IfExpr represents an if expression (not statement)
-// Example: if cond { a } else { b }
+// Example: if cond { a } else if other { b } else { c }
 type IfExpr struct {
 	Pos       lexer.Position
-	Cond      *Expr `"if" @@`
-	TrueBody  *Body `@@`
-	FalseBody *Body `("else" @@)?`
+	Cond      *Expr   `"if" @@`
+	TrueBody  *Body   `@@`
+	ElseIf    *IfExpr `("else" (@@` // else if chain
+	FalseBody *Body   `| @@))?`     // final else
 }
 
 // ForLoop represents a for loop (either range-based or C-style)
@@ -537,10 +715,26 @@ type ForLoop struct {
 	Range *Expr  `":=" "range" @@`
 	Body  *Body  `@@)`
 	// C-style for loop: for init; cond; post { body }
+	// In the UI tree, the body's children are rendered on each iteration
 	Init  *VarDecl        `| "for" (@@`
 	Cond  *Expr           `";" @@`
 	Post  *AssignmentStmt `";" @@`
-	CBody *FuncBody       `@@)`
+	CBody *Body           `@@)`
+}
+
+// LoopBody returns the body of a range or C-style loop
+func (f *ForLoop) LoopBody() *Body {
+	if f.Range != nil {
+		return f.Body
+	}
+	return f.CBody
+}
+
+// Slot represents the place a component renders the children passed to it
+// Example: {children}
+type Slot struct {
+	Pos  lexer.Position
+	Name string `"{" @Ident "}"`
 }
 
 // ChannelRecv represents a channel receive operation
@@ -581,6 +775,7 @@ func (n *BodyStatement) Accept(v Visitor) interface{}  { return v.VisitBodyState
 func (n *Statement) Accept(v Visitor) interface{}      { return v.VisitStatement(n) }
 func (n *CallStmt) Accept(v Visitor) interface{}       { return v.VisitCallStmt(n) }
 func (n *AssignmentStmt) Accept(v Visitor) interface{} { return v.VisitAssignmentStmt(n) }
+func (n *ConstDecl) Accept(v Visitor) interface{}      { return v.VisitConstDecl(n) }
 func (n *VarDecl) Accept(v Visitor) interface{}        { return v.VisitVarDecl(n) }
 func (n *Assignment) Accept(v Visitor) interface{}     { return v.VisitAssignment(n) }
 func (n *GoStmt) Accept(v Visitor) interface{}         { return v.VisitGoStmt(n) }
@@ -592,6 +787,7 @@ func (n *CommCase) Accept(v Visitor) interface{}       { return v.VisitCommCase(
 func (n *SendStmt) Accept(v Visitor) interface{}       { return v.VisitSendStmt(n) }
 func (n *RecvStmt) Accept(v Visitor) interface{}       { return v.VisitRecvStmt(n) }
 func (n *Return) Accept(v Visitor) interface{}         { return v.VisitReturn(n) }
+func (n *BranchStmt) Accept(v Visitor) interface{}     { return v.VisitBranchStmt(n) }
 func (n *IfStmt) Accept(v Visitor) interface{}         { return v.VisitIfStmt(n) }
 func (n *Else) Accept(v Visitor) interface{}           { return v.VisitElse(n) }
 func (n *ForLoop) Accept(v Visitor) interface{}        { return v.VisitForLoop(n) }
@@ -603,6 +799,7 @@ func (n *Prop) Accept(v Visitor) interface{}         { return v.VisitProp(n) }
 func (n *ExprStmt) Accept(v Visitor) interface{}     { return v.VisitExprStmt(n) }
 func (n *Expr) Accept(v Visitor) interface{}         { return v.VisitExpr(n) }
 func (n *BinaryOp) Accept(v Visitor) interface{}     { return v.VisitBinaryOp(n) }
+func (n *CondExpr) Accept(v Visitor) interface{}     { return v.VisitCondExpr(n) }
 func (n *Primary) Accept(v Visitor) interface{}      { return v.VisitPrimary(n) }
 func (n *UnaryExpr) Accept(v Visitor) interface{}    { return v.VisitUnaryExpr(n) }
 func (n *Literal) Accept(v Visitor) interface{}      { return v.VisitLiteral(n) }
@@ -616,6 +813,7 @@ func (n *FuncLit) Accept(v Visitor) interface{}      { return v.VisitFuncLit(n)
 func (n *FuncBody) Accept(v Visitor) interface{}     { return v.VisitFuncBody(n) }
 func (n *CompositeLit) Accept(v Visitor) interface{} { return v.VisitCompositeLit(n) }
 func (n *KeyValue) Accept(v Visitor) interface{}     { return v.VisitKeyValue(n) }
+func (n *LitElem) Accept(v Visitor) interface{}      { return v.VisitLitElem(n) }
 
 // Templates and special nodes
 func (n *TextNode) Accept(v Visitor) interface{}    { return v.VisitTextNode(n) }
@@ -623,4 +821,5 @@ func (n *Template) Accept(v Visitor) interface{}    { return v.VisitTemplate(n)
 func (n *Fragment) Accept(v Visitor) interface{}    { return v.VisitFragment(n) }
 func (n *IfExpr) Accept(v Visitor) interface{}      { return v.VisitIfExpr(n) }
 func (n *ChannelRecv) Accept(v Visitor) interface{} { return v.VisitChannelRecv(n) }
+func (n *Slot) Accept(v Visitor) interface{}        { return v.VisitSlot(n) }
 func (n *ChannelOp) Accept(v Visitor) interface{}   { return v.VisitChannelOp(n) }
@@ -93,6 +93,9 @@ func (v *BaseVisitor) VisitParameter(node *Parameter) interface{} {
 }
 
 func (v *BaseVisitor) VisitType(node *Type) interface{} {
+	if node.Struct != nil {
+		node.Struct.Accept(v)
+	}
 	if node.Generic != nil {
 		node.Generic.Accept(v)
 	}
@@ -108,6 +111,9 @@ func (v *BaseVisitor) VisitType(node *Type) interface{} {
 // Body and statements
 
 func (v *BaseVisitor) VisitBody(node *Body) interface{} {
+	for _, constDecl := range node.ConstDecls {
+		constDecl.Accept(v)
+	}
 	for _, varDecl := range node.VarDecls {
 		varDecl.Accept(v)
 	}
@@ -130,6 +136,9 @@ func (v *BaseVisitor) VisitBodyStatement(node *BodyStatement) interface{} {
 	if node.Return != nil {
 		node.Return.Accept(v)
 	}
+	if node.Branch != nil {
+		node.Branch.Accept(v)
+	}
 	if node.If != nil {
 		node.If.Accept(v)
 	}
@@ -156,6 +165,9 @@ func (v *BaseVisitor) VisitBodyStatement(node *BodyStatement) interface{} {
 }
 
 func (v *BaseVisitor) VisitStatement(node *Statement) interface{} {
+	if node.ConstDecl != nil {
+		node.ConstDecl.Accept(v)
+	}
 	if node.CallStmt != nil {
 		node.CallStmt.Accept(v)
 	}
@@ -168,6 +180,9 @@ func (v *BaseVisitor) VisitStatement(node *Statement) interface{} {
 	if node.Return != nil {
 		node.Return.Accept(v)
 	}
+	if node.Branch != nil {
+		node.Branch.Accept(v)
+	}
 	if node.If != nil {
 		node.If.Accept(v)
 	}
@@ -210,6 +225,15 @@ func (v *BaseVisitor) VisitAssignmentStmt(node *AssignmentStmt) interface{} {
 	return nil
 }
 
+func (v *BaseVisitor) VisitConstDecl(node *ConstDecl) interface{} {
+	for _, spec := range node.Specs {
+		if spec.Value != nil {
+			spec.Value.Accept(v)
+		}
+	}
+	return nil
+}
+
 func (v *BaseVisitor) VisitVarDecl(node *VarDecl) interface{} {
 	for _, val := range node.Values {
 		val.Accept(v)
@@ -231,6 +255,10 @@ func (v *BaseVisitor) VisitReturn(node *Return) interface{} {
 	return nil
 }
 
+func (v *BaseVisitor) VisitBranchStmt(node *BranchStmt) interface{} {
+	return nil
+}
+
 func (v *BaseVisitor) VisitGoStmt(node *GoStmt) interface{} {
 	if node.Func != nil {
 		node.Func.Accept(v)
@@ -367,6 +395,9 @@ func (v *BaseVisitor) VisitNode(node *Node) interface{} {
 	if node.ChannelRecv != nil {
 		node.ChannelRecv.Accept(v)
 	}
+	if node.Slot != nil {
+		node.Slot.Accept(v)
+	}
 	if node.Element != nil {
 		node.Element.Accept(v)
 	}
@@ -409,6 +440,9 @@ func (v *BaseVisitor) VisitExpr(node *Expr) interface{} {
 	for _, binOp := range node.BinOps {
 		binOp.Accept(v)
 	}
+	if node.Cond != nil {
+		node.Cond.Accept(v)
+	}
 	return nil
 }
 
@@ -419,6 +453,16 @@ func (v *BaseVisitor) VisitBinaryOp(node *BinaryOp) interface{} {
 	return nil
 }
 
+func (v *BaseVisitor) VisitCondExpr(node *CondExpr) interface{} {
+	if node.Then != nil {
+		node.Then.Accept(v)
+	}
+	if node.Else != nil {
+		node.Else.Accept(v)
+	}
+	return nil
+}
+
 func (v *BaseVisitor) VisitPrimary(node *Primary) interface{} {
 	if node.Unary != nil {
 		node.Unary.Accept(v)
@@ -539,6 +583,9 @@ func (v *BaseVisitor) VisitFuncBody(node *FuncBody) interface{} {
 }
 
 func (v *BaseVisitor) VisitCompositeLit(node *CompositeLit) interface{} {
+	for _, elem := range node.Values {
+		elem.Accept(v)
+	}
 	for _, elem := range node.Elements {
 		elem.Accept(v)
 	}
@@ -552,6 +599,16 @@ func (v *BaseVisitor) VisitKeyValue(node *KeyValue) interface{} {
 	return nil
 }
 
+func (v *BaseVisitor) VisitLitElem(node *LitElem) interface{} {
+	for _, elem := range node.Elements {
+		elem.Accept(v)
+	}
+	if node.Value != nil {
+		node.Value.Accept(v)
+	}
+	return nil
+}
+
 // Templates and special nodes
 
 func (v *BaseVisitor) VisitTextNode(node *TextNode) interface{} {
@@ -579,6 +636,9 @@ func (v *BaseVisitor) VisitIfExpr(node *IfExpr) interface{} {
 	if node.TrueBody != nil {
 		node.TrueBody.Accept(v)
 	}
+	if node.ElseIf != nil {
+		node.ElseIf.Accept(v)
+	}
 	if node.FalseBody != nil {
 		node.FalseBody.Accept(v)
 	}
@@ -589,6 +649,10 @@ func (v *BaseVisitor) VisitChannelRecv(node *ChannelRecv) interface{} {
 	return nil
 }
 
+func (v *BaseVisitor) VisitSlot(node *Slot) interface{} {
+	return nil
+}
+
 func (v *BaseVisitor) VisitChannelOp(node *ChannelOp) interface{} {
 	return nil
 }
@@ -24,6 +24,7 @@ type Visitor interface {
 	VisitStatement(*Statement) interface{}
 	VisitCallStmt(*CallStmt) interface{}
 	VisitAssignmentStmt(*AssignmentStmt) interface{}
+	VisitConstDecl(*ConstDecl) interface{}
 	VisitVarDecl(*VarDecl) interface{}
 	VisitAssignment(*Assignment) interface{}
 	VisitGoStmt(*GoStmt) interface{}
@@ -35,6 +36,7 @@ type Visitor interface {
 	VisitSendStmt(*SendStmt) interface{}
 	VisitRecvStmt(*RecvStmt) interface{}
 	VisitReturn(*Return) interface{}
+	VisitBranchStmt(*BranchStmt) interface{}
 	VisitIfStmt(*IfStmt) interface{}
 	VisitElse(*Else) interface{}
 	VisitForLoop(*ForLoop) interface{}
@@ -46,6 +48,7 @@ type Visitor interface {
 	VisitExprStmt(*ExprStmt) interface{}
 	VisitExpr(*Expr) interface{}
 	VisitBinaryOp(*BinaryOp) interface{}
+	VisitCondExpr(*CondExpr) interface{}
 	VisitPrimary(*Primary) interface{}
 	VisitUnaryExpr(*UnaryExpr) interface{}
 	VisitLiteral(*Literal) interface{}
@@ -59,6 +62,7 @@ type Visitor interface {
 	VisitFuncBody(*FuncBody) interface{}
 	VisitCompositeLit(*CompositeLit) interface{}
 	VisitKeyValue(*KeyValue) interface{}
+	VisitLitElem(*LitElem) interface{}
 
 	// Templates and special nodes
 	VisitTextNode(*TextNode) interface{}
@@ -66,6 +70,7 @@ type Visitor interface {
 	VisitFragment(*Fragment) interface{}
 	VisitIfExpr(*IfExpr) interface{}
 	VisitChannelRecv(*ChannelRecv) interface{}
+	VisitSlot(*Slot) interface{}
 	VisitChannelOp(*ChannelOp) interface{}
 }
 
@@ -157,7 +157,7 @@ func TestBaseVisitor_TraversesFile(t *testing.T) {
 	file := &File{
 		Package: "main",
 		Imports: []*Import{
-			{Paths: []string{"fmt"}},
+			{Path: `"fmt"`},
 		},
 		Components: []*Component{
 			{
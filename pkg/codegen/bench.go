@@ -0,0 +1,116 @@
+package codegen
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"strconv"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// GenerateBenchmarks generates a render benchmark for each UI component of
+// the file:
+//
+//	func BenchmarkCounter(b *testing.B) {
+//	    c := NewCounter(nil, "")
+//	    b.ReportAllocs()
+//	    for i := 0; i < b.N; i++ {
+//	        _ = c.RenderHTML()
+//	    }
+//	}
+//
+// The benchmarks render through the RenderHTML method of GenerateSSR, so
+// they share its !(js && wasm) build tag and run under plain go test -bench.
+// Props are left at their zero values.
+func (g *Generator) GenerateBenchmarks(file *guixast.File) ([]byte, error) {
+	var decls []ast.Decl
+	for _, comp := range file.Components {
+		if g.isComponentFunc(comp) {
+			decls = append(decls, g.generateBenchmark(comp))
+		}
+	}
+
+	candidates := []ast.Spec{
+		&ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote("testing")}},
+	}
+	return g.formatDeclsWithBuildTags(ssrBuildTags, file.Package, withImports(candidates, decls))
+}
+
+// generateBenchmark generates the BenchmarkX function of a component
+func (g *Generator) generateBenchmark(comp *guixast.Component) *ast.FuncDecl {
+	var args []ast.Expr
+	if !comp.AutoProps {
+		for _, param := range propParams(comp) {
+			if param.IsVariadic {
+				continue
+			}
+			args = append(args, g.zeroValueExpr(param))
+		}
+	}
+
+	construct := &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("c")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CallExpr{Fun: ast.NewIdent("New" + comp.Name), Args: args}},
+	}
+	reportAllocs := &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: ast.NewIdent("b"), Sel: ast.NewIdent("ReportAllocs")},
+		},
+	}
+	loop := &ast.ForStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("i")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: "0"}},
+		},
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("i"),
+			Op: token.LSS,
+			Y:  &ast.SelectorExpr{X: ast.NewIdent("b"), Sel: ast.NewIdent("N")},
+		},
+		Post: &ast.IncDecStmt{X: ast.NewIdent("i"), Tok: token.INC},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("_")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun: &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("RenderHTML")},
+					}},
+				},
+			},
+		},
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("Benchmark" + comp.Name),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("b")},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{X: ast.NewIdent("testing"), Sel: ast.NewIdent("B")},
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{construct, reportAllocs, loop}},
+	}
+}
+
+// zeroValueExpr returns the zero value of a parameter, falling back to
+// *new(T) for named types without a short literal
+func (g *Generator) zeroValueExpr(param *guixast.Parameter) ast.Expr {
+	if lit := zeroValue(param); lit != "" {
+		if expr, err := goparser.ParseExpr(lit); err == nil {
+			return expr
+		}
+	}
+	return &ast.StarExpr{
+		X: &ast.CallExpr{Fun: ast.NewIdent("new"), Args: []ast.Expr{g.typeToAST(param.Type)}},
+	}
+}
@@ -0,0 +1,151 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// childrenParam returns the parameter receiving the nodes nested inside the
+// component's element, or nil if the component takes no children
+func childrenParam(comp *guixast.Component) *guixast.Parameter {
+	for _, param := range comp.Params {
+		if param.IsChildren() {
+			return param
+		}
+	}
+	return nil
+}
+
+// propParams returns the parameters of a component that are passed as
+// props. The children parameter is set by the parent with SetChildren.
+func propParams(comp *guixast.Component) []*guixast.Parameter {
+	params := make([]*guixast.Parameter, 0, len(comp.Params))
+	for _, param := range comp.Params {
+		if !param.IsChildren() {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// generateSetChildrenMethod generates the runtime.ChildrenSetter
+// implementation of a component with a children parameter:
+//
+//	func (c *Card) SetChildren(children ...*runtime.VNode) {
+//	    c.Children = children
+//	}
+//
+// In server-side rendering the children arrive as markup:
+//
+//	func (c *Card) SetChildren(markup string) {
+//	    c.Children = markup
+//	}
+func (g *Generator) generateSetChildrenMethod(comp *guixast.Component, param *guixast.Parameter, ssr bool) *ast.FuncDecl {
+	arg := &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent("children")},
+		Type: &ast.Ellipsis{Elt: &ast.StarExpr{
+			X: &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent("VNode")},
+		}},
+	}
+	if ssr {
+		arg = &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent("markup")},
+			Type:  ast.NewIdent("string"),
+		}
+	}
+
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{List: []*ast.Field{{
+			Names: []*ast.Ident{ast.NewIdent("c")},
+			Type:  &ast.StarExpr{X: ast.NewIdent(comp.Name)},
+		}}},
+		Name: ast.NewIdent("SetChildren"),
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{arg}}},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(capitalize(param.Name))}},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{arg.Names[0]},
+			},
+		}},
+	}
+}
+
+// generateSlot renders the children passed to the component where its
+// template has {children}: runtime.Fragment(c.Children...)
+func (g *Generator) generateSlot(slot *guixast.Slot) ast.Expr {
+	return &ast.CallExpr{
+		Fun:      &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent("Fragment")},
+		Args:     []ast.Expr{g.generatePrimary(&guixast.Primary{Ident: slot.Name})},
+		Ellipsis: 1,
+	}
+}
+
+// writeHTMLComponentWithChildren writes a child component with nested
+// nodes, passing their markup to it first:
+//
+//	{
+//	    _card := c.cardInstance
+//	    _card.SetChildren("<p>Nested</p>")
+//	    __html.WriteString(_card.RenderHTML())
+//	}
+func (g *Generator) writeHTMLComponentWithChildren(w *htmlWriter, elem *guixast.Element, instance ast.Expr) {
+	compVar := ast.NewIdent("_" + strings.ToLower(elem.Tag[:1]) + elem.Tag[1:])
+
+	inner := &htmlWriter{}
+	for _, child := range elem.Children {
+		g.writeHTMLNode(inner, child)
+	}
+	// Static children are passed as a string literal
+	var markup ast.Expr = &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(inner.pending.String())}
+	if len(inner.stmts) > 0 {
+		markup = htmlFunc(inner)
+	}
+
+	w.stmt(&ast.BlockStmt{List: []ast.Stmt{
+		&ast.AssignStmt{Lhs: []ast.Expr{compVar}, Tok: token.DEFINE, Rhs: []ast.Expr{instance}},
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: compVar, Sel: ast.NewIdent("SetChildren")},
+			Args: []ast.Expr{markup},
+		}},
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: ast.NewIdent(htmlBuilder), Sel: ast.NewIdent("WriteString")},
+			Args: []ast.Expr{&ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: compVar, Sel: ast.NewIdent("RenderHTML")},
+			}},
+		}},
+	}})
+}
+
+// htmlFunc returns a function literal call returning the markup collected
+// by a writer:
+//
+//	func() string {
+//	    var __html strings.Builder
+//	    ...
+//	    return __html.String()
+//	}()
+func htmlFunc(w *htmlWriter) ast.Expr {
+	return &ast.CallExpr{Fun: &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("string")}}},
+		},
+		Body: &ast.BlockStmt{List: append(
+			append([]ast.Stmt{&ast.DeclStmt{Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{&ast.ValueSpec{
+					Names: []*ast.Ident{ast.NewIdent(htmlBuilder)},
+					Type:  &ast.SelectorExpr{X: ast.NewIdent("strings"), Sel: ast.NewIdent("Builder")},
+				}},
+			}}}, w.done()...),
+			&ast.ReturnStmt{Results: []ast.Expr{&ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: ast.NewIdent(htmlBuilder), Sel: ast.NewIdent("String")},
+			}}},
+		)},
+	}}
+}
@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+const layoutSource = `package main
+
+@props
+func Layout(title string, children Children) (Component) {
+	Div(Class("layout")) {
+		H2 {
+			` + "`{title}`" + `
+		}
+		{children}
+	}
+}
+
+func Page() (Component) {
+	Layout(WithTitle("Orders")) {
+		H1 {
+			"Orders"
+		}
+		P {
+			"No orders yet"
+		}
+	}
+}`
+
+func TestGenerateComponentWithChildren(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(layoutSource))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"Children runtime.Children",
+		// Children are not a prop
+		"func NewLayout(opts ...LayoutOption) *Layout {",
+		"func (c *Layout) SetChildren(children ...*runtime.VNode) {\n\tc.Children = children",
+		// The slot renders the children in place
+		`runtime.H2(runtime.Text(fmt.Sprint(c.Title))), runtime.Fragment(c.Children...))`,
+		// The parent passes its nested nodes on each render
+		`runtime.RenderWithChildren(c.layoutInstance, runtime.H1(runtime.Text("Orders")), runtime.P(runtime.Text("No orders yet")))`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	for _, unexpected := range []string{"func WithChildren(", "children runtime.Children"} {
+		if strings.Contains(generatedStr, unexpected) {
+			t.Errorf("Generated code should not contain %q\nGenerated:\n%s", unexpected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateSSRComponentWithChildren(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(layoutSource))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"Children string",
+		"func (c *Layout) SetChildren(markup string) {",
+		"__html.WriteString(c.Children)",
+		// Static children are rendered at generation time
+		`_layout.SetChildren("<h1>Orders</h1><p>No orders yet</p>")`,
+		"__html.WriteString(_layout.RenderHTML())",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
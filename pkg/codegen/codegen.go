@@ -7,8 +7,11 @@ import (
 	"go/ast"
 	"go/format"
 	"go/token"
+	"path"
+	"strconv"
 	"strings"
 
+	"github.com/alecthomas/participle/v2/lexer"
 	guixast "github.com/gaarutyunov/guix/pkg/ast"
 )
 
@@ -35,6 +38,10 @@ type Generator struct {
 	goroutines          []*guixast.GoStmt                        // Track goroutine statements in current component
 	receiverName        string                                   // Current receiver name: "c" for Component, "s" for Scene
 	verbose             bool                                     // Generate verbose logging statements
+	optimizeSize        bool                                     // Prefer strconv over fmt to shrink WASM binaries
+	accordionCount      int                                      // Accordions written by SSR, numbering their header and panel ids
+	emitLineDirectives  bool                                     // Write //line directives pointing back to the .gx source
+	sourceLines         map[ast.Node]lexer.Position              // .gx positions of generated statements and declarations
 
 	// Result accumulation for visitor pattern
 	generatedDecls []ast.Decl // Accumulated declarations during traversal
@@ -55,6 +62,21 @@ func (g *Generator) SetVerbose(verbose bool) {
 	g.verbose = verbose
 }
 
+// SetOptimizeSize enables or disables size-optimized code generation.
+// Interpolated values with a known static type are converted with strconv
+// instead of fmt, and channel listeners don't format received values.
+func (g *Generator) SetOptimizeSize(optimizeSize bool) {
+	g.optimizeSize = optimizeSize
+}
+
+// SetEmitLineDirectives enables or disables //line directives before
+// generated statements and declarations, so compiler errors and stack traces
+// point at the .gx line they came from. Positions need the file name the
+// source was parsed with, e.g. by Parser.ParseBytes.
+func (g *Generator) SetEmitLineDirectives(emit bool) {
+	g.emitLineDirectives = emit
+}
+
 // Visitor pattern implementation
 
 // VisitFile implements the visitor pattern for File nodes
@@ -93,6 +115,7 @@ func (g *Generator) VisitFile(file *guixast.File) interface{} {
 // VisitTypeDef implements the visitor pattern for TypeDef nodes
 func (g *Generator) VisitTypeDef(node *guixast.TypeDef) interface{} {
 	decl := g.generateTypeDef(node)
+	g.markLine(decl, node.Pos)
 	g.generatedDecls = append(g.generatedDecls, decl)
 	return nil
 }
@@ -110,6 +133,9 @@ func (g *Generator) VisitComponent(comp *guixast.Component) interface{} {
 		// Generate simple function
 		decls = []ast.Decl{g.generateFunction(comp)}
 	}
+	for _, decl := range decls {
+		g.markLine(decl, comp.Pos)
+	}
 	g.generatedDecls = append(g.generatedDecls, decls...)
 	return nil
 }
@@ -117,6 +143,7 @@ func (g *Generator) VisitComponent(comp *guixast.Component) interface{} {
 // VisitMethod implements the visitor pattern for Method nodes
 func (g *Generator) VisitMethod(method *guixast.Method) interface{} {
 	decl := g.generateMethod(method)
+	g.markLine(decl, method.Pos)
 	g.generatedDecls = append(g.generatedDecls, decl)
 	return nil
 }
@@ -167,18 +194,40 @@ func (g *Generator) Generate(file *guixast.File) ([]byte, error) {
 	// Use visitor pattern to traverse AST and generate declarations
 	file.Accept(g)
 
+	// Channel listeners log with fmt.Sprintf even when no template needs it
+	g.ensureImport("fmt")
+	g.ensureImport("strconv")
+	// Template time formats
+	g.ensureImport("time")
+	// Mount takes a js.Value even when the component renders no elements
+	g.ensureImport("syscall/js")
+
+	return g.formatDecls(file.Package, g.generatedDecls)
+}
+
+// wasmBuildTags restricts generated component code to the WASM target
+const wasmBuildTags = "//go:build js && wasm\n// +build js,wasm\n\n"
+
+// formatDecls formats declarations as a generated Go source file
+func (g *Generator) formatDecls(pkg string, decls []ast.Decl) ([]byte, error) {
+	return g.formatDeclsWithBuildTags(wasmBuildTags, pkg, decls)
+}
+
+// formatDeclsWithBuildTags formats declarations as a generated Go source
+// file starting with the given build constraint lines
+func (g *Generator) formatDeclsWithBuildTags(buildTags, pkg string, decls []ast.Decl) ([]byte, error) {
 	// Build Go AST file from accumulated declarations
 	goFile := &ast.File{
-		Name:  ast.NewIdent(file.Package),
-		Decls: g.generatedDecls,
+		Name:  ast.NewIdent(pkg),
+		Decls: decls,
 	}
+	g.insertLineMarkers(decls)
 
 	// Format and output
 	var buf bytes.Buffer
 
-	// Write build tags for WASM target
-	buf.WriteString("//go:build js && wasm\n")
-	buf.WriteString("// +build js,wasm\n\n")
+	// Write build tags for the target
+	buf.WriteString(buildTags)
 
 	// Write header comment manually
 	buf.WriteString("// Code generated by guix. DO NOT EDIT.\n\n")
@@ -188,9 +237,55 @@ func (g *Generator) Generate(file *guixast.File) ([]byte, error) {
 		return nil, fmt.Errorf("format error: %w", err)
 	}
 
+	if len(g.sourceLines) > 0 {
+		return replaceLineMarkers(buf.Bytes()), nil
+	}
 	return buf.Bytes(), nil
 }
 
+// ensureImport adds an import for pkg to the generated import block
+// if the generated declarations reference it and it is not imported yet
+func (g *Generator) ensureImport(pkg string) {
+	if len(g.generatedDecls) == 0 {
+		return
+	}
+	importDecl, ok := g.generatedDecls[0].(*ast.GenDecl)
+	if !ok || importDecl.Tok != token.IMPORT {
+		return
+	}
+
+	quoted := strconv.Quote(pkg)
+	for _, spec := range importDecl.Specs {
+		if imp, ok := spec.(*ast.ImportSpec); ok && imp.Path.Value == quoted {
+			return
+		}
+	}
+
+	used := false
+	for _, decl := range g.generatedDecls[1:] {
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == path.Base(pkg) {
+					used = true
+				}
+			}
+			return !used
+		})
+		if used {
+			break
+		}
+	}
+
+	if used {
+		importDecl.Specs = append(importDecl.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: quoted,
+			},
+		})
+	}
+}
+
 // generateImports creates import declarations
 func (g *Generator) generateImports(file *guixast.File) *ast.GenDecl {
 	specs := []ast.Spec{
@@ -225,8 +320,12 @@ func (g *Generator) generateImports(file *guixast.File) *ast.GenDecl {
 
 	// Only add fmt if there are template interpolations with expressions
 	// This is needed for fmt.Sprint() when displaying values in templates like `{value}` or `{<-channel}`
+	// In size-optimized mode fmt is only imported if still referenced
 	needsFmt := false
 	for _, comp := range file.Components {
+		if g.optimizeSize {
+			break
+		}
 		if g.hasChannelInterpolation(comp) {
 			needsFmt = true
 			break
@@ -242,17 +341,18 @@ func (g *Generator) generateImports(file *guixast.File) *ast.GenDecl {
 		})
 	}
 
-	// Add user imports
+	// Add user imports, keeping their aliases
 	for _, imp := range file.Imports {
-		// Handle both single and grouped imports
-		for _, path := range imp.Paths {
-			specs = append(specs, &ast.ImportSpec{
-				Path: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: path,
-				},
-			})
+		spec := &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: imp.Path,
+			},
+		}
+		if imp.Alias != "" {
+			spec.Name = ast.NewIdent(imp.Alias)
 		}
+		specs = append(specs, spec)
 	}
 
 	return &ast.GenDecl{
@@ -265,23 +365,16 @@ func (g *Generator) generateImports(file *guixast.File) *ast.GenDecl {
 // generateTypeDef generates code for a type definition
 func (g *Generator) generateTypeDef(typeDef *guixast.TypeDef) *ast.GenDecl {
 	if typeDef.Struct != nil {
-		// Generate struct type
-		fields := make([]*ast.Field, len(typeDef.Struct.Fields))
-		for i, field := range typeDef.Struct.Fields {
-			fields[i] = &ast.Field{
-				Names: []*ast.Ident{ast.NewIdent(field.Name)},
-				Type:  g.typeToAST(field.Type),
-			}
+		structType := g.structTypeToAST(typeDef.Struct)
+		if typeDef.JSON != nil {
+			structType = g.jsonStructToAST(typeDef.Struct, typeDef.JSON.Style)
 		}
-
 		return &ast.GenDecl{
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
 					Name: ast.NewIdent(typeDef.Name),
-					Type: &ast.StructType{
-						Fields: &ast.FieldList{List: fields},
-					},
+					Type: structType,
 				},
 			},
 		}
@@ -299,12 +392,29 @@ func (g *Generator) generateTypeDef(typeDef *guixast.TypeDef) *ast.GenDecl {
 	}
 }
 
+// structTypeToAST converts a Guix struct type to a Go struct type
+func (g *Generator) structTypeToAST(st *guixast.StructType) *ast.StructType {
+	fields := make([]*ast.Field, len(st.Fields))
+	for i, field := range st.Fields {
+		names := make([]*ast.Ident, 0, len(field.MoreNames)+1)
+		for _, name := range field.Names() {
+			names = append(names, ast.NewIdent(name))
+		}
+		fields[i] = &ast.Field{
+			Names: names,
+			Type:  g.typeToAST(field.Type),
+		}
+	}
+	return &ast.StructType{Fields: &ast.FieldList{List: fields}}
+}
+
 // generateFunction generates code for a regular helper function (not a UI component)
 func (g *Generator) generateFunction(comp *guixast.Component) *ast.FuncDecl {
 	// Set up context
 	g.hoistedVars = make(map[string]bool)
 	g.componentParams = nil // Clear component params for regular functions
 	g.currentCompBody = comp.Body
+	g.receiverName = "" // Functions have no component receiver
 
 	// Generate parameters
 	params := make([]*ast.Field, len(comp.Params))
@@ -348,6 +458,7 @@ func (g *Generator) generateMethod(method *guixast.Method) *ast.FuncDecl {
 	g.hoistedVars = make(map[string]bool)
 	g.componentParams = nil
 	g.currentCompBody = method.Body
+	g.receiverName = ""
 
 	// Generate receiver
 	var recv *ast.FieldList
@@ -405,7 +516,7 @@ func (g *Generator) generateMethod(method *guixast.Method) *ast.FuncDecl {
 
 // generateFunctionBodyStmts generates statements for a regular function body
 func (g *Generator) generateFunctionBodyStmts(body *guixast.Body) []ast.Stmt {
-	stmts := make([]ast.Stmt, 0)
+	stmts := g.generateConstDecls(body.ConstDecls)
 
 	// Add variable declarations
 	for _, varDecl := range body.VarDecls {
@@ -454,9 +565,9 @@ func (g *Generator) analyzeComponentBody(comp *guixast.Component) {
 	// Collect hoisted variable names and channel receive variables
 	if comp.Body != nil {
 		for _, varDecl := range comp.Body.VarDecls {
-			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 {
+			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 && !varDecl.Computed {
 				// Track channel receives first
-				if varDecl.Values[0].Left != nil && varDecl.Values[0].Left.ChannelOp != nil {
+				if isChannelReceiveDecl(varDecl) {
 					// Track channel receive: currentState := <-stateChannel
 					channelName := varDecl.Values[0].Left.ChannelOp.Channel
 					g.channelReceiveVars[varDecl.Names[0]] = capitalize(channelName)
@@ -476,6 +587,16 @@ func (g *Generator) analyzeComponentBody(comp *guixast.Component) {
 			}
 		}
 
+		// Derived and computed values read inline channel receives from
+		// current<Channel> fields
+		for _, varDecl := range comp.Body.VarDecls {
+			if g.isDerivedDecl(varDecl) || isComputedDecl(varDecl) {
+				for _, value := range varDecl.Values {
+					g.checkExprForChannelReceive(value)
+				}
+			}
+		}
+
 		// Also scan for inline channel receives in the component body (e.g., in template interpolations)
 		g.scanForChannelReceives(comp.Body.Children)
 	}
@@ -497,6 +618,10 @@ func (g *Generator) scanForChannelReceives(nodes []*guixast.Node) {
 		if node.Element != nil {
 			// Check element props for channel receives
 			for _, prop := range node.Element.Props {
+				// ErrorText(errChannel) reads the channel's current value
+				if node.Element.Tag == "ErrorText" && prop.IsBare() && g.isChannelParam(prop.Name) {
+					g.channelReceiveVars["__inline_"+prop.Name] = capitalize(prop.Name)
+				}
 				if prop.Args != nil {
 					for _, arg := range prop.Args {
 						g.checkExprForChannelReceive(arg)
@@ -519,6 +644,16 @@ func (g *Generator) scanForChannelReceives(nodes []*guixast.Node) {
 			if node.IfExpr.FalseBody != nil && node.IfExpr.FalseBody.Children != nil {
 				g.scanForChannelReceives(node.IfExpr.FalseBody.Children)
 			}
+			if node.IfExpr.ElseIf != nil {
+				g.scanForChannelReceives([]*guixast.Node{{IfExpr: node.IfExpr.ElseIf}})
+			}
+		}
+
+		// Check for loops
+		if node.ForLoop != nil && node.ForLoop.LoopBody() != nil {
+			g.checkExprForChannelReceive(node.ForLoop.Range)
+			g.checkExprForChannelReceive(node.ForLoop.Cond)
+			g.scanForChannelReceives(node.ForLoop.LoopBody().Children)
 		}
 	}
 }
@@ -535,18 +670,23 @@ func (g *Generator) checkExprForChannelReceive(expr *guixast.Expr) {
 		channelName := expr.Left.ChannelOp.Channel
 		// Use a dummy variable name to track that this channel is received from
 		g.channelReceiveVars["__inline_"+channelName] = capitalize(channelName)
-		return
+	} else {
+		// Recursively check primary expressions
+		g.checkPrimaryForChannelReceive(expr.Left)
 	}
 
-	// Recursively check primary expressions
-	g.checkPrimaryForChannelReceive(expr.Left)
-
 	// Check binary operation operands
 	for _, binOp := range expr.BinOps {
 		if binOp != nil && binOp.Right != nil {
 			g.checkPrimaryForChannelReceive(binOp.Right)
 		}
 	}
+
+	// Check conditional branches
+	if expr.Cond != nil {
+		g.checkExprForChannelReceive(expr.Cond.Then)
+		g.checkExprForChannelReceive(expr.Cond.Else)
+	}
 }
 
 // checkPrimaryForChannelReceive recursively checks a primary expression for channel receives
@@ -571,6 +711,13 @@ func (g *Generator) checkPrimaryForChannelReceive(primary *guixast.Primary) {
 	if primary.Unary != nil && primary.Unary.Right != nil {
 		g.checkPrimaryForChannelReceive(primary.Unary.Right)
 	}
+
+	// Check call arguments, e.g. float32(<-angle)
+	if primary.CallOrSel != nil {
+		for _, arg := range primary.CallOrSel.Args {
+			g.checkExprForChannelReceive(arg)
+		}
+	}
 }
 
 // generateComponent generates code for a component
@@ -592,17 +739,22 @@ func (g *Generator) generateComponent(comp *guixast.Component) []ast.Decl {
 	g.analyzeComponentBody(comp)
 
 	// Generate Props struct and option functions only if @props directive is present
-	if comp.AutoProps && len(comp.Params) > 0 {
+	if comp.AutoProps && len(propParams(comp)) > 0 {
 		decls = append(decls, g.generatePropsStruct(comp))
 		decls = append(decls, g.generateOptionType(comp))
 		decls = append(decls, g.generateOptionFuncs(comp)...)
+		decls = append(decls, g.generateSetterMethods(comp)...)
 	}
 
 	// Generate component struct
-	decls = append(decls, g.generateComponentStruct(comp))
+	structDecl := g.generateComponentStruct(comp)
+	decls = append(decls, structDecl)
 
 	// Generate constructor
 	decls = append(decls, g.generateConstructor(comp))
+	if len(requiredParams(comp)) > 0 {
+		decls = append(decls, g.generateRequiredConstructor(comp))
+	}
 
 	// Check if component has channel parameters
 	hasChannels := g.hasChannelParams(comp)
@@ -610,6 +762,11 @@ func (g *Generator) generateComponent(comp *guixast.Component) []ast.Decl {
 	// Always generate BindApp method for API consistency
 	decls = append(decls, g.generateBindAppMethod(comp))
 
+	// A component wrapping children receives them from its parent
+	if param := childrenParam(comp); param != nil {
+		decls = append(decls, g.generateSetChildrenMethod(comp, param, false))
+	}
+
 	// Generate listener methods for each channel (only if there are channels)
 	if hasChannels {
 		decls = append(decls, g.generateChannelListenerMethods(comp)...)
@@ -618,6 +775,11 @@ func (g *Generator) generateComponent(comp *guixast.Component) []ast.Decl {
 	// Generate Render method
 	decls = append(decls, g.generateRenderMethod(comp))
 
+	// Generate Props, ShouldUpdate and MemoCache only if @memo directive is present
+	if comp.Memo {
+		decls = append(decls, g.generateMemoDecls(comp, structDecl)...)
+	}
+
 	// Generate interface compliance methods
 	decls = append(decls, g.generateMountMethod(comp))
 	decls = append(decls, g.generateUnmountMethod(comp))
@@ -768,8 +930,12 @@ func (g *Generator) generateRenderSceneMethod(comp *guixast.Component) *ast.Func
 			break
 		}
 	}
-	// Generate variable declarations first
-	var stmts []ast.Stmt
+	// Track the latest value of channels read by the scene, then generate
+	// variable declarations
+	stmts := g.generateLatestValues(comp.Body)
+	if comp.Body != nil {
+		stmts = append(stmts, g.generateConstDecls(comp.Body.ConstDecls)...)
+	}
 	if comp.Body != nil && len(comp.Body.VarDecls) > 0 {
 		for _, varDecl := range comp.Body.VarDecls {
 			// Generate: varName := value
@@ -934,9 +1100,10 @@ func (g *Generator) collectChildComponentsFromNodes(nodes []*guixast.Node, resul
 
 // generatePropsStruct generates a Props struct for component parameters
 func (g *Generator) generatePropsStruct(comp *guixast.Component) *ast.GenDecl {
-	fields := make([]*ast.Field, len(comp.Params))
+	params := propParams(comp)
+	fields := make([]*ast.Field, len(params))
 
-	for i, param := range comp.Params {
+	for i, param := range params {
 		paramType := g.typeToAST(param.Type)
 
 		// For variadic parameters, wrap the type in a slice
@@ -992,11 +1159,18 @@ func (g *Generator) generateOptionType(comp *guixast.Component) *ast.GenDecl {
 func (g *Generator) generateOptionFuncs(comp *guixast.Component) []ast.Decl {
 	var decls []ast.Decl
 
-	for _, param := range comp.Params {
+	for _, param := range propParams(comp) {
 		funcName := "With" + capitalize(param.Name)
 		optionType := comp.Name + "Option"
 		fieldName := capitalize(param.Name)
 
+		// A variadic parameter takes its values variadically too:
+		// WithItems(a, b) sets Items to []Item{a, b}
+		paramType := g.typeToAST(param.Type)
+		if param.IsVariadic {
+			paramType = &ast.Ellipsis{Elt: paramType}
+		}
+
 		decl := &ast.FuncDecl{
 			Name: ast.NewIdent(funcName),
 			Type: &ast.FuncType{
@@ -1004,7 +1178,7 @@ func (g *Generator) generateOptionFuncs(comp *guixast.Component) []ast.Decl {
 					List: []*ast.Field{
 						{
 							Names: []*ast.Ident{ast.NewIdent("v")},
-							Type:  g.typeToAST(param.Type),
+							Type:  paramType,
 						},
 					},
 				},
@@ -1058,6 +1232,53 @@ func (g *Generator) generateOptionFuncs(comp *guixast.Component) []ast.Decl {
 	return decls
 }
 
+// generateSetterMethods generates a setter for each non-channel parameter,
+// which updates the prop after construction and re-renders the component,
+// through its app when it is bound or on its own when mounted standalone:
+//
+//	func (c *Card) SetTitle(v string) {
+//	    c.Title = v
+//	    c.Update()
+//	}
+func (g *Generator) generateSetterMethods(comp *guixast.Component) []ast.Decl {
+	var decls []ast.Decl
+
+	for _, param := range propParams(comp) {
+		if param.Type != nil && (param.Type.IsChannel || param.Type.IsChan) {
+			continue
+		}
+
+		paramType := g.typeToAST(param.Type)
+		if param.IsVariadic {
+			paramType = &ast.ArrayType{Elt: paramType}
+		}
+
+		decls = append(decls, &ast.FuncDecl{
+			Recv: &ast.FieldList{List: []*ast.Field{{
+				Names: []*ast.Ident{ast.NewIdent("c")},
+				Type:  &ast.StarExpr{X: ast.NewIdent(comp.Name)},
+			}}},
+			Name: ast.NewIdent("Set" + capitalize(param.Name)),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{List: []*ast.Field{{
+					Names: []*ast.Ident{ast.NewIdent("v")},
+					Type:  paramType,
+				}}},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(capitalize(param.Name))}},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("v")},
+				},
+				&ast.ExprStmt{X: &ast.CallExpr{Fun: &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("Update")}}},
+			}},
+		})
+	}
+
+	return decls
+}
+
 // generateComponentStruct generates the component struct
 func (g *Generator) generateComponentStruct(comp *guixast.Component) *ast.GenDecl {
 	fields := []*ast.Field{
@@ -1106,12 +1327,7 @@ func (g *Generator) generateComponentStruct(comp *guixast.Component) *ast.GenDec
 			// Only add the field if there's an actual inline receive in templates
 			if hasInlineReceive {
 				// Extract the element type from the channel
-				var elemType ast.Expr
-				if param.Type.Generic != nil {
-					elemType = g.typeToAST(param.Type.Generic)
-				} else {
-					elemType = g.typeToAST(&guixast.Type{Name: param.Type.Name})
-				}
+				elemType := g.typeToAST(chanElemType(param.Type))
 
 				fields = append(fields, &ast.Field{
 					Names: []*ast.Ident{ast.NewIdent(currentFieldName)},
@@ -1125,6 +1341,9 @@ func (g *Generator) generateComponentStruct(comp *guixast.Component) *ast.GenDec
 	// This ensures channels and components persist across renders
 	if comp.Body != nil {
 		for _, varDecl := range comp.Body.VarDecls {
+			if varDecl.Computed {
+				continue
+			}
 			for i, name := range varDecl.Names {
 				if i < len(varDecl.Values) {
 					// Determine the type from the value expression
@@ -1139,6 +1358,9 @@ func (g *Generator) generateComponentStruct(comp *guixast.Component) *ast.GenDec
 			}
 		}
 
+		// Add fields holding computed values
+		fields = append(fields, g.generateComputedFields(comp.Body)...)
+
 		// Add child component instance fields
 		// Collect child components from the template
 		childComponents := g.collectChildComponents(comp.Body.Children)
@@ -1152,6 +1374,17 @@ func (g *Generator) generateComponentStruct(comp *guixast.Component) *ast.GenDec
 		}
 	}
 
+	// Add the key listener cleanup of an @keys component
+	if comp.KeysChannel != "" {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(unbindKeysField)},
+			Type:  &ast.FuncType{Params: &ast.FieldList{}},
+		})
+	}
+
+	// Add the latest value and subscription of each signal parameter
+	fields = append(fields, g.generateSignalFields(comp)...)
+
 	// Add listenersStarted flag if component has channel parameters
 	// This makes BindApp idempotent to prevent multiple goroutine leaks
 	if g.hasChannelParams(comp) {
@@ -1161,6 +1394,28 @@ func (g *Generator) generateComponentStruct(comp *guixast.Component) *ast.GenDec
 		})
 	}
 
+	// Add the last render runtime.RenderComponent reuses for an @memo component
+	if comp.Memo {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent("memo")},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("MemoCache"),
+			},
+		})
+	}
+
+	// Add the tree last rendered by Mount or Update, diffed by the next Update
+	fields = append(fields, &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent("vnode")},
+		Type: &ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("VNode"),
+			},
+		},
+	})
+
 	return &ast.GenDecl{
 		Tok: token.TYPE,
 		Specs: []ast.Spec{
@@ -1181,17 +1436,18 @@ func (g *Generator) inferTypeFromExpr(expr *guixast.Expr) ast.Expr {
 		return nil
 	}
 
-	// Handle make(chan Type, size)
+	if expr.Cond != nil {
+		return g.condExprType(expr.Cond)
+	}
+
+	// Handle make(chan Type, size), make([]Type, len) and make(map[Key]Value)
 	if expr.Left.MakeCall != nil {
-		fmt.Printf("[DEBUG inferTypeFromExpr] Found make() call, inferring chan type\n")
-		return &ast.ChanType{
-			Dir:   ast.SEND | ast.RECV,
-			Value: g.typeToAST(expr.Left.MakeCall.ChanType),
-		}
+		fmt.Printf("[DEBUG inferTypeFromExpr] Found make() call, inferring its type\n")
+		return g.makeCallType(expr.Left.MakeCall)
 	}
 
 	// Handle channel receive: varName := <-channelName
-	if expr.Left.ChannelOp != nil {
+	if expr.Left.ChannelOp != nil && len(expr.BinOps) == 0 {
 		channelName := expr.Left.ChannelOp.Channel
 		fmt.Printf("[DEBUG inferTypeFromExpr] Found channel receive from '%s'\n", channelName)
 		// Find the channel parameter or hoisted channel to get its element type
@@ -1199,10 +1455,7 @@ func (g *Generator) inferTypeFromExpr(expr *guixast.Expr) ast.Expr {
 			for _, param := range g.currentComp.Params {
 				if param.Name == channelName && param.Type != nil && (param.Type.IsChannel || param.Type.IsChan) {
 					// Extract element type from channel
-					if param.Type.Generic != nil {
-						return g.typeToAST(param.Type.Generic)
-					}
-					return g.typeToAST(&guixast.Type{Name: param.Type.Name})
+					return g.typeToAST(chanElemType(param.Type))
 				}
 			}
 
@@ -1230,9 +1483,8 @@ func (g *Generator) inferTypeFromExpr(expr *guixast.Expr) ast.Expr {
 			return ast.NewIdent("bool")
 		}
 		if lit.Number != nil {
-			// Check if it contains a decimal point
-			numStr := *lit.Number
-			if contains(numStr, ".") {
+			// Check if it is a float, with a decimal point or an exponent
+			if isFloatLiteral(*lit.Number) {
 				fmt.Printf("[DEBUG inferTypeFromExpr] Found float literal\n")
 				return ast.NewIdent("float64")
 			}
@@ -1270,10 +1522,11 @@ func findSubstring(s, substr string) bool {
 func (g *Generator) generateConstructor(comp *guixast.Component) *ast.FuncDecl {
 	funcName := "New" + comp.Name
 	optionType := comp.Name + "Option"
+	props := propParams(comp)
 
 	// Build function params based on AutoProps flag
 	var params *ast.FieldList
-	if comp.AutoProps && len(comp.Params) > 0 {
+	if comp.AutoProps && len(props) > 0 {
 		// AutoProps mode: use variadic options pattern
 		params = &ast.FieldList{
 			List: []*ast.Field{
@@ -1285,10 +1538,10 @@ func (g *Generator) generateConstructor(comp *guixast.Component) *ast.FuncDecl {
 				},
 			},
 		}
-	} else if len(comp.Params) > 0 {
+	} else if len(props) > 0 {
 		// Manual mode: pass parameters as-is
 		var paramFields []*ast.Field
-		for _, param := range comp.Params {
+		for _, param := range props {
 			paramType := g.typeToAST(param.Type)
 
 			// Handle variadic parameters
@@ -1325,7 +1578,7 @@ func (g *Generator) generateConstructor(comp *guixast.Component) *ast.FuncDecl {
 	}
 
 	// Add option application loop or parameter assignments based on AutoProps
-	if comp.AutoProps && len(comp.Params) > 0 {
+	if comp.AutoProps && len(props) > 0 {
 		// AutoProps mode: apply variadic options
 		bodyStmts = append(bodyStmts, &ast.RangeStmt{
 			Key:   ast.NewIdent("_"),
@@ -1343,9 +1596,9 @@ func (g *Generator) generateConstructor(comp *guixast.Component) *ast.FuncDecl {
 				},
 			},
 		})
-	} else if len(comp.Params) > 0 {
+	} else if len(props) > 0 {
 		// Manual mode: assign parameters to struct fields
-		for _, param := range comp.Params {
+		for _, param := range props {
 			bodyStmts = append(bodyStmts, &ast.AssignStmt{
 				Lhs: []ast.Expr{
 					&ast.SelectorExpr{
@@ -1359,21 +1612,31 @@ func (g *Generator) generateConstructor(comp *guixast.Component) *ast.FuncDecl {
 		}
 	}
 
+	// Read the initial value of each signal parameter
+	for _, param := range signalParams(comp) {
+		bodyStmts = append(bodyStmts, generateSignalInitStmt(param))
+	}
+
 	// Initialize hoisted variables (like channels) in constructor
 	if comp.Body != nil {
 		// Set up hoisted vars map for expression generation
 		g.hoistedVars = make(map[string]bool)
 		g.currentCompBody = comp.Body
 		for _, varDecl := range comp.Body.VarDecls {
-			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 &&
+			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 && !varDecl.Computed &&
 				g.inferTypeFromExpr(varDecl.Values[0]) != nil {
 				g.hoistedVars[varDecl.Names[0]] = true
 			}
 		}
 
+		// Constants may be used by the initial values
+		if len(g.hoistedVars) > 0 {
+			bodyStmts = append(bodyStmts, g.generateConstDecls(comp.Body.ConstDecls)...)
+		}
+
 		for _, varDecl := range comp.Body.VarDecls {
 			// Only initialize single-variable declarations with inferable types
-			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 {
+			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 && !varDecl.Computed {
 				// Check for channel receives FIRST (before varType check)
 				if varDecl.Values[0].Left != nil && varDecl.Values[0].Left.ChannelOp != nil {
 					// This is a channel receive: varName := <-channelName
@@ -1532,6 +1795,11 @@ func (g *Generator) generateConstructor(comp *guixast.Component) *ast.FuncDecl {
 			// Generate option calls from props
 			optionCalls := []ast.Expr{}
 			for _, prop := range childInfo.element.Props {
+				// A bare identifier is passed through as an option value
+				if prop.IsBare() {
+					optionCalls = append(optionCalls, g.generatePrimary(&guixast.Primary{Ident: prop.Name}))
+					continue
+				}
 				// Generate option function call: PropName(args...)
 				args := make([]ast.Expr, len(prop.Args))
 				for i, arg := range prop.Args {
@@ -1593,6 +1861,7 @@ func (g *Generator) generateRenderMethod(comp *guixast.Component) *ast.FuncDecl
 		g.collectChildComponents(comp.Body.Children)
 	}
 
+	derived := g.generateDerivedValues(comp.Body)
 	body := g.generateBody(comp.Body)
 
 	return &ast.FuncDecl{
@@ -1639,6 +1908,9 @@ func (g *Generator) generateRenderMethod(comp *guixast.Component) *ast.FuncDecl
 				})
 			}
 
+			// Derived values are computed once per render
+			stmts = append(stmts, derived...)
+
 			// Add return statement
 			stmts = append(stmts, &ast.ReturnStmt{
 				Results: []ast.Expr{body},
@@ -1651,7 +1923,8 @@ func (g *Generator) generateRenderMethod(comp *guixast.Component) *ast.FuncDecl
 
 // generateBody generates code for component body
 func (g *Generator) generateBody(body *guixast.Body) ast.Expr {
-	// Check if we need an IIFE (for VarDecls or ExprStmts)
+	// Check if we need an IIFE (for statements or ExprStmts).
+	// Derived values are declared by generateRenderMethod.
 	hasExprStmts := false
 	for _, child := range body.Children {
 		if child.ExprStmt != nil {
@@ -1660,48 +1933,10 @@ func (g *Generator) generateBody(body *guixast.Body) ast.Expr {
 		}
 	}
 
-	// If there are variable declarations, statements, or expression statements, wrap everything in an IIFE
-	if len(body.VarDecls) > 0 || len(body.Statements) > 0 || hasExprStmts {
+	// If there are statements or expression statements, wrap everything in an IIFE
+	if len(body.Statements) > 0 || hasExprStmts {
 		stmts := make([]ast.Stmt, 0)
 
-		// Add variable declarations
-		// VarDecls with inferable types (like channels) are hoisted to struct fields
-		// and initialized in the constructor - skip them here in Render
-		// All other VarDecls remain as local variables (including multiple assignments)
-		for _, varDecl := range body.VarDecls {
-			// Check if ALL variables in this decl can be hoisted
-			// For now, only hoist if there's a single variable with an inferable type
-			canHoist := len(varDecl.Names) == 1 && len(varDecl.Values) == 1 &&
-				g.inferTypeFromExpr(varDecl.Values[0]) != nil
-
-			// Check if this is a channel receive variable (skip - will be accessed via c.current<ChannelName>)
-			isChannelReceive := len(varDecl.Names) == 1 &&
-				g.channelReceiveVars != nil &&
-				g.channelReceiveVars[varDecl.Names[0]] != ""
-
-			if canHoist || isChannelReceive {
-				// Hoisted field or channel receive - skip initialization in Render
-				continue
-			} else {
-				// Local variable(s) - generate: name := value or n, err := value
-				lhs := make([]ast.Expr, len(varDecl.Names))
-				for i, name := range varDecl.Names {
-					lhs[i] = ast.NewIdent(name)
-				}
-
-				rhs := make([]ast.Expr, len(varDecl.Values))
-				for i, val := range varDecl.Values {
-					rhs[i] = g.generateExpr(val)
-				}
-
-				stmts = append(stmts, &ast.AssignStmt{
-					Lhs: lhs,
-					Tok: g.assignOpToToken(varDecl.Op),
-					Rhs: rhs,
-				})
-			}
-		}
-
 		// Add statements (but skip initialization statements for hoisted variables)
 		for _, stmt := range body.Statements {
 			// Skip channel sends to hoisted variables - those are initialization
@@ -1741,30 +1976,7 @@ func (g *Generator) generateBody(body *guixast.Body) ast.Expr {
 		}
 
 		// Generate the UI tree from non-statement children
-		var uiExpr ast.Expr
-		if len(uiChildren) == 0 {
-			uiExpr = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("runtime"),
-					Sel: ast.NewIdent("Div"),
-				},
-			}
-		} else if len(uiChildren) == 1 {
-			uiExpr = g.generateNode(uiChildren[0])
-		} else {
-			// Multiple children - use Fragment
-			args := make([]ast.Expr, len(uiChildren))
-			for i, child := range uiChildren {
-				args[i] = g.generateNode(child)
-			}
-			uiExpr = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("runtime"),
-					Sel: ast.NewIdent("Fragment"),
-				},
-				Args: args,
-			}
-		}
+		uiExpr := g.generateRoot(uiChildren)
 
 		// Return the UI tree
 		stmts = append(stmts, &ast.ReturnStmt{
@@ -1796,22 +2008,18 @@ func (g *Generator) generateBody(body *guixast.Body) ast.Expr {
 	}
 
 	// No statements - simple case
-	if len(body.Children) == 0 {
-		return &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("runtime"),
-				Sel: ast.NewIdent("Div"),
-			},
-		}
-	}
+	return g.generateRoot(body.Children)
+}
 
-	if len(body.Children) == 1 {
-		return g.generateNode(body.Children[0])
+// generateRoot generates the root of a component's UI tree. A single node is
+// returned as-is; zero or several top-level nodes are wrapped in a Fragment.
+func (g *Generator) generateRoot(children []*guixast.Node) ast.Expr {
+	if len(children) == 1 {
+		return g.generateNode(children[0])
 	}
 
-	// Multiple children - use Fragment
-	args := make([]ast.Expr, len(body.Children))
-	for i, child := range body.Children {
+	args := make([]ast.Expr, len(children))
+	for i, child := range children {
 		args[i] = g.generateNode(child)
 	}
 
@@ -1857,6 +2065,10 @@ func (g *Generator) generateNode(node *guixast.Node) ast.Expr {
 		return g.generateForLoop(node.ForLoop)
 	}
 
+	if node.Slot != nil {
+		return g.generateSlot(node.Slot)
+	}
+
 	// Default: empty div
 	return &ast.CallExpr{
 		Fun: &ast.SelectorExpr{
@@ -1875,6 +2087,13 @@ var knownDOMElements = map[string]bool{
 	"Td": true, "Th": true, "Thead": true, "Tbody": true, "Tfoot": true,
 	"Header": true, "Footer": true, "Nav": true, "Section": true, "Article": true,
 	"Aside": true, "Main": true, "Figure": true, "Figcaption": true,
+	"Output": true, "Progress": true, "Meter": true, "ErrorText": true,
+	// Layout components (generate a styled Div)
+	"HStack": true, "VStack": true, "Grid": true,
+	// Widgets
+	"ListBox": true, "EmptyState": true, "Image": true,
+	"Accordion": true, "Panel": true, "Tooltip": true, "Sortable": true, "CopyButton": true,
+	"Portal": true, "Fragment": true,
 	// WebGPU Canvas
 	"Canvas": true, "GPUScene": true, "GPUChart": true,
 }
@@ -1882,11 +2101,13 @@ var knownDOMElements = map[string]bool{
 // Known WebGPU/3D element names - treated as runtime elements like DOM elements
 var knownGPUElements = map[string]bool{
 	// Scene graph elements
-	"Scene": true, "Mesh": true, "Group": true,
+	"Scene": true, "Mesh": true, "InstancedMesh": true, "Group": true,
 	// Camera elements
 	"PerspectiveCamera": true, "OrthographicCamera": true,
 	// Light elements
-	"AmbientLight": true, "DirectionalLight": true, "PointLight": true,
+	"AmbientLight": true, "DirectionalLight": true, "PointLight": true, "SpotLight": true,
+	// Effect elements
+	"ParticleSystem": true,
 	// Chart elements
 	"Chart": true, "XAxis": true, "YAxis": true,
 	"CandlestickSeries": true, "LineSeries": true,
@@ -1902,44 +2123,70 @@ var runtimeFunctions = map[string]bool{
 	"Td": true, "Th": true, "Thead": true, "Tbody": true, "Tfoot": true,
 	"Header": true, "Footer": true, "Nav": true, "Section": true, "Article": true,
 	"Aside": true, "Main": true, "Figure": true, "Figcaption": true,
+	"Output": true, "Progress": true, "Meter": true, "ErrorText": true,
+	// Widgets
+	"ListBox": true, "Items": true, "Selected": true,
+	"Accordion": true, "Active": true, "Panel": true, "Tooltip": true, "Placement": true, "Text": true,
+	"Sortable": true, "SortableItems": true, "OnReorder": true, "CopyButton": true,
+	"Portal": true,
+	// Template helpers
+	"Join": true,
 	// WebGPU Canvas
 	"Canvas": true, "GPUScene": true, "GPUChart": true,
 	// GPU elements
-	"Scene": true, "Mesh": true, "Group": true,
+	"Scene": true, "Mesh": true, "InstancedMesh": true, "Group": true,
 	"PerspectiveCamera": true, "OrthographicCamera": true,
-	"AmbientLight": true, "DirectionalLight": true, "PointLight": true,
+	"AmbientLight": true, "DirectionalLight": true, "PointLight": true, "SpotLight": true,
+	"ParticleSystem": true,
 	// GPU properties
 	"Position": true, "Rotation": true, "ScaleValue": true,
-	"Color": true, "Metalness": true, "Roughness": true,
-	"Intensity": true, "FOV": true, "Near": true, "Far": true,
+	"Color": true, "Metalness": true, "Roughness": true, "Transparent": true,
+	"Intensity": true, "Direction": true, "ConeAngle": true, "FOV": true, "Near": true, "Far": true,
+	"Count": true, "Shader": true, "ParticleSize": true,
 	"LookAtPos": true, "Background": true,
 	"Width": true, "Height": true,
-	"GeometryProp": true, "MaterialProp": true, "GPURenderUpdate": true,
+	"GeometryProp": true, "MaterialProp": true, "MaterialOf": true, "GPURenderUpdate": true,
 	"WithGeometry": true, "WithMaterial": true, "BindRotation": true,
 	// GPU constructors
 	"NewBoxGeometry": true, "NewSphereGeometry": true, "NewPlaneGeometry": true,
+	"BoxGeometryNode": true, "SphereGeometryNode": true, "PlaneGeometryNode": true, "VertexColors": true,
 	"StandardMaterial": true,
 	// Math functions
 	"DegreesToRadians": true, "RadiansToDegrees": true,
 	// Props and attributes
 	"Class": true, "ID": true, "Href": true, "Src": true,
+	"Classes": true, "ClassIf": true,
 	"Type": true, "Placeholder": true, "Value": true, "Disabled": true, "Checked": true,
 	"Name": true, "Alt": true, "Title": true, "Style": true,
 	"Min": true, "Max": true, "Step": true, "TabIndex": true,
+	"Low": true, "High": true, "Optimum": true, "Role": true,
+	"SrcSet": true, "Sizes": true, "Lazy": true,
 	// Event handlers
 	"OnClick": true, "OnInput": true, "OnChange": true, "OnSubmit": true,
 	"OnKeyDown": true, "OnKeyUp": true, "OnKeyPress": true,
 	"OnMouseOver": true, "OnMouseOut": true, "OnMouseEnter": true, "OnMouseLeave": true,
-	"OnFocus": true, "OnBlur": true,
+	"OnWheel": true, "OnScroll": true, "OnContextMenu": true,
+	"OnFocus": true, "OnBlur": true, "OnLoad": true,
+	"Debounced": true, "Throttled": true, "SwapPlaceholder": true,
+	"PreventDefault": true, "StopPropagation": true, "Passive": true,
+	// Lifecycle hooks
+	"OnMount": true, "OnBeforeUnmount": true,
 	// Chart elements
 	"Chart": true, "XAxis": true, "YAxis": true,
 	"CandlestickSeries": true, "LineSeries": true,
 	// Chart properties
-	"ChartBackground": true, "ChartPadding": true, "ChartInteractive": true,
-	"AxisPosition": true, "TimeScale": true, "GridLines": true, "GridColor": true,
+	"ChartBackground": true, "ChartPadding": true, "ChartInteractive": true, "ChartDepth": true,
+	"ChartIndexedLines": true, "AxisPosition": true, "TimeScale": true, "GridLines": true, "GridColor": true,
 	"ChartData": true,
 	"UpColor":   true, "DownColor": true, "WickColor": true, "BarWidth": true,
-	"StrokeColor": true, "StrokeWidth": true, "FillColor": true, "FillEnabled": true,
+	"StrokeColor": true, "StrokeWidth": true, "FillColor": true, "FillEnabled": true, "ZIndex": true,
+	"StrokeStyle": true, "DashPattern": true,
+}
+
+// templateHelpers maps the lowercase helpers templates can call, such as
+// {join(tags, ", ")}, to their runtime functions
+var templateHelpers = map[string]string{
+	"join": "Join",
 }
 
 // isRuntimeFunction checks if a function name is a known runtime function
@@ -1949,6 +2196,26 @@ func isRuntimeFunction(name string) bool {
 
 // generateElement generates code for an element
 func (g *Generator) generateElement(elem *guixast.Element) ast.Expr {
+	if elem.Tag == "ErrorText" {
+		return g.generateErrorText(elem)
+	}
+	if isLayoutElement(elem.Tag) {
+		return g.generateLayout(elem)
+	}
+	if elem.Tag == "Image" {
+		return g.generateImage(elem)
+	}
+	if elem.Tag == "Fragment" {
+		return g.generateFragment(elem)
+	}
+
+	// Scene shorthands such as Camera render their runtime element
+	if tag := g.sceneShorthand(elem.Tag); tag != "" {
+		shorthand := *elem
+		shorthand.Tag = tag
+		elem = &shorthand
+	}
+
 	args := []ast.Expr{}
 
 	// Check if this is a custom component
@@ -1960,6 +2227,11 @@ func (g *Generator) generateElement(elem *guixast.Element) ast.Expr {
 	// Add props as arguments
 	for _, prop := range elem.Props {
 		if isComponent {
+			// A bare identifier is passed through as an option value
+			if prop.IsBare() {
+				args = append(args, g.generatePrimary(&guixast.Primary{Ident: prop.Name}))
+				continue
+			}
 			// For components, props are passed as option functions: PropName(args...)
 			propArgs := make([]ast.Expr, len(prop.Args))
 			for i, arg := range prop.Args {
@@ -1969,31 +2241,54 @@ func (g *Generator) generateElement(elem *guixast.Element) ast.Expr {
 				Fun:  ast.NewIdent(prop.Name),
 				Args: propArgs,
 			})
+		} else if prop.IsBind() {
+			// Bind(field) expands to a value setter plus a change listener
+			args = append(args, g.generateBind(elem, prop)...)
+		} else if elem.Tag == "Form" && isFormValuesSubmit(elem, prop) {
+			// OnSubmit(func(values FormValues)) receives the bound field values
+			args = append(args, g.generateFormSubmit(elem, prop))
 		} else {
 			// For DOM elements, wrap in runtime.Prop()
 			args = append(args, g.generateProp(prop))
 		}
 	}
 
-	// Add children
+	// Add children. Components receive them with SetChildren instead.
+	var children []ast.Expr
 	for _, child := range elem.Children {
-		args = append(args, g.generateNode(child))
+		children = append(children, g.generateNode(child))
+	}
+	if !isComponent {
+		args = append(args, children...)
 	}
 
 	// Generate function call
 	if isComponent {
 		// Check if this component is hoisted
 		if hoistedInfo, isHoisted := g.hoistedComponentMap[elem]; isHoisted {
-			// Hoisted component: just call Render on the hoisted instance
-			// Generate: c.counterInstance.Render()
+			// Hoisted component: render the hoisted instance, which an @memo
+			// component skips while its props are unchanged
+			// Generate: runtime.RenderComponent(c.counterInstance), or
+			// runtime.RenderWithChildren(c.cardInstance, children...)
+			instance := &ast.SelectorExpr{
+				X:   ast.NewIdent("c"),
+				Sel: ast.NewIdent(hoistedInfo.varName),
+			}
+			if len(children) > 0 {
+				return &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("runtime"),
+						Sel: ast.NewIdent("RenderWithChildren"),
+					},
+					Args: append([]ast.Expr{instance}, children...),
+				}
+			}
 			return &ast.CallExpr{
 				Fun: &ast.SelectorExpr{
-					X: &ast.SelectorExpr{
-						X:   ast.NewIdent("c"),
-						Sel: ast.NewIdent(hoistedInfo.varName),
-					},
-					Sel: ast.NewIdent("Render"),
+					X:   ast.NewIdent("runtime"),
+					Sel: ast.NewIdent("RenderComponent"),
 				},
+				Args: []ast.Expr{instance},
 			}
 		}
 
@@ -2005,6 +2300,22 @@ func (g *Generator) generateElement(elem *guixast.Element) ast.Expr {
 		//     return comp.Render()
 		// }()
 		compVar := "_" + strings.ToLower(elem.Tag[:1]) + elem.Tag[1:]
+		var render ast.Expr = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent(compVar),
+				Sel: ast.NewIdent("Render"),
+			},
+		}
+		if len(children) > 0 {
+			// return runtime.RenderWithChildren(comp, children...)
+			render = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("runtime"),
+					Sel: ast.NewIdent("RenderWithChildren"),
+				},
+				Args: append([]ast.Expr{ast.NewIdent(compVar)}, children...),
+			}
+		}
 		return &ast.CallExpr{
 			Fun: &ast.FuncLit{
 				Type: &ast.FuncType{
@@ -2065,14 +2376,7 @@ func (g *Generator) generateElement(elem *guixast.Element) ast.Expr {
 						},
 						// return comp.Render()
 						&ast.ReturnStmt{
-							Results: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent(compVar),
-										Sel: ast.NewIdent("Render"),
-									},
-								},
-							},
+							Results: []ast.Expr{render},
 						},
 					},
 				},
@@ -2099,37 +2403,337 @@ func (g *Generator) generateElement(elem *guixast.Element) ast.Expr {
 	}
 }
 
-// generateProp generates code for a prop/event handler
-func (g *Generator) generateProp(prop *guixast.Prop) ast.Expr {
-	// Generate runtime.Name(args...)
-	var fun ast.Expr
-	if isRuntimeFunction(prop.Name) {
-		fun = &ast.SelectorExpr{
-			X:   ast.NewIdent("runtime"),
-			Sel: ast.NewIdent(prop.Name),
+// isChannelParam checks if name is a channel parameter of the current component
+func (g *Generator) isChannelParam(name string) bool {
+	if g.currentComp == nil {
+		return false
+	}
+	for _, param := range g.currentComp.Params {
+		if param.Name == name && param.Type != nil && (param.Type.IsChannel || param.Type.IsChan) {
+			return true
 		}
-	} else {
-		fun = ast.NewIdent(prop.Name)
 	}
+	return false
+}
 
-	args := make([]ast.Expr, len(prop.Args))
-	for i, arg := range prop.Args {
-		args[i] = g.generateExpr(arg)
-	}
+// generateErrorText generates an alert that renders the current error, or nothing when it is nil
+// ErrorText(errChannel) reads the value last received from the channel
+func (g *Generator) generateErrorText(elem *guixast.Element) ast.Expr {
+	errExpr, options := g.errorTextSource(elem)
 
+	// func() *runtime.VNode {
+	//     if err == nil { return runtime.Fragment() }
+	//     return runtime.ErrorText(err.Error(), options...)
+	// }()
 	return &ast.CallExpr{
-		Fun:  fun,
-		Args: args,
-	}
-}
-
-// generateTemplate generates code for template interpolation
-func (g *Generator) generateTemplate(tmpl *guixast.Template) ast.Expr {
-	if len(tmpl.Fragments) == 0 {
-		return &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("runtime"),
-				Sel: ast.NewIdent("Text"),
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("runtime"),
+									Sel: ast.NewIdent("VNode"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.IfStmt{
+						Cond: &ast.BinaryExpr{
+							X:  errExpr,
+							Op: token.EQL,
+							Y:  ast.NewIdent("nil"),
+						},
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								&ast.ReturnStmt{
+									Results: []ast.Expr{
+										&ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X:   ast.NewIdent("runtime"),
+												Sel: ast.NewIdent("Fragment"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("runtime"),
+									Sel: ast.NewIdent("ErrorText"),
+								},
+								Args: append([]ast.Expr{
+									&ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   errExpr,
+											Sel: ast.NewIdent("Error"),
+										},
+									},
+								}, options...),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// errorTextSource returns the error expression bound to an ErrorText element
+// (the bare prop) and its remaining props as runtime options
+func (g *Generator) errorTextSource(elem *guixast.Element) (ast.Expr, []ast.Expr) {
+	var errExpr ast.Expr = ast.NewIdent("nil")
+	var options []ast.Expr
+	for _, prop := range elem.Props {
+		if !prop.IsBare() {
+			options = append(options, g.generateProp(prop))
+			continue
+		}
+		if g.isChannelParam(prop.Name) {
+			errExpr = &ast.SelectorExpr{
+				X:   ast.NewIdent("c"),
+				Sel: ast.NewIdent("current" + capitalize(prop.Name)),
+			}
+		} else {
+			errExpr = g.generatePrimary(&guixast.Primary{Ident: prop.Name})
+		}
+	}
+	return errExpr, options
+}
+
+// isCheckboxElement checks if an element is an Input with Type("checkbox")
+func isCheckboxElement(elem *guixast.Element) bool {
+	if elem.Tag != "Input" {
+		return false
+	}
+	for _, prop := range elem.Props {
+		if prop.Name != "Type" || len(prop.Args) != 1 {
+			continue
+		}
+		arg := prop.Args[0]
+		if arg.Left != nil && arg.Left.Literal != nil && arg.Left.Literal.String != nil {
+			if value, err := strconv.Unquote(*arg.Left.Literal.String); err == nil && value == "checkbox" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateBind generates two-way binding for Bind(field) on form elements
+// Text inputs bind the value property and listen to input events,
+// checkboxes bind checked and Select elements listen to change events:
+//
+//	runtime.Value(c.field), runtime.OnInput(func(e runtime.Event) {
+//	    c.field = e.Target.Value
+//	    if c.app != nil { c.app.Update() }
+//	})
+func (g *Generator) generateBind(elem *guixast.Element, prop *guixast.Prop) []ast.Expr {
+	target := prop.BindTarget()
+
+	setter, eventName, targetField := "Value", "OnInput", "Value"
+	if isCheckboxElement(elem) {
+		setter, eventName, targetField = "Checked", "OnChange", "Checked"
+	} else if elem.Tag == "Select" {
+		eventName = "OnChange"
+	}
+
+	handler := &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("e")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("runtime"),
+							Sel: ast.NewIdent("Event"),
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{g.generateExpr(target)},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.SelectorExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("e"),
+								Sel: ast.NewIdent("Target"),
+							},
+							Sel: ast.NewIdent(targetField),
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("c"),
+							Sel: ast.NewIdent("app"),
+						},
+						Op: token.NEQ,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("c"),
+											Sel: ast.NewIdent("app"),
+										},
+										Sel: ast.NewIdent("Update"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return []ast.Expr{
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent(setter),
+			},
+			Args: []ast.Expr{g.generateExpr(target)},
+		},
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent(eventName),
+			},
+			Args: []ast.Expr{handler},
+		},
+	}
+}
+
+// generateProp generates code for a prop/event handler
+func (g *Generator) generateProp(prop *guixast.Prop) ast.Expr {
+	// A bare identifier passes the value itself, e.g. a variable holding an option
+	if prop.IsBare() {
+		return g.generatePrimary(&guixast.Primary{Ident: prop.Name})
+	}
+
+	// Class("name", cond) toggles a class on a boolean, e.g. <-isActiveChannel
+	if prop.Name == "Class" && len(prop.Args) == 2 {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("ToggleClass"),
+			},
+			Args: []ast.Expr{g.generateExpr(prop.Args[0]), g.generateExpr(prop.Args[1])},
+		}
+	}
+
+	// Transform props of a Scene that read channels are re-evaluated each frame
+	if g.isBoundSceneProp(prop) {
+		return g.generateBoundSceneProp(prop)
+	}
+
+	// Style(CSS{...}) passes the map itself, which El turns into the style
+	// attribute; runtime.Style is a string type
+	if prop.Name == "Style" && len(prop.Args) == 1 && cssLit(prop.Args[0]) != nil {
+		return g.generateExpr(prop.Args[0])
+	}
+
+	// Key(id) sets the reconciliation key; runtime.Key is a type, so use WithKey
+	if prop.Name == "Key" && len(prop.Args) == 1 {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("WithKey"),
+			},
+			Args: []ast.Expr{g.generateExpr(prop.Args[0])},
+		}
+	}
+
+	// Scene shorthands such as Geometry(...) use their runtime builder
+	if name := g.sceneShorthand(prop.Name); name != "" {
+		shorthand := *prop
+		shorthand.Name = name
+		prop = &shorthand
+	}
+
+	// Generate runtime.Name(args...)
+	var fun ast.Expr
+	if isRuntimeFunction(prop.Name) {
+		fun = &ast.SelectorExpr{
+			X:   ast.NewIdent("runtime"),
+			Sel: ast.NewIdent(prop.Name),
+		}
+	} else {
+		fun = ast.NewIdent(prop.Name)
+	}
+
+	args := make([]ast.Expr, len(prop.Args))
+	for i, arg := range prop.Args {
+		args[i] = g.generateExpr(arg)
+	}
+
+	// A handler declared as func(e KeyboardEvent) is wrapped in the adapter
+	// that fills in the richer event
+	if strings.HasPrefix(prop.Name, "On") && len(prop.Args) == 1 {
+		if adapter := typedEventAdapter(prop.Args[0]); adapter != "" {
+			args[0] = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("runtime"),
+					Sel: ast.NewIdent(adapter),
+				},
+				Args: []ast.Expr{args[0]},
+			}
+		}
+	}
+
+	return &ast.CallExpr{
+		Fun:  fun,
+		Args: args,
+	}
+}
+
+// typedEventAdapters maps event subtypes to the runtime adapter that turns a
+// handler taking them into a func(Event)
+var typedEventAdapters = map[string]string{
+	"KeyboardEvent": "KeyboardHandler",
+	"MouseEvent":    "MouseHandler",
+	"WheelEvent":    "WheelHandler",
+}
+
+// typedEventAdapter returns the adapter for a func literal handler whose
+// parameter is an event subtype, or "" for other handlers
+func typedEventAdapter(arg *guixast.Expr) string {
+	if arg == nil || len(arg.BinOps) != 0 || arg.Left == nil || arg.Left.FuncLit == nil {
+		return ""
+	}
+	params := arg.Left.FuncLit.Params
+	if len(params) != 1 || params[0].Type == nil || params[0].Type.IsPointer || params[0].Type.IsSlice {
+		return ""
+	}
+	return typedEventAdapters[params[0].Type.Name]
+}
+
+// generateTemplate generates code for template interpolation
+func (g *Generator) generateTemplate(tmpl *guixast.Template) ast.Expr {
+	if len(tmpl.Fragments) == 0 {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("Text"),
 			},
 			Args: []ast.Expr{
 				&ast.BasicLit{
@@ -2149,15 +2753,11 @@ func (g *Generator) generateTemplate(tmpl *guixast.Template) ast.Expr {
 				Kind:  token.STRING,
 				Value: fmt.Sprintf(`"%s"`, frag.Text),
 			})
+		} else if frag.Expr != nil && frag.Format != "" {
+			parts = append(parts, g.format(frag.Expr, frag.Format))
 		} else if frag.Expr != nil {
 			// Convert expression to string
-			parts = append(parts, &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("fmt"),
-					Sel: ast.NewIdent("Sprint"),
-				},
-				Args: []ast.Expr{g.generateExpr(frag.Expr)},
-			})
+			parts = append(parts, g.stringify(frag.Expr))
 		}
 	}
 
@@ -2187,93 +2787,15 @@ func (g *Generator) generateTemplate(tmpl *guixast.Template) ast.Expr {
 	}
 }
 
-// generateIfExpr generates code for a conditional expression (if/else)
+// generateIfExpr generates code for a conditional expression (if/else if/else)
 // Generates an IIFE that returns different VNodes based on the condition
 func (g *Generator) generateIfExpr(ifExpr *guixast.IfExpr) ast.Expr {
-	// Generate the condition expression
-	cond := g.generateExpr(ifExpr.Cond)
-
-	// Generate the true branch body
-	trueNodes := []ast.Expr{}
-	if ifExpr.TrueBody != nil {
-		for _, node := range ifExpr.TrueBody.Children {
-			trueNodes = append(trueNodes, g.generateNode(node))
-		}
-	}
-
-	// Generate the false branch body (if it exists)
-	var falseBody []ast.Stmt
-	if ifExpr.FalseBody != nil {
-		falseNodes := []ast.Expr{}
-		for _, node := range ifExpr.FalseBody.Children {
-			falseNodes = append(falseNodes, g.generateNode(node))
-		}
-
-		// If there are multiple nodes in false branch, wrap in fragment
-		var falseReturn ast.Expr
-		if len(falseNodes) == 0 {
-			falseReturn = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("runtime"),
-					Sel: ast.NewIdent("Div"),
-				},
-			}
-		} else if len(falseNodes) == 1 {
-			falseReturn = falseNodes[0]
-		} else {
-			falseReturn = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("runtime"),
-					Sel: ast.NewIdent("Fragment"),
-				},
-				Args: falseNodes,
-			}
-		}
-
-		falseBody = []ast.Stmt{
-			&ast.ReturnStmt{Results: []ast.Expr{falseReturn}},
-		}
-	} else {
-		// No else clause - return empty div
-		falseBody = []ast.Stmt{
-			&ast.ReturnStmt{
-				Results: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("runtime"),
-							Sel: ast.NewIdent("Div"),
-						},
-					},
-				},
-			},
-		}
-	}
-
-	// Build the true body return statement
-	var trueReturn ast.Expr
-	if len(trueNodes) == 0 {
-		trueReturn = &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("runtime"),
-				Sel: ast.NewIdent("Div"),
-			},
-		}
-	} else if len(trueNodes) == 1 {
-		trueReturn = trueNodes[0]
-	} else {
-		trueReturn = &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("runtime"),
-				Sel: ast.NewIdent("Fragment"),
-			},
-			Args: trueNodes,
-		}
-	}
-
 	// Create the IIFE:
 	// func() *runtime.VNode {
 	//     if <condition> {
 	//         return <trueBody>
+	//     } else if <condition> {
+	//         return <elseIfBody>
 	//     } else {
 	//         return <falseBody>
 	//     }
@@ -2295,35 +2817,166 @@ func (g *Generator) generateIfExpr(ifExpr *guixast.IfExpr) ast.Expr {
 				},
 			},
 			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.IfStmt{
-						Cond: cond,
-						Body: &ast.BlockStmt{
-							List: []ast.Stmt{
-								&ast.ReturnStmt{Results: []ast.Expr{trueReturn}},
-							},
-						},
-						Else: &ast.BlockStmt{
-							List: falseBody,
-						},
-					},
-				},
+				List: []ast.Stmt{g.generateIfExprStmt(ifExpr)},
+			},
+		},
+	}
+}
+
+// generateIfExprStmt generates the if statement for one link of an
+// if/else if/else chain. A missing else returns an empty Fragment.
+func (g *Generator) generateIfExprStmt(ifExpr *guixast.IfExpr) *ast.IfStmt {
+	stmt := &ast.IfStmt{
+		Cond: g.generateExpr(ifExpr.Cond),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{g.generateBranch(ifExpr.TrueBody)}},
 			},
 		},
 	}
+
+	if ifExpr.ElseIf != nil {
+		stmt.Else = g.generateIfExprStmt(ifExpr.ElseIf)
+	} else {
+		stmt.Else = &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{g.generateBranch(ifExpr.FalseBody)}},
+			},
+		}
+	}
+
+	return stmt
 }
 
-// generateForLoop generates code for a for loop expression
-// For now, this is a placeholder - proper implementation needed
+// generateBranch generates the VNode of an if/else branch: the single child,
+// a Fragment of several children, or an empty Fragment
+func (g *Generator) generateBranch(body *guixast.Body) ast.Expr {
+	if body == nil {
+		return g.generateRoot(nil)
+	}
+	return g.generateRoot(body.Children)
+}
+
+// generateForLoop generates a range or C-style loop in the UI tree as a
+// Fragment of each iteration's nodes:
+//
+//	func() *runtime.VNode {
+//	    var nodes []*runtime.VNode
+//	    for _, item := range c.Items {
+//	        nodes = append(nodes, runtime.Li(runtime.Text(item)))
+//	    }
+//	    if len(nodes) == 0 {
+//	        return runtime.P(runtime.Text("No items"))
+//	    }
+//	    return runtime.Fragment(nodes...)
+//	}()
 func (g *Generator) generateForLoop(forLoop *guixast.ForLoop) ast.Expr {
-	// TODO: Implement for loop rendering
-	// This would generate code that iterates and collects VNodes
-	return &ast.CallExpr{
-		Fun: &ast.SelectorExpr{
+	body := forLoop.LoopBody()
+	if body == nil {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("Div"),
+			},
+		}
+	}
+
+	vnodeType := &ast.StarExpr{
+		X: &ast.SelectorExpr{
 			X:   ast.NewIdent("runtime"),
-			Sel: ast.NewIdent("Div"),
+			Sel: ast.NewIdent("VNode"),
 		},
 	}
+
+	// Loop body: locals and statements, then append the item's nodes
+	loopStmts := append(g.generateConstDecls(body.ConstDecls), g.generateLocalDecls(body.VarDecls)...)
+	for _, stmt := range body.Statements {
+		if genStmt := g.generateBodyStatement(stmt); genStmt != nil {
+			loopStmts = append(loopStmts, genStmt)
+		}
+	}
+
+	itemNodes, emptyState := splitEmptyState(body.Children)
+	if len(itemNodes) > 0 {
+		loopStmts = append(loopStmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("nodes")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun:  ast.NewIdent("append"),
+					Args: []ast.Expr{ast.NewIdent("nodes"), g.generateRoot(itemNodes)},
+				},
+			},
+		})
+	}
+
+	stmts := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("nodes")},
+						Type:  &ast.ArrayType{Elt: vnodeType},
+					},
+				},
+			},
+		},
+		g.generateLoop(forLoop, &ast.BlockStmt{List: loopStmts}),
+	}
+
+	// An EmptyState child renders instead of the items when there are none
+	if emptyState != nil {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{ast.NewIdent("nodes")}},
+				Op: token.EQL,
+				Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: []ast.Expr{g.generateRoot(emptyState.Children)}},
+				},
+			},
+		})
+	}
+
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("runtime"),
+					Sel: ast.NewIdent("Fragment"),
+				},
+				Args:     []ast.Expr{ast.NewIdent("nodes")},
+				Ellipsis: 1,
+			},
+		},
+	})
+
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Results: &ast.FieldList{List: []*ast.Field{{Type: vnodeType}}},
+			},
+			Body: &ast.BlockStmt{List: stmts},
+		},
+	}
+}
+
+// splitEmptyState separates the per-item nodes of a for loop body from its
+// EmptyState child, which is rendered when the loop produces no items
+func splitEmptyState(children []*guixast.Node) ([]*guixast.Node, *guixast.Element) {
+	var items []*guixast.Node
+	var emptyState *guixast.Element
+	for _, child := range children {
+		if child.Element != nil && child.Element.Tag == "EmptyState" {
+			emptyState = child.Element
+			continue
+		}
+		items = append(items, child)
+	}
+	return items, emptyState
 }
 
 // generateExpr generates code for an expression
@@ -2332,6 +2985,10 @@ func (g *Generator) generateExpr(expr *guixast.Expr) ast.Expr {
 		return ast.NewIdent("nil")
 	}
 
+	if expr.Cond != nil {
+		return g.generateCondExpr(expr)
+	}
+
 	// Generate the left (primary) expression
 	left := g.generatePrimary(expr.Left)
 
@@ -2404,6 +3061,14 @@ func (g *Generator) generatePrimary(primary *guixast.Primary) ast.Expr {
 			}
 		}
 
+		// Computed values are stored in current<Name> fields
+		if g.computedDecl(primary.Ident) != nil {
+			return &ast.SelectorExpr{
+				X:   ast.NewIdent(g.receiverName),
+				Sel: ast.NewIdent(computedField(primary.Ident)),
+			}
+		}
+
 		// Check if it's an automatically generated channel field (e.g., currentState for state chan ControlState)
 		// Pattern: current + capitalize(paramName)
 		if g.currentComp != nil && strings.HasPrefix(primary.Ident, "current") && len(primary.Ident) > 7 {
@@ -2446,6 +3111,16 @@ func (g *Generator) generatePrimary(primary *guixast.Primary) ast.Expr {
 	}
 
 	if primary.ChannelOp != nil {
+		// Scene components read the channel's latest value without blocking
+		if g.receiverName == "s" {
+			return &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent(latestValueName(primary.ChannelOp.Channel)),
+					Sel: ast.NewIdent("Value"),
+				},
+			}
+		}
+
 		// Check if this channel receive has a hoisted variable (e.g., currentState := <-stateChannel)
 		// by looking for a variable that receives from this channel
 		capitalizedChannelName := capitalize(primary.ChannelOp.Channel)
@@ -2530,22 +3205,84 @@ func (g *Generator) binaryOpToToken(op string) token.Token {
 	}
 }
 
-// generateCompositeLit generates code for a composite literal (struct initialization)
+// generateCompositeLit generates code for a composite literal: a struct
+// initialization, or a slice or map literal
 func (g *Generator) generateCompositeLit(lit *guixast.CompositeLit) ast.Expr {
+	if lit.SliceOf != nil {
+		return &ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: g.typeToAST(lit.SliceOf)},
+			Elts: g.generateLitElems(lit.Values, lit.SliceOf, false),
+		}
+	}
+	if lit.Map != nil {
+		return &ast.CompositeLit{
+			Type: g.typeToAST(&guixast.Type{Map: lit.Map}),
+			Elts: g.generateLitElems(lit.Values, lit.Map.Value, true),
+		}
+	}
+
 	elts := make([]ast.Expr, len(lit.Elements))
 	for i, elem := range lit.Elements {
+		var key ast.Expr = ast.NewIdent(elem.Key)
+		if elem.LitKey != nil {
+			key = g.generateLiteral(elem.LitKey)
+		}
 		elts[i] = &ast.KeyValueExpr{
-			Key:   ast.NewIdent(elem.Key),
+			Key:   key,
 			Value: g.generateExpr(elem.Value),
 		}
 	}
 
 	return &ast.CompositeLit{
-		Type: ast.NewIdent(lit.Type),
+		Type: g.typeToAST(&guixast.Type{Name: lit.Type}),
 		Elts: elts,
 	}
 }
 
+// generateLitElems generates the elements of a slice or map literal, or of
+// a composite literal with its type elided. elemType is the type of the
+// values, used for the literals elided in them. Keys of map entries are
+// expressions, while the keys of an elided struct are field names.
+func (g *Generator) generateLitElems(elems []*guixast.LitElem, elemType *guixast.Type, isMap bool) []ast.Expr {
+	elts := make([]ast.Expr, len(elems))
+	for i, elem := range elems {
+		var value ast.Expr
+		if elem.Elided {
+			value = g.generateElidedLit(elem.Elements, elemType)
+		} else {
+			value = g.generateExpr(elem.Value)
+		}
+
+		switch {
+		case elem.LitKey != nil:
+			elts[i] = &ast.KeyValueExpr{Key: g.generateLiteral(elem.LitKey), Value: value}
+		case elem.Key != "" && isMap:
+			key := g.generateExpr(&guixast.Expr{Left: &guixast.Primary{Ident: elem.Key}})
+			elts[i] = &ast.KeyValueExpr{Key: key, Value: value}
+		case elem.Key != "":
+			elts[i] = &ast.KeyValueExpr{Key: ast.NewIdent(elem.Key), Value: value}
+		default:
+			elts[i] = value
+		}
+	}
+	return elts
+}
+
+// generateElidedLit generates a composite literal of typ with the type
+// elided, as in []Point{{X: 1, Y: 2}}
+func (g *Generator) generateElidedLit(elems []*guixast.LitElem, typ *guixast.Type) ast.Expr {
+	switch {
+	case typ != nil && typ.Map != nil && !typ.IsSlice:
+		return &ast.CompositeLit{Elts: g.generateLitElems(elems, typ.Map.Value, true)}
+	case typ != nil && typ.IsSlice:
+		elemType := *typ
+		elemType.IsSlice = false
+		return &ast.CompositeLit{Elts: g.generateLitElems(elems, &elemType, false)}
+	default:
+		return &ast.CompositeLit{Elts: g.generateLitElems(elems, nil, false)}
+	}
+}
+
 // generateLiteral generates code for a literal
 func (g *Generator) generateLiteral(lit *guixast.Literal) ast.Expr {
 	if lit.String != nil {
@@ -2556,8 +3293,12 @@ func (g *Generator) generateLiteral(lit *guixast.Literal) ast.Expr {
 	}
 
 	if lit.Number != nil {
+		kind := token.INT
+		if isFloatLiteral(*lit.Number) {
+			kind = token.FLOAT
+		}
 		return &ast.BasicLit{
-			Kind:  token.INT,
+			Kind:  kind,
 			Value: *lit.Number,
 		}
 	}
@@ -2569,31 +3310,23 @@ func (g *Generator) generateLiteral(lit *guixast.Literal) ast.Expr {
 	return ast.NewIdent("nil")
 }
 
+// isFloatLiteral checks if a number literal is a float, like 1.5 or 6.022e23
+func isFloatLiteral(num string) bool {
+	return strings.ContainsAny(num, ".eE")
+}
+
 // generateMakeCall generates code for a make() function call
 // Example: make(chan int, 10)
 func (g *Generator) generateMakeCall(makeCall *guixast.MakeCall) ast.Expr {
-	var args []ast.Expr
+	args := []ast.Expr{g.makeCallType(makeCall)}
 
 	if makeCall.ChanType != nil {
 		// Channel make: make(chan Type, size)
-		args = []ast.Expr{
-			&ast.ChanType{
-				Dir:   ast.SEND | ast.RECV,
-				Value: g.typeToAST(makeCall.ChanType),
-			},
-		}
-		// Add size argument if present
 		if makeCall.ChanSize != nil {
 			args = append(args, g.generateExpr(makeCall.ChanSize))
 		}
 	} else if makeCall.SliceType != nil {
 		// Slice make: make([]Type, len, cap)
-		args = []ast.Expr{
-			&ast.ArrayType{
-				Elt: g.typeToAST(makeCall.SliceType),
-			},
-		}
-		// Add length argument
 		if makeCall.SliceLen != nil {
 			args = append(args, g.generateExpr(makeCall.SliceLen))
 		}
@@ -2601,6 +3334,11 @@ func (g *Generator) generateMakeCall(makeCall *guixast.MakeCall) ast.Expr {
 		if makeCall.SliceCap != nil {
 			args = append(args, g.generateExpr(makeCall.SliceCap))
 		}
+	} else if makeCall.MapType != nil {
+		// Map make: make(map[Key]Value, size)
+		if makeCall.MapSize != nil {
+			args = append(args, g.generateExpr(makeCall.MapSize))
+		}
 	}
 
 	return &ast.CallExpr{
@@ -2609,6 +3347,21 @@ func (g *Generator) generateMakeCall(makeCall *guixast.MakeCall) ast.Expr {
 	}
 }
 
+// makeCallType returns the type a make() call creates
+func (g *Generator) makeCallType(makeCall *guixast.MakeCall) ast.Expr {
+	switch {
+	case makeCall.SliceType != nil:
+		return &ast.ArrayType{Elt: g.typeToAST(makeCall.SliceType)}
+	case makeCall.MapType != nil:
+		return g.typeToAST(&guixast.Type{Map: makeCall.MapType})
+	default:
+		return &ast.ChanType{
+			Dir:   ast.SEND | ast.RECV,
+			Value: g.typeToAST(makeCall.ChanType),
+		}
+	}
+}
+
 // generateCallOrSelect generates code for a call or selector expression
 // If Args is present, generates a call. Otherwise, generates a selector.
 // generateIndexExpr generates an index or slice expression
@@ -2616,7 +3369,7 @@ func (g *Generator) generateIndexExpr(idx *guixast.IndexExpr) ast.Expr {
 	if idx.Index != nil {
 		// Regular indexing: arr[index]
 		return &ast.IndexExpr{
-			X:     ast.NewIdent(idx.Base),
+			X:     g.generatePrimary(&guixast.Primary{Ident: idx.Base}),
 			Index: g.generateExpr(idx.Index),
 		}
 	} else if idx.Slice != nil {
@@ -2629,7 +3382,7 @@ func (g *Generator) generateIndexExpr(idx *guixast.IndexExpr) ast.Expr {
 			high = g.generateExpr(idx.Slice.High)
 		}
 		return &ast.SliceExpr{
-			X:    ast.NewIdent(idx.Base),
+			X:    g.generatePrimary(&guixast.Primary{Ident: idx.Base}),
 			Low:  low,
 			High: high,
 		}
@@ -2638,6 +3391,25 @@ func (g *Generator) generateIndexExpr(idx *guixast.IndexExpr) ast.Expr {
 }
 
 func (g *Generator) generateCallOrSelect(cos *guixast.CallOrSelect) ast.Expr {
+	// Template helper calls such as join(...) go to their runtime function,
+	// unless a parameter or variable shadows the helper
+	if helper, ok := templateHelpers[cos.Base]; ok && len(cos.Fields) == 0 && cos.Args != nil &&
+		!g.componentParams[cos.Base] && !g.hoistedVars[cos.Base] {
+		call := *cos
+		call.Base = helper
+		cos = &call
+	}
+	// Scene shorthands such as Box(1, 1, 1) call their runtime builder
+	if name := g.sceneShorthand(cos.Base); name != "" && len(cos.Fields) == 0 && cos.Args != nil {
+		call := *cos
+		call.Base = name
+		cos = &call
+	}
+	// count.Get() of a signal parameter reads the value BindApp keeps
+	if field := g.signalGet(cos); field != nil {
+		return field
+	}
+
 	// Check if this is a simple identifier (no fields, no args)
 	if len(cos.Fields) == 0 && len(cos.Args) == 0 {
 		// Check if it's a hoisted variable
@@ -2663,7 +3435,13 @@ func (g *Generator) generateCallOrSelect(cos *guixast.CallOrSelect) ast.Expr {
 	// Build the base selector expression from base and fields
 	// Check if base is a channel receive variable that needs to be replaced
 	var expr ast.Expr
-	if g.channelReceiveVars != nil {
+	if g.computedDecl(cos.Base) != nil {
+		// Computed values are stored in current<Name> fields
+		expr = &ast.SelectorExpr{
+			X:   ast.NewIdent(g.receiverName),
+			Sel: ast.NewIdent(computedField(cos.Base)),
+		}
+	} else if g.channelReceiveVars != nil {
 		if channelName, ok := g.channelReceiveVars[cos.Base]; ok {
 			// Replace base with c.current<ChannelName>
 			expr = &ast.SelectorExpr{
@@ -2848,8 +3626,33 @@ func (g *Generator) generateFuncBody(body *guixast.FuncBody) *ast.BlockStmt {
 	return &ast.BlockStmt{List: stmts}
 }
 
+// assignTarget generates the left side of an assignment statement. Component
+// parameters and hoisted variables are assigned through the receiver, as they
+// are read, unless := declares a local variable.
+func (g *Generator) assignTarget(assign *guixast.AssignmentStmt) ast.Expr {
+	var target ast.Expr = ast.NewIdent(assign.Base)
+	if assign.Op != ":=" {
+		target = g.generatePrimary(&guixast.Primary{Ident: assign.Base})
+	}
+	for _, field := range assign.Fields {
+		target = &ast.SelectorExpr{
+			X:   target,
+			Sel: ast.NewIdent(field),
+		}
+	}
+	if assign.Index != nil {
+		target = &ast.IndexExpr{
+			X:     target,
+			Index: g.generateExpr(assign.Index),
+		}
+	}
+	return target
+}
+
 // generateBodyStatement generates code for a body statement
-func (g *Generator) generateBodyStatement(stmt *guixast.BodyStatement) ast.Stmt {
+func (g *Generator) generateBodyStatement(stmt *guixast.BodyStatement) (out ast.Stmt) {
+	defer func() { g.markLine(out, stmt.Pos) }()
+
 	// Skip goroutine statements - they're executed in BindApp, not in Render
 	if stmt.GoStmt != nil {
 		return nil
@@ -2874,6 +3677,17 @@ func (g *Generator) generateBodyStatement(stmt *guixast.BodyStatement) ast.Stmt
 			}
 		}
 
+		// Methods of parameters are called on their field, e.g. count.Set(1)
+		if len(stmt.CallStmt.Fields) > 0 && g.componentParams[string(stmt.CallStmt.Base)] {
+			baseExpr = &ast.SelectorExpr{
+				X:   ast.NewIdent(g.receiverName),
+				Sel: ast.NewIdent(capitalize(string(stmt.CallStmt.Base))),
+			}
+			for _, field := range stmt.CallStmt.Fields {
+				baseExpr = &ast.SelectorExpr{X: baseExpr, Sel: ast.NewIdent(field)}
+			}
+		}
+
 		// Generate function call arguments
 		args := make([]ast.Expr, len(stmt.CallStmt.Args))
 		for i, arg := range stmt.CallStmt.Args {
@@ -2890,45 +3704,14 @@ func (g *Generator) generateBodyStatement(stmt *guixast.BodyStatement) ast.Stmt
 
 	// Handle AssignmentStmt (assignment statements)
 	if stmt.AssignStmt != nil {
-		// Build base expression (identifier or selector)
-		var baseExpr ast.Expr = ast.NewIdent(stmt.AssignStmt.Base)
-		for _, field := range stmt.AssignStmt.Fields {
-			baseExpr = &ast.SelectorExpr{
-				X:   baseExpr,
-				Sel: ast.NewIdent(field),
-			}
-		}
+		baseExpr := g.assignTarget(stmt.AssignStmt)
 
-		// Check if hoisted variable needs c. prefix
-		if len(stmt.AssignStmt.Fields) == 0 && g.hoistedVars != nil && g.hoistedVars[stmt.AssignStmt.Base] {
-			baseExpr = &ast.SelectorExpr{
-				X:   ast.NewIdent("c"),
-				Sel: ast.NewIdent(stmt.AssignStmt.Base),
-			}
-		}
-
-		// Add index if present
-		if stmt.AssignStmt.Index != nil {
-			baseExpr = &ast.IndexExpr{
-				X:     baseExpr,
-				Index: g.generateExpr(stmt.AssignStmt.Index),
-			}
+		if stmt.AssignStmt.IncDec != "" {
+			return incDecStmt(baseExpr, stmt.AssignStmt.IncDec)
 		}
 
 		// Handle channel send operation
 		if stmt.AssignStmt.Op == "<-" {
-			// Check if component parameter needs c. prefix (capitalized) for channel sends
-			if len(stmt.AssignStmt.Fields) == 0 && g.componentParams != nil && g.componentParams[stmt.AssignStmt.Base] {
-				receiverName := g.receiverName
-				if receiverName == "" {
-					receiverName = "c"
-				}
-				baseExpr = &ast.SelectorExpr{
-					X:   ast.NewIdent(receiverName),
-					Sel: ast.NewIdent(capitalize(stmt.AssignStmt.Base)),
-				}
-			}
-
 			return &ast.SendStmt{
 				Chan:  baseExpr,
 				Value: g.generateExpr(stmt.AssignStmt.Right),
@@ -3020,6 +3803,10 @@ func (g *Generator) generateBodyStatement(stmt *guixast.BodyStatement) ast.Stmt
 		return ifStmt
 	}
 
+	if stmt.Branch != nil {
+		return branchStmt(stmt.Branch)
+	}
+
 	if stmt.For != nil {
 		return g.generateForLoopStmt(stmt.For)
 	}
@@ -3037,6 +3824,12 @@ func (g *Generator) generateBodyStatement(stmt *guixast.BodyStatement) ast.Stmt
 
 // generateForLoopStmt generates a for loop statement
 func (g *Generator) generateForLoopStmt(forLoop *guixast.ForLoop) ast.Stmt {
+	return g.generateLoop(forLoop, g.generateBodyAsBlock(forLoop.LoopBody()))
+}
+
+// generateLoop generates the range or C-style loop statement of a for loop
+// around the given body
+func (g *Generator) generateLoop(forLoop *guixast.ForLoop, body *ast.BlockStmt) ast.Stmt {
 	// Check if it's a range-based for loop or C-style for loop
 	if forLoop.Range != nil {
 		// Range-based for loop: for key, val in range
@@ -3058,7 +3851,7 @@ func (g *Generator) generateForLoopStmt(forLoop *guixast.ForLoop) ast.Stmt {
 			Value: val,
 			Tok:   token.DEFINE,
 			X:     g.generateExpr(forLoop.Range),
-			Body:  g.generateBodyAsBlock(forLoop.Body),
+			Body:  body,
 		}
 	} else {
 		// C-style for loop: for init; cond; post { body }
@@ -3095,10 +3888,14 @@ func (g *Generator) generateForLoopStmt(forLoop *guixast.ForLoop) ast.Stmt {
 					Sel: ast.NewIdent(field),
 				}
 			}
-			post = &ast.AssignStmt{
-				Lhs: []ast.Expr{baseExpr},
-				Tok: g.assignOpToToken(forLoop.Post.Op),
-				Rhs: []ast.Expr{g.generateExpr(forLoop.Post.Right)},
+			if forLoop.Post.IncDec != "" {
+				post = incDecStmt(baseExpr, forLoop.Post.IncDec)
+			} else {
+				post = &ast.AssignStmt{
+					Lhs: []ast.Expr{baseExpr},
+					Tok: g.assignOpToToken(forLoop.Post.Op),
+					Rhs: []ast.Expr{g.generateExpr(forLoop.Post.Right)},
+				}
 			}
 		}
 
@@ -3107,11 +3904,29 @@ func (g *Generator) generateForLoopStmt(forLoop *guixast.ForLoop) ast.Stmt {
 			Init: init,
 			Cond: cond,
 			Post: post,
-			Body: g.generateFuncBody(forLoop.CBody),
+			Body: body,
 		}
 	}
 }
 
+// incDecStmt generates x++ or x--
+func incDecStmt(x ast.Expr, op string) ast.Stmt {
+	tok := token.INC
+	if op == "--" {
+		tok = token.DEC
+	}
+	return &ast.IncDecStmt{X: x, Tok: tok}
+}
+
+// branchStmt generates a break or continue statement
+func branchStmt(branch *guixast.BranchStmt) ast.Stmt {
+	tok := token.BREAK
+	if branch.Tok == "continue" {
+		tok = token.CONTINUE
+	}
+	return &ast.BranchStmt{Tok: tok}
+}
+
 // generateSwitchStmt generates a switch statement
 func (g *Generator) generateSwitchStmt(switchStmt *guixast.SwitchStmt) ast.Stmt {
 	var tagExpr ast.Expr
@@ -3190,36 +4005,16 @@ func (g *Generator) generateSelectStmt(selectStmt *guixast.SelectStmt) ast.Stmt
 				if commClause.Comm.Send != nil {
 					// Send operation: ch <- value
 					send := commClause.Comm.Send
-					var chanExpr ast.Expr = ast.NewIdent(send.Channel)
-
-					// Check if hoisted variable needs c. prefix
-					if g.hoistedVars != nil && g.hoistedVars[send.Channel] {
-						chanExpr = &ast.SelectorExpr{
-							X:   ast.NewIdent("c"),
-							Sel: ast.NewIdent(send.Channel),
-						}
-					}
-
 					caseStmt.Comm = &ast.SendStmt{
-						Chan:  chanExpr,
+						Chan:  g.generateCommChannel(send.Channel),
 						Value: g.generateExpr(send.Value),
 					}
 				} else if commClause.Comm.Recv != nil {
 					// Receive operation: x := <-ch or <-ch
 					recv := commClause.Comm.Recv
-					var chanExpr ast.Expr = ast.NewIdent(recv.Channel)
-
-					// Check if hoisted variable needs c. prefix
-					if g.hoistedVars != nil && g.hoistedVars[recv.Channel] {
-						chanExpr = &ast.SelectorExpr{
-							X:   ast.NewIdent("c"),
-							Sel: ast.NewIdent(recv.Channel),
-						}
-					}
-
 					recvExpr := &ast.UnaryExpr{
 						Op: token.ARROW,
-						X:  chanExpr,
+						X:  g.generateCommChannel(recv.Channel),
 					}
 
 					if len(recv.Names) > 0 {
@@ -3260,6 +4055,28 @@ func (g *Generator) generateSelectStmt(selectStmt *guixast.SelectStmt) ast.Stmt
 	}
 }
 
+// generateCommChannel resolves the channel of a select case, prefixing
+// hoisted variables and component parameters with the receiver
+func (g *Generator) generateCommChannel(name string) ast.Expr {
+	if g.hoistedVars != nil && g.hoistedVars[name] {
+		return &ast.SelectorExpr{
+			X:   ast.NewIdent("c"),
+			Sel: ast.NewIdent(name),
+		}
+	}
+	if g.componentParams != nil && g.componentParams[name] {
+		receiverName := g.receiverName
+		if receiverName == "" {
+			receiverName = "c"
+		}
+		return &ast.SelectorExpr{
+			X:   ast.NewIdent(receiverName),
+			Sel: ast.NewIdent(capitalize(name)),
+		}
+	}
+	return ast.NewIdent(name)
+}
+
 // generateBodyAsBlock converts a Body to a BlockStmt
 func (g *Generator) generateBodyAsBlock(body *guixast.Body) *ast.BlockStmt {
 	stmts := make([]ast.Stmt, 0)
@@ -3314,7 +4131,13 @@ func (g *Generator) generateGoStatement(goStmt *guixast.GoStmt) ast.Stmt {
 }
 
 // generateStatement generates code for a statement
-func (g *Generator) generateStatement(stmt *guixast.Statement) ast.Stmt {
+func (g *Generator) generateStatement(stmt *guixast.Statement) (out ast.Stmt) {
+	defer func() { g.markLine(out, stmt.Pos) }()
+
+	if stmt.ConstDecl != nil {
+		return g.generateConstDecl(stmt.ConstDecl)
+	}
+
 	// Handle CallStmt (function call statements)
 	if stmt.CallStmt != nil {
 		// Build base expression (identifier or selector)
@@ -3334,6 +4157,17 @@ func (g *Generator) generateStatement(stmt *guixast.Statement) ast.Stmt {
 			}
 		}
 
+		// Methods of parameters are called on their field, e.g. count.Set(1)
+		if len(stmt.CallStmt.Fields) > 0 && g.componentParams[string(stmt.CallStmt.Base)] {
+			baseExpr = &ast.SelectorExpr{
+				X:   ast.NewIdent(g.receiverName),
+				Sel: ast.NewIdent(capitalize(string(stmt.CallStmt.Base))),
+			}
+			for _, field := range stmt.CallStmt.Fields {
+				baseExpr = &ast.SelectorExpr{X: baseExpr, Sel: ast.NewIdent(field)}
+			}
+		}
+
 		// Generate function call arguments
 		args := make([]ast.Expr, len(stmt.CallStmt.Args))
 		for i, arg := range stmt.CallStmt.Args {
@@ -3350,45 +4184,14 @@ func (g *Generator) generateStatement(stmt *guixast.Statement) ast.Stmt {
 
 	// Handle AssignmentStmt (assignment statements)
 	if stmt.AssignStmt != nil {
-		// Build base expression (identifier or selector)
-		var baseExpr ast.Expr = ast.NewIdent(stmt.AssignStmt.Base)
-		for _, field := range stmt.AssignStmt.Fields {
-			baseExpr = &ast.SelectorExpr{
-				X:   baseExpr,
-				Sel: ast.NewIdent(field),
-			}
-		}
-
-		// Check if hoisted variable needs c. prefix
-		if len(stmt.AssignStmt.Fields) == 0 && g.hoistedVars != nil && g.hoistedVars[stmt.AssignStmt.Base] {
-			baseExpr = &ast.SelectorExpr{
-				X:   ast.NewIdent("c"),
-				Sel: ast.NewIdent(stmt.AssignStmt.Base),
-			}
-		}
+		baseExpr := g.assignTarget(stmt.AssignStmt)
 
-		// Add index if present
-		if stmt.AssignStmt.Index != nil {
-			baseExpr = &ast.IndexExpr{
-				X:     baseExpr,
-				Index: g.generateExpr(stmt.AssignStmt.Index),
-			}
+		if stmt.AssignStmt.IncDec != "" {
+			return incDecStmt(baseExpr, stmt.AssignStmt.IncDec)
 		}
 
 		// Handle channel send operation
 		if stmt.AssignStmt.Op == "<-" {
-			// Check if component parameter needs c. prefix (capitalized) for channel sends
-			if len(stmt.AssignStmt.Fields) == 0 && g.componentParams != nil && g.componentParams[stmt.AssignStmt.Base] {
-				receiverName := g.receiverName
-				if receiverName == "" {
-					receiverName = "c"
-				}
-				baseExpr = &ast.SelectorExpr{
-					X:   ast.NewIdent(receiverName),
-					Sel: ast.NewIdent(capitalize(stmt.AssignStmt.Base)),
-				}
-			}
-
 			return &ast.SendStmt{
 				Chan:  baseExpr,
 				Value: g.generateExpr(stmt.AssignStmt.Right),
@@ -3483,6 +4286,10 @@ func (g *Generator) generateStatement(stmt *guixast.Statement) ast.Stmt {
 		return ifStmt
 	}
 
+	if stmt.Branch != nil {
+		return branchStmt(stmt.Branch)
+	}
+
 	if stmt.For != nil {
 		return g.generateForLoopStmt(stmt.For)
 	}
@@ -3502,7 +4309,9 @@ func (g *Generator) generateStatement(stmt *guixast.Statement) ast.Stmt {
 // Known runtime types that should be qualified with runtime package
 var runtimeTypes = map[string]bool{
 	"Event": true, "VNode": true, "App": true, "Component": true,
-	"GPUNode": true, "GPUCanvas": true, "Scene": true,
+	"GPUNode": true, "GPUCanvas": true, "Scene": true, "FormValues": true,
+	"KeyboardEvent": true, "MouseEvent": true, "WheelEvent": true, "KeyState": true,
+	"Transform": true, "Children": true, "Signal": true, "CSS": true,
 }
 
 func (g *Generator) typeToAST(t *guixast.Type) ast.Expr {
@@ -3519,7 +4328,14 @@ func (g *Generator) typeToAST(t *guixast.Type) ast.Expr {
 
 	var base ast.Expr
 	// Use runtime.TypeName for known runtime types
-	if runtimeTypes[t.Name] {
+	if t.Struct != nil {
+		base = g.structTypeToAST(t.Struct)
+	} else if t.Map != nil {
+		base = &ast.MapType{
+			Key:   g.typeToAST(t.Map.Key),
+			Value: g.typeToAST(t.Map.Value),
+		}
+	} else if runtimeTypes[t.Name] {
 		base = &ast.SelectorExpr{
 			X:   ast.NewIdent("runtime"),
 			Sel: ast.NewIdent(t.Name),
@@ -3528,6 +4344,25 @@ func (g *Generator) typeToAST(t *guixast.Type) ast.Expr {
 		base = ast.NewIdent(t.Name)
 	}
 
+	// Instantiate generic types, e.g. Signal[int]
+	if t.Generic != nil {
+		base = &ast.IndexExpr{X: base, Index: g.typeToAST(t.Generic)}
+	}
+
+	// Modifiers wrap the type from the inside out, in the reverse of their
+	// source order: chan []*T
+	if t.IsPointer {
+		base = &ast.StarExpr{X: base}
+	}
+
+	// Handle slice types
+	if t.IsSlice {
+		base = &ast.ArrayType{
+			Len: nil, // nil Len means it's a slice, not an array
+			Elt: base,
+		}
+	}
+
 	// Handle channel types
 	// IsChannel && IsChan means "<-chan T" (receive-only)
 	// IsChan only means "chan T" (bidirectional)
@@ -3543,19 +4378,18 @@ func (g *Generator) typeToAST(t *guixast.Type) ast.Expr {
 		}
 	}
 
-	// Handle slice types
-	if t.IsSlice {
-		base = &ast.ArrayType{
-			Len: nil, // nil Len means it's a slice, not an array
-			Elt: base,
-		}
-	}
+	return base
+}
 
-	if t.IsPointer {
-		base = &ast.StarExpr{X: base}
+// chanElemType returns the element type of a channel type, e.g. []string
+// for chan []string
+func chanElemType(t *guixast.Type) *guixast.Type {
+	if t.Generic != nil {
+		return t.Generic
 	}
-
-	return base
+	elem := *t
+	elem.IsChannel, elem.IsChan = false, false
+	return &elem
 }
 
 // generateAssignmentLHS generates the left-hand side expression for an assignment
@@ -3598,8 +4432,16 @@ func (g *Generator) assignOpToToken(op string) token.Token {
 	}
 }
 
-// generateMountMethod generates the Mount method
+// generateMountMethod generates the Mount method, which keeps the mounted
+// tree for Update to diff against:
+//
+//	func (c *Counter) Mount(parent js.Value) {
+//	    c.vnode = c.Render()
+//	    runtime.Mount(c.vnode, parent)
+//	}
 func (g *Generator) generateMountMethod(comp *guixast.Component) *ast.FuncDecl {
+	vnode := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("vnode")}
+
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -3627,21 +4469,25 @@ func (g *Generator) generateMountMethod(comp *guixast.Component) *ast.FuncDecl {
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{vnode},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("c"),
+								Sel: ast.NewIdent("Render"),
+							},
+						},
+					},
+				},
 				&ast.ExprStmt{
 					X: &ast.CallExpr{
 						Fun: &ast.SelectorExpr{
 							X:   ast.NewIdent("runtime"),
 							Sel: ast.NewIdent("Mount"),
 						},
-						Args: []ast.Expr{
-							&ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("c"),
-									Sel: ast.NewIdent("Render"),
-								},
-							},
-							ast.NewIdent("parent"),
-						},
+						Args: []ast.Expr{vnode, ast.NewIdent("parent")},
 					},
 				},
 			},
@@ -3651,6 +4497,14 @@ func (g *Generator) generateMountMethod(comp *guixast.Component) *ast.FuncDecl {
 
 // generateUnmountMethod generates the Unmount method
 func (g *Generator) generateUnmountMethod(comp *guixast.Component) *ast.FuncDecl {
+	var stmts []ast.Stmt
+	if comp.KeysChannel != "" {
+		stmts = append(stmts, generateUnbindKeysStmt())
+	}
+	for _, param := range signalParams(comp) {
+		stmts = append(stmts, generateUnsubscribeStmt(param))
+	}
+
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -3664,12 +4518,31 @@ func (g *Generator) generateUnmountMethod(comp *guixast.Component) *ast.FuncDecl
 		},
 		Name: ast.NewIdent("Unmount"),
 		Type: &ast.FuncType{},
-		Body: &ast.BlockStmt{},
+		Body: &ast.BlockStmt{List: stmts},
 	}
 }
 
-// generateUpdateMethod generates the Update method
+// generateUpdateMethod generates the Update method. A component bound to an
+// app lets the app diff its whole tree, which contains the component's. A
+// component mounted on its own diffs a fresh render against the tree it
+// last rendered and patches only the DOM that changed:
+//
+//	func (c *Counter) Update() {
+//	    if c.app != nil {
+//	        c.app.Update()
+//	        return
+//	    }
+//	    if c.vnode == nil {
+//	        return
+//	    }
+//	    next := c.Render()
+//	    runtime.Reconcile(c.vnode, next)
+//	    c.vnode = next
+//	}
 func (g *Generator) generateUpdateMethod(comp *guixast.Component) *ast.FuncDecl {
+	app := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("app")}
+	vnode := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("vnode")}
+
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -3686,30 +4559,42 @@ func (g *Generator) generateUpdateMethod(comp *guixast.Component) *ast.FuncDecl
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
 				&ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X: &ast.SelectorExpr{
-							X:   ast.NewIdent("c"),
-							Sel: ast.NewIdent("app"),
-						},
-						Op: token.NEQ,
-						Y:  ast.NewIdent("nil"),
-					},
+					Cond: &ast.BinaryExpr{X: app, Op: token.NEQ, Y: ast.NewIdent("nil")},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
 							&ast.ExprStmt{
 								X: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X: &ast.SelectorExpr{
-											X:   ast.NewIdent("c"),
-											Sel: ast.NewIdent("app"),
-										},
-										Sel: ast.NewIdent("Update"),
-									},
+									Fun: &ast.SelectorExpr{X: app, Sel: ast.NewIdent("Update")},
 								},
 							},
+							&ast.ReturnStmt{},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{X: vnode, Op: token.EQL, Y: ast.NewIdent("nil")},
+					Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{}}},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("next")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("Render")},
 						},
 					},
 				},
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent("Reconcile")},
+						Args: []ast.Expr{vnode, ast.NewIdent("next")},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{vnode},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("next")},
+				},
 			},
 		},
 	}
@@ -3748,6 +4633,25 @@ func (g *Generator) generateBindAppMethod(comp *guixast.Component) *ast.FuncDecl
 		},
 	)
 
+	// Send key state changes to the @keys channel. The binding is guarded
+	// by its own cleanup field, so it is restored when BindApp runs after
+	// Unmount.
+	if comp.KeysChannel != "" {
+		stmts = append(stmts, generateBindKeysStmt(comp))
+	}
+
+	// Report @required props left nil
+	for _, param := range requiredParams(comp) {
+		if isNillable(param.Type) {
+			stmts = append(stmts, generateRequiredCheckStmt(comp, param))
+		}
+	}
+
+	// Re-render when a signal parameter is set
+	for _, param := range signalParams(comp) {
+		stmts = append(stmts, g.generateSubscribeStmt(param))
+	}
+
 	// Check if component has channel parameters
 	hasChannels := g.hasChannelParams(comp)
 
@@ -3935,34 +4839,39 @@ func (g *Generator) generateChannelListenerMethods(comp *guixast.Component) []as
 				continue
 			}
 
-			// Build list of assignments to update in the listener
+			// Build list of assignments to update in the listener,
+			// logging to trace state updates
+			var logMessage ast.Expr = &ast.BinaryExpr{
+				X: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"[` + comp.Name + `] Received update from ` + param.Name + ` channel: "`,
+				},
+				Op: token.ADD,
+				Y: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("fmt"),
+						Sel: ast.NewIdent("Sprintf"),
+					},
+					Args: []ast.Expr{
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: `"%+v"`,
+						},
+						ast.NewIdent("val"),
+					},
+				},
+			}
+			if g.optimizeSize {
+				logMessage = &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"[` + comp.Name + `] Received update from ` + param.Name + ` channel"`,
+				}
+			}
 			updateStmts := []ast.Stmt{
-				// Add logging to trace state updates
 				&ast.ExprStmt{
 					X: &ast.CallExpr{
-						Fun: ast.NewIdent("log"),
-						Args: []ast.Expr{
-							&ast.BinaryExpr{
-								X: &ast.BasicLit{
-									Kind:  token.STRING,
-									Value: `"[` + comp.Name + `] Received update from ` + param.Name + ` channel: "`,
-								},
-								Op: token.ADD,
-								Y: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("fmt"),
-										Sel: ast.NewIdent("Sprintf"),
-									},
-									Args: []ast.Expr{
-										&ast.BasicLit{
-											Kind:  token.STRING,
-											Value: `"%+v"`,
-										},
-										ast.NewIdent("val"),
-									},
-								},
-							},
-						},
+						Fun:  ast.NewIdent("log"),
+						Args: []ast.Expr{logMessage},
 					},
 				},
 			}
@@ -3979,8 +4888,9 @@ func (g *Generator) generateChannelListenerMethods(comp *guixast.Component) []as
 					Tok: token.ASSIGN,
 					Rhs: []ast.Expr{ast.NewIdent("val")},
 				})
-			} else {
-				// Only update current + paramName for inline receives (no explicit variable)
+			}
+			if hasInlineReceive {
+				// Update current + paramName for inline receives
 				updateStmts = append(updateStmts, &ast.AssignStmt{
 					Lhs: []ast.Expr{
 						&ast.SelectorExpr{
@@ -3993,6 +4903,9 @@ func (g *Generator) generateChannelListenerMethods(comp *guixast.Component) []as
 				})
 			}
 
+			// Recompute the computed values receiving from the channel
+			updateStmts = append(updateStmts, g.generateComputedUpdates(param.Name)...)
+
 			// Add app.Update() call
 			updateStmts = append(updateStmts, &ast.IfStmt{
 				Cond: &ast.BinaryExpr{
@@ -1,6 +1,14 @@
 package codegen
 
 import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
 	"strings"
 	"testing"
 
@@ -488,6 +496,48 @@ func Simple() (Component) {
 	}
 }
 
+func TestGenerateUserImports(t *testing.T) {
+	source := `package main
+
+import str "strconv"
+
+import (
+	"strings"
+	m "math"
+)
+
+func Simple(count int) (Component) {
+	Div {
+		` + "`{str.Itoa(count)} {strings.ToUpper(\"a\")} {m.Pi}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	// User imports keep their aliases
+	for _, imp := range []string{"\tstr \"strconv\"\n", "\t\"strings\"\n", "\tm \"math\"\n"} {
+		if !strings.Contains(generatedStr, imp) {
+			t.Errorf("Generated code does not contain user import %q\nGenerated:\n%s", imp, generatedStr)
+		}
+	}
+}
+
 func TestGenerateImportsWithTemplateInterpolation(t *testing.T) {
 	source := `package main
 
@@ -948,3 +998,2331 @@ func App() (Component) {
 		}
 	}
 }
+
+func TestGenerateChannelClassToggle(t *testing.T) {
+	source := `package main
+
+func Tab(label string, isActiveChannel chan bool) (Component) {
+	Div(Class("tab"), Class("active", <-isActiveChannel)) {
+		` + "`{label}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"currentIsActiveChannel bool",                               // stored field for the channel value
+		"c.currentIsActiveChannel = val",                            // listener updates the field
+		"runtime.Class(\"tab\")",                                    // static class untouched
+		"runtime.ToggleClass(\"active\", c.currentIsActiveChannel)", // toggle reads the stored field
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateProgressAndMeterElements(t *testing.T) {
+	source := `package main
+
+func Loading() (Component) {
+	Div {
+		Progress(Value(50), Max(100))
+		Meter(Value(0.6), Min(0), Max(1), Low(0.2), High(0.8))
+		Output(ID("result")) {
+			"42"
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"runtime.Progress(runtime.Value(50), runtime.Max(100))",
+		"runtime.Meter(runtime.Value(0.6), runtime.Min(0), runtime.Max(1), runtime.Low(0.2), runtime.High(0.8))",
+		"runtime.Output(",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Progress is a DOM element, not a user component
+	if strings.Contains(generatedStr, "NewProgress(") {
+		t.Error("Progress should not be generated as a component")
+	}
+}
+
+func TestGenerateErrorTextFromChannel(t *testing.T) {
+	source := `package main
+
+func LoginForm(errs <-chan error) (Component) {
+	Div {
+		ErrorText(errs)
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"currentErrs",                                     // stored field for the latest error
+		"c.startErrsListener()",                           // listener started in BindApp
+		"c.currentErrs = val",                             // listener stores the latest error
+		"if c.currentErrs == nil {",                       // hidden when there is no error
+		"return runtime.Fragment()",                       // renders nothing when nil
+		"return runtime.ErrorText(c.currentErrs.Error())", // renders the message otherwise
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	if strings.Contains(generatedStr, "NewErrorText(") {
+		t.Error("ErrorText should not be generated as a component")
+	}
+}
+
+func TestGenerateBindProp(t *testing.T) {
+	source := `package main
+
+func Settings(agree bool) (Component) {
+	name := ""
+	color := "red"
+
+	Div {
+		Input(Type("text"), Bind(&name))
+		Input(Type("checkbox"), Bind(agree))
+		Select(Bind(color)) {
+			Option(Value("red")) {
+				"Red"
+			}
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		// Text input: value setter plus input listener writing the field back
+		"runtime.Value(c.name), runtime.OnInput(func(e runtime.Event) {",
+		"c.name = e.Target.Value",
+		// Checkbox: checked setter plus change listener
+		"runtime.Checked(c.Agree), runtime.OnChange(func(e runtime.Event) {",
+		"c.Agree = e.Target.Checked",
+		// Select: value setter plus change listener
+		"runtime.Select(runtime.Value(c.color), runtime.OnChange(func(e runtime.Event) {",
+		"c.color = e.Target.Value",
+		"c.app.Update()",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	if strings.Contains(generatedStr, "runtime.Bind(") {
+		t.Error("Bind should be lowered, not emitted as a runtime call")
+	}
+}
+
+func TestGenerateKeyProp(t *testing.T) {
+	source := `package main
+
+func Row(id string) (Component) {
+	Div(Key(id), Class("row")) {
+		"Row"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	if !strings.Contains(generatedStr, "runtime.Div(runtime.WithKey(c.Id), runtime.Class(\"row\")") {
+		t.Errorf("Expected Key prop to generate runtime.WithKey\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateLayoutComponents(t *testing.T) {
+	source := `package main
+
+func Toolbar(spacing int) (Component) {
+	VStack(Gap(spacing), Class("toolbar")) {
+		HStack(Gap(8), Align("center")) {
+			Span { "Left" }
+		}
+		Grid(Columns(3)) {
+			Span { "Cell" }
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		// Literal props are folded into a constant flexbox style
+		`runtime.Div(runtime.Style("display: flex; flex-direction: row; gap: 8px; align-items: center")`,
+		// Dynamic gap is formatted at render time, other props pass through
+		`runtime.Div(runtime.Style("display: flex; flex-direction: column; gap: "+fmt.Sprint(c.Spacing)+"px"), runtime.Class("toolbar")`,
+		`runtime.Style("display: grid; grid-template-columns: repeat(3, 1fr)")`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	for _, unexpected := range []string{"HStack", "VStack", "Grid(", "Gap("} {
+		if strings.Contains(generatedStr, unexpected) {
+			t.Errorf("Layout helper %q should be lowered to a styled Div\nGenerated:\n%s", unexpected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateFormAggregatesBoundFields(t *testing.T) {
+	source := `package main
+
+func Signup(agree bool) (Component) {
+	email := ""
+
+	Form(OnSubmit(func(values FormValues) {
+		submitted = values
+	})) {
+		Input(Type("email"), Name("user-email"), Bind(&email))
+		Div {
+			Input(Type("checkbox"), Bind(agree))
+		}
+		Button(Type("submit")) {
+			"Sign up"
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"runtime.Form(runtime.OnFormSubmit(func() runtime.FormValues {",
+		// Named fields use their Name, others the bound variable
+		`return runtime.FormValues{"user-email": c.email, "agree": c.Agree}`,
+		"}, func(values runtime.FormValues) {",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateElseIfChain(t *testing.T) {
+	source := `package main
+
+func Status(loading bool, failed bool) (Component) {
+	Div {
+		if loading {
+			Span {
+				"Loading"
+			}
+		} else if failed {
+			Button {
+				"Retry"
+			}
+		} else {
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"if c.Loading {",
+		"return runtime.Span(runtime.Text(\"Loading\"))",
+		"} else if c.Failed {",
+		"return runtime.Button(runtime.Text(\"Retry\"))",
+		// Empty branches render nothing
+		"return runtime.Fragment()",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateDerivedValues(t *testing.T) {
+	source := `package main
+
+func Counter(count int, updates chan int) (Component) {
+	doubled := count * 2
+	scaled := <-updates * 10
+
+	Div {
+		Span {
+			` + "`Doubled: {doubled}`" + `
+		}
+		Span {
+			` + "`Again: {doubled}, scaled {scaled}`" + `
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"func (c *Counter) Render() *runtime.VNode {\n\tdoubled := c.Count * 2\n",
+		// Channel-backed derived values read the listener-updated field
+		"scaled := c.currentUpdates * 10",
+		"c.currentUpdates = val",
+		`runtime.Text("Doubled: "+fmt.Sprint(doubled))`,
+		`runtime.Text("Again: "+fmt.Sprint(doubled)+", scaled "+fmt.Sprint(scaled))`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// The local is declared once and shared by both uses
+	if count := strings.Count(generatedStr, "doubled :="); count != 1 {
+		t.Errorf("Expected doubled to be declared once, got %d declarations", count)
+	}
+	if strings.Contains(generatedStr, "c.doubled") {
+		t.Errorf("Derived value should not be a struct field\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateComputedValue(t *testing.T) {
+	source := `package main
+
+func Sum(aChannel chan int, bChannel chan int) (Component) {
+	computed total := <-aChannel + <-bChannel
+
+	Div {
+		` + "`Total: {total}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"currentTotal     int",
+		// Both channels get a listener
+		"c.startAChannelListener()",
+		"c.startBChannelListener()",
+		// An update on either channel recomputes the value, then re-renders
+		"c.currentAChannel = val\n\t\t\tc.currentTotal = c.currentAChannel + c.currentBChannel\n\t\t\tif c.app != nil {\n\t\t\t\tc.app.Update()",
+		"c.currentBChannel = val\n\t\t\tc.currentTotal = c.currentAChannel + c.currentBChannel\n\t\t\tif c.app != nil {\n\t\t\t\tc.app.Update()",
+		`runtime.Text("Total: " + fmt.Sprint(c.currentTotal))`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Render reads the field instead of recomputing a local
+	if strings.Contains(generatedStr, "total :=") {
+		t.Errorf("Computed value should not be a Render local\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateOptimizeSize(t *testing.T) {
+	source := `package main
+
+func Counter(count int, label string, items []string) (Component) {
+	Div {
+		Span {
+			` + "`{label}: {count} of {items}`" + `
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	gen.SetOptimizeSize(true)
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		`"strconv"`,
+		// Strings are used as-is, ints use strconv, other types fall back to fmt
+		`runtime.Text(c.Label + ": " + strconv.Itoa(c.Count) + " of " + fmt.Sprint(c.Items))`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	if strings.Contains(generatedStr, "fmt.Sprint(c.Count)") {
+		t.Errorf("Integer interpolation should not use fmt.Sprint in optimized mode\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateListBox(t *testing.T) {
+	source := `package main
+
+func Picker(fruits []string, choice chan string) (Component) {
+	ListBox(Items(fruits), Selected(choice))
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expected := "return runtime.ListBox(runtime.Items(c.Fruits), runtime.Selected(c.Choice))"
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+}
+
+func TestGenerateAccordion(t *testing.T) {
+	source := `package main
+
+func FAQ(active chan int) (Component) {
+	Accordion(Active(active)) {
+		Panel(Title("Shipping")) {
+			P { "Ships in 2 days" }
+		}
+		Panel(Title("Returns & refunds"), Class("returns")) {
+			P { "30 day returns" }
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := "return runtime.Accordion(runtime.Active(c.Active), " +
+		"runtime.Panel(runtime.Title(\"Shipping\"), runtime.P(runtime.Text(\"Ships in 2 days\"))), " +
+		"runtime.Panel(runtime.Title(\"Returns & refunds\"), runtime.Class(\"returns\"), runtime.P(runtime.Text(\"30 day returns\"))))"
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+
+	// Panels render closed, each behind a header button that controls it
+	expectedStrings := []string{
+		`<div class=\"accordion\"><h3><button id=\"accordion-ssr-1-header-0\" type=\"button\" aria-expanded=\"false\" aria-controls=\"accordion-ssr-1-panel-0\">Shipping</button></h3>`,
+		`<div id=\"accordion-ssr-1-panel-0\" role=\"region\" aria-labelledby=\"accordion-ssr-1-header-0\" hidden><p>Ships in 2 days</p></div>`,
+		`aria-controls=\"accordion-ssr-1-panel-1\">Returns &amp; refunds</button></h3>`,
+		`<div id=\"accordion-ssr-1-panel-1\" role=\"region\" aria-labelledby=\"accordion-ssr-1-header-1\" hidden class=\"returns\">`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(string(ssr), expected) {
+			t.Errorf("Generated SSR code does not contain expected string: %q\nGenerated:\n%s", expected, ssr)
+		}
+	}
+}
+
+func TestGenerateTooltip(t *testing.T) {
+	source := `package main
+
+func Toolbar(label string) (Component) {
+	Div {
+		Tooltip(Text("Copy to clipboard"), Placement("bottom")) {
+			Button {
+				` + "`{label}`" + `
+			}
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := `runtime.Tooltip(runtime.Text("Copy to clipboard"), runtime.Placement("bottom"), runtime.Button(`
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+
+	// Only the anchor is rendered; the tooltip shows once hydrated
+	expected = `<div><span class=\"tooltip-anchor\"><button>`
+	if !strings.Contains(string(ssr), expected) || strings.Contains(string(ssr), "Copy to clipboard") {
+		t.Errorf("Expected SSR to render only the anchor %q\nGenerated:\n%s", expected, ssr)
+	}
+}
+
+func TestGenerateSortable(t *testing.T) {
+	source := `package main
+
+func Todos(order chan []string) (Component) {
+	Div {
+		Sortable(SortableItems(<-order, order), OnReorder(func(items []string) {
+			log("reordered")
+		}))
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	checks := []string{
+		// The slice is the element type of the channel
+		"Order            chan []string",
+		"currentOrder     []string",
+		"runtime.Div(runtime.Sortable(runtime.SortableItems(c.currentOrder, c.Order), runtime.OnReorder(func(items []string) {",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(generated), check) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", check, generated)
+		}
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+
+	expected := `for _, item := range c.currentOrder {`
+	if !strings.Contains(string(ssr), expected) || !strings.Contains(string(ssr), `<ul class=\"sortable\">`) {
+		t.Errorf("Expected SSR to render the items in order %q\nGenerated:\n%s", expected, ssr)
+	}
+}
+
+func TestGenerateCondExpr(t *testing.T) {
+	source := `package main
+
+func Toggle(active bool, count int) (Component) {
+	Div(Class(active ? "on" : "off")) {
+		` + "`{count > 1 ? count : 0}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	checks := []string{
+		"runtime.Class(func() string {",
+		"if c.Active {",
+		"return \"on\"",
+		"return \"off\"",
+		// The result type follows the branches
+		"fmt.Sprint(func() int {",
+		"if c.Count > 1 {",
+		"return c.Count",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(generated), check) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", check, generated)
+		}
+	}
+}
+
+func TestGenerateCondExprMixedTypes(t *testing.T) {
+	source := `package main
+
+func Price(sale bool, price float64, label string, count int) (Component) {
+	Div {
+		` + "`{sale ? 0 : price} {sale ? price : 1.5} {sale ? \"free\" : price} {sale ? label : count}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	genFile, err := goparser.ParseFile(token.NewFileSet(), "", generated, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse generated code: %v\n%s", err, generated)
+	}
+
+	// Type check each conditional against the component's fields, since the
+	// generated file imports the wasm-only runtime
+	var check strings.Builder
+	check.WriteString("package main\n\nvar c struct {\n\tSale bool\n\tPrice float64\n\tLabel string\n\tCount int\n}\n\n")
+	var results []string
+	goast.Inspect(genFile, func(n goast.Node) bool {
+		call, ok := n.(*goast.CallExpr)
+		if !ok {
+			return true
+		}
+		lit, ok := call.Fun.(*goast.FuncLit)
+		if !ok || len(call.Args) != 0 || lit.Type.Results == nil {
+			return true
+		}
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, token.NewFileSet(), call); err != nil {
+			t.Fatalf("Failed to print conditional: %v", err)
+		}
+		fmt.Fprintf(&check, "var _ = %s\n\n", buf.String())
+		buf.Reset()
+		printer.Fprint(&buf, token.NewFileSet(), lit.Type.Results.List[0].Type)
+		results = append(results, strings.Join(strings.Fields(buf.String()), ""))
+		return false
+	})
+
+	expected := []string{"float64", "float64", "interface{}", "interface{}"}
+	if strings.Join(results, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected conditional result types %v, got %v\nGenerated:\n%s", expected, results, generated)
+	}
+
+	fset := token.NewFileSet()
+	checkFile, err := goparser.ParseFile(fset, "check.go", check.String(), 0)
+	if err != nil {
+		t.Fatalf("Failed to parse conditionals: %v\n%s", err, check.String())
+	}
+	if _, err := (&types.Config{}).Check("main", fset, []*goast.File{checkFile}, nil); err != nil {
+		t.Errorf("Conditionals do not compile: %v\n%s", err, check.String())
+	}
+}
+
+func TestGenerateMemo(t *testing.T) {
+	source := `package main
+
+@props @memo func Widget(label string, value chan int) (Component) {
+	Div {
+		` + "`{label}: {<-value}`" + `
+	}
+}
+
+@memo func Tags(tags []string) (Component) {
+	Div {
+		` + "`{len(tags)}`" + `
+	}
+}
+
+func Dashboard() (Component) {
+	Div {
+		Widget(WithLabel("cpu"))
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+	if !file.Components[0].Memo || file.Components[2].Memo {
+		t.Fatal("Expected only the @memo components to be memoized")
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	checks := []string{
+		// Channel parameters are compared by their last received value
+		"type widgetMemoProps struct {\n\tLabel        string\n\tcurrentValue int\n}",
+		"func (c *Widget) Props() runtime.Props {\n\treturn widgetMemoProps{Label: c.Label, currentValue: c.currentValue}",
+		"func (c *Widget) ShouldUpdate(old, new runtime.Props) bool {\n\treturn old != new",
+		// Slices can't be compared, so Tags always updates
+		"func (c *Tags) ShouldUpdate(old, new runtime.Props) bool {\n\treturn true",
+		// Hoisted children render through the memo check
+		"runtime.Div(runtime.RenderComponent(c.widgetInstance))",
+		// The last render is kept in the component
+		"memo             runtime.MemoCache",
+		"func (c *Widget) MemoCache() *runtime.MemoCache {\n\treturn &c.memo",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(generated), check) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", check, generated)
+		}
+	}
+	if strings.Contains(string(generated), "func (c *Dashboard) ShouldUpdate") {
+		t.Error("Expected no ShouldUpdate without @memo")
+	}
+}
+
+func TestGenerateCopyButton(t *testing.T) {
+	source := `package main
+
+func Share(url string) (Component) {
+	Div {
+		CopyButton(Text(url))
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := `runtime.Div(runtime.CopyButton(runtime.Text(c.Url)))`
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+
+	// The text to copy is escaped into data-copy, with the default label
+	checks := []string{
+		`<button type=\"button\" class=\"copy-button\" data-copy=\"")`,
+		`html.EscapeString(fmt.Sprint(c.Url))`,
+		`\">Copy</button>`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(ssr), check) {
+			t.Errorf("Expected SSR to contain %q\nGenerated:\n%s", check, ssr)
+		}
+	}
+}
+
+func TestGenerateSetterMethods(t *testing.T) {
+	source := `package main
+
+@props func Card(title string, updates chan string, tags ...string) (Component) {
+	Div {
+		` + "`{title}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	checks := []string{
+		// Update re-renders through the app or, mounted standalone, on its own
+		"func (c *Card) SetTitle(v string) {\n\tc.Title = v\n\tc.Update()\n}",
+		"func (c *Card) SetTags(v []string) {",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(generated), check) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", check, generated)
+		}
+	}
+	// Channel parameters are updated by sending on them
+	if strings.Contains(string(generated), "SetUpdates") {
+		t.Errorf("Expected no setter for a channel parameter\nGenerated:\n%s", generated)
+	}
+}
+
+func TestGenerateJoin(t *testing.T) {
+	source := `package main
+
+func Tags(tags []string, sizes []int) (Component) {
+	Div {
+		Span { ` + "`{join(tags, \", \")}`" + ` }
+		Span { ` + "`{join(sizes, \"/\")}`" + ` }
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	for _, expected := range []string{
+		`runtime.Join(c.Tags, ", ")`,
+		`runtime.Join(c.Sizes, "/")`,
+	} {
+		if !strings.Contains(string(generated), expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+		}
+	}
+}
+
+func TestGenerateMethods(t *testing.T) {
+	source := `package main
+
+type Spinner struct {
+	Angle float64
+	Speed float64
+}
+
+func (s *Spinner) Rotate(dt float64) {
+	step := s.Speed * dt
+	s.Angle += step
+	s.Speed = s.Speed * 0.99
+}
+
+func (s Spinner) Degrees() (float64) {
+	return s.Angle * 57.2958
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := `func (s *Spinner) Rotate(dt float64) {
+	step := s.Speed * dt
+	s.Angle += step
+	s.Speed = s.Speed * 0.99
+}
+func (s Spinner) Degrees() float64 {
+	return s.Angle * 57.2958
+}`
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("Generated code does not contain expected methods:\n%s\nGenerated:\n%s", expected, generated)
+	}
+}
+
+func TestGenerateCStyleForLoop(t *testing.T) {
+	source := `package main
+
+func List(count int) (Component) {
+	Ul {
+		for i := 0; i < count; i++ {
+			Li {
+				` + "`{i}`" + `
+			}
+		}
+	}
+}
+
+func sumTo(n int) (int) {
+	total := 0
+	for i := 0; i < n; i++ {
+		if i == 3 {
+			break
+		}
+		total = total + i
+	}
+	return total
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	code := string(generated)
+
+	// The loop body's children are appended on each iteration
+	for _, expected := range []string{
+		"var nodes []*runtime.VNode",
+		"for i := 0; i < c.Count; i++ {",
+		"nodes = append(nodes, runtime.Li(runtime.Text(fmt.Sprint(i))))",
+		"return runtime.Fragment(nodes...)",
+		"for i := 0; i < n; i++ {",
+		"break",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code does not contain %q\nGenerated:\n%s", expected, code)
+		}
+	}
+}
+
+func TestGenerateSliceAndMapBuiltins(t *testing.T) {
+	source := `package main
+
+func TodoList(items []string) (Component) {
+	counts := make(map[string]int)
+
+	Div {
+		Button(OnClick(func(e Event) {
+			items = append(items, "new")
+			counts["new"] = len(items)
+			delete(counts, "old")
+			if cap(items) > 10 {
+				items = items[:0]
+			}
+			c.Update()
+		})) {
+			"Add"
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	code := string(generated)
+
+	// Parameters and hoisted variables are assigned through the receiver
+	for _, expected := range []string{
+		"counts map[string]int",
+		`c.counts = make(map[string]int)`,
+		`c.Items = append(c.Items, "new")`,
+		`c.counts["new"] = len(c.Items)`,
+		`delete(c.counts, "old")`,
+		"if cap(c.Items) > 10 {",
+		"c.Items = c.Items[:0]",
+		"c.Update()",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code does not contain %q\nGenerated:\n%s", expected, code)
+		}
+	}
+}
+
+func TestGeneratePortal(t *testing.T) {
+	source := `package main
+
+func Modal(open bool) (Component) {
+	const modalRoot = "body"
+	Div(Class("page")) {
+		if open {
+			Portal(modalRoot) {
+				Div(Class("modal")) {
+					"Saved"
+				}
+			}
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	code := string(generated)
+
+	// Portal is a runtime element, not a child component
+	expected := `runtime.Portal(modalRoot, runtime.Div(runtime.Class("modal"), runtime.Text("Saved")))`
+	if !strings.Contains(code, expected) {
+		t.Errorf("Generated code does not contain %q\nGenerated:\n%s", expected, code)
+	}
+	if strings.Contains(code, "NewPortal") {
+		t.Errorf("Portal should not be hoisted as a component\nGenerated:\n%s", code)
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+	if !strings.Contains(string(ssr), `__html.WriteString("<!--portal-->")`) || strings.Contains(string(ssr), "Saved") {
+		t.Errorf("Expected only the portal placeholder in server-rendered markup\nGenerated:\n%s", ssr)
+	}
+}
+
+func TestGenerateTemplateFormatSpec(t *testing.T) {
+	source := `package main
+
+func Receipt(price float64, count int, paidAt int64) (Component) {
+	P {
+		` + "`{price:.2f} for {count:03d} items, paid {paidAt:date}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	code := string(generated)
+
+	for _, expected := range []string{
+		`fmt.Sprintf("%.2f", c.Price)`,
+		`fmt.Sprintf("%03d", c.Count)`,
+		"time.Unix(int64(c.PaidAt), 0).Format(time.DateOnly)",
+		`"time"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code does not contain %q\nGenerated:\n%s", expected, code)
+		}
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+	if expected := `html.EscapeString(fmt.Sprintf("%.2f", c.Price))`; !strings.Contains(string(ssr), expected) {
+		t.Errorf("Generated SSR code does not contain %q\nGenerated:\n%s", expected, ssr)
+	}
+}
+
+func TestGenerateUpdateReconcilesLastRender(t *testing.T) {
+	source := `package main
+
+func Greeting(name string) (Component) {
+	Div {
+		"Hello, {name}"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"vnode *runtime.VNode",
+		// Mount keeps the tree it mounts
+		"func (c *Greeting) Mount(parent js.Value) {\n\tc.vnode = c.Render()\n\truntime.Mount(c.vnode, parent)\n}",
+		// Update diffs a fresh render against it unless an app owns the tree
+		"if c.app != nil {\n\t\tc.app.Update()\n\t\treturn\n\t}",
+		"next := c.Render()\n\truntime.Reconcile(c.vnode, next)\n\tc.vnode = next",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+	if strings.Contains(string(ssr), "vnode") {
+		t.Errorf("SSR component should not keep a vnode\nGenerated:\n%s", ssr)
+	}
+}
+
+func TestGenerateClasses(t *testing.T) {
+	source := `package main
+
+func Card(isActive bool) (Component) {
+	Div(Classes("card", ClassIf("active", isActive)), Class("wide")) {
+		"Card"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	expected := `runtime.Classes("card", runtime.ClassIf("active", c.IsActive)), runtime.Class("wide")`
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+	}
+
+	// SSR writes the class list, with the ClassIf names behind their condition
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+	expected = "__html.WriteString(\"<div class=\\\"card\")\n\tif c.IsActive {\n\t\t__html.WriteString(\" active\")\n\t}\n\t__html.WriteString(\" wide\\\">Card</div>\")"
+	if !strings.Contains(string(ssr), expected) {
+		t.Errorf("SSR code does not contain expected string: %q\nGenerated:\n%s", expected, ssr)
+	}
+}
+
+func TestGenerateLifecycleHooks(t *testing.T) {
+	source := `package main
+
+func Toast(message string) (Component) {
+	Div(OnMount(fadeIn), OnBeforeUnmount(fadeOut)) {
+		"Saved"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	expected := `runtime.Div(runtime.OnMount(fadeIn), runtime.OnBeforeUnmount(fadeOut), runtime.Text("Saved"))`
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+	}
+
+	// The hooks only run in the browser, so SSR writes the bare element
+	ssr, err := New("main").GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+	expected = `__html.WriteString("<div>Saved</div>")`
+	if !strings.Contains(string(ssr), expected) {
+		t.Errorf("SSR code does not contain expected string: %q\nGenerated:\n%s", expected, ssr)
+	}
+}
+
+func TestGenerateNestedCompositeLiterals(t *testing.T) {
+	source := `package main
+
+func Plot(origin Point) (Component) {
+	state := State{Inner: Inner{Points: []Point{{X: 1, Y: 2}, origin}}}
+	tags := map[string]Point{"a": {X: 3}}
+	Div {
+		"Plot"
+	}
+}
+`
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	code, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	generated := string(code)
+
+	for _, want := range []string{
+		"state := State{Inner: Inner{Points: []Point{{X: 1, Y: 2}, c.Origin}}}",
+		`tags := map[string]Point{"a": {X: 3}}`,
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("Expected %q in generated code:\n%s", want, generated)
+		}
+	}
+}
+
+func TestGenerateVariadicParam(t *testing.T) {
+	source := `package main
+
+@props func List(items ...string) (Component) {
+	Ul {
+		for _, item := range items {
+			Li {
+				` + "`{item}`" + `
+			}
+		}
+	}
+}
+`
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	code, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	generated := string(code)
+
+	for _, want := range []string{
+		"Items []string",
+		"func WithItems(v ...string) ListOption {",
+		"func (c *List) SetItems(v []string) {",
+		"for _, item := range c.Items {",
+		"nodes = append(nodes, runtime.Li(runtime.Text(fmt.Sprint(item))))",
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("Expected %q in generated code:\n%s", want, generated)
+		}
+	}
+}
+
+func TestGenerateCSSStyle(t *testing.T) {
+	source := `package main
+
+func Badge(color string) (Component) {
+	Div(Style(CSS{"margin-top": "4px", "color": color})) {
+		HStack(Gap(8), Style(CSS{"z-index": "2", "color": "red"})) {
+			"New"
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	gen = New("main")
+	ssr, err := gen.GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate SSR code: %v", err)
+	}
+
+	expectedStrings := []string{
+		// The map is passed to the element, which sorts it when rendering
+		`runtime.Div(runtime.CSS{"margin-top": "4px", "color": c.Color}`,
+		// Layout styles fold literal declarations in sorted order
+		`runtime.Style("display: flex; flex-direction: row; gap: 8px; color: red; z-index: 2")`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(string(generated), expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+		}
+	}
+
+	// The server joins the declarations without the runtime
+	expected := `"color: " + fmt.Sprint(c.Color) + "; margin-top: 4px"`
+	if !strings.Contains(string(ssr), expected) {
+		t.Errorf("SSR code does not contain expected string: %q\nGenerated:\n%s", expected, ssr)
+	}
+	if strings.Contains(string(ssr), "runtime.CSS") {
+		t.Errorf("SSR code should not use runtime.CSS\nGenerated:\n%s", ssr)
+	}
+}
+
+func TestGenerateRequiredProps(t *testing.T) {
+	source := `package main
+
+@props func Button(@required label string, @required clicks chan int, size int) (Component) {
+	Div {
+		` + "`{label}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expectedStrings := []string{
+		// The options constructor is kept
+		"func NewButton(opts ...ButtonOption) *Button",
+		"func NewButtonRequired(label string, clicks chan int, opts ...ButtonOption) *Button",
+		"return NewButton(append([]ButtonOption{WithLabel(label), WithClicks(clicks)}, opts...)...)",
+		// Only props that can be nil are checked when binding
+		`app.MissingProp("Button", "clicks")`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(string(generated), expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+		}
+	}
+	if strings.Contains(string(generated), `app.MissingProp("Button", "label")`) {
+		t.Errorf("A string prop should not be checked for nil\nGenerated:\n%s", generated)
+	}
+}
+
+func TestGenerateNumberLiterals(t *testing.T) {
+	source := `package main
+
+func Stats(n int) (Component) {
+	const big = 1_000_000
+	total := -1.5e3
+	HStack(Gap(1_0)) {
+		` + "`{total} {6.022e23} {big - n}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expectedStrings := []string{
+		"const big = 1_000_000",
+		"total := -1.5e3",
+		"fmt.Sprint(6.022e23)",
+		"fmt.Sprint(big-c.N)",
+		// CSS has no digit separators
+		"gap: 10px",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(string(generated), expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generated)
+		}
+	}
+}
+
+func TestGenerateEventListenerOptions(t *testing.T) {
+	source := `package main
+
+func Zoom(onZoom WheelZoom) (Component) {
+	Canvas(PreventDefault(OnWheel(onZoom)), Passive(OnScroll(onZoom)))
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expected := "runtime.Canvas(runtime.PreventDefault(runtime.OnWheel(c.OnZoom)), runtime.Passive(runtime.OnScroll(c.OnZoom)))"
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+}
+
+func TestGenerateJSONTags(t *testing.T) {
+	source := `package main
+
+@json type User struct {
+	UserID int
+	FirstName, LastName string
+	secret string
+}
+
+@json(snake) type Order struct {
+	OrderID int
+	HTTPStatus int
+}
+
+type Point struct {
+	X, Y float64
+}
+`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	for _, expected := range []string{
+		"UserID    int    `json:\"userID\"`",
+		"FirstName string `json:\"firstName\"`",
+		"LastName  string `json:\"lastName\"`",
+		"OrderID    int `json:\"order_id\"`",
+		"HTTPStatus int `json:\"http_status\"`",
+		"X, Y float64",
+	} {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Unexported fields aren't encoded
+	if strings.Contains(generatedStr, `json:"secret"`) {
+		t.Errorf("Expected no json tag on unexported field secret\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestJSONFieldName(t *testing.T) {
+	for _, tc := range []struct {
+		name, camel, snake string
+	}{
+		{"Name", "name", "name"},
+		{"UserID", "userID", "user_id"},
+		{"ID", "id", "id"},
+		{"HTTPServer", "httpServer", "http_server"},
+		{"Address2", "address2", "address2"},
+	} {
+		if got := jsonFieldName(tc.name, ""); got != tc.camel {
+			t.Errorf("Expected %s in camel case to be %s, got %s", tc.name, tc.camel, got)
+		}
+		if got := jsonFieldName(tc.name, "snake"); got != tc.snake {
+			t.Errorf("Expected %s in snake case to be %s, got %s", tc.name, tc.snake, got)
+		}
+	}
+}
+
+func TestGenerateKeyedFragment(t *testing.T) {
+	source := `package main
+
+func RowList(rows []Row) (Component) {
+	Div {
+		for _, row := range rows {
+			Fragment(Key(row.ID)) {
+				Div(Class("row")) { "Row" }
+				Div(Class("detail")) { "Detail" }
+			}
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expected := `runtime.KeyedFragment(row.ID, runtime.Div(runtime.Class("row"), runtime.Text("Row")), runtime.Div(runtime.Class("detail"), runtime.Text("Detail")))`
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+	if strings.Contains(generatedStr, "NewFragment") {
+		t.Errorf("Fragment should not be generated as a component\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateDebouncedHandler(t *testing.T) {
+	source := `package main
+
+func Search(onSearch SearchHandler) (Component) {
+	Input(OnInput(Debounced(onSearch, 200)))
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expected := "runtime.Input(runtime.OnInput(runtime.Debounced(c.OnSearch, 200)))"
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+}
+
+func TestGenerateMultipleRootsAsFragment(t *testing.T) {
+	source := `package main
+
+func Columns() (Component) {
+	Div {
+		"One"
+	}
+	Div {
+		"Two"
+	}
+	Div {
+		"Three"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expected := `return runtime.Fragment(runtime.Div(runtime.Text("One")), runtime.Div(runtime.Text("Two")), runtime.Div(runtime.Text("Three")))`
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+}
+
+func TestGenerateEmptyBodyAsFragment(t *testing.T) {
+	source := `package main
+
+func Empty() (Component) {
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expected := "return runtime.Fragment()"
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+}
+
+func TestGenerateForLoopEmptyState(t *testing.T) {
+	source := `package main
+
+func TodoList(items []string) (Component) {
+	Ul {
+		for i, item := range items {
+			Li(Key(i)) {
+				` + "`{item}`" + `
+			}
+			EmptyState {
+				Li(Class("empty")) {
+					"Nothing to do"
+				}
+			}
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"for i, item := range c.Items {",
+		"nodes = append(nodes, runtime.Li(runtime.WithKey(i), runtime.Text(fmt.Sprint(item))))",
+		"if len(nodes) == 0 {",
+		`return runtime.Li(runtime.Class("empty"), runtime.Text("Nothing to do"))`,
+		"return runtime.Fragment(nodes...)",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// The empty-state node must not be rendered as a loop item
+	if strings.Contains(generatedStr, "runtime.EmptyState") {
+		t.Errorf("EmptyState should not be generated as an element\nGenerated:\n%s", generatedStr)
+	}
+
+	genFile, err := goparser.ParseFile(token.NewFileSet(), "", generated, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse generated code: %v\n%s", err, generated)
+	}
+	var loop *goast.FuncLit
+	goast.Inspect(genFile, func(n goast.Node) bool {
+		if lit, ok := n.(*goast.FuncLit); ok && loop == nil {
+			for _, stmt := range lit.Body.List {
+				if _, ok := stmt.(*goast.RangeStmt); ok {
+					loop = lit
+				}
+			}
+		}
+		return loop == nil
+	})
+	if loop == nil {
+		t.Fatalf("Expected a function rendering the loop\nGenerated:\n%s", generated)
+	}
+
+	// Only the loop appends nodes, so an empty slice leaves nodes empty and
+	// the EmptyState branch returns before the fragment
+	printNode := func(n goast.Node) string {
+		var buf bytes.Buffer
+		printer.Fprint(&buf, token.NewFileSet(), n)
+		return buf.String()
+	}
+	stmts := loop.Body.List
+	if len(stmts) != 4 {
+		t.Fatalf("Expected nodes, the loop, the EmptyState branch and the fragment, got:\n%s", printNode(loop))
+	}
+	if _, ok := stmts[1].(*goast.RangeStmt); !ok {
+		t.Errorf("Expected the loop before the EmptyState branch, got:\n%s", printNode(loop))
+	}
+	emptyBranch, ok := stmts[2].(*goast.IfStmt)
+	if !ok || printNode(emptyBranch.Cond) != "len(nodes) == 0" {
+		t.Fatalf("Expected the EmptyState branch to check len(nodes) == 0, got:\n%s", printNode(loop))
+	}
+	if got := printNode(emptyBranch.Body); !strings.Contains(got, `return runtime.Li(runtime.Class("empty"), runtime.Text("Nothing to do"))`) {
+		t.Errorf("Expected the EmptyState branch to return its node, got:\n%s", got)
+	}
+
+	// Type check the loop against a stub runtime, since the generated file
+	// imports the wasm-only runtime
+	check := "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/gaarutyunov/guix/pkg/runtime\"\n)\n\n" +
+		"var c struct{ Items []string }\n\nvar _ = " + printNode(loop) + "()\n"
+	stub := `package runtime
+
+type VNode struct{}
+type Key struct{}
+
+func WithKey(key interface{}) Key
+func Class(name string) interface{}
+func Text(text string) *VNode
+func Li(args ...interface{}) *VNode
+func Fragment(children ...*VNode) *VNode
+`
+	fset := token.NewFileSet()
+	stubFile, err := goparser.ParseFile(fset, "runtime.go", stub, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse runtime stub: %v", err)
+	}
+	runtimePkg, err := (&types.Config{}).Check("github.com/gaarutyunov/guix/pkg/runtime", fset, []*goast.File{stubFile}, nil)
+	if err != nil {
+		t.Fatalf("Failed to check runtime stub: %v", err)
+	}
+	checkFile, err := goparser.ParseFile(fset, "check.go", check, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse loop: %v\n%s", err, check)
+	}
+	conf := types.Config{Importer: stubImporter{runtimePkg, importer.ForCompiler(fset, "source", nil)}}
+	if _, err := conf.Check("main", fset, []*goast.File{checkFile}, nil); err != nil {
+		t.Errorf("Loop does not compile: %v\n%s", err, check)
+	}
+}
+
+// stubImporter imports a stub runtime package and the standard library
+type stubImporter struct {
+	runtime *types.Package
+	std     types.Importer
+}
+
+func (i stubImporter) Import(path string) (*types.Package, error) {
+	if path == i.runtime.Path() {
+		return i.runtime, nil
+	}
+	return i.std.Import(path)
+}
+
+func TestGenerateConstDecl(t *testing.T) {
+	source := `package main
+
+func Counter(count int) (Component) {
+	const (
+		maxCount = 10
+		label = "Count"
+	)
+	const step = 2
+	doubled := count * step
+	Div {
+		Button(OnClick(func(e Event) {
+			const delta = 1
+			log(delta + maxCount)
+		})) {
+			` + "`{label}: {doubled}`" + `
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"const (\n\t\tmaxCount = 10\n\t\tlabel    = \"Count\"\n\t)",
+		"const step = 2\n\tdoubled := c.Count * step",
+		"const delta = 1",
+		"log(delta + maxCount)",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Constants are locals, never struct fields
+	if strings.Contains(generatedStr, "c.maxCount") || strings.Contains(generatedStr, "c.step") {
+		t.Errorf("Constants should not be referenced through the receiver\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateAnonymousStructProp(t *testing.T) {
+	source := `package main
+
+func Point(p struct{X, Y float64}, tags []struct{ Name string; Weight int }) (Component) {
+	Span {
+		` + "`{p.X}, {p.Y}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"P   struct {\n\t\tX, Y float64\n\t}",
+		"Tags []struct {\n\t\tName   string\n\t\tWeight int\n\t}",
+		"func NewPoint(p struct {\n\tX, Y float64\n}, tags []struct {",
+		"fmt.Sprint(c.P.X)",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateSwitchKeyHandler(t *testing.T) {
+	source := `package main
+
+func Keys(keys chan string, quit chan bool) (Component) {
+	Div {
+		Input(OnKeyDown(func(e Event) {
+			switch e.Key {
+			case "Enter", "Tab":
+				keys <- e.Key
+			case "Escape":
+				quit <- true
+			default:
+				log(e.Key)
+			}
+		}))
+		Button(OnClick(func(e Event) {
+			select {
+			case k := <-keys:
+				log(k)
+			case quit <- true:
+				log("quit")
+			default:
+				log("idle")
+			}
+		})) {
+			"Poll"
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"switch e.Key {",
+		"case \"Enter\", \"Tab\":\n\t\t\tc.Keys <- e.Key",
+		"case \"Escape\":\n\t\t\tc.Quit <- true",
+		"default:\n\t\t\tlog(e.Key)",
+		"select {",
+		"case k := <-c.Keys:",
+		"case c.Quit <- true:",
+		"default:\n\t\t\tlog(\"idle\")",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Go cases never fall through implicitly, so none should be emitted
+	if strings.Contains(generatedStr, "fallthrough") {
+		t.Errorf("Generated code should not contain fallthrough:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateTypedEventHandlers(t *testing.T) {
+	source := `package main
+
+func Shortcuts(keys chan string) (Component) {
+	Div {
+		Input(OnKeyDown(func(e KeyboardEvent) {
+			if e.Code == "KeyS" {
+				keys <- e.Key
+			}
+		}), OnInput(func(e Event) {
+			keys <- e.Target.Value
+		}))
+		Div(OnWheel(func(e WheelEvent) {}), OnClick(func(e MouseEvent) {}))
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"runtime.OnKeyDown(runtime.KeyboardHandler(func(e runtime.KeyboardEvent) {",
+		"if e.Code == \"KeyS\" {\n\t\t\tc.Keys <- e.Key",
+		"runtime.OnWheel(runtime.WheelHandler(func(e runtime.WheelEvent) {",
+		"runtime.OnClick(runtime.MouseHandler(func(e runtime.MouseEvent) {",
+		// Handlers taking a plain Event are passed as before
+		"runtime.OnInput(func(e runtime.Event) {",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateKeysBinding(t *testing.T) {
+	source := `package main
+
+@keys -> input
+func Game(input chan KeyState) (Component) {
+	Div {
+		"Use the arrow keys"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	if got := file.Components[0].KeysChannel; got != "input" {
+		t.Fatalf("Expected @keys channel input, got %q", got)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"func NewGame(input chan runtime.KeyState) *Game {",
+		// BindApp registers the keydown/keyup listeners once
+		"c.app = app\n\tif c.unbindKeys == nil {\n\t\tc.unbindKeys = runtime.BindKeys(c.Input)\n\t}",
+		// Unmount removes them
+		"func (c *Game) Unmount() {\n\tif c.unbindKeys != nil {\n\t\tc.unbindKeys()\n\t\tc.unbindKeys = nil\n\t}\n}",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateImage(t *testing.T) {
+	source := `package main
+
+func Photo(url string, thumb string, onLoaded LoadHandler) (Component) {
+	Div {
+		Image(
+			Src(url),
+			SrcSet("photo-480.jpg 480w, photo-800.jpg 800w"),
+			Sizes("(max-width: 600px) 480px, 800px"),
+			Lazy(true),
+			Placeholder(thumb)
+		)
+		Image(Src("logo.png"), Placeholder("logo-tiny.png"), Style("width: 64px"), OnLoad(onLoaded))
+		Image(Src("icon.png"))
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		// srcset, sizes and lazy loading attributes
+		`runtime.Img(runtime.Src(c.Url), runtime.SrcSet("photo-480.jpg 480w, photo-800.jpg 800w"), runtime.Sizes("(max-width: 600px) 480px, 800px"), runtime.Lazy(true),`,
+		// The placeholder is shown as the background until the image loads
+		`runtime.Style("background-image: url('"+fmt.Sprint(c.Thumb)+"'); background-size: cover; background-position: center")`,
+		"runtime.OnLoad(runtime.SwapPlaceholder(nil))",
+		// An explicit Style and OnLoad are merged with the placeholder
+		`runtime.Style("background-image: url('logo-tiny.png'); background-size: cover; background-position: center; width: 64px")`,
+		"runtime.OnLoad(runtime.SwapPlaceholder(c.OnLoaded))",
+		// Without a placeholder the image is a plain img
+		`runtime.Img(runtime.Src("icon.png"))`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	if strings.Contains(generatedStr, "runtime.Image(") || strings.Contains(generatedStr, "runtime.Placeholder(") {
+		t.Errorf("Image should be generated as an img without a placeholder attribute\nGenerated:\n%s", generatedStr)
+	}
+}
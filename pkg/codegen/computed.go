@@ -0,0 +1,160 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// comparisonOps are the binary operators whose result is a bool
+var comparisonOps = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "&&": true, "||": true,
+}
+
+// isComputedDecl checks if a declaration is a computed value:
+//
+//	computed total := <-aChannel + <-bChannel
+func isComputedDecl(varDecl *guixast.VarDecl) bool {
+	return varDecl.Computed && len(varDecl.Names) == 1 && len(varDecl.Values) == 1
+}
+
+// computedField returns the struct field holding a computed value
+func computedField(name string) string {
+	return "current" + capitalize(name)
+}
+
+// computedDecl returns the computed declaration of name in the current
+// component body, or nil
+func (g *Generator) computedDecl(name string) *guixast.VarDecl {
+	if g.currentCompBody == nil {
+		return nil
+	}
+	for _, varDecl := range g.currentCompBody.VarDecls {
+		if isComputedDecl(varDecl) && varDecl.Names[0] == name {
+			return varDecl
+		}
+	}
+	return nil
+}
+
+// computedType infers the type of a computed value: bool for comparisons,
+// otherwise the type of its first operand with a known type, e.g. the
+// element type of the first channel it receives from
+func (g *Generator) computedType(expr *guixast.Expr) ast.Expr {
+	for _, binOp := range expr.BinOps {
+		if comparisonOps[binOp.Op] {
+			return ast.NewIdent("bool")
+		}
+	}
+
+	operands := []*guixast.Primary{expr.Left}
+	for _, binOp := range expr.BinOps {
+		operands = append(operands, binOp.Right)
+	}
+	for _, operand := range operands {
+		if operand == nil {
+			continue
+		}
+		if operand.Paren != nil {
+			if t := g.computedType(operand.Paren); t != nil {
+				return t
+			}
+			continue
+		}
+		// Conversions like float64(<-angle)
+		if cos := operand.CallOrSel; cos != nil && len(cos.Fields) == 0 && cos.HasParens {
+			if _, ok := zeroValues[cos.Base]; ok {
+				return ast.NewIdent(cos.Base)
+			}
+		}
+		if t := g.inferTypeFromExpr(&guixast.Expr{Left: operand}); t != nil {
+			return t
+		}
+	}
+	return &ast.InterfaceType{Methods: &ast.FieldList{}}
+}
+
+// generateComputedFields generates the fields holding the computed values
+// of a component body
+func (g *Generator) generateComputedFields(body *guixast.Body) []*ast.Field {
+	var fields []*ast.Field
+	for _, varDecl := range body.VarDecls {
+		if isComputedDecl(varDecl) {
+			fields = append(fields, &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(computedField(varDecl.Names[0]))},
+				Type:  g.computedType(varDecl.Values[0]),
+			})
+		}
+	}
+	return fields
+}
+
+// generateComputedUpdates generates the recomputation of the computed
+// values that receive from a channel, run by its listener:
+//
+//	c.currentTotal = c.currentAChannel + c.currentBChannel
+func (g *Generator) generateComputedUpdates(channel string) []ast.Stmt {
+	if g.currentCompBody == nil {
+		return nil
+	}
+
+	var stmts []ast.Stmt
+	for _, varDecl := range g.currentCompBody.VarDecls {
+		if !isComputedDecl(varDecl) || !receivesFrom(varDecl.Values[0], channel) {
+			continue
+		}
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.SelectorExpr{
+				X:   ast.NewIdent("c"),
+				Sel: ast.NewIdent(computedField(varDecl.Names[0])),
+			}},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{g.generateExpr(varDecl.Values[0])},
+		})
+	}
+	return stmts
+}
+
+// receivesFrom checks if an expression receives from the channel
+func receivesFrom(expr *guixast.Expr, channel string) bool {
+	if expr == nil {
+		return false
+	}
+	if primaryReceivesFrom(expr.Left, channel) {
+		return true
+	}
+	for _, binOp := range expr.BinOps {
+		if primaryReceivesFrom(binOp.Right, channel) {
+			return true
+		}
+	}
+	if expr.Cond != nil {
+		return receivesFrom(expr.Cond.Then, channel) || receivesFrom(expr.Cond.Else, channel)
+	}
+	return false
+}
+
+// primaryReceivesFrom checks if a primary expression receives from the channel
+func primaryReceivesFrom(primary *guixast.Primary, channel string) bool {
+	if primary == nil {
+		return false
+	}
+	if primary.ChannelOp != nil {
+		return primary.ChannelOp.Channel == channel
+	}
+	if primary.Paren != nil {
+		return receivesFrom(primary.Paren, channel)
+	}
+	if primary.Unary != nil {
+		return primaryReceivesFrom(primary.Unary.Right, channel)
+	}
+	if primary.CallOrSel != nil {
+		for _, arg := range primary.CallOrSel.Args {
+			if receivesFrom(arg, channel) {
+				return true
+			}
+		}
+	}
+	return false
+}
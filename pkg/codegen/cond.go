@@ -0,0 +1,94 @@
+package codegen
+
+import (
+	"go/ast"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// generateCondExpr lowers a conditional expression to an immediately
+// invoked function, since Go has no conditional operator. Only the chosen
+// branch is evaluated:
+//
+//	active ? "on" : "off"  ->  func() string { if c.Active { return "on" }; return "off" }()
+func (g *Generator) generateCondExpr(expr *guixast.Expr) ast.Expr {
+	cond := g.generateExpr(&guixast.Expr{Pos: expr.Pos, Left: expr.Left, BinOps: expr.BinOps})
+
+	resultType := g.condExprType(expr.Cond)
+	if resultType == nil {
+		resultType = &ast.InterfaceType{Methods: &ast.FieldList{}}
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: resultType}}},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.IfStmt{
+						Cond: cond,
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{g.generateExpr(expr.Cond.Then)}}},
+						},
+					},
+					&ast.ReturnStmt{Results: []ast.Expr{g.generateExpr(expr.Cond.Else)}},
+				},
+			},
+		},
+	}
+}
+
+// condExprType returns the type of a conditional expression: the basic type
+// of its branches, the type inferred from either branch when neither basic
+// type is known, or nil when the branch types differ or are unknown
+func (g *Generator) condExprType(cond *guixast.CondExpr) ast.Expr {
+	if typeName := g.condStaticType(cond); typeName != "" {
+		return ast.NewIdent(typeName)
+	}
+	if g.staticTypeOf(cond.Then) != "" && g.staticTypeOf(cond.Else) != "" {
+		return nil
+	}
+	if t := g.inferTypeFromExpr(cond.Then); t != nil {
+		return t
+	}
+	return g.inferTypeFromExpr(cond.Else)
+}
+
+// condStaticType returns the basic type both branches of a conditional
+// expression share. An untyped constant branch takes the other branch's
+// type when it converts to it, as in sale ? 0 : price with a float64 price.
+// It returns "" otherwise.
+func (g *Generator) condStaticType(cond *guixast.CondExpr) string {
+	thenType, elseType := g.staticTypeOf(cond.Then), g.staticTypeOf(cond.Else)
+	switch {
+	case thenType == elseType:
+		return thenType
+	case thenType == "" || elseType == "":
+		return ""
+	case isUntypedConst(cond.Then) && untypedConstFits(thenType, elseType):
+		return elseType
+	case isUntypedConst(cond.Else) && untypedConstFits(elseType, thenType):
+		return thenType
+	}
+	return ""
+}
+
+// isUntypedConst returns true if the expression is a single literal
+func isUntypedConst(expr *guixast.Expr) bool {
+	return expr.Left != nil && expr.Left.Literal != nil && len(expr.BinOps) == 0 && expr.Cond == nil
+}
+
+// untypedConstFits returns true if an untyped constant whose default type
+// is constType converts to typeName: integer constants to any numeric type,
+// floating-point constants to either float type
+func untypedConstFits(constType, typeName string) bool {
+	switch constType {
+	case "int":
+		return primitiveTypes[typeName] && typeName != "bool" && typeName != "string"
+	case "float64":
+		return typeName == "float32" || typeName == "float64"
+	}
+	return false
+}
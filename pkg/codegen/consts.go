@@ -0,0 +1,40 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// generateConstDecls generates const declarations as local Go consts.
+// Grouped declarations keep their parentheses:
+//
+//	const ( maxItems = 10 label = "Items" )
+//	const (
+//	    maxItems = 10
+//	    label    = "Items"
+//	)
+func (g *Generator) generateConstDecls(constDecls []*guixast.ConstDecl) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0, len(constDecls))
+	for _, constDecl := range constDecls {
+		stmts = append(stmts, g.generateConstDecl(constDecl))
+	}
+	return stmts
+}
+
+// generateConstDecl generates a single const declaration statement
+func (g *Generator) generateConstDecl(constDecl *guixast.ConstDecl) ast.Stmt {
+	decl := &ast.GenDecl{Tok: token.CONST}
+	if len(constDecl.Specs) != 1 {
+		decl.Lparen = 1
+		decl.Rparen = 1
+	}
+	for _, spec := range constDecl.Specs {
+		decl.Specs = append(decl.Specs, &ast.ValueSpec{
+			Names:  []*ast.Ident{ast.NewIdent(spec.Name)},
+			Values: []ast.Expr{g.generateExpr(spec.Value)},
+		})
+	}
+	return &ast.DeclStmt{Decl: decl}
+}
@@ -0,0 +1,80 @@
+package codegen
+
+import (
+	"go/ast"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// isDerivedDecl checks if a component body declaration is a derived value:
+// one that is neither hoisted into a struct field, a channel receive
+// variable nor a computed value. Derived values are recomputed as locals at the top of Render.
+func (g *Generator) isDerivedDecl(varDecl *guixast.VarDecl) bool {
+	if isComputedDecl(varDecl) {
+		return false
+	}
+	if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 {
+		if g.inferTypeFromExpr(varDecl.Values[0]) != nil {
+			return false
+		}
+		if g.channelReceiveVars != nil && g.channelReceiveVars[varDecl.Names[0]] != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isChannelReceiveDecl checks if a declaration is a bare channel receive
+// (value := <-ch), which is stored in a field updated by the channel listener
+func isChannelReceiveDecl(varDecl *guixast.VarDecl) bool {
+	if len(varDecl.Names) != 1 || len(varDecl.Values) != 1 {
+		return false
+	}
+	value := varDecl.Values[0]
+	return value.Left != nil && value.Left.ChannelOp != nil && len(value.BinOps) == 0
+}
+
+// generateDerivedValues generates the derived value declarations of a
+// component body, so every use in the template shares one local:
+//
+//	doubled := count * 2
+//	doubled := c.Count * 2
+//
+// Inline channel receives in a derived value read the listener-updated
+// current<Channel> field. Const declarations of the body come first.
+func (g *Generator) generateDerivedValues(body *guixast.Body) []ast.Stmt {
+	if body == nil {
+		return nil
+	}
+
+	var derived []*guixast.VarDecl
+	for _, varDecl := range body.VarDecls {
+		if g.isDerivedDecl(varDecl) {
+			derived = append(derived, varDecl)
+		}
+	}
+	return append(g.generateConstDecls(body.ConstDecls), g.generateLocalDecls(derived)...)
+}
+
+// generateLocalDecls generates declarations as local variables
+func (g *Generator) generateLocalDecls(varDecls []*guixast.VarDecl) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0, len(varDecls))
+	for _, varDecl := range varDecls {
+		lhs := make([]ast.Expr, len(varDecl.Names))
+		for i, name := range varDecl.Names {
+			lhs[i] = ast.NewIdent(name)
+		}
+
+		rhs := make([]ast.Expr, len(varDecl.Values))
+		for i, val := range varDecl.Values {
+			rhs[i] = g.generateExpr(val)
+		}
+
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: lhs,
+			Tok: g.assignOpToToken(varDecl.Op),
+			Rhs: rhs,
+		})
+	}
+	return stmts
+}
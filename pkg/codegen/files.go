@@ -0,0 +1,145 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// SharedFileName is the name of the file holding declarations that don't
+// belong to a single component (type definitions and methods)
+const SharedFileName = "shared_gen.go"
+
+// GenerateFiles generates one Go file per component plus a shared file for
+// type definitions and methods. Each file only imports the packages it uses.
+// The map is keyed by file name, e.g. "user_card_gen.go" for UserCard. A
+// component whose file name is taken, as by one named Shared, is an error.
+func (g *Generator) GenerateFiles(file *guixast.File) (map[string][]byte, error) {
+	// Collect component names so elements referencing them resolve
+	for _, comp := range file.Components {
+		if g.isComponentFunc(comp) {
+			g.components[comp.Name] = true
+		}
+	}
+
+	// Every import any file may need; each file keeps the ones it references
+	candidates := g.generateImports(file).Specs
+	for _, pkg := range []string{"fmt", "strconv", "time"} {
+		candidates = append(candidates, &ast.ImportSpec{
+			Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(pkg)},
+		})
+	}
+
+	files := make(map[string][]byte)
+
+	// Shared declarations: type definitions and methods
+	g.generatedDecls = nil
+	for _, typeDef := range file.Types {
+		typeDef.Accept(g)
+	}
+	for _, method := range file.Methods {
+		method.Accept(g)
+	}
+	if len(g.generatedDecls) > 0 {
+		out, err := g.formatDecls(file.Package, withImports(candidates, g.generatedDecls))
+		if err != nil {
+			return nil, err
+		}
+		files[SharedFileName] = out
+	}
+
+	// One file per component. The shared file name is reserved even when
+	// there are no shared declarations.
+	owners := map[string]string{SharedFileName: "type definitions and methods"}
+	for _, comp := range file.Components {
+		name := componentFileName(comp.Name)
+		if owner, ok := owners[name]; ok {
+			return nil, fmt.Errorf("component %s: file %s is already generated for %s", comp.Name, name, owner)
+		}
+		owners[name] = "component " + comp.Name
+
+		g.generatedDecls = nil
+		comp.Accept(g)
+
+		out, err := g.formatDecls(file.Package, withImports(candidates, g.generatedDecls))
+		if err != nil {
+			return nil, err
+		}
+		files[name] = out
+	}
+
+	g.generatedDecls = nil
+	return files, nil
+}
+
+// withImports prepends an import declaration containing only the candidate
+// imports referenced by decls
+func withImports(candidates []ast.Spec, decls []ast.Decl) []ast.Decl {
+	used := make(map[string]bool)
+	for _, decl := range decls {
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					used[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	var specs []ast.Spec
+	seen := make(map[string]bool)
+	for _, spec := range candidates {
+		imp, ok := spec.(*ast.ImportSpec)
+		if !ok || seen[imp.Path.Value] {
+			continue
+		}
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if used[name] {
+			seen[imp.Path.Value] = true
+			specs = append(specs, imp)
+		}
+	}
+
+	if len(specs) == 0 {
+		return decls
+	}
+
+	return append([]ast.Decl{&ast.GenDecl{
+		Tok:    token.IMPORT,
+		Lparen: 1,
+		Specs:  specs,
+	}}, decls...)
+}
+
+// componentFileName converts a component name to a snake_case file name
+// Example: UserCard -> user_card_gen.go
+func componentFileName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word on a lower->upper boundary or at the end of an acronym
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String() + "_gen.go"
+}
@@ -0,0 +1,164 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+func TestGenerateFiles(t *testing.T) {
+	source := `package main
+
+type Item struct {
+	Label string
+}
+
+func Header(title string) (Component) {
+	H1 {
+		` + "`{title}`" + `
+	}
+}
+
+func StatusBar() (Component) {
+	Div {
+		"Ready"
+	}
+}
+
+func Receipt(paidAt int64) (Component) {
+	P {
+		` + "`Paid {paidAt:time}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	files, err := gen.GenerateFiles(file)
+	if err != nil {
+		t.Fatalf("Failed to generate files: %v", err)
+	}
+
+	if len(files) != 4 {
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		t.Fatalf("Expected 4 files, got %d: %v", len(files), names)
+	}
+
+	tests := []struct {
+		name        string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "header_gen.go",
+			contains: []string{
+				"// Code generated by guix. DO NOT EDIT.",
+				"package main",
+				"type Header struct",
+				`"fmt"`,
+				`"syscall/js"`,
+				`"github.com/gaarutyunov/guix/pkg/runtime"`,
+			},
+			notContains: []string{"type StatusBar struct", "type Item struct", `"time"`},
+		},
+		{
+			name: "status_bar_gen.go",
+			contains: []string{
+				"type StatusBar struct",
+				`"syscall/js"`,
+				`"github.com/gaarutyunov/guix/pkg/runtime"`,
+			},
+			// No template interpolation, so fmt is not needed here
+			notContains: []string{"type Header struct", "type Item struct", `"fmt"`},
+		},
+		{
+			name: "receipt_gen.go",
+			contains: []string{
+				"type Receipt struct",
+				`"time"`,
+				"time.Unix(",
+			},
+			notContains: []string{"type Header struct", "type Item struct"},
+		},
+		{
+			name:        SharedFileName,
+			contains:    []string{"type Item struct", "package main"},
+			notContains: []string{"import", "type Header struct", "type StatusBar struct"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, ok := files[tt.name]
+			if !ok {
+				t.Fatalf("Expected output file %s", tt.name)
+			}
+			generatedStr := string(out)
+
+			for _, expected := range tt.contains {
+				if !strings.Contains(generatedStr, expected) {
+					t.Errorf("%s does not contain expected string: %q\nGenerated:\n%s", tt.name, expected, generatedStr)
+				}
+			}
+			for _, unexpected := range tt.notContains {
+				if strings.Contains(generatedStr, unexpected) {
+					t.Errorf("%s should not contain %q\nGenerated:\n%s", tt.name, unexpected, generatedStr)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFilesNameCollision(t *testing.T) {
+	for name, source := range map[string]string{
+		"shared": `package main
+
+func Shared() (Component) {
+	Div {
+		"Shared"
+	}
+}`,
+		"components": `package main
+
+func UserCard() (Component) {
+	Div {
+		"Card"
+	}
+}
+
+func User_card() (Component) {
+	Div {
+		"Card"
+	}
+}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, err := parser.New()
+			if err != nil {
+				t.Fatalf("Failed to create parser: %v", err)
+			}
+
+			file, err := p.Parse(strings.NewReader(source))
+			if err != nil {
+				t.Fatalf("Failed to parse source: %v", err)
+			}
+
+			// A second file of the same name would overwrite the first
+			if _, err := New("main").GenerateFiles(file); err == nil || !strings.Contains(err.Error(), "is already generated for") {
+				t.Errorf("Expected a file name collision error, got %v", err)
+			}
+		})
+	}
+}
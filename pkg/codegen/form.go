@@ -0,0 +1,134 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// formField is a bound input inside a Form
+type formField struct {
+	key    ast.Expr
+	target *guixast.Expr
+}
+
+// isFormValuesSubmit checks if an OnSubmit prop on a Form should receive the
+// aggregated field values. That is the case when the form has bound fields
+// and the handler is not a func(e Event) literal.
+func isFormValuesSubmit(form *guixast.Element, prop *guixast.Prop) bool {
+	if prop.Name != "OnSubmit" || len(prop.Args) != 1 {
+		return false
+	}
+	if fn := prop.Args[0].Left; fn != nil && fn.FuncLit != nil {
+		params := fn.FuncLit.Params
+		if len(params) == 1 && params[0].Type != nil && params[0].Type.Name == "Event" {
+			return false
+		}
+	}
+	return len(collectFormFields(form.Children)) > 0
+}
+
+// collectFormFields finds the Bind props of elements nested in a form.
+// A field is keyed by its Name prop, or by the bound variable's name.
+func collectFormFields(nodes []*guixast.Node) []*guixast.Element {
+	var fields []*guixast.Element
+	for _, node := range nodes {
+		switch {
+		case node.Element != nil:
+			for _, prop := range node.Element.Props {
+				if prop.IsBind() {
+					fields = append(fields, node.Element)
+					break
+				}
+			}
+			fields = append(fields, collectFormFields(node.Element.Children)...)
+		case node.IfExpr != nil:
+			if node.IfExpr.TrueBody != nil {
+				fields = append(fields, collectFormFields(node.IfExpr.TrueBody.Children)...)
+			}
+			if node.IfExpr.ElseIf != nil {
+				fields = append(fields, collectFormFields([]*guixast.Node{{IfExpr: node.IfExpr.ElseIf}})...)
+			}
+			if node.IfExpr.FalseBody != nil {
+				fields = append(fields, collectFormFields(node.IfExpr.FalseBody.Children)...)
+			}
+		}
+	}
+	return fields
+}
+
+// formFieldOf returns the key and bound target of a form field element
+func (g *Generator) formFieldOf(elem *guixast.Element) formField {
+	var field formField
+	for _, prop := range elem.Props {
+		if prop.IsBind() {
+			field.target = prop.BindTarget()
+		} else if prop.Name == "Name" && len(prop.Args) == 1 {
+			field.key = g.generateExpr(prop.Args[0])
+		}
+	}
+
+	if field.key == nil {
+		name := ""
+		if left := field.target.Left; left != nil {
+			if left.CallOrSel != nil {
+				name = left.CallOrSel.Base
+				if n := len(left.CallOrSel.Fields); n > 0 {
+					name = left.CallOrSel.Fields[n-1]
+				}
+			} else {
+				name = left.Ident
+			}
+		}
+		field.key = &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(name)}
+	}
+	return field
+}
+
+// generateFormSubmit generates a submit handler that collects the form's
+// bound field values:
+//
+//	runtime.OnFormSubmit(func() runtime.FormValues {
+//	    return runtime.FormValues{"email": c.email, "agree": c.agree}
+//	}, handler)
+func (g *Generator) generateFormSubmit(form *guixast.Element, prop *guixast.Prop) ast.Expr {
+	formValuesType := &ast.SelectorExpr{
+		X:   ast.NewIdent("runtime"),
+		Sel: ast.NewIdent("FormValues"),
+	}
+
+	var elts []ast.Expr
+	for _, elem := range collectFormFields(form.Children) {
+		field := g.formFieldOf(elem)
+		elts = append(elts, &ast.KeyValueExpr{
+			Key:   field.key,
+			Value: g.generateExpr(field.target),
+		})
+	}
+
+	collect := &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: formValuesType}}},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CompositeLit{Type: formValuesType, Elts: elts},
+					},
+				},
+			},
+		},
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("runtime"),
+			Sel: ast.NewIdent("OnFormSubmit"),
+		},
+		Args: []ast.Expr{collect, g.generateExpr(prop.Args[0])},
+	}
+}
@@ -0,0 +1,39 @@
+package codegen
+
+import (
+	"go/ast"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// fragmentKey returns the Key prop of a Fragment, or nil when it has none
+func fragmentKey(elem *guixast.Element) *guixast.Prop {
+	for _, prop := range elem.Props {
+		if prop.Name == "Key" && len(prop.Args) == 1 {
+			return prop
+		}
+	}
+	return nil
+}
+
+// generateFragment generates a Fragment element. With a Key prop it becomes
+// runtime.KeyedFragment(key, children...), so a loop body of several nodes
+// is reordered as one group; without one it is runtime.Fragment(children...).
+func (g *Generator) generateFragment(elem *guixast.Element) ast.Expr {
+	var args []ast.Expr
+	fun := "Fragment"
+	if key := fragmentKey(elem); key != nil {
+		fun = "KeyedFragment"
+		args = append(args, g.generateExpr(key.Args[0]))
+	}
+	for _, child := range elem.Children {
+		args = append(args, g.generateNode(child))
+	}
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("runtime"),
+			Sel: ast.NewIdent(fun),
+		},
+		Args: args,
+	}
+}
@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// mountSelectorPattern matches the selectors GenerateIndexHTML can turn into
+// a mount element: #id, .class or a tag name
+var mountSelectorPattern = regexp.MustCompile(`^[#.]?[A-Za-z][A-Za-z0-9_-]*$`)
+
+// indexHTML is the page generated by GenerateIndexHTML; %s is the mount
+// element
+const indexHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Guix App</title>
+</head>
+<body>
+    %s
+
+    <script src="wasm_exec.js"></script>
+    <script>
+        const go = new Go();
+        WebAssembly.instantiateStreaming(fetch("main.wasm"), go.importObject)
+            .then((result) => go.run(result.instance))
+            .catch((err) => console.error("Failed to load main.wasm:", err));
+    </script>
+</body>
+</html>
+`
+
+// GenerateIndexHTML generates a minimal page that runs a Guix app: it holds
+// the element the app mounts into, loads wasm_exec.js and instantiates
+// main.wasm, both served next to the page. appSelector is the selector the
+// app passes to Mount, e.g. "#root", and may be an #id, a .class or a tag
+// name.
+func GenerateIndexHTML(appSelector string) ([]byte, error) {
+	if !mountSelectorPattern.MatchString(appSelector) {
+		return nil, fmt.Errorf("unsupported mount selector %q: expected #id, .class or a tag name", appSelector)
+	}
+
+	var mount string
+	switch appSelector[0] {
+	case '#':
+		mount = fmt.Sprintf(`<div id="%s"></div>`, appSelector[1:])
+	case '.':
+		mount = fmt.Sprintf(`<div class="%s"></div>`, appSelector[1:])
+	default:
+		mount = fmt.Sprintf("<%s></%s>", appSelector, appSelector)
+	}
+
+	return []byte(fmt.Sprintf(indexHTML, mount)), nil
+}
@@ -0,0 +1,52 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateIndexHTML(t *testing.T) {
+	out, err := GenerateIndexHTML("#root")
+	if err != nil {
+		t.Fatalf("Failed to generate HTML: %v", err)
+	}
+	page := string(out)
+
+	checks := []string{
+		`<div id="root"></div>`,
+		`<script src="wasm_exec.js"></script>`,
+		`const go = new Go();`,
+		`WebAssembly.instantiateStreaming(fetch("main.wasm"), go.importObject)`,
+		`go.run(result.instance)`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(page, check) {
+			t.Errorf("Expected generated HTML to contain %q\nGot:\n%s", check, page)
+		}
+	}
+
+	// The mount element comes before the scripts that run the app
+	if strings.Index(page, `id="root"`) > strings.Index(page, "wasm_exec.js") {
+		t.Error("Expected the mount element before the scripts")
+	}
+
+	mounts := map[string]string{
+		".app": `<div class="app"></div>`,
+		"main": `<main></main>`,
+	}
+	for selector, mount := range mounts {
+		out, err := GenerateIndexHTML(selector)
+		if err != nil {
+			t.Fatalf("Failed to generate HTML for %q: %v", selector, err)
+		}
+		if !strings.Contains(string(out), mount) {
+			t.Errorf("Expected %s for selector %q", mount, selector)
+		}
+	}
+
+	for _, selector := range []string{"", "#", "#a b", `#"><script>`} {
+		if _, err := GenerateIndexHTML(selector); err == nil {
+			t.Errorf("Expected an error for selector %q", selector)
+		}
+	}
+}
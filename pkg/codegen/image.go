@@ -0,0 +1,113 @@
+package codegen
+
+import (
+	"go/ast"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// Image placeholders are shown as the img background until the full image
+// has loaded; the URL goes between the prefix and suffix
+const (
+	imagePlaceholderPrefix = "background-image: url('"
+	imagePlaceholderSuffix = "'); background-size: cover; background-position: center"
+)
+
+// imageParts splits the props of an Image into the placeholder, the Style
+// and OnLoad props it is merged with, and the props passed to the img as is
+type imageParts struct {
+	placeholder *guixast.Prop
+	style       *guixast.Prop
+	onLoad      *guixast.Prop
+	props       []*guixast.Prop
+}
+
+// splitImageProps sorts the props of an Image element into imageParts.
+// Style and OnLoad are only taken out when there is a placeholder.
+func splitImageProps(elem *guixast.Element) imageParts {
+	var parts imageParts
+	for _, prop := range elem.Props {
+		if prop.Name == "Placeholder" && len(prop.Args) == 1 {
+			parts.placeholder = prop
+		}
+	}
+
+	for _, prop := range elem.Props {
+		switch {
+		case prop == parts.placeholder:
+		case parts.placeholder != nil && prop.Name == "Style" && len(prop.Args) == 1:
+			parts.style = prop
+		case parts.placeholder != nil && prop.Name == "OnLoad" && len(prop.Args) == 1:
+			parts.onLoad = prop
+		default:
+			parts.props = append(parts.props, prop)
+		}
+	}
+	return parts
+}
+
+// imageStyle builds the style of an Image with a placeholder. An explicit
+// Style prop is appended after the placeholder background.
+func (g *Generator) imageStyle(parts imageParts) ast.Expr {
+	style := &styleBuilder{g: g}
+	style.text(imagePlaceholderPrefix)
+	style.value(parts.placeholder.Args[0])
+	style.text(imagePlaceholderSuffix)
+	if parts.style != nil {
+		style.text("; ")
+		style.value(parts.style.Args[0])
+	}
+	return style.expr()
+}
+
+// generateImage generates an Image as an Img. A Placeholder is shown as the
+// background until the load event swaps it out:
+//
+//	Image(Src(url), SrcSet(set), Lazy(true), Placeholder(thumb))
+//	runtime.Img(runtime.Src(url), runtime.SrcSet(set), runtime.Lazy(true),
+//	    runtime.Style("background-image: url('"+thumb+"'); ..."),
+//	    runtime.OnLoad(runtime.SwapPlaceholder(nil)))
+func (g *Generator) generateImage(elem *guixast.Element) ast.Expr {
+	parts := splitImageProps(elem)
+	img := &guixast.Element{
+		Pos:   elem.Pos,
+		Tag:   "Img",
+		Props: parts.props,
+	}
+
+	call := g.generateElement(img).(*ast.CallExpr)
+	if parts.placeholder == nil {
+		return call
+	}
+
+	var next ast.Expr = ast.NewIdent("nil")
+	if parts.onLoad != nil {
+		next = g.generateExpr(parts.onLoad.Args[0])
+	}
+
+	call.Args = append(call.Args,
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("Style"),
+			},
+			Args: []ast.Expr{g.imageStyle(parts)},
+		},
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("runtime"),
+				Sel: ast.NewIdent("OnLoad"),
+			},
+			Args: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("runtime"),
+						Sel: ast.NewIdent("SwapPlaceholder"),
+					},
+					Args: []ast.Expr{next},
+				},
+			},
+		},
+	)
+	return call
+}
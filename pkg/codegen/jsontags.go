@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// jsonStructToAST converts the struct of a @json type definition to a Go
+// struct type with a json tag on each exported field. Fields sharing a type
+// are split, so each gets its own tag; unexported fields aren't encoded and
+// are left untagged.
+func (g *Generator) jsonStructToAST(st *guixast.StructType, style string) *ast.StructType {
+	var fields []*ast.Field
+	for _, field := range st.Fields {
+		for _, name := range field.Names() {
+			f := &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(name)},
+				Type:  g.typeToAST(field.Type),
+			}
+			if token.IsExported(name) {
+				f.Tag = &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("`json:%q`", jsonFieldName(name, style)),
+				}
+			}
+			fields = append(fields, f)
+		}
+	}
+	return &ast.StructType{Fields: &ast.FieldList{List: fields}}
+}
+
+// jsonFieldName returns the JSON name of a field in a @json style: UserID
+// becomes userID in camel case (the default) and user_id in snake case
+func jsonFieldName(name, style string) string {
+	words := splitWords(name)
+	if style == "snake" {
+		for i, word := range words {
+			words[i] = strings.ToLower(word)
+		}
+		return strings.Join(words, "_")
+	}
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// splitWords splits a Go identifier into its words. A run of capitals is
+// one word, an initialism, unless its last capital starts the next word:
+// HTTPServer2 splits into HTTP and Server2.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if !unicode.IsUpper(prev) || nextLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
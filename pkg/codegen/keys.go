@@ -0,0 +1,72 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// unbindKeysField holds the cleanup returned by runtime.BindKeys for
+// components declared with @keys -> ch
+const unbindKeysField = "unbindKeys"
+
+// keysChannelExpr returns the channel a component's @keys directive sends
+// to: a parameter field or a hoisted variable
+func keysChannelExpr(comp *guixast.Component) ast.Expr {
+	name := comp.KeysChannel
+	for _, param := range comp.Params {
+		if param.Name == name {
+			name = capitalize(name)
+			break
+		}
+	}
+	return &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(name)}
+}
+
+// generateBindKeysStmt generates the key listener binding of BindApp:
+//
+//	if c.unbindKeys == nil {
+//	    c.unbindKeys = runtime.BindKeys(c.Input)
+//	}
+func generateBindKeysStmt(comp *guixast.Component) ast.Stmt {
+	field := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(unbindKeysField)}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: field, Op: token.EQL, Y: ast.NewIdent("nil")},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{field},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent("BindKeys")},
+						Args: []ast.Expr{keysChannelExpr(comp)},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// generateUnbindKeysStmt generates the key listener cleanup of Unmount:
+//
+//	if c.unbindKeys != nil {
+//	    c.unbindKeys()
+//	    c.unbindKeys = nil
+//	}
+func generateUnbindKeysStmt() ast.Stmt {
+	field := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(unbindKeysField)}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: field, Op: token.NEQ, Y: ast.NewIdent("nil")},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{Fun: field}},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{field},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("nil")},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,209 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// layoutElements maps layout components to the base style of the Div they
+// generate
+var layoutElements = map[string]string{
+	"HStack": "display: flex; flex-direction: row",
+	"VStack": "display: flex; flex-direction: column",
+	"Grid":   "display: grid",
+}
+
+// layoutProps maps layout props to the CSS declaration they produce.
+// The prop argument replaces %s.
+var layoutProps = map[string]string{
+	"Gap":     "gap: %spx",
+	"Align":   "align-items: %s",
+	"Justify": "justify-content: %s",
+	"Columns": "grid-template-columns: repeat(%s, 1fr)",
+}
+
+// isLayoutElement checks if a tag is a layout component (HStack, VStack, Grid)
+func isLayoutElement(tag string) bool {
+	_, ok := layoutElements[tag]
+	return ok
+}
+
+// generateLayout generates a layout component as a Div with flex/grid styles:
+//
+//	HStack(Gap(8), Align("center")) { ... }
+//	runtime.Div(runtime.Style("display: flex; flex-direction: row; gap: 8px; align-items: center"), ...)
+func (g *Generator) generateLayout(elem *guixast.Element) ast.Expr {
+	div := &guixast.Element{
+		Pos:      elem.Pos,
+		Tag:      "Div",
+		Props:    nonLayoutProps(elem),
+		Children: elem.Children,
+	}
+
+	call := g.generateElement(div).(*ast.CallExpr)
+	style := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("runtime"),
+			Sel: ast.NewIdent("Style"),
+		},
+		Args: []ast.Expr{g.layoutStyle(elem)},
+	}
+	call.Args = append([]ast.Expr{style}, call.Args...)
+	return call
+}
+
+// nonLayoutProps returns the props of a layout element that are passed
+// through to the generated Div unchanged
+func nonLayoutProps(elem *guixast.Element) []*guixast.Prop {
+	var props []*guixast.Prop
+	for _, prop := range elem.Props {
+		if _, ok := layoutProps[prop.Name]; ok || prop.Name == "Style" {
+			continue
+		}
+		props = append(props, prop)
+	}
+	return props
+}
+
+// layoutStyle builds the style string of a layout element. Literal prop
+// arguments are folded into a constant; other expressions are stringified
+// at render time. An explicit Style prop is appended last.
+func (g *Generator) layoutStyle(elem *guixast.Element) ast.Expr {
+	style := &styleBuilder{g: g}
+	style.text(layoutElements[elem.Tag])
+
+	for _, prop := range elem.Props {
+		if len(prop.Args) != 1 {
+			continue
+		}
+		if prop.Name == "Style" {
+			style.text("; ")
+			style.value(prop.Args[0])
+			continue
+		}
+		decl, ok := layoutProps[prop.Name]
+		if !ok {
+			continue
+		}
+		prefix, suffix, _ := strings.Cut(decl, "%s")
+		style.text("; " + prefix)
+		style.value(prop.Args[0])
+		style.text(suffix)
+	}
+
+	return style.expr()
+}
+
+// styleBuilder concatenates a style string from constant text and prop
+// arguments, folding literal arguments into the constant parts
+type styleBuilder struct {
+	g      *Generator
+	parts  []ast.Expr
+	static strings.Builder
+}
+
+// text appends constant text
+func (b *styleBuilder) text(text string) {
+	b.static.WriteString(text)
+}
+
+// value appends a prop argument, stringified at render time unless it is a literal
+func (b *styleBuilder) value(arg *guixast.Expr) {
+	if lit := cssLit(arg); lit != nil {
+		b.css(lit)
+		return
+	}
+	if text, ok := literalText(arg); ok {
+		b.static.WriteString(text)
+		return
+	}
+	b.flush()
+	b.parts = append(b.parts, b.g.stringify(arg))
+}
+
+// css appends the declarations of a CSS{...} literal. Like runtime.CSS,
+// they are sorted by property name; properties that aren't literals follow
+// in source order.
+func (b *styleBuilder) css(lit *guixast.CompositeLit) {
+	elems := make([]*guixast.KeyValue, len(lit.Elements))
+	copy(elems, lit.Elements)
+	sort.SliceStable(elems, func(i, j int) bool {
+		ki, iok := literalText(cssKey(elems[i]))
+		kj, jok := literalText(cssKey(elems[j]))
+		if iok && jok {
+			return ki < kj
+		}
+		return iok && !jok
+	})
+
+	for i, elem := range elems {
+		if i > 0 {
+			b.text("; ")
+		}
+		b.value(cssKey(elem))
+		b.text(": ")
+		b.value(elem.Value)
+	}
+}
+
+// cssKey returns the property name of a CSS{...} entry as an expression
+func cssKey(elem *guixast.KeyValue) *guixast.Expr {
+	if elem.LitKey != nil {
+		return &guixast.Expr{Left: &guixast.Primary{Literal: elem.LitKey}}
+	}
+	return &guixast.Expr{Left: &guixast.Primary{Ident: elem.Key}}
+}
+
+// cssLit returns the literal of a CSS{...} argument, or nil
+func cssLit(arg *guixast.Expr) *guixast.CompositeLit {
+	if arg == nil || len(arg.BinOps) > 0 || arg.Left == nil || arg.Left.CompositeLit == nil {
+		return nil
+	}
+	if lit := arg.Left.CompositeLit; lit.Type == "CSS" {
+		return lit
+	}
+	return nil
+}
+
+// flush moves pending constant text into the parts
+func (b *styleBuilder) flush() {
+	if b.static.Len() > 0 {
+		b.parts = append(b.parts, &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(b.static.String())})
+		b.static.Reset()
+	}
+}
+
+// expr returns the concatenation of all parts
+func (b *styleBuilder) expr() ast.Expr {
+	b.flush()
+	if len(b.parts) == 0 {
+		return &ast.BasicLit{Kind: token.STRING, Value: `""`}
+	}
+	result := b.parts[0]
+	for _, part := range b.parts[1:] {
+		result = &ast.BinaryExpr{X: result, Op: token.ADD, Y: part}
+	}
+	return result
+}
+
+// literalText returns the text of a string or number literal argument
+func literalText(arg *guixast.Expr) (string, bool) {
+	if arg == nil || len(arg.BinOps) > 0 || arg.Left == nil || arg.Left.Literal == nil {
+		return "", false
+	}
+	lit := arg.Left.Literal
+	if lit.String != nil {
+		text, err := strconv.Unquote(*lit.String)
+		return text, err == nil
+	}
+	if lit.Number != nil {
+		// CSS has no digit separators
+		return strings.ReplaceAll(*lit.Number, "_", ""), true
+	}
+	return "", false
+}
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// lineMarker is the call that stands in for a line directive until the code
+// is printed: go/printer places comments by position, and generated nodes
+// have none
+const lineMarker = "__guixLine"
+
+var (
+	// lineMarkerOnLine matches a marker printed on its own line, which
+	// becomes a //line directive for the statement on the next line
+	lineMarkerOnLine = regexp.MustCompile(`(?m)^[ \t]*` + lineMarker + `\("([^"]*)", (\d+)\)\n`)
+	// lineMarkerInline matches a marker in a block printed on one line, such
+	// as func() { count++ }, which becomes a /*line*/ directive
+	lineMarkerInline = regexp.MustCompile(lineMarker + `\("([^"]*)", (\d+)\); `)
+)
+
+// markLine records the .gx position a generated statement or declaration
+// came from, so a line directive can be written before it
+func (g *Generator) markLine(node ast.Node, pos lexer.Position) {
+	if !g.emitLineDirectives || node == nil || pos.Filename == "" || pos.Line == 0 {
+		return
+	}
+	if g.sourceLines == nil {
+		g.sourceLines = make(map[ast.Node]lexer.Position)
+	}
+	g.sourceLines[node] = pos
+}
+
+// insertLineMarkers adds a //line doc comment to the marked declarations and
+// a marker before every marked statement in their blocks
+func (g *Generator) insertLineMarkers(decls []ast.Decl) {
+	if len(g.sourceLines) == 0 {
+		return
+	}
+	for _, decl := range decls {
+		if pos, ok := g.sourceLines[decl]; ok {
+			directive := &ast.Comment{Text: "//line " + filepath.Base(pos.Filename) + ":" + strconv.Itoa(pos.Line)}
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				d.Doc = appendComment(d.Doc, directive)
+			case *ast.GenDecl:
+				d.Doc = appendComment(d.Doc, directive)
+			}
+		}
+		ast.Inspect(decl, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BlockStmt:
+				n.List = g.withLineMarkers(n.List)
+			case *ast.CaseClause:
+				n.Body = g.withLineMarkers(n.Body)
+			case *ast.CommClause:
+				n.Body = g.withLineMarkers(n.Body)
+			}
+			return true
+		})
+	}
+}
+
+// withLineMarkers returns stmts with a marker before each marked statement
+func (g *Generator) withLineMarkers(stmts []ast.Stmt) []ast.Stmt {
+	var out []ast.Stmt
+	for _, stmt := range stmts {
+		if pos, ok := g.sourceLines[stmt]; ok {
+			out = append(out, &ast.ExprStmt{X: &ast.CallExpr{
+				Fun: ast.NewIdent(lineMarker),
+				Args: []ast.Expr{
+					&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(filepath.Base(pos.Filename))},
+					&ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(pos.Line)},
+				},
+			}})
+		}
+		out = append(out, stmt)
+	}
+	return out
+}
+
+// replaceLineMarkers turns the markers in printed code into line directives
+func replaceLineMarkers(src []byte) []byte {
+	src = lineMarkerOnLine.ReplaceAll(src, []byte("//line $1:$2\n"))
+	return lineMarkerInline.ReplaceAll(src, []byte("/*line $1:$2*/"))
+}
+
+// appendComment adds c to the end of a comment group
+func appendComment(group *ast.CommentGroup, c *ast.Comment) *ast.CommentGroup {
+	if group == nil {
+		return &ast.CommentGroup{List: []*ast.Comment{c}}
+	}
+	group.List = append(group.List, c)
+	return group
+}
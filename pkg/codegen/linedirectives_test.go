@@ -0,0 +1,92 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+func TestGenerateLineDirectives(t *testing.T) {
+	source := `package main
+
+func clamp(value int, max int) (int) {
+	if value > max {
+		return max
+	}
+	return value
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.ParseBytes("components/clamp.gx", []byte(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	gen.SetEmitLineDirectives(true)
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	code := string(generated)
+
+	expected := `//line clamp.gx:3
+func clamp(value int, max int) int {
+//line clamp.gx:4
+	if value > max {
+//line clamp.gx:5
+		return max
+	}
+//line clamp.gx:7
+	return value
+}`
+	if !strings.Contains(code, expected) {
+		t.Errorf("Generated code does not contain line directives:\n%s\nGenerated:\n%s", expected, code)
+	}
+	if strings.Contains(code, lineMarker) {
+		t.Errorf("Generated code contains unreplaced line markers:\n%s", code)
+	}
+
+	// Formatting keeps the directives at the start of the line
+	formatted, err := format.Source(generated)
+	if err != nil {
+		t.Fatalf("Generated code does not parse: %v", err)
+	}
+	if string(formatted) != code {
+		t.Errorf("Generated code is not gofmt-formatted:\n%s", code)
+	}
+}
+
+func TestGenerateWithoutLineDirectives(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.ParseBytes("clamp.gx", []byte("package main\n\nfunc one() (int) {\n\treturn 1\n}"))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if strings.Contains(string(generated), "line clamp.gx") {
+		t.Errorf("Line directives are written by default:\n%s", generated)
+	}
+}
+
+func TestReplaceLineMarkers(t *testing.T) {
+	src := "func f() {\n\t__guixLine(\"a.gx\", 4)\n\tx++\n\tdefer func() { __guixLine(\"a.gx\", 5); y++ }()\n}\n"
+	expected := "func f() {\n//line a.gx:4\n\tx++\n\tdefer func() { /*line a.gx:5*/y++ }()\n}\n"
+	if got := string(replaceLineMarkers([]byte(src))); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,153 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// primitiveTypes are the types a generated ShouldUpdate compares with ==
+var primitiveTypes = map[string]bool{
+	"bool": true, "string": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "byte": true, "rune": true,
+}
+
+// memoPropsType returns the name of the props snapshot struct of an @memo
+// component
+func memoPropsType(comp *guixast.Component) string {
+	return strings.ToLower(comp.Name[:1]) + comp.Name[1:] + "MemoProps"
+}
+
+// memoFields returns the component struct fields the props snapshot of an
+// @memo component copies: parameters, and the last received value of
+// channel parameters. allPrimitive is false when a parameter Render reads
+// can't be compared with ==.
+func memoFields(comp *guixast.Component, structDecl *ast.GenDecl) (fields []*ast.Field, allPrimitive bool) {
+	structFields := make(map[string]ast.Expr)
+	for _, field := range structDecl.Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List {
+		structFields[field.Names[0].Name] = field.Type
+	}
+
+	allPrimitive = true
+	for _, param := range comp.Params {
+		name := capitalize(param.Name)
+		if param.Type != nil && (param.Type.IsChannel || param.Type.IsChan) {
+			// Render reads the received value, not the channel
+			name = "current" + name
+		}
+		typ, ok := structFields[name]
+		if !ok {
+			continue
+		}
+		if ident, isIdent := typ.(*ast.Ident); !isIdent || !primitiveTypes[ident.Name] {
+			allPrimitive = false
+			continue
+		}
+		fields = append(fields, &ast.Field{Names: []*ast.Ident{ast.NewIdent(name)}, Type: typ})
+	}
+	return fields, allPrimitive
+}
+
+// generateMemoDecls generates the runtime.Memoizable implementation of an
+// @memo component, so runtime.RenderComponent skips rendering it again
+// while its props are unchanged:
+//
+//	type counterMemoProps struct {
+//	    Label        string
+//	    currentCount int
+//	}
+//
+//	func (c *Counter) Props() runtime.Props {
+//	    return counterMemoProps{Label: c.Label, currentCount: c.currentCount}
+//	}
+//
+//	func (c *Counter) ShouldUpdate(old, new runtime.Props) bool {
+//	    return old != new
+//	}
+//
+//	func (c *Counter) MemoCache() *runtime.MemoCache {
+//	    return &c.memo
+//	}
+//
+// A parameter that isn't a primitive type makes ShouldUpdate always return
+// true. State declared in the component body, such as child components, is
+// not compared.
+func (g *Generator) generateMemoDecls(comp *guixast.Component, structDecl *ast.GenDecl) []ast.Decl {
+	fields, allPrimitive := memoFields(comp, structDecl)
+	typeName := memoPropsType(comp)
+
+	elts := make([]ast.Expr, len(fields))
+	for i, field := range fields {
+		name := field.Names[0].Name
+		elts[i] = &ast.KeyValueExpr{
+			Key:   ast.NewIdent(name),
+			Value: &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(name)},
+		}
+	}
+
+	var changed ast.Expr = &ast.BinaryExpr{X: ast.NewIdent("old"), Op: token.NEQ, Y: ast.NewIdent("new")}
+	if !allPrimitive {
+		changed = ast.NewIdent("true")
+	}
+
+	recv := &ast.FieldList{List: []*ast.Field{{
+		Names: []*ast.Ident{ast.NewIdent("c")},
+		Type:  &ast.StarExpr{X: ast.NewIdent(comp.Name)},
+	}}}
+	propsType := &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent("Props")}
+
+	return []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{&ast.TypeSpec{
+				Name: ast.NewIdent(typeName),
+				Type: &ast.StructType{Fields: &ast.FieldList{List: fields}},
+			}},
+		},
+		&ast.FuncDecl{
+			Recv: recv,
+			Name: ast.NewIdent("Props"),
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: propsType}}},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{&ast.CompositeLit{Type: ast.NewIdent(typeName), Elts: elts}}},
+			}},
+		},
+		&ast.FuncDecl{
+			Recv: recv,
+			Name: ast.NewIdent("ShouldUpdate"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{List: []*ast.Field{{
+					Names: []*ast.Ident{ast.NewIdent("old"), ast.NewIdent("new")},
+					Type:  propsType,
+				}}},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("bool")}}},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{changed}},
+			}},
+		},
+		&ast.FuncDecl{
+			Recv: recv,
+			Name: ast.NewIdent("MemoCache"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{
+					Type: &ast.StarExpr{X: &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent("MemoCache")}},
+				}}},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{&ast.UnaryExpr{
+					Op: token.AND,
+					X:  &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("memo")},
+				}}},
+			}},
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// requiredParams returns the @required parameters of a @props component.
+// Without @props every parameter is already a constructor argument.
+func requiredParams(comp *guixast.Component) []*guixast.Parameter {
+	if !comp.AutoProps {
+		return nil
+	}
+	var params []*guixast.Parameter
+	for _, param := range propParams(comp) {
+		if param.Required {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// isNillable checks if the zero value of a type is nil, so a missing
+// required prop of that type can be detected when the component is bound.
+// Slices are left out, as an empty list is a valid value.
+func isNillable(t *guixast.Type) bool {
+	if t == nil || t.IsSlice {
+		return false
+	}
+	return t.IsChannel || t.IsChan || t.IsPointer || t.IsFunc || t.IsInterface || t.Map != nil
+}
+
+// generateRequiredConstructor generates the constructor taking the
+// @required parameters positionally, before the remaining options:
+//
+//	func NewButtonRequired(label string, opts ...ButtonOption) *Button {
+//	    return NewButton(append([]ButtonOption{WithLabel(label)}, opts...)...)
+//	}
+func (g *Generator) generateRequiredConstructor(comp *guixast.Component) *ast.FuncDecl {
+	optionType := comp.Name + "Option"
+
+	var fields []*ast.Field
+	var options []ast.Expr
+	for _, param := range requiredParams(comp) {
+		paramType := g.typeToAST(param.Type)
+		arg := ast.Expr(ast.NewIdent(param.Name))
+		var ellipsis token.Pos
+		if param.IsVariadic {
+			// Only the last parameter may be variadic, so the values are
+			// taken as a slice
+			paramType = &ast.ArrayType{Elt: paramType}
+			ellipsis = 1
+		}
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(param.Name)},
+			Type:  paramType,
+		})
+		options = append(options, &ast.CallExpr{
+			Fun:      ast.NewIdent("With" + capitalize(param.Name)),
+			Args:     []ast.Expr{arg},
+			Ellipsis: ellipsis,
+		})
+	}
+	fields = append(fields, &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent("opts")},
+		Type:  &ast.Ellipsis{Elt: ast.NewIdent(optionType)},
+	})
+
+	all := &ast.CallExpr{
+		Fun: ast.NewIdent("append"),
+		Args: []ast.Expr{
+			&ast.CompositeLit{
+				Type: &ast.ArrayType{Elt: ast.NewIdent(optionType)},
+				Elts: options,
+			},
+			ast.NewIdent("opts"),
+		},
+		Ellipsis: 1,
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("New" + comp.Name + "Required"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{List: fields},
+			Results: &ast.FieldList{
+				List: []*ast.Field{{Type: &ast.StarExpr{X: ast.NewIdent(comp.Name)}}},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{&ast.CallExpr{
+						Fun:      ast.NewIdent("New" + comp.Name),
+						Args:     []ast.Expr{all},
+						Ellipsis: 1,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// generateRequiredCheckStmt generates the check of BindApp that a nillable
+// @required parameter was set. A missing prop is reported to the app, which
+// then fails to mount:
+//
+//	if c.OnClick == nil {
+//	    app.MissingProp("Button", "onClick")
+//	}
+func generateRequiredCheckStmt(comp *guixast.Component, param *guixast.Parameter) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(capitalize(param.Name))},
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{X: ast.NewIdent("app"), Sel: ast.NewIdent("MissingProp")},
+					Args: []ast.Expr{
+						&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("%q", comp.Name)},
+						&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("%q", param.Name)},
+					},
+				}},
+			},
+		},
+	}
+}
@@ -384,3 +384,155 @@ func TestScene() (Scene) {
 		t.Error("Scene components should not import syscall/js")
 	}
 }
+
+func TestGenerateSceneChannelBoundRotation(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	source := `package main
+
+func SpinningScene(angle chan float32) (Scene) {
+	Scene {
+		Mesh(
+			Rotation(0, <-angle, 0),
+			Position(0, 0, 0)
+		)
+	}
+}`
+
+	file, err := p.ParseString(source)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	gen := New("main")
+	output, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	outputStr := string(output)
+
+	// The channel is wrapped once per scene
+	if !strings.Contains(outputStr, "latestAngle := runtime.Latest(s.Angle)") {
+		t.Error("Expected latest value tracker for the angle channel")
+	}
+
+	// The rotation is re-evaluated every frame from the latest value
+	if !strings.Contains(outputStr, "runtime.BindProp(func() runtime.GPUProp {") {
+		t.Error("Expected channel-bound rotation to be wrapped in runtime.BindProp")
+	}
+	if !strings.Contains(outputStr, "return runtime.Rotation(0, latestAngle.Value(), 0)") {
+		t.Error("Expected rotation to read the latest channel value")
+	}
+
+	// Props without channel receives stay static
+	if !strings.Contains(outputStr, "runtime.Position(0, 0, 0)") || strings.Contains(outputStr, "<-s.Angle") {
+		t.Errorf("Expected static position and no blocking receive\n%s", outputStr)
+	}
+
+	// Verify code is valid Go
+	_, err = format.Source(output)
+	if err != nil {
+		t.Errorf("Generated code is not valid Go: %v\n%s", err, outputStr)
+	}
+}
+
+func TestGenerateSceneWithParticleSystem(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	source := `package main
+
+func Sparks(update string) (Scene) {
+	Scene {
+		ParticleSystem(Count(1000), Shader(update), ParticleSize(0.08))
+		PerspectiveCamera(Position(0, 2, 6))
+	}
+}`
+
+	file, err := p.ParseString(source)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	output, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	expected := "runtime.ParticleSystem(runtime.Count(1000), runtime.Shader(s.Update), runtime.ParticleSize(0.08))"
+	if !strings.Contains(string(output), expected) {
+		t.Errorf("Expected %q in generated code:\n%s", expected, output)
+	}
+}
+
+func TestGenerateSceneWithInstancedMesh(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	source := `package main
+
+func Cubes(cubes []Transform) (Scene) {
+	Scene {
+		InstancedMesh(Box(0.5, 0.5, 0.5), StandardMaterial(Color(0.3, 0.6, 1, 1)), cubes)
+		PerspectiveCamera(Position(0, 5, 12))
+	}
+}`
+
+	file, err := p.ParseString(source)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	output, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	for _, expected := range []string{
+		"func NewCubes(cubes []runtime.Transform) runtime.Scene",
+		"runtime.InstancedMesh(runtime.BoxGeometryNode(0.5, 0.5, 0.5), runtime.StandardMaterial(runtime.Color(0.3, 0.6, 1, 1)), s.Cubes)",
+	} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("Expected %q in generated code:\n%s", expected, output)
+		}
+	}
+}
+
+func TestGenerateSceneShorthands(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	source := `package main
+
+func RedCube() (Scene) {
+	Scene {
+		Mesh(Geometry(Box(1, 1, 1)), Material(Color(1, 0, 0, 1)))
+		Camera(Position(0, 0, 5))
+	}
+}`
+
+	file, err := p.ParseString(source)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	output, err := New("main").Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	expected := "return runtime.SceneNode(runtime.Mesh(runtime.WithGeometry(runtime.BoxGeometryNode(1, 1, 1)), runtime.MaterialOf(runtime.Color(1, 0, 0, 1))), runtime.PerspectiveCamera(runtime.Position(0, 0, 5)))"
+	if !strings.Contains(string(output), expected) {
+		t.Errorf("Expected %q in generated code:\n%s", expected, output)
+	}
+}
@@ -0,0 +1,167 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// sceneTransformProps are the GPU props that are re-evaluated every frame
+// when their arguments read a channel
+var sceneTransformProps = map[string]bool{
+	"Position":   true,
+	"Rotation":   true,
+	"ScaleValue": true,
+}
+
+// latestValueName returns the local variable holding a channel's latest value
+// in a RenderScene method
+func latestValueName(channel string) string {
+	return "latest" + capitalize(channel)
+}
+
+// generateLatestValues declares a LatestValue for every channel received
+// from in the scene body:
+//
+//	latestAngle := runtime.Latest(s.Angle)
+func (g *Generator) generateLatestValues(body *guixast.Body) []ast.Stmt {
+	if body == nil {
+		return nil
+	}
+
+	g.channelReceiveVars = make(map[string]string)
+	g.scanForChannelReceives(body.Children)
+
+	var channels []string
+	for name, channelName := range g.channelReceiveVars {
+		if g.isChannelParam(strings.TrimPrefix(name, "__inline_")) {
+			channels = append(channels, channelName)
+		}
+	}
+	sort.Strings(channels)
+
+	stmts := make([]ast.Stmt, 0, len(channels))
+	for _, channelName := range channels {
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(latestValueName(channelName))},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("runtime"),
+						Sel: ast.NewIdent("Latest"),
+					},
+					Args: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent(g.receiverName),
+							Sel: ast.NewIdent(channelName),
+						},
+					},
+				},
+			},
+		})
+	}
+	return stmts
+}
+
+// isBoundSceneProp checks if a prop of a Scene component must be
+// re-evaluated every frame, e.g. Rotation(0, <-angle, 0)
+func (g *Generator) isBoundSceneProp(prop *guixast.Prop) bool {
+	if g.receiverName != "s" || !sceneTransformProps[prop.Name] {
+		return false
+	}
+	for _, arg := range prop.Args {
+		if exprReadsChannel(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateBoundSceneProp wraps a transform prop so the renderer evaluates it
+// on every frame:
+//
+//	runtime.BindProp(func() runtime.GPUProp {
+//	    return runtime.Rotation(0, latestAngle.Value(), 0)
+//	})
+func (g *Generator) generateBoundSceneProp(prop *guixast.Prop) ast.Expr {
+	args := make([]ast.Expr, len(prop.Args))
+	for i, arg := range prop.Args {
+		args[i] = g.generateExpr(arg)
+	}
+
+	gpuPropType := &ast.SelectorExpr{
+		X:   ast.NewIdent("runtime"),
+		Sel: ast.NewIdent("GPUProp"),
+	}
+
+	eval := &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: gpuPropType}}},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("runtime"),
+								Sel: ast.NewIdent(prop.Name),
+							},
+							Args: args,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("runtime"),
+			Sel: ast.NewIdent("BindProp"),
+		},
+		Args: []ast.Expr{eval},
+	}
+}
+
+// exprReadsChannel checks if an expression contains a channel receive
+func exprReadsChannel(expr *guixast.Expr) bool {
+	if expr == nil {
+		return false
+	}
+	if primaryReadsChannel(expr.Left) {
+		return true
+	}
+	for _, binOp := range expr.BinOps {
+		if binOp != nil && primaryReadsChannel(binOp.Right) {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryReadsChannel checks if a primary expression contains a channel receive
+func primaryReadsChannel(primary *guixast.Primary) bool {
+	switch {
+	case primary == nil:
+		return false
+	case primary.ChannelOp != nil:
+		return true
+	case primary.Paren != nil:
+		return exprReadsChannel(primary.Paren)
+	case primary.Unary != nil:
+		return primaryReadsChannel(primary.Unary.Right)
+	case primary.CallOrSel != nil:
+		for _, arg := range primary.CallOrSel.Args {
+			if exprReadsChannel(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
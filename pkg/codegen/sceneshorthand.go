@@ -0,0 +1,23 @@
+package codegen
+
+// sceneShorthands maps the short names a scene body can use, such as
+// Mesh(Geometry(Box(1, 1, 1)), Material(Color(1, 0, 0, 1))), to their
+// runtime builders. Geometry and Material are runtime types, so they map to
+// the functions that wrap a value as a prop.
+var sceneShorthands = map[string]string{
+	"Camera":   "PerspectiveCamera",
+	"Geometry": "WithGeometry",
+	"Material": "MaterialOf",
+	"Box":      "BoxGeometryNode",
+	"Sphere":   "SphereGeometryNode",
+	"Plane":    "PlaneGeometryNode",
+}
+
+// sceneShorthand returns the runtime name of a shorthand used in a Scene
+// component, or "" outside scenes and for names the file declares itself
+func (g *Generator) sceneShorthand(name string) string {
+	if g.receiverName != "s" || g.components[name] || g.componentParams[name] || g.hoistedVars[name] {
+		return ""
+	}
+	return sceneShorthands[name]
+}
@@ -0,0 +1,131 @@
+package codegen
+
+import (
+	"encoding/json"
+	"strings"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// ComponentSchema is the JSON description of a component's props, consumed
+// by editors and documentation generators
+type ComponentSchema struct {
+	Component string       `json:"component"`
+	Props     []PropSchema `json:"props"`
+}
+
+// PropSchema describes a single prop of a component
+type PropSchema struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+	Variadic bool   `json:"variadic,omitempty"`
+	Channel  bool   `json:"channel"`
+	Func     bool   `json:"func"`
+}
+
+// zeroValues maps built-in types to the Go literal of their zero value
+var zeroValues = map[string]string{
+	"string":  `""`,
+	"bool":    "false",
+	"int":     "0",
+	"int8":    "0",
+	"int16":   "0",
+	"int32":   "0",
+	"int64":   "0",
+	"uint":    "0",
+	"uint8":   "0",
+	"uint16":  "0",
+	"uint32":  "0",
+	"uint64":  "0",
+	"float32": "0",
+	"float64": "0",
+	"byte":    "0",
+	"rune":    "0",
+}
+
+// PropsSchema generates a JSON schema of a component's props. Props of an
+// @props component are set through With* options and are optional, so their
+// default is the zero value of their type, unless marked @required; props
+// of other components are required constructor arguments.
+func PropsSchema(comp *guixast.Component) []byte {
+	schema := ComponentSchema{
+		Component: comp.Name,
+		Props:     make([]PropSchema, 0, len(comp.Params)),
+	}
+
+	for _, param := range propParams(comp) {
+		prop := PropSchema{
+			Name:     param.Name,
+			Type:     typeString(param.Type),
+			Required: !comp.AutoProps || param.Required,
+			Variadic: param.IsVariadic,
+		}
+		if param.Type != nil {
+			prop.Channel = param.Type.IsChannel || param.Type.IsChan
+			prop.Func = param.Type.IsFunc
+		}
+		if comp.AutoProps {
+			prop.Default = zeroValue(param)
+		}
+		schema.Props = append(schema.Props, prop)
+	}
+
+	// The schema only holds strings and bools, so encoding cannot fail
+	data, _ := json.MarshalIndent(schema, "", "  ")
+	return data
+}
+
+// typeString formats a type as written in Guix source, e.g. <-chan int
+func typeString(t *guixast.Type) string {
+	if t == nil || t.IsInterface {
+		return "interface{}"
+	}
+
+	s := ""
+	if t.IsChannel {
+		s += "<-"
+	}
+	if t.IsChan {
+		s += "chan "
+	}
+	if t.IsSlice {
+		s += "[]"
+	}
+	if t.IsPointer {
+		s += "*"
+	}
+	if t.Struct != nil {
+		fields := make([]string, len(t.Struct.Fields))
+		for i, field := range t.Struct.Fields {
+			fields[i] = strings.Join(field.Names(), ", ") + " " + typeString(field.Type)
+		}
+		s += "struct{" + strings.Join(fields, "; ") + "}"
+	}
+	if t.Map != nil {
+		s += "map[" + typeString(t.Map.Key) + "]" + typeString(t.Map.Value)
+	}
+	s += t.Name
+	if t.Generic != nil {
+		s += "[" + typeString(t.Generic) + "]"
+	}
+	if t.IsFunc {
+		s += "func"
+	}
+	return s
+}
+
+// zeroValue returns the Go literal of a prop's zero value, or "" when the
+// type is a named type whose zero value has no short literal
+func zeroValue(param *guixast.Parameter) string {
+	t := param.Type
+	if param.IsVariadic || t == nil || t.IsInterface || t.IsChannel || t.IsChan ||
+		t.IsSlice || t.IsPointer || t.IsFunc || t.Map != nil {
+		return "nil"
+	}
+	if t.Struct != nil {
+		return typeString(t) + "{}"
+	}
+	return zeroValues[t.Name]
+}
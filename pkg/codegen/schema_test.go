@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+func TestPropsSchema(t *testing.T) {
+	source := `package main
+
+@props
+func TodoItem(title string, done bool, count int, tags []string, updates <-chan string) (Component) {
+	Li {
+		` + "`{title}`" + `
+	}
+}
+
+func Header(title string, events chan Event) (Component) {
+	H1 {
+		` + "`{title}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	var schema ComponentSchema
+	if err := json.Unmarshal(PropsSchema(file.Components[0]), &schema); err != nil {
+		t.Fatalf("Failed to decode schema: %v", err)
+	}
+
+	if schema.Component != "TodoItem" {
+		t.Errorf("Expected component TodoItem, got %q", schema.Component)
+	}
+
+	expected := []PropSchema{
+		{Name: "title", Type: "string", Default: `""`},
+		{Name: "done", Type: "bool", Default: "false"},
+		{Name: "count", Type: "int", Default: "0"},
+		{Name: "tags", Type: "[]string", Default: "nil"},
+		{Name: "updates", Type: "<-chan string", Default: "nil", Channel: true},
+	}
+	if len(schema.Props) != len(expected) {
+		t.Fatalf("Expected %d props, got %d: %+v", len(expected), len(schema.Props), schema.Props)
+	}
+	for i, want := range expected {
+		if schema.Props[i] != want {
+			t.Errorf("Prop %d: expected %+v, got %+v", i, want, schema.Props[i])
+		}
+	}
+
+	// Props of components without @props are required and have no default
+	schema = ComponentSchema{}
+	if err := json.Unmarshal(PropsSchema(file.Components[1]), &schema); err != nil {
+		t.Fatalf("Failed to decode schema: %v", err)
+	}
+	events := schema.Props[1]
+	if !events.Required || !events.Channel || events.Default != "" || events.Type != "chan Event" {
+		t.Errorf("Expected required channel prop chan Event, got %+v", events)
+	}
+}
@@ -0,0 +1,185 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// isSignalType checks if a type is a runtime signal, *Signal[T]
+func isSignalType(t *guixast.Type) bool {
+	return t != nil && t.IsPointer && t.Name == "Signal" && t.Generic != nil &&
+		!t.IsSlice && !t.IsChan && !t.IsChannel
+}
+
+// signalParams returns the parameters of a component taking a *Signal[T]
+func signalParams(comp *guixast.Component) []*guixast.Parameter {
+	var params []*guixast.Parameter
+	for _, param := range comp.Params {
+		if isSignalType(param.Type) {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// signalField returns the struct field holding the latest value of a
+// signal parameter
+func signalField(name string) string {
+	return "current" + capitalize(name)
+}
+
+// unsubscribeField returns the struct field holding the cleanup returned by
+// Subscribe for a signal parameter
+func unsubscribeField(name string) string {
+	return "unsubscribe" + capitalize(name)
+}
+
+// signalGet returns the field read for count.Get() when count is a signal
+// parameter of the current UI component, or nil
+func (g *Generator) signalGet(cos *guixast.CallOrSelect) ast.Expr {
+	if g.currentComp == nil || g.receiverName != "c" || len(cos.Fields) != 1 || cos.Fields[0] != "Get" || !cos.HasParens || len(cos.Args) > 0 {
+		return nil
+	}
+	for _, param := range signalParams(g.currentComp) {
+		if param.Name == cos.Base {
+			return &ast.SelectorExpr{X: ast.NewIdent(g.receiverName), Sel: ast.NewIdent(signalField(param.Name))}
+		}
+	}
+	return nil
+}
+
+// generateSignalFields generates the fields holding the latest value and
+// the subscription of each signal parameter
+func (g *Generator) generateSignalFields(comp *guixast.Component) []*ast.Field {
+	var fields []*ast.Field
+	for _, param := range signalParams(comp) {
+		fields = append(fields,
+			&ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(signalField(param.Name))},
+				Type:  g.typeToAST(param.Type.Generic),
+			},
+			&ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(unsubscribeField(param.Name))},
+				Type:  &ast.FuncType{Params: &ast.FieldList{}},
+			},
+		)
+	}
+	return fields
+}
+
+// generateSignalInitStmt generates the constructor's read of the initial
+// value of a signal parameter:
+//
+//	if c.Count != nil {
+//	    c.currentCount = c.Count.Get()
+//	}
+func generateSignalInitStmt(param *guixast.Parameter) ast.Stmt {
+	signal := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(capitalize(param.Name))}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: signal, Op: token.NEQ, Y: ast.NewIdent("nil")},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(signalField(param.Name))}},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun: &ast.SelectorExpr{X: signal, Sel: ast.NewIdent("Get")},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// generateSubscribeStmt generates the subscription of BindApp to a signal
+// parameter. The subscription is guarded by its cleanup field, so binding
+// again doesn't subscribe twice:
+//
+//	if c.Count != nil && c.unsubscribeCount == nil {
+//	    c.currentCount = c.Count.Get()
+//	    c.unsubscribeCount = c.Count.Subscribe(func(val int) {
+//	        c.currentCount = val
+//	        c.Update()
+//	    })
+//	}
+func (g *Generator) generateSubscribeStmt(param *guixast.Parameter) ast.Stmt {
+	signal := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(capitalize(param.Name))}
+	current := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(signalField(param.Name))}
+	unsubscribe := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(unsubscribeField(param.Name))}
+
+	onSet := &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{{
+					Names: []*ast.Ident{ast.NewIdent("val")},
+					Type:  g.typeToAST(param.Type.Generic),
+				}},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{current},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("val")},
+				},
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent("Update")},
+				}},
+			},
+		},
+	}
+
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  &ast.BinaryExpr{X: signal, Op: token.NEQ, Y: ast.NewIdent("nil")},
+			Op: token.LAND,
+			Y:  &ast.BinaryExpr{X: unsubscribe, Op: token.EQL, Y: ast.NewIdent("nil")},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{current},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun: &ast.SelectorExpr{X: signal, Sel: ast.NewIdent("Get")},
+					}},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{unsubscribe},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: signal, Sel: ast.NewIdent("Subscribe")},
+						Args: []ast.Expr{onSet},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// generateUnsubscribeStmt generates the cleanup of Unmount for a signal
+// parameter:
+//
+//	if c.unsubscribeCount != nil {
+//	    c.unsubscribeCount()
+//	    c.unsubscribeCount = nil
+//	}
+func generateUnsubscribeStmt(param *guixast.Parameter) ast.Stmt {
+	field := &ast.SelectorExpr{X: ast.NewIdent("c"), Sel: ast.NewIdent(unsubscribeField(param.Name))}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: field, Op: token.NEQ, Y: ast.NewIdent("nil")},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{Fun: field}},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{field},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("nil")},
+				},
+			},
+		},
+	}
+}
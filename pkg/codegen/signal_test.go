@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+const cartSource = `package main
+
+@props
+func CartBadge(count *Signal[int]) (Component) {
+	Span(Class("badge")) {
+		` + "`Items: {count.Get()}`" + `
+	}
+}
+
+func Cart(count *Signal[int]) (Component) {
+	Div {
+		CartBadge(WithCount(count))
+		Button(OnClick(func(e Event) {
+			count.Set(count.Get() + 1)
+		})) {
+			"Add"
+		}
+	}
+}`
+
+func TestGenerateSignalParam(t *testing.T) {
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(cartSource))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.Generate(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"func WithCount(v *runtime.Signal[int]) CartBadgeOption {",
+		"currentCount     int\n\tunsubscribeCount func()",
+		// The constructor reads the initial value
+		"if c.Count != nil {\n\t\tc.currentCount = c.Count.Get()\n\t}",
+		// BindApp subscribes once and re-renders on every Set
+		"if c.Count != nil && c.unsubscribeCount == nil {\n\t\tc.currentCount = c.Count.Get()\n\t\t" +
+			"c.unsubscribeCount = c.Count.Subscribe(func(val int) {\n\t\t\tc.currentCount = val\n\t\t\tc.Update()\n\t\t})\n\t}",
+		// Unmount unsubscribes
+		"func (c *CartBadge) Unmount() {\n\tif c.unsubscribeCount != nil {\n\t\tc.unsubscribeCount()\n\t\tc.unsubscribeCount = nil\n\t}\n}",
+		// Reads use the value kept by the subscription
+		`runtime.Text("Items: "+fmt.Sprint(c.currentCount))`,
+		// Other methods are called on the signal
+		"c.Count.Set(c.currentCount + 1)",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Signals are not channels: no listener goroutine is started
+	if strings.Contains(generatedStr, "startCountListener") {
+		t.Errorf("Generated code should not start a channel listener for a signal\nGenerated:\n%s", generatedStr)
+	}
+}
@@ -0,0 +1,908 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"html"
+	"strconv"
+	"strings"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// ssrBuildTags restricts server-side rendering output to non-WASM builds,
+// the counterpart of the js && wasm tags on the regular generated files
+const ssrBuildTags = "//go:build !(js && wasm)\n// +build !js !wasm\n\n"
+
+// htmlBuilder is the strings.Builder RenderHTML writes to. Its name is
+// reserved so it can't be shadowed by a variable of the component.
+const htmlBuilder = "__html"
+
+// emptyFragmentHTML is the markup of the placeholder comment an empty
+// Fragment mounts as
+const emptyFragmentHTML = "<!---->"
+
+// voidElements are HTML elements that have no closing tag
+var voidElements = map[string]bool{
+	"input": true, "img": true, "br": true, "hr": true, "meta": true, "link": true,
+}
+
+// htmlAttributes maps DOM props to the HTML attribute they render as
+var htmlAttributes = map[string]string{
+	"ID": "id", "ClassAttr": "class", "Href": "href", "Src": "src", "Type": "type",
+	"Placeholder": "placeholder", "Value": "value", "Name": "name", "For": "for",
+	"Alt": "alt", "Title": "title", "Style": "style", "TabIndex": "tabindex",
+	"Min": "min", "Max": "max", "Step": "step", "Low": "low", "High": "high", "Optimum": "optimum",
+	"Role": "role", "SrcSet": "srcset", "Sizes": "sizes",
+}
+
+// htmlBooleanAttributes are props rendered as a bare attribute when true
+var htmlBooleanAttributes = map[string]string{
+	"Checked": "checked", "Disabled": "disabled",
+}
+
+// GenerateSSR generates a server-side rendering variant of the file's
+// components. Each component gets the same struct and constructor as the
+// WASM build plus a RenderHTML() string method that writes the markup
+// Render() would produce to a strings.Builder. The output is guarded by a
+// !(js && wasm) build tag so it can live next to the regular generated file.
+func (g *Generator) GenerateSSR(file *guixast.File) ([]byte, error) {
+	for _, comp := range file.Components {
+		if g.isComponentFunc(comp) {
+			g.components[comp.Name] = true
+		}
+	}
+
+	// Verbose logging relies on the WASM-only log helper
+	verbose := g.verbose
+	g.verbose = false
+	defer func() { g.verbose = verbose }()
+
+	candidates := g.generateImports(file).Specs
+	for _, pkg := range []string{"fmt", "html", "strconv", "strings", "time"} {
+		candidates = append(candidates, &ast.ImportSpec{
+			Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(pkg)},
+		})
+	}
+
+	var decls []ast.Decl
+	for _, typeDef := range file.Types {
+		decls = append(decls, g.generateTypeDef(typeDef))
+	}
+	for _, comp := range file.Components {
+		if g.isComponentFunc(comp) {
+			decls = append(decls, g.generateSSRComponent(comp)...)
+		} else if !g.isSceneFunc(comp) {
+			decls = append(decls, g.generateFunction(comp))
+		}
+	}
+	for _, method := range file.Methods {
+		decls = append(decls, g.generateMethod(method))
+	}
+
+	return g.formatDeclsWithBuildTags(ssrBuildTags, file.Package, withImports(candidates, decls))
+}
+
+// generateSSRComponent generates the struct, constructor and RenderHTML
+// method of a UI component. Fields tied to the browser runtime are dropped.
+func (g *Generator) generateSSRComponent(comp *guixast.Component) []ast.Decl {
+	var decls []ast.Decl
+
+	g.currentComp = comp
+	g.receiverName = "c"
+	g.componentParams = make(map[string]bool)
+	for _, param := range comp.Params {
+		g.componentParams[param.Name] = true
+	}
+	g.hoistedComponentMap = make(map[*guixast.Element]*childComponentInfo)
+	g.analyzeComponentBody(comp)
+
+	if comp.AutoProps && len(propParams(comp)) > 0 {
+		decls = append(decls, g.generatePropsStruct(comp))
+		decls = append(decls, g.generateOptionType(comp))
+		decls = append(decls, g.generateOptionFuncs(comp)...)
+	}
+
+	structDecl := g.generateComponentStruct(comp)
+	structType := structDecl.Specs[0].(*ast.TypeSpec).Type.(*ast.StructType)
+	var fields []*ast.Field
+	for _, field := range structType.Fields.List {
+		name := field.Names[0].Name
+		if name == "app" || name == "vnode" || name == "listenersStarted" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	structType.Fields.List = fields
+
+	// Children are passed to the component as markup
+	children := childrenParam(comp)
+	if children != nil {
+		for _, field := range fields {
+			if field.Names[0].Name == capitalize(children.Name) {
+				field.Type = ast.NewIdent("string")
+			}
+		}
+	}
+
+	decls = append(decls, structDecl)
+	decls = append(decls, g.generateConstructor(comp))
+	if len(requiredParams(comp)) > 0 {
+		decls = append(decls, g.generateRequiredConstructor(comp))
+	}
+	if children != nil {
+		decls = append(decls, g.generateSetChildrenMethod(comp, children, true))
+	}
+	decls = append(decls, g.generateRenderHTMLMethod(comp))
+
+	return decls
+}
+
+// generateRenderHTMLMethod generates:
+//
+//	func (c *Component) RenderHTML() string {
+//	    var __html strings.Builder
+//	    __html.WriteString("<div class=\"...\">")
+//	    ...
+//	    return __html.String()
+//	}
+func (g *Generator) generateRenderHTMLMethod(comp *guixast.Component) *ast.FuncDecl {
+	if comp.Body != nil {
+		g.collectChildComponents(comp.Body.Children)
+	}
+
+	stmts := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent(htmlBuilder)},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("strings"),
+							Sel: ast.NewIdent("Builder"),
+						},
+					},
+				},
+			},
+		},
+	}
+	stmts = append(stmts, g.generateHTMLBody(comp.Body)...)
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent(htmlBuilder),
+					Sel: ast.NewIdent("String"),
+				},
+			},
+		},
+	})
+
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("c")},
+					Type:  &ast.StarExpr{X: ast.NewIdent(comp.Name)},
+				},
+			},
+		},
+		Name: ast.NewIdent("RenderHTML"),
+		Type: &ast.FuncType{
+			Results: &ast.FieldList{
+				List: []*ast.Field{{Type: ast.NewIdent("string")}},
+			},
+		},
+		Body: &ast.BlockStmt{List: stmts},
+	}
+}
+
+// htmlWriter collects statements writing to the htmlBuilder,
+// merging consecutive static markup into a single WriteString call
+type htmlWriter struct {
+	stmts   []ast.Stmt
+	pending strings.Builder
+}
+
+// markup appends static, already escaped HTML
+func (w *htmlWriter) markup(s string) {
+	w.pending.WriteString(s)
+}
+
+// escaped appends the HTML-escaped string value of a dynamic expression
+func (w *htmlWriter) escaped(expr ast.Expr) {
+	w.escapedString(&ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fmt"),
+			Sel: ast.NewIdent("Sprint"),
+		},
+		Args: []ast.Expr{expr},
+	})
+}
+
+// escapedString appends a dynamic string expression, HTML-escaped
+func (w *htmlWriter) escapedString(expr ast.Expr) {
+	w.write(&ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("html"),
+			Sel: ast.NewIdent("EscapeString"),
+		},
+		Args: []ast.Expr{expr},
+	})
+}
+
+// write appends a string expression that is written as-is
+func (w *htmlWriter) write(expr ast.Expr) {
+	w.flush()
+	w.stmts = append(w.stmts, &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent(htmlBuilder),
+				Sel: ast.NewIdent("WriteString"),
+			},
+			Args: []ast.Expr{expr},
+		},
+	})
+}
+
+// stmt appends an arbitrary statement
+func (w *htmlWriter) stmt(s ast.Stmt) {
+	w.flush()
+	w.stmts = append(w.stmts, s)
+}
+
+// append appends everything collected by another writer
+func (w *htmlWriter) append(other *htmlWriter) {
+	if len(other.stmts) > 0 {
+		w.flush()
+		w.stmts = append(w.stmts, other.stmts...)
+	}
+	w.markup(other.pending.String())
+}
+
+func (w *htmlWriter) flush() {
+	if w.pending.Len() == 0 {
+		return
+	}
+	w.stmts = append(w.stmts, &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent(htmlBuilder),
+				Sel: ast.NewIdent("WriteString"),
+			},
+			Args: []ast.Expr{
+				&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(w.pending.String())},
+			},
+		},
+	})
+	w.pending.Reset()
+}
+
+// done flushes pending markup and returns the collected statements
+func (w *htmlWriter) done() []ast.Stmt {
+	w.flush()
+	return w.stmts
+}
+
+// generateHTMLBody mirrors generateBody: derived values and statements run
+// as in Render, then each UI child is written in order. An empty body
+// renders the same empty fragment as Render.
+func (g *Generator) generateHTMLBody(body *guixast.Body) []ast.Stmt {
+	w := &htmlWriter{}
+	if body == nil {
+		w.markup(emptyFragmentHTML)
+		return w.done()
+	}
+
+	for _, stmt := range g.generateDerivedValues(body) {
+		w.stmt(stmt)
+	}
+
+	for _, stmt := range body.Statements {
+		// Sends to hoisted channels are initialization done by the constructor
+		if stmt.Assignment != nil && stmt.Assignment.Op == "<-" && g.hoistedVars[stmt.Assignment.Left] {
+			continue
+		}
+		if stmt.AssignStmt != nil && stmt.AssignStmt.Op == "<-" && g.hoistedVars[stmt.AssignStmt.Base] {
+			continue
+		}
+		if genStmt := g.generateBodyStatement(stmt); genStmt != nil {
+			w.stmt(genStmt)
+		}
+	}
+
+	rendered := false
+	for _, child := range body.Children {
+		if child.ExprStmt != nil {
+			w.stmt(&ast.ExprStmt{X: g.generateCallOrSelect(child.ExprStmt.Expr)})
+			continue
+		}
+		g.writeHTMLNode(w, child)
+		rendered = true
+	}
+
+	if !rendered {
+		w.markup(emptyFragmentHTML)
+	}
+
+	return w.done()
+}
+
+// writeHTMLNode writes the markup for a single UI node
+func (g *Generator) writeHTMLNode(w *htmlWriter, node *guixast.Node) {
+	switch {
+	case node.Element != nil:
+		g.writeHTMLElement(w, node.Element)
+
+	case node.Text != nil:
+		if text, err := strconv.Unquote(node.Text.Text); err == nil {
+			w.markup(html.EscapeString(text))
+		} else {
+			w.escaped(&ast.BasicLit{Kind: token.STRING, Value: node.Text.Text})
+		}
+
+	case node.Template != nil:
+		for _, frag := range node.Template.Fragments {
+			if frag.Expr != nil && frag.Format != "" {
+				w.escapedString(g.format(frag.Expr, frag.Format))
+			} else if frag.Expr != nil {
+				w.escaped(g.generateExpr(frag.Expr))
+			} else {
+				w.markup(html.EscapeString(frag.Text))
+			}
+		}
+
+	case node.IfExpr != nil:
+		w.stmt(g.generateHTMLIf(node.IfExpr))
+
+	case node.ForLoop != nil && node.ForLoop.LoopBody() != nil:
+		g.writeHTMLFor(w, node.ForLoop)
+
+	case node.Slot != nil:
+		// The markup of the children passed to the component
+		w.write(g.generatePrimary(&guixast.Primary{Ident: node.Slot.Name}))
+
+	default:
+		// Same placeholder as generateNode
+		w.markup("<div></div>")
+	}
+}
+
+// writeHTMLFor writes each iteration of a range or C-style loop, followed by its
+// EmptyState when the loop wrote no items:
+//
+//	{
+//	    empty := true
+//	    for _, item := range c.Items {
+//	        empty = false
+//	        ...
+//	    }
+//	    if empty {
+//	        ...
+//	    }
+//	}
+func (g *Generator) writeHTMLFor(w *htmlWriter, forLoop *guixast.ForLoop) {
+	body := forLoop.LoopBody()
+	itemNodes, emptyState := splitEmptyState(body.Children)
+
+	item := &htmlWriter{}
+	if emptyState != nil {
+		item.stmt(&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("empty")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{ast.NewIdent("false")},
+		})
+	}
+	for _, stmt := range g.generateConstDecls(body.ConstDecls) {
+		item.stmt(stmt)
+	}
+	for _, stmt := range g.generateLocalDecls(body.VarDecls) {
+		item.stmt(stmt)
+	}
+	for _, stmt := range body.Statements {
+		if genStmt := g.generateBodyStatement(stmt); genStmt != nil {
+			item.stmt(genStmt)
+		}
+	}
+	for _, child := range itemNodes {
+		g.writeHTMLNode(item, child)
+	}
+
+	loop := g.generateLoop(forLoop, &ast.BlockStmt{List: item.done()})
+
+	if emptyState == nil {
+		w.stmt(loop)
+		return
+	}
+
+	empty := &htmlWriter{}
+	for _, child := range emptyState.Children {
+		g.writeHTMLNode(empty, child)
+	}
+	// A block of its own keeps the flags of sibling loops apart
+	w.stmt(&ast.BlockStmt{List: []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("empty")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{ast.NewIdent("true")},
+		},
+		loop,
+		&ast.IfStmt{
+			Cond: ast.NewIdent("empty"),
+			Body: &ast.BlockStmt{List: empty.done()},
+		},
+	}})
+}
+
+// generateHTMLIf writes one link of an if/else if/else chain
+func (g *Generator) generateHTMLIf(ifExpr *guixast.IfExpr) *ast.IfStmt {
+	stmt := &ast.IfStmt{
+		Cond: g.generateExpr(ifExpr.Cond),
+		Body: &ast.BlockStmt{List: g.generateHTMLBranch(ifExpr.TrueBody)},
+	}
+	if ifExpr.ElseIf != nil {
+		stmt.Else = g.generateHTMLIf(ifExpr.ElseIf)
+	} else {
+		stmt.Else = &ast.BlockStmt{List: g.generateHTMLBranch(ifExpr.FalseBody)}
+	}
+	return stmt
+}
+
+// generateHTMLBranch writes the nodes of an if/else branch. An empty branch
+// renders the comment that stands in for an empty Fragment.
+func (g *Generator) generateHTMLBranch(body *guixast.Body) []ast.Stmt {
+	w := &htmlWriter{}
+	if body == nil || len(body.Children) == 0 {
+		w.markup(emptyFragmentHTML)
+		return w.done()
+	}
+	for _, child := range body.Children {
+		g.writeHTMLNode(w, child)
+	}
+	return w.done()
+}
+
+// writeHTMLElement writes a DOM element with its attributes and children,
+// or the RenderHTML output of a child component
+func (g *Generator) writeHTMLElement(w *htmlWriter, elem *guixast.Element) {
+	isComponent := g.components[elem.Tag] || (len(elem.Tag) > 0 && elem.Tag[0] >= 'A' && elem.Tag[0] <= 'Z' && !knownDOMElements[elem.Tag] && !knownGPUElements[elem.Tag])
+
+	if isComponent {
+		var instance ast.Expr
+		if hoistedInfo, isHoisted := g.hoistedComponentMap[elem]; isHoisted {
+			instance = &ast.SelectorExpr{
+				X:   ast.NewIdent("c"),
+				Sel: ast.NewIdent(hoistedInfo.varName),
+			}
+		} else {
+			var args []ast.Expr
+			for _, prop := range elem.Props {
+				if prop.IsBare() {
+					args = append(args, g.generatePrimary(&guixast.Primary{Ident: prop.Name}))
+					continue
+				}
+				propArgs := make([]ast.Expr, len(prop.Args))
+				for i, arg := range prop.Args {
+					propArgs[i] = g.generateExpr(arg)
+				}
+				args = append(args, &ast.CallExpr{Fun: ast.NewIdent(prop.Name), Args: propArgs})
+			}
+			instance = &ast.CallExpr{Fun: ast.NewIdent("New" + elem.Tag), Args: args}
+		}
+		if len(elem.Children) > 0 {
+			g.writeHTMLComponentWithChildren(w, elem, instance)
+			return
+		}
+		w.write(&ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: instance, Sel: ast.NewIdent("RenderHTML")},
+		})
+		return
+	}
+
+	// GPU scene graph and chart nodes have no markup of their own
+	if knownGPUElements[elem.Tag] {
+		return
+	}
+
+	// A Fragment has no element of its own, only its children
+	if elem.Tag == "Fragment" {
+		if len(elem.Children) == 0 {
+			w.markup(emptyFragmentHTML)
+		}
+		for _, child := range elem.Children {
+			g.writeHTMLNode(w, child)
+		}
+		return
+	}
+
+	if elem.Tag == "ErrorText" {
+		errExpr, _ := g.errorTextSource(elem)
+		inner := &htmlWriter{}
+		inner.markup(`<div role="alert" class="error-text">`)
+		inner.escaped(&ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: errExpr, Sel: ast.NewIdent("Error")},
+		})
+		inner.markup("</div>")
+		empty := &htmlWriter{}
+		empty.markup(emptyFragmentHTML)
+		w.stmt(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: errExpr, Op: token.NEQ, Y: ast.NewIdent("nil")},
+			Body: &ast.BlockStmt{List: inner.done()},
+			Else: &ast.BlockStmt{List: empty.done()},
+		})
+		return
+	}
+
+	if elem.Tag == "ListBox" {
+		g.writeHTMLListBox(w, elem)
+		return
+	}
+
+	if elem.Tag == "Accordion" {
+		g.writeHTMLAccordion(w, elem)
+		return
+	}
+
+	if elem.Tag == "Image" {
+		g.writeHTMLImage(w, elem)
+		return
+	}
+
+	if elem.Tag == "Tooltip" {
+		g.writeHTMLTooltip(w, elem)
+		return
+	}
+
+	if elem.Tag == "Sortable" {
+		g.writeHTMLSortable(w, elem)
+		return
+	}
+
+	if elem.Tag == "CopyButton" {
+		g.writeHTMLCopyButton(w, elem)
+		return
+	}
+
+	if elem.Tag == "Portal" {
+		// The children of a portal belong to another element of the page,
+		// so only the placeholder the client mounts is written
+		w.markup("<!--portal-->")
+		return
+	}
+
+	if isLayoutElement(elem.Tag) {
+		// Layout components render as a styled div, as in Render
+		w.markup("<div")
+		g.writeAttribute(w, "style", nil, g.layoutStyle(elem))
+		elem = &guixast.Element{Tag: "Div", Props: nonLayoutProps(elem), Children: elem.Children}
+	} else {
+		w.markup("<" + strings.ToLower(elem.Tag))
+	}
+
+	tag := strings.ToLower(elem.Tag)
+	g.writeHTMLAttributes(w, elem)
+	w.markup(">")
+
+	if voidElements[tag] {
+		return
+	}
+
+	for _, child := range elem.Children {
+		g.writeHTMLNode(w, child)
+	}
+	w.markup("</" + tag + ">")
+}
+
+// writeHTMLListBox writes the markup of a ListBox's first render: the first
+// option is in the tab order and none is selected
+func (g *Generator) writeHTMLListBox(w *htmlWriter, elem *guixast.Element) {
+	w.markup(`<ul role="listbox">`)
+	for _, prop := range elem.Props {
+		if prop.Name != "Items" || len(prop.Args) != 1 {
+			continue
+		}
+
+		first := &htmlWriter{}
+		first.markup(`<li role="option" tabindex="0" aria-selected="false">`)
+		rest := &htmlWriter{}
+		rest.markup(`<li role="option" tabindex="-1" aria-selected="false">`)
+
+		option := &htmlWriter{}
+		option.stmt(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ast.NewIdent("i"), Op: token.EQL, Y: &ast.BasicLit{Kind: token.INT, Value: "0"}},
+			Body: &ast.BlockStmt{List: first.done()},
+			Else: &ast.BlockStmt{List: rest.done()},
+		})
+		option.escaped(ast.NewIdent("item"))
+		option.markup("</li>")
+
+		w.stmt(&ast.RangeStmt{
+			Key:   ast.NewIdent("i"),
+			Value: ast.NewIdent("item"),
+			Tok:   token.DEFINE,
+			X:     g.generateExpr(prop.Args[0]),
+			Body:  &ast.BlockStmt{List: option.done()},
+		})
+	}
+	w.markup("</ul>")
+}
+
+// writeHTMLAccordion writes the markup of an Accordion's first render: every
+// panel is closed, and other children come before the panels as in the runtime
+func (g *Generator) writeHTMLAccordion(w *htmlWriter, elem *guixast.Element) {
+	g.accordionCount++
+	prefix := "accordion-ssr-" + strconv.Itoa(g.accordionCount)
+
+	w.markup(`<div class="accordion">`)
+	var panels []*guixast.Element
+	for _, child := range elem.Children {
+		if child.Element != nil && child.Element.Tag == "Panel" {
+			panels = append(panels, child.Element)
+			continue
+		}
+		g.writeHTMLNode(w, child)
+	}
+
+	for i, panel := range panels {
+		headerID := prefix + "-header-" + strconv.Itoa(i)
+		panelID := prefix + "-panel-" + strconv.Itoa(i)
+
+		w.markup(`<h3><button id="` + headerID + `" type="button" aria-expanded="false" aria-controls="` + panelID + `">`)
+		var props []*guixast.Prop
+		for _, prop := range panel.Props {
+			if prop.Name == "Title" && len(prop.Args) == 1 {
+				g.writeAttributeValue(w, prop.Args[0], nil)
+				continue
+			}
+			props = append(props, prop)
+		}
+		w.markup("</button></h3>")
+
+		w.markup(`<div id="` + panelID + `" role="region" aria-labelledby="` + headerID + `" hidden`)
+		g.writeHTMLAttributes(w, &guixast.Element{Tag: "Div", Props: props})
+		w.markup(">")
+		for _, child := range panel.Children {
+			g.writeHTMLNode(w, child)
+		}
+		w.markup("</div>")
+	}
+	w.markup("</div>")
+}
+
+// writeHTMLTooltip writes the anchor of a Tooltip. The tooltip itself is
+// only shown on hover or focus, once the page is hydrated.
+func (g *Generator) writeHTMLTooltip(w *htmlWriter, elem *guixast.Element) {
+	w.markup(`<span class="tooltip-anchor">`)
+	for _, child := range elem.Children {
+		g.writeHTMLNode(w, child)
+	}
+	w.markup("</span>")
+}
+
+// writeHTMLSortable writes the items of a Sortable in their current order;
+// dragging works once the page is hydrated
+func (g *Generator) writeHTMLSortable(w *htmlWriter, elem *guixast.Element) {
+	w.markup(`<ul class="sortable">`)
+	for _, prop := range elem.Props {
+		if prop.Name != "SortableItems" || len(prop.Args) != 2 {
+			continue
+		}
+
+		item := &htmlWriter{}
+		item.markup(`<li tabindex="0" aria-roledescription="sortable item">`)
+		item.escaped(ast.NewIdent("item"))
+		item.markup("</li>")
+
+		w.stmt(&ast.RangeStmt{
+			Key:   ast.NewIdent("_"),
+			Value: ast.NewIdent("item"),
+			Tok:   token.DEFINE,
+			X:     g.generateExpr(prop.Args[0]),
+			Body:  &ast.BlockStmt{List: item.done()},
+		})
+	}
+	w.markup("</ul>")
+}
+
+// writeHTMLCopyButton writes a CopyButton with the text to copy in its
+// data-copy attribute; copying works once the page is hydrated
+func (g *Generator) writeHTMLCopyButton(w *htmlWriter, elem *guixast.Element) {
+	w.markup(`<button type="button" class="copy-button"`)
+	for _, prop := range elem.Props {
+		if prop.Name == "Text" && len(prop.Args) == 1 {
+			g.writeAttribute(w, "data-copy", prop.Args[0], nil)
+			break
+		}
+	}
+	w.markup(">")
+	if len(elem.Children) == 0 {
+		w.markup("Copy")
+	}
+	for _, child := range elem.Children {
+		g.writeHTMLNode(w, child)
+	}
+	w.markup("</button>")
+}
+
+// writeHTMLImage writes an Image as an img. The placeholder background is
+// part of the markup; the load handler that swaps it out is attached when
+// the page is hydrated.
+func (g *Generator) writeHTMLImage(w *htmlWriter, elem *guixast.Element) {
+	parts := splitImageProps(elem)
+	w.markup("<img")
+	if parts.placeholder != nil {
+		g.writeAttribute(w, "style", nil, g.imageStyle(parts))
+	}
+	g.writeHTMLAttributes(w, &guixast.Element{Tag: "Img", Props: parts.props})
+	w.markup(">")
+}
+
+// writeHTMLAttributes writes the attributes set by an element's props.
+// Event handlers and other runtime-only props are skipped.
+func (g *Generator) writeHTMLAttributes(w *htmlWriter, elem *guixast.Element) {
+	var classes []classEntry
+	for _, prop := range elem.Props {
+		switch {
+		case prop.IsBare():
+			continue
+
+		case prop.Name == "Class" && len(prop.Args) > 0:
+			classes = append(classes, classEntry{name: prop.Args[0], cond: propArg(prop, 1)})
+
+		case prop.Name == "Classes":
+			classes = append(classes, classesEntries(prop)...)
+
+		case prop.IsBind():
+			target := g.generateExpr(prop.BindTarget())
+			if isCheckboxElement(elem) {
+				g.writeBooleanAttribute(w, "checked", target)
+			} else {
+				g.writeAttribute(w, "value", nil, target)
+			}
+
+		case prop.Name == "Lazy" && len(prop.Args) == 1:
+			g.writeLoadingAttribute(w, prop.Args[0])
+
+		case htmlBooleanAttributes[prop.Name] != "" && len(prop.Args) == 1:
+			g.writeBooleanAttribute(w, htmlBooleanAttributes[prop.Name], g.generateExpr(prop.Args[0]))
+
+		case prop.Name == "Style" && len(prop.Args) == 1 && cssLit(prop.Args[0]) != nil:
+			// The runtime isn't available on the server, so the
+			// declarations are joined here
+			style := &styleBuilder{g: g}
+			style.css(cssLit(prop.Args[0]))
+			g.writeAttribute(w, "style", nil, style.expr())
+
+		case htmlAttributes[prop.Name] != "" && len(prop.Args) == 1:
+			g.writeAttribute(w, htmlAttributes[prop.Name], prop.Args[0], nil)
+		}
+	}
+
+	if len(classes) == 0 {
+		return
+	}
+
+	// Class("a") entries are joined with spaces, Class("b", cond) only when cond holds
+	w.markup(` class="`)
+	for i, class := range classes {
+		entry := &htmlWriter{}
+		if i > 0 {
+			entry.markup(" ")
+		}
+		g.writeAttributeValue(entry, class.name, nil)
+		if class.cond != nil {
+			w.stmt(&ast.IfStmt{
+				Cond: g.generateExpr(class.cond),
+				Body: &ast.BlockStmt{List: entry.done()},
+			})
+		} else {
+			w.append(entry)
+		}
+	}
+	w.markup(`"`)
+}
+
+// classEntry is a class name of an element, written only when cond holds
+// if it is set
+type classEntry struct {
+	name *guixast.Expr
+	cond *guixast.Expr
+}
+
+// classesEntries returns the class names of a Classes prop: its string
+// arguments, and the name and condition of its ClassIf calls
+func classesEntries(prop *guixast.Prop) []classEntry {
+	var entries []classEntry
+	for _, arg := range prop.Args {
+		call := arg.Left.CallOrSel
+		if len(arg.BinOps) == 0 && arg.Cond == nil && call != nil &&
+			call.Base == "ClassIf" && len(call.Fields) == 0 && len(call.Args) == 2 {
+			entries = append(entries, classEntry{name: call.Args[0], cond: call.Args[1]})
+			continue
+		}
+		entries = append(entries, classEntry{name: arg})
+	}
+	return entries
+}
+
+// propArg returns the i-th argument of a prop, or nil
+func propArg(prop *guixast.Prop, i int) *guixast.Expr {
+	if i < len(prop.Args) {
+		return prop.Args[i]
+	}
+	return nil
+}
+
+// writeAttribute writes name="value" from either a source argument or a
+// generated expression
+func (g *Generator) writeAttribute(w *htmlWriter, name string, arg *guixast.Expr, value ast.Expr) {
+	w.markup(" " + name + `="`)
+	g.writeAttributeValue(w, arg, value)
+	w.markup(`"`)
+}
+
+// writeAttributeValue writes an escaped attribute value. Literal arguments
+// are escaped at generation time, anything else when rendering.
+func (g *Generator) writeAttributeValue(w *htmlWriter, arg *guixast.Expr, value ast.Expr) {
+	if text, ok := literalText(arg); ok {
+		w.markup(html.EscapeString(text))
+		return
+	}
+	if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if text, err := strconv.Unquote(lit.Value); err == nil {
+			w.markup(html.EscapeString(text))
+			return
+		}
+	}
+	if value == nil {
+		value = g.generateExpr(arg)
+	}
+	w.escaped(value)
+}
+
+// writeLoadingAttribute writes loading="lazy" when lazy is true and
+// loading="eager" otherwise
+func (g *Generator) writeLoadingAttribute(w *htmlWriter, lazy *guixast.Expr) {
+	lazyAttr := &htmlWriter{}
+	lazyAttr.markup(` loading="lazy"`)
+	eagerAttr := &htmlWriter{}
+	eagerAttr.markup(` loading="eager"`)
+
+	if len(lazy.BinOps) == 0 && lazy.Left != nil && lazy.Left.Literal != nil && lazy.Left.Literal.Bool != nil {
+		if *lazy.Left.Literal.Bool == "true" {
+			w.append(lazyAttr)
+		} else {
+			w.append(eagerAttr)
+		}
+		return
+	}
+
+	w.stmt(&ast.IfStmt{
+		Cond: g.generateExpr(lazy),
+		Body: &ast.BlockStmt{List: lazyAttr.done()},
+		Else: &ast.BlockStmt{List: eagerAttr.done()},
+	})
+}
+
+// writeBooleanAttribute writes a bare attribute when cond is true
+func (g *Generator) writeBooleanAttribute(w *htmlWriter, name string, cond ast.Expr) {
+	w.stmt(&ast.IfStmt{
+		Cond: cond,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent(htmlBuilder),
+							Sel: ast.NewIdent("WriteString"),
+						},
+						Args: []ast.Expr{
+							&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(" " + name)},
+						},
+					},
+				},
+			},
+		},
+	})
+}
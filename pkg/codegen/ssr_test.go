@@ -0,0 +1,203 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+func TestGenerateSSR(t *testing.T) {
+	source := `package main
+
+@props func Counter(counterChannel chan int, label string, active bool) (Component) {
+	Div(Class("counter"), Class("active", active), Title("a<b")) {
+		Span {
+			` + "`{label}: {<-counterChannel}`" + `
+		}
+		Input(Type("checkbox"), Disabled(active), OnClick(func(e Event) {}))
+		"Tom & Jerry"
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	expectedStrings := []string{
+		"//go:build !(js && wasm)",
+		"func (c *Counter) RenderHTML() string {",
+		"var __html strings.Builder",
+		// Static attribute values are escaped at generation time
+		`__html.WriteString("<div title=\"a&lt;b\" class=\"counter")`,
+		// Conditional classes and boolean attributes
+		"if c.Active {\n\t\t__html.WriteString(\" active\")",
+		"__html.WriteString(\" disabled\")",
+		// Interpolations render the current value, escaped at render time
+		"html.EscapeString(fmt.Sprint(c.Label))",
+		"html.EscapeString(fmt.Sprint(c.currentCounterChannel))",
+		"Tom &amp; Jerry</div>",
+		"return __html.String()",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+
+	// Nothing tied to the browser runtime
+	for _, unexpected := range []string{"runtime.", "syscall/js", "OnClick", "app *"} {
+		if strings.Contains(generatedStr, unexpected) {
+			t.Errorf("Generated SSR code should not contain %q\nGenerated:\n%s", unexpected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateBenchmarks(t *testing.T) {
+	source := `package main
+
+@props func Counter(counterChannel chan int, label string) (Component) {
+	Div {
+		` + "`{label}: {<-counterChannel}`" + `
+	}
+}
+
+func Badge(label string, count int, todo Todo) (Component) {
+	Span {
+		` + "`{label}`" + `
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	generated, err := New("main").GenerateBenchmarks(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	// The benchmarks call RenderHTML, so they must share its build tag
+	if !strings.HasPrefix(generatedStr, ssrBuildTags) {
+		t.Errorf("Expected benchmarks to start with the SSR build tags\nGenerated:\n%s", generatedStr)
+	}
+
+	expectedStrings := []string{
+		`"testing"`,
+		"func BenchmarkCounter(b *testing.B) {\n\tc := NewCounter()",
+		"func BenchmarkBadge(b *testing.B) {\n\tc := NewBadge(\"\", 0, *new(Todo))",
+		"for i := 0; i < b.N; i++ {\n\t\t_ = c.RenderHTML()",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(generatedStr, expected) {
+			t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+		}
+	}
+}
+
+func TestGenerateSSRUserVariableNamedB(t *testing.T) {
+	source := `package main
+
+func List(items []string) (Component) {
+	Ul {
+		for _, b := range items {
+			Li { ` + "`{b}`" + ` }
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	// The builder keeps its own name, so the loop variable doesn't shadow it
+	expected := "for _, b := range c.Items {\n\t\t__html.WriteString(\"<li>\")\n\t\t__html.WriteString(html.EscapeString(fmt.Sprint(b)))"
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+	if strings.Contains(generatedStr, "b.WriteString") {
+		t.Errorf("Generated code should not write to b\nGenerated:\n%s", generatedStr)
+	}
+}
+
+func TestGenerateSSRTwoEmptyStateLoops(t *testing.T) {
+	source := `package main
+
+func Board(todos []string, done []string) (Component) {
+	Div {
+		for _, todo := range todos {
+			P { ` + "`{todo}`" + ` }
+			EmptyState { "Nothing to do" }
+		}
+		for _, item := range done {
+			P { ` + "`{item}`" + ` }
+			EmptyState { "Nothing done" }
+		}
+	}
+}`
+
+	p, err := parser.New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	gen := New("main")
+	generated, err := gen.GenerateSSR(file)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	generatedStr := string(generated)
+
+	// Each loop declares its flag in a block of its own
+	expected := "\t{\n\t\tempty := true\n\t\tfor _, item := range c.Done {"
+	if !strings.Contains(generatedStr, expected) {
+		t.Errorf("Generated code does not contain expected string: %q\nGenerated:\n%s", expected, generatedStr)
+	}
+	if count := strings.Count(generatedStr, "empty := true"); count != 2 {
+		t.Errorf("Expected 2 empty flags, got %d\nGenerated:\n%s", count, generatedStr)
+	}
+}
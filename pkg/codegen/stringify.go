@@ -0,0 +1,218 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	guixast "github.com/gaarutyunov/guix/pkg/ast"
+)
+
+// stringify converts an interpolated expression to a string. By default it
+// uses fmt.Sprint; in size-optimized mode, expressions with a known static
+// type use strconv instead so simple apps don't link fmt and reflect:
+//
+//	{count}  ->  strconv.Itoa(c.Count)
+func (g *Generator) stringify(expr *guixast.Expr) ast.Expr {
+	value := g.generateExpr(expr)
+	if g.optimizeSize {
+		if converted := stringifyAs(g.staticTypeOf(expr), value); converted != nil {
+			return converted
+		}
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fmt"),
+			Sel: ast.NewIdent("Sprint"),
+		},
+		Args: []ast.Expr{value},
+	}
+}
+
+// timeLayouts maps the time format specs of templates to their layout in
+// package time
+var timeLayouts = map[guixast.FormatSpec]string{
+	"time": "DateTime",
+	"date": "DateOnly",
+}
+
+// format converts an interpolated expression with a format spec to a
+// string. time and date format a Unix timestamp in seconds; any other spec
+// is a fmt verb:
+//
+//	{price:.2f}  ->  fmt.Sprintf("%.2f", c.Price)
+//	{ts:date}    ->  time.Unix(int64(c.Ts), 0).Format(time.DateOnly)
+func (g *Generator) format(expr *guixast.Expr, spec guixast.FormatSpec) ast.Expr {
+	value := g.generateExpr(expr)
+
+	if layout, ok := timeLayouts[spec]; ok {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{X: ast.NewIdent("time"), Sel: ast.NewIdent("Unix")},
+					Args: []ast.Expr{
+						&ast.CallExpr{Fun: ast.NewIdent("int64"), Args: []ast.Expr{value}},
+						&ast.BasicLit{Kind: token.INT, Value: "0"},
+					},
+				},
+				Sel: ast.NewIdent("Format"),
+			},
+			Args: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("time"), Sel: ast.NewIdent(layout)}},
+		}
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{X: ast.NewIdent("fmt"), Sel: ast.NewIdent("Sprintf")},
+		Args: []ast.Expr{
+			&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote("%" + string(spec))},
+			value,
+		},
+	}
+}
+
+// stringifyAs returns a type-specific conversion of value to a string, or
+// nil if typeName has none
+func stringifyAs(typeName string, value ast.Expr) ast.Expr {
+	strconvCall := func(fn string, args ...ast.Expr) ast.Expr {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("strconv"),
+				Sel: ast.NewIdent(fn),
+			},
+			Args: args,
+		}
+	}
+	conv := func(typ string, x ast.Expr) ast.Expr {
+		return &ast.CallExpr{Fun: ast.NewIdent(typ), Args: []ast.Expr{x}}
+	}
+	intLit := func(v string) ast.Expr {
+		return &ast.BasicLit{Kind: token.INT, Value: v}
+	}
+	formatFloat := func(x ast.Expr, bitSize string) ast.Expr {
+		return strconvCall("FormatFloat", x, &ast.BasicLit{Kind: token.CHAR, Value: "'g'"}, intLit("-1"), intLit(bitSize))
+	}
+
+	switch typeName {
+	case "string":
+		return value
+	case "int":
+		return strconvCall("Itoa", value)
+	case "int8", "int16", "int32":
+		return strconvCall("Itoa", conv("int", value))
+	case "int64":
+		return strconvCall("FormatInt", value, intLit("10"))
+	case "uint", "uint8", "uint16", "uint32":
+		return strconvCall("FormatUint", conv("uint64", value), intLit("10"))
+	case "uint64":
+		return strconvCall("FormatUint", value, intLit("10"))
+	case "float64":
+		return formatFloat(value, "64")
+	case "float32":
+		return formatFloat(conv("float64", value), "32")
+	case "bool":
+		return strconvCall("FormatBool", value)
+	}
+	return nil
+}
+
+// staticTypeOf returns the name of an expression's basic type when it can be
+// determined without type checking: literals, component parameters, hoisted
+// state, channel receives and arithmetic or comparisons on those.
+// It returns "" otherwise.
+func (g *Generator) staticTypeOf(expr *guixast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+
+	if expr.Cond != nil {
+		return g.condStaticType(expr.Cond)
+	}
+
+	typeName := g.primaryTypeOf(expr.Left)
+	for _, binOp := range expr.BinOps {
+		switch binOp.Op {
+		case "==", "!=", "<=", ">=", "<", ">", "&&", "||":
+			return "bool"
+		}
+		if g.primaryTypeOf(binOp.Right) != typeName {
+			return ""
+		}
+	}
+	return typeName
+}
+
+// primaryTypeOf returns the basic type name of a primary expression, or ""
+func (g *Generator) primaryTypeOf(primary *guixast.Primary) string {
+	if primary == nil {
+		return ""
+	}
+
+	if primary.Paren != nil {
+		return g.staticTypeOf(primary.Paren)
+	}
+
+	if primary.Literal != nil {
+		if ident, ok := g.inferTypeFromExpr(&guixast.Expr{Left: primary}).(*ast.Ident); ok {
+			return ident.Name
+		}
+		return ""
+	}
+
+	if primary.ChannelOp != nil {
+		if typ := g.channelElemType(primary.ChannelOp.Channel); isBasicType(typ) {
+			return typ.Name
+		}
+		return ""
+	}
+
+	// Plain identifiers parse as a selector without fields
+	name := primary.Ident
+	if cos := primary.CallOrSel; cos != nil && len(cos.Fields) == 0 && !cos.HasParens {
+		name = cos.Base
+	}
+	if name == "" || g.currentComp == nil {
+		return ""
+	}
+
+	for _, param := range g.currentComp.Params {
+		if param.Name == name {
+			if param.IsVariadic || !isBasicType(param.Type) {
+				return ""
+			}
+			return param.Type.Name
+		}
+	}
+
+	if g.currentCompBody != nil {
+		for _, varDecl := range g.currentCompBody.VarDecls {
+			if len(varDecl.Names) == 1 && len(varDecl.Values) == 1 && varDecl.Names[0] == name {
+				return g.staticTypeOf(varDecl.Values[0])
+			}
+		}
+	}
+	return ""
+}
+
+// channelElemType returns the element type of a channel parameter, or nil
+// if it is not a channel parameter or its element type is not a plain name
+func (g *Generator) channelElemType(name string) *guixast.Type {
+	for _, param := range g.currentComp.Params {
+		if param.Name == name && param.Type != nil && (param.Type.IsChannel || param.Type.IsChan) {
+			if param.Type.Generic != nil {
+				return param.Type.Generic
+			}
+			if param.Type.IsSlice || param.Type.IsPointer || param.Type.Map != nil {
+				return nil
+			}
+			return &guixast.Type{Name: param.Type.Name}
+		}
+	}
+	return nil
+}
+
+// isBasicType checks if a type is a plain named type with no modifiers
+func isBasicType(t *guixast.Type) bool {
+	return t != nil && !t.IsPointer && !t.IsSlice && !t.IsChannel && !t.IsChan &&
+		!t.IsInterface && !t.IsFunc && t.Map == nil && t.Generic == nil
+}
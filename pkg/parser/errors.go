@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+)
+
+// maxExpectedHint is the length of the grammar an expected-token hint shows
+// as is; longer expectations are reduced to the tokens they quote
+const maxExpectedHint = 40
+
+var (
+	// expectedClause matches the expectation participle appends to messages
+	expectedClause = regexp.MustCompile(` \(expected (.*)\)$`)
+	// quotedToken matches a literal token in an expectation, e.g. "}"
+	quotedToken = regexp.MustCompile(`"(?:\\.|[^"\\])*"`)
+)
+
+// ParseError is a syntax error in Guix source. Error formats it like a C
+// compiler does, with the line of source and a caret under the column:
+//
+//	counter.gx:5:9: error: unexpected token "=" (expected ")")
+//		Button(OnClick = increment)
+//		               ^
+type ParseError struct {
+	Filename string // Empty when the source was not read from a file
+	Line     int
+	Column   int
+	Message  string // What went wrong, e.g. unexpected token "="
+	Expected string // A hint at what was expected instead, if known
+	Source   string // The line of source the error is on
+}
+
+// Error formats the error as file:line:column, the message and the snippet
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	if e.Filename != "" {
+		b.WriteString(e.Filename + ":")
+	}
+	fmt.Fprintf(&b, "%d:%d: error: %s", e.Line, e.Column, e.Message)
+	if e.Expected != "" {
+		fmt.Fprintf(&b, " (expected %s)", e.Expected)
+	}
+	if snippet := e.Snippet(); snippet != "" {
+		b.WriteString("\n" + snippet)
+	}
+	return b.String()
+}
+
+// Snippet returns the line of source the error is on with a caret under
+// the column, or "" when the line is unknown
+func (e *ParseError) Snippet() string {
+	if e.Source == "" || e.Column < 1 {
+		return e.Source
+	}
+	// Tabs are kept so the caret lines up however they are displayed
+	var caret strings.Builder
+	for i, r := range []rune(e.Source) {
+		if i >= e.Column-1 {
+			break
+		}
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+	return e.Source + "\n" + caret.String()
+}
+
+// newParseError converts a participle error into a ParseError for source,
+// returning other errors unchanged
+func newParseError(err error, source []byte) error {
+	var perr participle.Error
+	if !errors.As(err, &perr) {
+		return err
+	}
+
+	pos := perr.Position()
+	parseErr := &ParseError{
+		Filename: pos.Filename,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Message:  perr.Message(),
+		Source:   sourceLine(source, pos.Line),
+	}
+	if m := expectedClause.FindStringSubmatchIndex(parseErr.Message); m != nil {
+		parseErr.Expected = expectedHint(parseErr.Message[m[2]:m[3]])
+		parseErr.Message = parseErr.Message[:m[0]]
+	}
+	return parseErr
+}
+
+// expectedHint shortens the grammar participle expected to the tokens it
+// quotes, e.g. ("}" | Node) becomes "}"
+func expectedHint(expected string) string {
+	if len(expected) <= maxExpectedHint {
+		return expected
+	}
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, token := range quotedToken.FindAllString(expected, -1) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) > 4 {
+		tokens = append(tokens[:4], "...")
+	}
+	return strings.Join(tokens, ", ")
+}
+
+// sourceLine returns line n of source, counting from 1, without its line
+// ending
+func sourceLine(source []byte, n int) string {
+	if n < 1 {
+		return ""
+	}
+	lines := strings.Split(string(source), "\n")
+	if n > len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[n-1], "\r")
+}
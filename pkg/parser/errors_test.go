@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		line     int
+		column   int
+		message  string
+		expected string
+		snippet  string
+	}{
+		{
+			name:     "unexpected token",
+			source:   "package main\n\nfunc Counter() (Component) {\n\tDiv {\n\t\tButton(OnClick = increment)\n\t}\n}\n",
+			line:     5,
+			column:   18,
+			message:  `unexpected token "="`,
+			expected: `")"`,
+			snippet:  "\t\tButton(OnClick = increment)\n\t\t               ^",
+		},
+		{
+			name:     "missing result parentheses",
+			source:   "package main\n\nfunc double(n int) int {\n\treturn n * 2\n}\n",
+			line:     3,
+			column:   20,
+			message:  `unexpected token "int"`,
+			expected: "Body",
+			snippet:  "func double(n int) int {\n                   ^",
+		},
+		{
+			name:    "unterminated string",
+			source:  "package main\n\nfunc Label() (Component) {\n\tSpan { \"Total }\n}\n",
+			line:    4,
+			column:  9,
+			message: "lexer: invalid input text",
+			snippet: "\tSpan { \"Total }\n\t       ^",
+		},
+	}
+
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseBytes("broken.gx", []byte(tt.source))
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected *ParseError, got %T: %v", err, err)
+			}
+
+			if parseErr.Filename != "broken.gx" || parseErr.Line != tt.line || parseErr.Column != tt.column {
+				t.Errorf("Expected broken.gx:%d:%d, got %s:%d:%d", tt.line, tt.column, parseErr.Filename, parseErr.Line, parseErr.Column)
+			}
+			if !strings.HasPrefix(parseErr.Message, tt.message) {
+				t.Errorf("Expected message starting with %q, got %q", tt.message, parseErr.Message)
+			}
+			if parseErr.Expected != tt.expected {
+				t.Errorf("Expected hint %q, got %q", tt.expected, parseErr.Expected)
+			}
+			if snippet := parseErr.Snippet(); snippet != tt.snippet {
+				t.Errorf("Expected snippet:\n%s\ngot:\n%s", tt.snippet, snippet)
+			}
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	_, err = p.ParseString("package main\n\nfunc Empty() (Component) {\n\tDiv { ) }\n}\n")
+	if err == nil {
+		t.Fatal("Expected an error for a stray parenthesis")
+	}
+
+	// Without a file name the message starts at the line
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "4:8: error: unexpected token \")\"") {
+		t.Errorf("Expected a gcc-style message with a snippet, got:\n%s", err)
+	}
+}
+
+func TestExpectedHint(t *testing.T) {
+	tests := map[string]string{
+		`")"`: `")"`,
+		`(("chan" Type ("," Expr)? ")") | ("[" "]" Type "," Expr ("," Expr)? ")"))`: `"chan", ",", ")", "[", ...`,
+		`"}" | "{" | "(" | "[" | "," | ";" | "." | "=" | ":="`:                      `"}", "{", "(", "[", ...`,
+		`Body`: `Body`,
+	}
+	for expected, want := range tests {
+		if got := expectedHint(expected); got != want {
+			t.Errorf("expectedHint(%q): expected %q, got %q", expected, want, got)
+		}
+	}
+}
@@ -15,12 +15,13 @@ var guixLexer = lexer.MustStateful(lexer.Rules{
 	"Root": {
 		{"Comment", `//[^\n]*`, nil},
 		{"Whitespace", `\s+`, nil},
-		{"Directive", `@props\b`, nil},
+		{"Directive", `@(props|memo|keys|required|json)\b`, nil},
 		{"Ellipsis", `\.\.\.`, nil},
-		{"Keyword", `\b(package|import|type|struct|if|else|for|in|range|return|func|chan|true|false|make|go|switch|case|default|select|interface)\b`, nil},
-		{"Op", `(<-|:=|\+=|-=|\*=|/=|==|!=|<=|>=|&&|\|\||[+\-*/<>&|!.=])`, nil},
+		{"Keyword", `\b(package|import|type|struct|if|else|for|in|range|return|func|chan|true|false|make|go|switch|case|default|select|interface|const|break|continue|map)\b`, nil},
+		{"Op", `(<-|->|\+\+|--|:=|\+=|-=|\*=|/=|==|!=|<=|>=|&&|\|\||[+\-*/<>&|!.=?])`, nil},
 		{"Ident", `[a-zA-Z_][a-zA-Z0-9_]*`, nil},
-		{"Number", `\d+\.?\d*`, nil},
+		// Digits may be separated by underscores, as in Go: 1_000, 6.022e23
+		{"Number", `\d(_?\d)*(\.(\d(_?\d)*)?)?([eE][+-]?\d(_?\d)*)?`, nil},
 		{"String", `"(?:\\.|[^"\\])*"`, nil},
 		{"Backtick", "`", lexer.Push("Template")},
 		{"Punct", `[{}()\[\],;:]`, nil},
@@ -32,6 +33,8 @@ var guixLexer = lexer.MustStateful(lexer.Rules{
 	},
 	"TemplateExpr": {
 		{"ExprEnd", `\}`, lexer.Pop()},
+		// The # flag of a format spec: {id:#x}
+		{"FormatFlag", `#`, nil},
 		lexer.Include("Root"),
 	},
 })
@@ -46,7 +49,9 @@ func New() (*Parser, error) {
 	p, err := participle.Build[ast.File](
 		participle.Lexer(guixLexer),
 		participle.Elide("Comment", "Whitespace"),
-		participle.UseLookahead(20), // Required for 3+ arg element props as first child
+		// A call statement and a component element with children share the
+		// Name(args...) prefix, which may be arbitrarily long
+		participle.UseLookahead(participle.MaxLookahead),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build parser: %w", err)
@@ -55,29 +60,26 @@ func New() (*Parser, error) {
 	return &Parser{parser: p}, nil
 }
 
-// Parse parses a Guix source file
+// Parse parses a Guix source file. Syntax errors are returned as a
+// *ParseError.
 func (p *Parser) Parse(r io.Reader) (*ast.File, error) {
-	file, err := p.parser.Parse("", r)
+	source, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
-	return file, nil
+	return p.ParseBytes("", source)
 }
 
 // ParseString parses a Guix source string
 func (p *Parser) ParseString(source string) (*ast.File, error) {
-	file, err := p.parser.ParseString("", source)
-	if err != nil {
-		return nil, fmt.Errorf("parse error: %w", err)
-	}
-	return file, nil
+	return p.ParseBytes("", []byte(source))
 }
 
 // ParseBytes parses Guix source bytes
 func (p *Parser) ParseBytes(filename string, source []byte) (*ast.File, error) {
 	file, err := p.parser.ParseBytes(filename, source)
 	if err != nil {
-		return nil, fmt.Errorf("parse error in %s: %w", filename, err)
+		return nil, newParseError(err, source)
 	}
 	return file, nil
 }
@@ -47,6 +47,63 @@ func Button(label string) (Component) {
 	}
 }
 
+func TestParseImports(t *testing.T) {
+	source := `
+package main
+
+import "fmt"
+import r "github.com/gaarutyunov/guix/pkg/runtime"
+
+import (
+	"strings"
+	str "strconv"
+	. "math"
+	_ "embed"
+)
+
+func Button(label string) (Component) {
+	Div {
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	expected := []struct {
+		alias string
+		path  string
+	}{
+		{"", `"fmt"`},
+		{"r", `"github.com/gaarutyunov/guix/pkg/runtime"`},
+		{"", `"strings"`},
+		{"str", `"strconv"`},
+		{".", `"math"`},
+		{"_", `"embed"`},
+	}
+
+	if len(file.Imports) != len(expected) {
+		t.Fatalf("Expected %d imports, got %d", len(expected), len(file.Imports))
+	}
+
+	for i, want := range expected {
+		imp := file.Imports[i]
+		if imp.Alias != want.alias || imp.Path != want.path {
+			t.Errorf("Import %d: expected %q %s, got %q %s", i, want.alias, want.path, imp.Alias, imp.Path)
+		}
+	}
+
+	if len(file.Components) != 1 {
+		t.Errorf("Expected 1 component after the imports, got %d", len(file.Components))
+	}
+}
+
 func TestParseTemplate(t *testing.T) {
 	source := `
 package main
@@ -114,6 +171,53 @@ func Counter(counterChannel <-chan int) (Component) {
 	}
 }
 
+func TestParseAnonymousStructParameter(t *testing.T) {
+	source := `
+package main
+
+func Point(p struct{X, Y float64}, tags []struct{ Name string; Weight int }) (Component) {
+	Div {
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	params := file.Components[0].Params
+	if len(params) != 2 {
+		t.Fatalf("Expected 2 parameters, got %d", len(params))
+	}
+
+	point := params[0].Type.Struct
+	if point == nil {
+		t.Fatal("Expected anonymous struct type for p")
+	}
+	if len(point.Fields) != 1 {
+		t.Fatalf("Expected 1 field group, got %d", len(point.Fields))
+	}
+	if names := point.Fields[0].Names(); len(names) != 2 || names[0] != "X" || names[1] != "Y" {
+		t.Errorf("Expected fields X, Y, got %v", names)
+	}
+	if point.Fields[0].Type.Name != "float64" {
+		t.Errorf("Expected float64 fields, got %s", point.Fields[0].Type.Name)
+	}
+
+	tags := params[1].Type
+	if !tags.IsSlice || tags.Struct == nil || len(tags.Struct.Fields) != 2 {
+		t.Fatalf("Expected slice of struct with 2 fields, got %+v", tags)
+	}
+	if tags.Struct.Fields[1].Name != "Weight" {
+		t.Errorf("Expected second field Weight, got %s", tags.Struct.Fields[1].Name)
+	}
+}
+
 func TestParseMakeCall(t *testing.T) {
 	source := `
 package main
@@ -434,6 +538,75 @@ func Handler() (Component) {
 	}
 }
 
+// TestParseConstDecl tests parsing of single and grouped const declarations
+// in component and function bodies
+func TestParseConstDecl(t *testing.T) {
+	source := `
+package main
+
+func Counter(count int) (Component) {
+	const (
+		maxCount = 10
+		label = "Count"
+	)
+	const step = 2
+	doubled := count * step
+
+	Div {
+		Button(OnClick(func(e Event) {
+			const delta = 1
+			log(delta)
+		})) {
+			` + "`{label}: {doubled}`" + `
+		}
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	body := file.Components[0].Body
+	if len(body.ConstDecls) != 2 {
+		t.Fatalf("Expected 2 const declarations, got %d", len(body.ConstDecls))
+	}
+
+	group := body.ConstDecls[0]
+	if len(group.Specs) != 2 {
+		t.Fatalf("Expected 2 specs in grouped const, got %d", len(group.Specs))
+	}
+	if group.Specs[0].Name != "maxCount" || group.Specs[1].Name != "label" {
+		t.Errorf("Expected maxCount and label, got %s and %s", group.Specs[0].Name, group.Specs[1].Name)
+	}
+	if lit := group.Specs[1].Value.Left.Literal; lit == nil || lit.String == nil {
+		t.Errorf("Expected string literal value for label")
+	}
+
+	single := body.ConstDecls[1]
+	if len(single.Specs) != 1 || single.Specs[0].Name != "step" {
+		t.Errorf("Expected single const step, got %+v", single.Specs)
+	}
+
+	if len(body.VarDecls) != 1 || body.VarDecls[0].Names[0] != "doubled" {
+		t.Fatalf("Expected var declaration doubled after consts")
+	}
+
+	handler := body.Children[0].Element.Children[0].Element.Props[0].Args[0].Left.FuncLit
+	if handler == nil {
+		t.Fatal("Expected OnClick function literal")
+	}
+	stmt := handler.Body.Statements[0]
+	if stmt.ConstDecl == nil || stmt.ConstDecl.Specs[0].Name != "delta" {
+		t.Errorf("Expected const delta in function body, got %+v", stmt)
+	}
+}
+
 // TestParseCustomFunctionCall tests parsing of custom (non-package-qualified) function calls
 // EXPECTED STATE: Parser should handle function calls to custom functions just like stdlib calls
 func TestParseCustomFunctionCall(t *testing.T) {
@@ -809,3 +982,582 @@ func App() (Component) {
 		t.Fatal("Expected statements in function body")
 	}
 }
+
+func TestParseCondExpr(t *testing.T) {
+	source := `
+package main
+
+func Toggle(active bool, count int) (Component) {
+	Div(Class(active ? "on" : "off")) {
+		` + "`{count > 1 ? \"many\" : count > 0 ? \"one\" : \"none\"}`" + `
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse conditional expression: %v", err)
+	}
+
+	div := file.Components[0].Body.Children[0].Element
+	class := div.Props[0].Args[0]
+	if class.Left.CallOrSel == nil || class.Left.CallOrSel.Base != "active" || class.Cond == nil {
+		t.Fatal("Expected Class argument to be a conditional expression on active")
+	}
+	if *class.Cond.Then.Left.Literal.String != `"on"` || *class.Cond.Else.Left.Literal.String != `"off"` {
+		t.Errorf("Expected branches \"on\" and \"off\", got %v and %v", class.Cond.Then.Left.Literal, class.Cond.Else.Left.Literal)
+	}
+
+	// Conditionals nest to the right in the else branch
+	expr := div.Children[0].Template.Fragments[0].Expr
+	if expr.Cond == nil || len(expr.BinOps) != 1 || expr.BinOps[0].Op != ">" {
+		t.Fatal("Expected template expression to be a conditional on count > 1")
+	}
+	nested := expr.Cond.Else
+	if nested.Cond == nil || *nested.Cond.Else.Left.Literal.String != `"none"` {
+		t.Error("Expected the else branch to be a nested conditional")
+	}
+}
+
+func TestParseMethods(t *testing.T) {
+	source := `
+package main
+
+type Spinner struct {
+	Angle float64
+}
+
+func (s *Spinner) Rotate(dt float64) {
+	s.Angle += dt
+}
+
+func View(label string) (Component) {
+	Div { ` + "`{label}`" + ` }
+}
+
+func (s Spinner) Degrees() (float64) {
+	return s.Angle * 57.2958
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse methods: %v", err)
+	}
+
+	if len(file.Types) != 1 || len(file.Components) != 1 {
+		t.Fatalf("Expected 1 type and 1 component, got %d and %d", len(file.Types), len(file.Components))
+	}
+	if len(file.Methods) != 2 {
+		t.Fatalf("Expected 2 methods, got %d", len(file.Methods))
+	}
+
+	rotate := file.Methods[0]
+	if rotate.Name != "Rotate" || rotate.Receiver.Name != "s" || rotate.Receiver.Type != "Spinner" || !rotate.Receiver.IsPointer {
+		t.Errorf("Expected pointer receiver (s *Spinner) Rotate, got (%s %s) %s", rotate.Receiver.Name, rotate.Receiver.Type, rotate.Name)
+	}
+	if len(rotate.Params) != 1 || rotate.Params[0].Name != "dt" {
+		t.Errorf("Expected parameter dt, got %v", rotate.Params)
+	}
+
+	degrees := file.Methods[1]
+	if degrees.Name != "Degrees" || degrees.Receiver.IsPointer {
+		t.Errorf("Expected value receiver (s Spinner) Degrees, got pointer=%v %s", degrees.Receiver.IsPointer, degrees.Name)
+	}
+	if len(degrees.Results) != 1 || degrees.Results[0].Name != "float64" {
+		t.Errorf("Expected result float64, got %v", degrees.Results)
+	}
+}
+
+func TestParseCStyleForLoop(t *testing.T) {
+	source := `package main
+
+func List(count int) (Component) {
+	Ul {
+		for i := 0; i < count; i++ {
+			Li { ` + "`{i}`" + ` }
+		}
+	}
+}
+
+func firstOdd(n int) (int) {
+	for i := 0; i < n; i++ {
+		if i == 0 {
+			continue
+		}
+		if i == 3 {
+			break
+		}
+	}
+	return n
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse C-style for loops: %v", err)
+	}
+
+	if len(file.Components) != 2 {
+		t.Fatalf("Expected 2 components, got %d", len(file.Components))
+	}
+
+	ul := file.Components[0].Body.Children[0].Element
+	loop := ul.Children[0].ForLoop
+	if loop == nil || loop.Init == nil || loop.Post == nil {
+		t.Fatalf("Expected C-style for loop in Ul, got %+v", ul.Children[0])
+	}
+	if loop.Post.IncDec != "++" {
+		t.Errorf("Expected post statement i++, got %q", loop.Post.IncDec)
+	}
+	if body := loop.LoopBody(); body == nil || len(body.Children) != 1 || body.Children[0].Element.Tag != "Li" {
+		t.Errorf("Expected loop body with a Li child")
+	}
+
+	fn := file.Components[1].Body.Statements[0].For
+	if fn == nil {
+		t.Fatalf("Expected for loop in function body")
+	}
+	var branches []string
+	for _, stmt := range fn.LoopBody().Statements {
+		if stmt.If != nil && stmt.If.Body != nil && len(stmt.If.Body.Statements) == 1 && stmt.If.Body.Statements[0].Branch != nil {
+			branches = append(branches, stmt.If.Body.Statements[0].Branch.Tok)
+		}
+	}
+	if len(branches) != 2 || branches[0] != "continue" || branches[1] != "break" {
+		t.Errorf("Expected continue and break statements, got %v", branches)
+	}
+}
+
+func TestParseMapTypes(t *testing.T) {
+	source := `package main
+
+type Index struct {
+	Tags map[string][]int
+}
+
+func count(words []string) (map[string]int) {
+	counts := make(map[string]int, len(words))
+	return counts
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse map types: %v", err)
+	}
+
+	tags := file.Types[0].Struct.Fields[0].Type
+	if tags.Map == nil || tags.Map.Key.Name != "string" || !tags.Map.Value.IsSlice || tags.Map.Value.Name != "int" {
+		t.Errorf("Expected map[string][]int field, got %+v", tags)
+	}
+
+	fn := file.Components[0]
+	if len(fn.Results) != 1 || fn.Results[0].Map == nil {
+		t.Fatalf("Expected map result, got %+v", fn.Results)
+	}
+
+	makeCall := fn.Body.VarDecls[0].Values[0].Left.MakeCall
+	if makeCall == nil || makeCall.MapType == nil || makeCall.MapSize == nil {
+		t.Errorf("Expected make(map[string]int, size), got %+v", makeCall)
+	}
+}
+
+func TestParseNestedCompositeLiterals(t *testing.T) {
+	source := `package main
+
+func Plot(origin Point) (Component) {
+	state := State{Inner: Inner{Points: []Point{{X: 1, Y: 2}, origin}}}
+	tags := map[string]Point{"a": {X: 3}}
+	Div {
+		"Plot"
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse nested composite literals: %v", err)
+	}
+
+	decls := file.Components[0].Body.VarDecls
+	state := decls[0].Values[0].Left.CompositeLit
+	if state == nil || state.Type != "State" || len(state.Elements) != 1 {
+		t.Fatalf("Expected State{Inner: ...}, got %+v", state)
+	}
+	inner := state.Elements[0].Value.Left.CompositeLit
+	if inner == nil || inner.Type != "Inner" || len(inner.Elements) != 1 || inner.Elements[0].Key != "Points" {
+		t.Fatalf("Expected nested Inner{Points: ...}, got %+v", inner)
+	}
+
+	points := inner.Elements[0].Value.Left.CompositeLit
+	if points == nil || points.SliceOf == nil || points.SliceOf.Name != "Point" || len(points.Values) != 2 {
+		t.Fatalf("Expected []Point with 2 elements, got %+v", points)
+	}
+	if first := points.Values[0]; !first.Elided || len(first.Elements) != 2 || first.Elements[0].Key != "X" {
+		t.Errorf("Expected elided {X: 1, Y: 2}, got %+v", first)
+	}
+	if second := points.Values[1]; second.Elided || second.Value == nil || second.Value.Left.CallOrSel == nil || second.Value.Left.CallOrSel.Base != "origin" {
+		t.Errorf("Expected origin, got %+v", second)
+	}
+
+	tags := decls[1].Values[0].Left.CompositeLit
+	if tags == nil || tags.Map == nil || len(tags.Values) != 1 {
+		t.Fatalf("Expected map[string]Point literal, got %+v", tags)
+	}
+	if entry := tags.Values[0]; entry.LitKey == nil || *entry.LitKey.String != `"a"` || !entry.Elided {
+		t.Errorf("Expected entry \"a\": {X: 3}, got %+v", entry)
+	}
+}
+
+func TestParseCSSLiteral(t *testing.T) {
+	source := `package main
+
+func Badge() (Component) {
+	Div(Style(CSS{"margin-top": "4px", color: "red"})) {
+		"New"
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse CSS literal: %v", err)
+	}
+
+	style := file.Components[0].Body.Children[0].Element.Props[0]
+	css := style.Args[0].Left.CompositeLit
+	if css == nil || css.Type != "CSS" || len(css.Elements) != 2 {
+		t.Fatalf("Expected CSS literal with 2 entries, got %+v", css)
+	}
+	if first := css.Elements[0]; first.LitKey == nil || *first.LitKey.String != `"margin-top"` {
+		t.Errorf("Expected literal key \"margin-top\", got %+v", first)
+	}
+	if second := css.Elements[1]; second.LitKey != nil || second.Key != "color" {
+		t.Errorf("Expected identifier key color, got %+v", second)
+	}
+}
+
+func TestParseRequiredParams(t *testing.T) {
+	source := `package main
+
+@props func Button(@required label string, size int) (Component) {
+	Div {
+		"Button"
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse @required parameter: %v", err)
+	}
+
+	params := file.Components[0].Params
+	if len(params) != 2 {
+		t.Fatalf("Expected 2 parameters, got %d", len(params))
+	}
+	if !params[0].Required || params[0].Name != "label" {
+		t.Errorf("Expected required parameter label, got %+v", params[0])
+	}
+	if params[1].Required {
+		t.Errorf("Expected parameter size to be optional")
+	}
+}
+
+func TestParseNumberLiterals(t *testing.T) {
+	source := `package main
+
+func Stats() (Component) {
+	a := -1.5e3
+	b := 6.022e23
+	c := 1_000_000
+	d := c-1
+	Div {
+		"Stats"
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse number literals: %v", err)
+	}
+
+	decls := file.Components[0].Body.VarDecls
+
+	// A negative number is the unary minus of a single number token
+	neg := decls[0].Values[0].Left.Unary
+	if neg == nil || neg.Op != "-" || neg.Right.Literal == nil || *neg.Right.Literal.Number != "1.5e3" {
+		t.Errorf("Expected -1.5e3 to be the negation of 1.5e3, got %+v", decls[0].Values[0].Left)
+	}
+
+	for i, want := range []string{"6.022e23", "1_000_000"} {
+		lit := decls[i+1].Values[0].Left.Literal
+		if lit == nil || lit.Number == nil || *lit.Number != want {
+			t.Errorf("Expected number %s, got %+v", want, decls[i+1].Values[0].Left)
+		}
+	}
+
+	// A minus between operands is still a subtraction
+	if sub := decls[3].Values[0]; len(sub.BinOps) != 1 || sub.BinOps[0].Op != "-" {
+		t.Errorf("Expected c-1 to be a subtraction, got %+v", sub)
+	}
+}
+
+func TestParseJSONTypeDefs(t *testing.T) {
+	source := `package main
+
+@json type User struct {
+	UserID int
+}
+
+@json(snake) type Order struct {
+	OrderID int
+}
+
+type Point struct {
+	X, Y float64
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse @json types: %v", err)
+	}
+
+	if len(file.Types) != 3 {
+		t.Fatalf("Expected 3 types, got %d", len(file.Types))
+	}
+	if json := file.Types[0].JSON; json == nil || json.Style != "" {
+		t.Errorf("Expected @json with the default style on User, got %+v", json)
+	}
+	if json := file.Types[1].JSON; json == nil || json.Style != "snake" {
+		t.Errorf("Expected @json(snake) on Order, got %+v", json)
+	}
+	if file.Types[2].JSON != nil {
+		t.Errorf("Expected no @json on Point")
+	}
+
+	if _, err := p.Parse(strings.NewReader("package main\n\n@json(kebab) type A struct {\n\tX int\n}\n")); err == nil {
+		t.Error("Expected an error for an unknown @json style")
+	}
+}
+
+func TestParseTemplateTernaryIdentElse(t *testing.T) {
+	source := `package main
+
+func Toggle(active bool, on string, off string) (Component) {
+	P {
+		` + "`{active ? on : off} {active ? \"x\" : off} {active ? on :off} {on:-8s} {len(on):#x} {len(off):+08.3f}`" + `
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse conditional expressions: %v", err)
+	}
+
+	var formats []string
+	var conds int
+	for _, frag := range file.Components[0].Body.Children[0].Element.Children[0].Template.Fragments {
+		if frag.Expr == nil {
+			continue
+		}
+		formats = append(formats, string(frag.Format))
+		if frag.Expr.Cond != nil {
+			conds++
+		}
+	}
+
+	// The else branch of a conditional is never taken for a format spec
+	expected := []string{"", "", "", "-8s", "#x", "+08.3f"}
+	if len(formats) != len(expected) {
+		t.Fatalf("Expected %d interpolations, got %d: %v", len(expected), len(formats), formats)
+	}
+	for i, want := range expected {
+		if formats[i] != want {
+			t.Errorf("Interpolation %d: expected format %q, got %q", i, want, formats[i])
+		}
+	}
+	if conds != 3 {
+		t.Errorf("Expected 3 conditional expressions, got %d", conds)
+	}
+}
+
+func TestParseLongElementPropsWithChildren(t *testing.T) {
+	source := `package main
+
+func Card(color string, isActive bool) (Component) {
+	Div(Style(CSS{"k0": "v", "k1": "v", "k2": "v", "k3": "v", "k4": "v", "k5": "v", "k6": "v", "k7": "v"})) {
+		"Card"
+	}
+	Div(Classes("card", ClassIf("active", isActive)), Style(CSS{"color": color, "margin": "4px"}), OnClick(func(e Event) {})) {
+		"Active"
+	}
+	Panel(Title("x"), Style(CSS{"k0": "v", "k1": "v", "k2": "v", "k3": "v", "k4": "v", "k5": "v", "k6": "v", "k7": "v"})) {
+		"Panel"
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse elements with long props: %v", err)
+	}
+
+	body := file.Components[0].Body
+	if len(body.Statements) != 0 {
+		t.Fatalf("Expected no body statements, got %d", len(body.Statements))
+	}
+	expected := []struct {
+		tag   string
+		props int
+	}{{"Div", 1}, {"Div", 3}, {"Panel", 2}}
+	if len(body.Children) != len(expected) {
+		t.Fatalf("Expected %d children, got %d", len(expected), len(body.Children))
+	}
+	for i, want := range expected {
+		elem := body.Children[i].Element
+		if elem == nil {
+			t.Fatalf("Child %d: expected an element", i)
+		}
+		if elem.Tag != want.tag || len(elem.Props) != want.props || len(elem.Children) != 1 {
+			t.Errorf("Child %d: expected %s with %d props and 1 child, got %s with %d props and %d children",
+				i, want.tag, want.props, elem.Tag, len(elem.Props), len(elem.Children))
+		}
+	}
+	if entries := len(body.Children[0].Element.Props[0].Args[0].Left.CompositeLit.Elements); entries != 8 {
+		t.Errorf("Expected 8 CSS entries, got %d", entries)
+	}
+}
+
+func TestParseChildrenSlot(t *testing.T) {
+	source := `package main
+
+func Layout(children Children) (Component) {
+	Div {
+		{children}
+	}
+}
+
+func Page() (Component) {
+	Layout() {
+		P {
+			"Nested"
+		}
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse children slot: %v", err)
+	}
+
+	layout := file.Components[0]
+	if !layout.Params[0].IsChildren() {
+		t.Errorf("Expected children to be a Children parameter")
+	}
+	slot := layout.Body.Children[0].Element.Children[0].Slot
+	if slot == nil || slot.Name != "children" {
+		t.Fatalf("Expected {children} slot, got %+v", layout.Body.Children[0].Element.Children[0])
+	}
+
+	// A component call followed by a block is an element with children
+	page := file.Components[1].Body
+	if len(page.Statements) != 0 || len(page.Children) != 1 {
+		t.Fatalf("Expected a single element, got %d statements and %d children", len(page.Statements), len(page.Children))
+	}
+	elem := page.Children[0].Element
+	if elem == nil || elem.Tag != "Layout" || len(elem.Children) != 1 {
+		t.Errorf("Expected Layout element with one child, got %+v", elem)
+	}
+}
+
+func TestParseTemplateFormatSpec(t *testing.T) {
+	source := `package main
+
+func Receipt(price float64, paidAt int64, items []string, ok bool) (Component) {
+	P {
+		` + "`{price:.2f} on {paidAt:date}, {len(items):3d} {price} {ok ? \"paid\" : \"due\"} {items[:2]}`" + `
+	}
+}
+`
+	p, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := p.Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Failed to parse format specs: %v", err)
+	}
+
+	var formats []string
+	for _, frag := range file.Components[0].Body.Children[0].Element.Children[0].Template.Fragments {
+		if frag.Expr != nil {
+			formats = append(formats, string(frag.Format))
+		}
+	}
+
+	// Slices and conditionals keep their colons
+	expected := []string{".2f", "date", "3d", "", "", ""}
+	if len(formats) != len(expected) {
+		t.Fatalf("Expected %d interpolations, got %d: %v", len(expected), len(formats), formats)
+	}
+	for i, want := range expected {
+		if formats[i] != want {
+			t.Errorf("Interpolation %d: expected format %q, got %q", i, want, formats[i])
+		}
+	}
+}
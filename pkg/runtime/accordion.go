@@ -0,0 +1,215 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"syscall/js"
+)
+
+// accordionPanelTag marks a Panel until its Accordion lays it out, like the
+// webgpu-scene tag of GPUScene
+const accordionPanelTag = "accordion-panel"
+
+// AccordionActive sets the channel of an Accordion that receives the index
+// of the opened panel, or -1 when the open panel is closed
+type AccordionActive struct {
+	Channel chan int
+}
+
+// Active sets the channel that receives the index of the panel opened by a
+// click, or -1 when a click closes the open panel
+func Active(ch chan int) AccordionActive {
+	return AccordionActive{Channel: ch}
+}
+
+// Panel creates a collapsible section of an Accordion. The Title attribute
+// labels the header button of the panel:
+//
+//	Panel(Title("Shipping"), P(Text("Ships in 2 days")))
+func Panel(options ...interface{}) *VNode {
+	return El(accordionPanelTag, options...)
+}
+
+// accordionState is the open panel of an Accordion across renders
+type accordionState struct {
+	id   int
+	open int
+	mu   sync.Mutex
+}
+
+// accordionKeys are the keys that move the focus between Accordion headers
+var accordionKeys = map[string]bool{"ArrowDown": true, "ArrowUp": true, "Home": true, "End": true}
+
+var (
+	accordionStates   = make(map[chan int]*accordionState)
+	accordionStatesMu sync.Mutex
+	accordionCount    int
+)
+
+// accordionStateFor returns the state of the Accordion feeding the given
+// Active channel. An Accordion without a channel starts closed every render.
+func accordionStateFor(active chan int) *accordionState {
+	accordionStatesMu.Lock()
+	defer accordionStatesMu.Unlock()
+
+	state, ok := accordionStates[active]
+	if ok && active != nil {
+		return state
+	}
+	accordionCount++
+	state = &accordionState{id: accordionCount, open: -1}
+	if active != nil {
+		accordionStates[active] = state
+	}
+	return state
+}
+
+// Accordion creates a set of exclusive collapsible panels following the
+// WAI-ARIA accordion pattern:
+//
+//	Accordion(Active(active), Panel(Title("A"), ...), Panel(Title("B"), ...))
+//
+// Each panel gets a header button with aria-expanded and aria-controls, and
+// its content is a region labelled by the header. Opening a panel closes the
+// others. ArrowDown/ArrowUp move the focus between headers, Home/End jump to
+// the first and last header, and Enter or Space toggles the focused panel.
+func Accordion(options ...interface{}) *VNode {
+	var active chan int
+	var panels []*VNode
+	opts := []interface{}{Class("accordion")}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case AccordionActive:
+			active = o.Channel
+		case *VNode:
+			if o.Tag == accordionPanelTag {
+				panels = append(panels, o)
+			} else {
+				opts = append(opts, o)
+			}
+		default:
+			opts = append(opts, o)
+		}
+	}
+
+	state := accordionStateFor(active)
+	state.mu.Lock()
+	if state.open >= len(panels) {
+		state.open = -1
+	}
+	open := state.open
+	state.mu.Unlock()
+
+	toggle := func(e Event, index int) {
+		state.mu.Lock()
+		if state.open == index {
+			state.open = -1
+		} else {
+			state.open = index
+		}
+		current := state.open
+		state.mu.Unlock()
+
+		expandAccordionPanel(e, current)
+		if active != nil {
+			// Event handlers must not block the JS event loop
+			go func() { active <- current }()
+		}
+	}
+
+	for i, panel := range panels {
+		index := i
+		headerID := fmt.Sprintf("accordion-%d-header-%d", state.id, i)
+		panelID := fmt.Sprintf("accordion-%d-panel-%d", state.id, i)
+
+		title := panel.Attributes["title"]
+		delete(panel.Attributes, "title")
+
+		header := El("h3", Button(
+			ID(headerID),
+			Type("button"),
+			Attr{Key: "aria-expanded", Value: strconv.FormatBool(i == open)},
+			Attr{Key: "aria-controls", Value: panelID},
+			OnClick(func(e Event) { toggle(e, index) }),
+			PreventDefaultIf(OnKeyDown(func(e Event) {
+				next := index
+				switch e.Key {
+				case "ArrowDown":
+					next = (index + 1) % len(panels)
+				case "ArrowUp":
+					next = (index - 1 + len(panels)) % len(panels)
+				case "Home":
+					next = 0
+				case "End":
+					next = len(panels) - 1
+				default:
+					return
+				}
+				focusAccordionHeader(e, next)
+			}), func(e Event) bool { return accordionKeys[e.Key] }),
+			Text(title),
+		))
+
+		panel.Tag = "div"
+		panel.Attributes["id"] = panelID
+		panel.Attributes["role"] = "region"
+		panel.Attributes["aria-labelledby"] = headerID
+		if i != open {
+			panel.Attributes["hidden"] = ""
+		}
+
+		opts = append(opts, header, panel)
+	}
+
+	return Div(opts...)
+}
+
+// accordionHeaders returns the h3 header elements of the Accordion holding
+// the event's header button, in document order
+func accordionHeaders(e Event) []js.Value {
+	if e.Native.IsUndefined() || e.Native.IsNull() {
+		return nil
+	}
+	button := e.Native.Get("currentTarget")
+	if button.IsUndefined() || button.IsNull() {
+		return nil
+	}
+
+	var headers []js.Value
+	children := button.Get("parentNode").Get("parentNode").Get("children")
+	for i := 0; i < children.Length(); i++ {
+		if child := children.Index(i); child.Get("tagName").String() == "H3" {
+			headers = append(headers, child)
+		}
+	}
+	return headers
+}
+
+// expandAccordionPanel updates aria-expanded and hidden in the DOM without
+// waiting for a re-render, so only the panel at index is open
+func expandAccordionPanel(e Event, index int) {
+	for i, header := range accordionHeaders(e) {
+		expanded := i == index
+		header.Get("firstElementChild").Call("setAttribute", "aria-expanded", strconv.FormatBool(expanded))
+		panel := header.Get("nextElementSibling")
+		if panel.IsNull() {
+			continue
+		}
+		if expanded {
+			panel.Call("removeAttribute", "hidden")
+		} else {
+			panel.Call("setAttribute", "hidden", "")
+		}
+	}
+}
+
+// focusAccordionHeader moves the focus to the header button at index
+func focusAccordionHeader(e Event, index int) {
+	headers := accordionHeaders(e)
+	if index < len(headers) {
+		headers[index].Get("firstElementChild").Call("focus")
+	}
+}
@@ -0,0 +1,127 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"testing"
+	"time"
+)
+
+func faqAccordion(active chan int) *VNode {
+	return Accordion(
+		Active(active),
+		Panel(Title("Shipping"), P(Text("Ships in 2 days"))),
+		Panel(Title("Returns"), P(Text("30 day returns"))),
+		Panel(Title("Warranty"), P(Text("One year"))),
+	)
+}
+
+// openPanels returns the indices of the panels that are not hidden and
+// checks that their headers agree
+func openPanels(t *testing.T, accordion *VNode) []int {
+	t.Helper()
+	var open []int
+	for i := 0; i+1 < len(accordion.Children); i += 2 {
+		button := accordion.Children[i].Children[0]
+		panel := accordion.Children[i+1]
+		_, hidden := panel.Attributes["hidden"]
+		if expanded := button.Attributes["aria-expanded"]; (expanded == "true") == hidden {
+			t.Errorf("Panel %d: aria-expanded=%q does not match hidden=%v", i/2, expanded, hidden)
+		}
+		if !hidden {
+			open = append(open, i/2)
+		}
+	}
+	return open
+}
+
+func clickHeader(accordion *VNode, index int) {
+	accordion.Children[index*2].Children[0].Events["click"].Handler(Event{})
+}
+
+func receiveActive(t *testing.T, active chan int) int {
+	t.Helper()
+	select {
+	case index := <-active:
+		return index
+	case <-time.After(time.Second):
+		t.Fatal("Expected the open panel to be sent to the channel")
+		return 0
+	}
+}
+
+func TestAccordionStructure(t *testing.T) {
+	accordion := faqAccordion(make(chan int))
+
+	if accordion.Tag != "div" || len(accordion.Children) != 6 {
+		t.Fatalf("Expected div with 3 headers and 3 panels, got %s with %d children", accordion.Tag, len(accordion.Children))
+	}
+	for i := 0; i < 3; i++ {
+		header := accordion.Children[i*2]
+		button := header.Children[0]
+		panel := accordion.Children[i*2+1]
+
+		if header.Tag != "h3" || button.Tag != "button" {
+			t.Errorf("Panel %d: expected h3 > button header, got %s > %s", i, header.Tag, button.Tag)
+		}
+		if button.Attributes["aria-controls"] != panel.Attributes["id"] {
+			t.Errorf("Panel %d: aria-controls=%q does not reference panel %q", i, button.Attributes["aria-controls"], panel.Attributes["id"])
+		}
+		if panel.Attributes["role"] != "region" || panel.Attributes["aria-labelledby"] != button.Attributes["id"] {
+			t.Errorf("Panel %d: expected region labelled by %q, got role=%q aria-labelledby=%q",
+				i, button.Attributes["id"], panel.Attributes["role"], panel.Attributes["aria-labelledby"])
+		}
+		if _, ok := panel.Attributes["title"]; ok {
+			t.Errorf("Panel %d: the title should label the header, not the region", i)
+		}
+	}
+	if got := accordion.Children[2].Children[0].Children[0].Text; got != "Returns" {
+		t.Errorf("Expected second header to be labelled Returns, got %q", got)
+	}
+	if open := openPanels(t, accordion); len(open) != 0 {
+		t.Errorf("Expected all panels to start closed, got %v", open)
+	}
+}
+
+func TestAccordionOpensOnePanel(t *testing.T) {
+	active := make(chan int)
+
+	accordion := faqAccordion(active)
+	clickHeader(accordion, 0)
+	if got := receiveActive(t, active); got != 0 {
+		t.Errorf("Expected panel 0 to be active, got %d", got)
+	}
+
+	// Opening another panel closes the first, and survives a re-render
+	accordion = faqAccordion(active)
+	clickHeader(accordion, 2)
+	if got := receiveActive(t, active); got != 2 {
+		t.Errorf("Expected panel 2 to be active, got %d", got)
+	}
+	accordion = faqAccordion(active)
+	if open := openPanels(t, accordion); len(open) != 1 || open[0] != 2 {
+		t.Errorf("Expected only panel 2 to be open, got %v", open)
+	}
+
+	// Clicking the open panel closes it
+	clickHeader(accordion, 2)
+	if got := receiveActive(t, active); got != -1 {
+		t.Errorf("Expected -1 after closing the open panel, got %d", got)
+	}
+	accordion = faqAccordion(active)
+	if open := openPanels(t, accordion); len(open) != 0 {
+		t.Errorf("Expected all panels to be closed, got %v", open)
+	}
+}
+
+func TestAccordionCancelsNavigationKeys(t *testing.T) {
+	accordion := faqAccordion(make(chan int))
+	keydown := accordion.Children[0].Children[0].Events["keydown"]
+
+	// Enter and Space keep the button's default action, which clicks it
+	for key, want := range map[string]bool{"ArrowDown": true, "ArrowUp": true, "Home": true, "End": true, "Enter": false, " ": false, "Tab": false} {
+		if got := keydown.PreventDefaultIf(Event{Key: key}); got != want {
+			t.Errorf("Key %q: expected cancel %v, got %v", key, want, got)
+		}
+	}
+}
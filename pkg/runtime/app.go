@@ -4,7 +4,9 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"syscall/js"
 )
 
@@ -14,6 +16,13 @@ type App struct {
 	rootVNode *VNode
 	component Component
 	mounted   bool
+
+	// Context values set via Provider.Provide
+	contexts  map[interface{}]interface{}
+	contextMu sync.RWMutex
+
+	// Errors of @required props reported by BindApp
+	propErrors []error
 }
 
 // NewApp creates a new Guix application
@@ -54,6 +63,11 @@ func (a *App) MountElement(element js.Value) error {
 func (a *App) render() error {
 	log("App: Rendering component, mounted:", a.mounted)
 
+	if err := errors.Join(a.propErrors...); err != nil {
+		logError("App: Missing required props:", err)
+		return err
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			logError("App: Render panicked:", r)
@@ -61,8 +75,12 @@ func (a *App) render() error {
 		}
 	}()
 
-	newVNode := a.component.Render()
+	newVNode := RenderComponent(a.component)
 	log("App: Component.Render() returned vnode")
+	if a.mounted && newVNode == a.rootVNode {
+		log("App: Component did not change, skipping update")
+		return nil
+	}
 
 	if !a.mounted {
 		// Initial render
@@ -79,14 +97,10 @@ func (a *App) render() error {
 	} else {
 		// Update render
 		log("App: Performing update (diff/patch)")
-		patches := Diff(a.rootVNode, newVNode)
-		log("App: Generated", len(patches), "patches")
-		if err := ApplyPatches(patches); err != nil {
+		if err := Reconcile(a.rootVNode, newVNode); err != nil {
 			logError("App: Apply patches failed:", err)
 			return err
 		}
-		// Copy DOMNode references from old tree to new tree so next update can find them
-		CopyDOMRefs(a.rootVNode, newVNode)
 		a.rootVNode = newVNode
 		log("App: Update complete")
 	}
@@ -94,6 +108,13 @@ func (a *App) render() error {
 	return nil
 }
 
+// MissingProp reports that the @required prop of a component was left nil.
+// It is called by the generated BindApp; the app then fails to render
+// with an error naming each missing prop.
+func (a *App) MissingProp(component, prop string) {
+	a.propErrors = append(a.propErrors, fmt.Errorf("%s: required prop %s is nil", component, prop))
+}
+
 // Update triggers a re-render of the application
 func (a *App) Update() {
 	log("App: Update triggered")
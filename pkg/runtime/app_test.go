@@ -0,0 +1,94 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strings"
+	"syscall/js"
+	"testing"
+)
+
+// labelComponent renders its label in a div
+type labelComponent struct {
+	label string
+}
+
+func (c *labelComponent) Render() *VNode        { return Div(Text(c.label)) }
+func (c *labelComponent) Mount(parent js.Value) {}
+func (c *labelComponent) Unmount()              {}
+func (c *labelComponent) Update()               {}
+
+// rootText returns the text of the div an app rendered into root
+func rootText(root js.Value) string {
+	if root.Get("childNodes").Length() == 0 {
+		return ""
+	}
+	return root.Get("childNodes").Index(0).Get("childNodes").Index(0).Get("textContent").String()
+}
+
+func TestTwoAppsOnOnePage(t *testing.T) {
+	installFakeDocument(t)
+
+	doc := js.Global().Get("document")
+	roots := map[string]js.Value{
+		"#a": doc.Call("createElement", "div"),
+		"#b": doc.Call("createElement", "div"),
+	}
+	querySelector := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return roots[args[0].String()]
+	})
+	defer querySelector.Release()
+	doc.Set("querySelector", querySelector)
+
+	first, second := &labelComponent{label: "first"}, &labelComponent{label: "second"}
+	a, b := NewApp(first), NewApp(second)
+	if err := a.Mount("#a"); err != nil {
+		t.Fatalf("Failed to mount app a: %v", err)
+	}
+	if err := b.Mount("#b"); err != nil {
+		t.Fatalf("Failed to mount app b: %v", err)
+	}
+
+	// Updating one app patches only its own root
+	first.label = "updated"
+	if err := a.render(); err != nil {
+		t.Fatalf("Failed to update app a: %v", err)
+	}
+	if got := rootText(roots["#a"]); got != "updated" {
+		t.Errorf("Expected app a to show %q, got %q", "updated", got)
+	}
+	if got := rootText(roots["#b"]); got != "second" {
+		t.Errorf("Expected app b to keep %q, got %q", "second", got)
+	}
+
+	// Unmounting one app leaves the other mounted
+	a.Unmount()
+	if n := roots["#a"].Get("childNodes").Length(); n != 0 {
+		t.Errorf("Expected app a to be removed, got %d nodes", n)
+	}
+	if got := rootText(roots["#b"]); got != "second" {
+		t.Errorf("Expected app b to stay mounted, got %q", got)
+	}
+}
+
+func TestMissingPropFailsMount(t *testing.T) {
+	installFakeDocument(t)
+
+	app := NewApp(&labelComponent{label: "button"})
+	app.MissingProp("Button", "onClick")
+	app.MissingProp("Button", "clicks")
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	err := app.MountElement(root)
+	if err == nil {
+		t.Fatal("Expected mounting with missing props to fail")
+	}
+	for _, want := range []string{"Button: required prop onClick is nil", "Button: required prop clicks is nil"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %q", want, err)
+		}
+	}
+	if n := root.Get("childNodes").Length(); n != 0 {
+		t.Errorf("Expected nothing to be mounted, got %d nodes", n)
+	}
+}
@@ -209,19 +209,48 @@ func NiceNumber(x float64, round bool) float64 {
 
 // CalculateNiceTicks generates "nice" tick values
 func CalculateNiceTicks(r Range, maxTicks int) []float64 {
-	tickRange := NiceNumber(r.Max-r.Min, false)
-	tickSpacing := NiceNumber(tickRange/float64(maxTicks-1), true)
-	niceMin := math.Floor(r.Min/tickSpacing) * tickSpacing
-	niceMax := math.Ceil(r.Max/tickSpacing) * tickSpacing
-
-	ticks := []float64{}
-	for tick := niceMin; tick <= niceMax; tick += tickSpacing {
-		ticks = append(ticks, tick)
+	return NiceTicks(r.Min, r.Max, maxTicks)
+}
+
+// NiceTicks returns about count evenly spaced tick values covering
+// [min, max], rounded to 1, 2 or 5 times a power of ten (e.g. 0, 20, ..., 100
+// for [0, 97]). The first and last ticks may extend past min and max to the
+// next nice value. It returns a single tick for an empty range and nil if
+// count is below 2 or a bound is not finite.
+func NiceTicks(min, max float64, count int) []float64 {
+	if count < 2 || math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil
+	}
+	if min > max {
+		min, max = max, min
+	}
+	if min == max {
+		return []float64{min}
+	}
+
+	span := NiceNumber(max-min, false)
+	step := NiceNumber(span/float64(count-1), true)
+	niceMin := math.Floor(min/step) * step
+	niceMax := math.Ceil(max/step) * step
+
+	// Compute each tick from its index and round to the step's precision
+	// so fractional steps don't accumulate floating point error
+	scale := math.Pow(10, math.Max(0, -math.Floor(math.Log10(step))))
+	n := int(math.Round((niceMax-niceMin)/step)) + 1
+	ticks := make([]float64, n)
+	for i := range ticks {
+		ticks[i] = math.Round((niceMin+float64(i)*step)*scale) / scale
 	}
 
 	return ticks
 }
 
+// GridTicks returns the values at which the axis draws ticks and gridlines,
+// rounded to nice numbers within its range
+func (c *AxisConfig) GridTicks() []float64 {
+	return NiceTicks(c.Range.Min, c.Range.Max, c.TickCount)
+}
+
 // FormatTime formats a timestamp for display
 func FormatTime(timestamp int64, format string) string {
 	t := time.Unix(timestamp/1000, (timestamp%1000)*1000000)
@@ -0,0 +1,45 @@
+//go:build js && wasm
+
+package chart
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNiceTicks(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		count    int
+		want     []float64
+	}{
+		{"zero to 97", 0, 97, 5, []float64{0, 20, 40, 60, 80, 100}},
+		{"zero to 97 dense", 0, 97, 10, []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100}},
+		{"thousands", 1000, 5500, 5, []float64{1000, 2000, 3000, 4000, 5000, 6000}},
+		{"fractional", 0.12, 0.48, 5, []float64{0.1, 0.2, 0.3, 0.4, 0.5}},
+		{"negative", -42, 42, 5, []float64{-60, -40, -20, 0, 20, 40, 60}},
+		{"reversed bounds", 97, 0, 5, []float64{0, 20, 40, 60, 80, 100}},
+		{"empty range", 3, 3, 5, []float64{3}},
+		{"too few ticks", 0, 97, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NiceTicks(tt.min, tt.max, tt.count)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NiceTicks(%v, %v, %d) = %v, want %v", tt.min, tt.max, tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAxisGridTicks(t *testing.T) {
+	axis := YAxis(AxisRange(1000, 5500), TickCount(5))
+	config := axis.Properties["config"].(*AxisConfig)
+
+	want := []float64{1000, 2000, 3000, 4000, 5000, 6000}
+	if got := config.GridTicks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GridTicks() = %v, want %v", got, want)
+	}
+}
@@ -9,6 +9,7 @@ struct ChartUniforms {
     upColor: vec4<f32>,          // Color for up candles (close >= open)
     downColor: vec4<f32>,        // Color for down candles (close < open)
     wickColor: vec4<f32>,        // Color for candle wicks
+    depth: f32,                  // Depth of the series (from its ZIndex)
 }
 
 struct Candle {
@@ -112,7 +113,7 @@ fn vs_main(
 
     // Transform to clip space
     let clipPos = dataToClip(position.x, position.y);
-    output.position = vec4<f32>(clipPos.x, clipPos.y, 0.0, 1.0);
+    output.position = vec4<f32>(clipPos.x, clipPos.y, uniforms.depth, 1.0);
 
     return output;
 }
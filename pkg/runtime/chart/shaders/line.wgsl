@@ -9,17 +9,21 @@ struct ChartUniforms {
     strokeColor: vec4<f32>,      // Line color
     fillEnabled: u32,            // 1 if fill is enabled, 0 otherwise
     fillColor: vec4<f32>,        // Fill color (under the line)
+    depth: f32,                  // Depth of the series (from its ZIndex)
+    dashPattern: vec2<f32>,      // Dash and gap lengths in pixels; a zero gap draws a solid line
 }
 
 struct Point {
     x: f32,
     y: f32,
+    distance: f32,               // Length of the line in pixels up to this point
 }
 
 struct VertexOutput {
     @builtin(position) position: vec4<f32>,
     @location(0) color: vec4<f32>,
     @location(1) @interpolate(flat) isFill: u32,
+    @location(2) distance: f32,  // Length of the line in pixels, for dashes
 }
 
 @group(0) @binding(0) var<uniform> uniforms: ChartUniforms;
@@ -86,19 +90,38 @@ fn vs_line(
 
     // Generate quad vertices for the line segment
     var position: vec2<f32>;
+    var distance = p1.distance;
     switch (vertexIndex) {
         case 0u: { position = vec2<f32>(clip1.x - perpX, clip1.y - perpY); }
         case 1u: { position = vec2<f32>(clip1.x + perpX, clip1.y + perpY); }
-        case 2u: { position = vec2<f32>(clip2.x - perpX, clip2.y - perpY); }
+        case 2u: { position = vec2<f32>(clip2.x - perpX, clip2.y - perpY); distance = p2.distance; }
         case 3u: { position = vec2<f32>(clip1.x + perpX, clip1.y + perpY); }
-        case 4u: { position = vec2<f32>(clip2.x + perpX, clip2.y + perpY); }
-        case 5u: { position = vec2<f32>(clip2.x - perpX, clip2.y - perpY); }
+        case 4u: { position = vec2<f32>(clip2.x + perpX, clip2.y + perpY); distance = p2.distance; }
+        case 5u: { position = vec2<f32>(clip2.x - perpX, clip2.y - perpY); distance = p2.distance; }
         default: { position = vec2<f32>(0.0, 0.0); }
     }
 
-    output.position = vec4<f32>(position.x, position.y, 0.0, 1.0);
+    output.position = vec4<f32>(position.x, position.y, uniforms.depth, 1.0);
     output.color = uniforms.strokeColor;
     output.isFill = 0u;
+    output.distance = distance;
+
+    return output;
+}
+
+// Vertex shader for pre-expanded line vertices
+// Positions are computed on the CPU with mitred joins and drawn indexed
+@vertex
+fn vs_line_indexed(
+    @location(0) position: vec2<f32>,
+    @location(1) distance: f32
+) -> VertexOutput {
+    var output: VertexOutput;
+
+    output.position = vec4<f32>(position.x, position.y, uniforms.depth, 1.0);
+    output.color = uniforms.strokeColor;
+    output.isFill = 0u;
+    output.distance = distance;
 
     return output;
 }
@@ -134,9 +157,11 @@ fn vs_fill(
         default: { position = vec2<f32>(0.0, 0.0); }
     }
 
-    output.position = vec4<f32>(position.x, position.y, 0.0, 1.0);
+    // Keep the fill just behind its own line
+    output.position = vec4<f32>(position.x, position.y, uniforms.depth + 0.001, 1.0);
     output.color = uniforms.fillColor;
     output.isFill = 1u;
+    output.distance = 0.0;
 
     return output;
 }
@@ -144,5 +169,10 @@ fn vs_fill(
 // Fragment shader
 @fragment
 fn fs_main(input: VertexOutput) -> @location(0) vec4<f32> {
+    // Discard the gaps of a dashed line, by its length up to the fragment
+    let dash = uniforms.dashPattern;
+    if (input.isFill == 0u && dash.y > 0.0 && input.distance % (dash.x + dash.y) >= dash.x) {
+        discard;
+    }
     return input.color;
 }
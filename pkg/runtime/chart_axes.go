@@ -0,0 +1,407 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"syscall/js"
+	"time"
+)
+
+const (
+	// defaultAxisTickCount is about how many ticks an axis shows by default
+	defaultAxisTickCount = 6
+
+	// axisGridWidth is the width of grid lines in pixels
+	axisGridWidth = 1
+
+	// axisGridDepth keeps grid lines behind every series; see seriesDepth
+	axisGridDepth = 0.99
+
+	// axisLabelGap is the distance between the plot area and tick labels
+	// in pixels
+	axisLabelGap = 6
+)
+
+// timeTickSteps are the tick steps of time scale axes in milliseconds, so
+// ticks fall on whole seconds, minutes, hours or days
+var timeTickSteps = []float64{
+	1000, 5000, 15000, 30000, // seconds
+	60000, 5 * 60000, 15 * 60000, 30 * 60000, // minutes
+	3600000, 3 * 3600000, 6 * 3600000, 12 * 3600000, // hours
+	86400000, 7 * 86400000, 30 * 86400000, 365 * 86400000, // days
+}
+
+// axisOptions are the properties of an axis node
+type axisOptions struct {
+	axisType    string // "x" or "y"
+	position    string // AxisTop, AxisBottom, AxisLeft or AxisRight
+	tickCount   int
+	labelFormat string
+	timeScale   bool
+	gridLines   bool
+	gridColor   Vec4
+}
+
+// axisLabel is a tick label in canvas pixels from the top left of the
+// canvas as shown
+type axisLabel struct {
+	Text     string
+	X, Y     float64
+	Position string // Side of the plot area, which sets the anchor of the label
+}
+
+// newAxisOptions reads the properties of an axis node, defaulting like XAxis
+// and YAxis
+func newAxisOptions(axis *GPUNode) axisOptions {
+	opts := axisOptions{
+		axisType:  "x",
+		tickCount: defaultAxisTickCount,
+		gridLines: true,
+		gridColor: NewVec4(0.2, 0.2, 0.25, 0.5),
+	}
+	if t, ok := axis.Properties["axisType"].(string); ok {
+		opts.axisType = t
+	}
+	opts.position = AxisBottom
+	if opts.axisType == "y" {
+		opts.position = AxisRight
+	}
+	if p, ok := axis.Properties["position"].(string); ok {
+		opts.position = p
+	}
+	if n, ok := axis.Properties["tickCount"].(int); ok {
+		opts.tickCount = n
+	}
+	if f, ok := axis.Properties["labelFormat"].(string); ok {
+		opts.labelFormat = f
+	}
+	if t, ok := axis.Properties["timeScale"].(bool); ok {
+		opts.timeScale = t
+	}
+	if g, ok := axis.Properties["gridLines"].(bool); ok {
+		opts.gridLines = g
+	}
+	if c, ok := axis.Properties["gridColor"].(Vec4); ok {
+		opts.gridColor = c
+	}
+	return opts
+}
+
+// axisTicks returns about count tick values inside [min, max] and the step
+// between them. Numeric steps are 1, 2 or 5 times a power of ten, like
+// chart.NiceTicks; time scale steps are taken from timeTickSteps. It returns
+// nil for an empty or non-finite range.
+func axisTicks(min, max float64, count int, timeScale bool) ([]float64, float64) {
+	if count < 2 || !(max > min) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil, 0
+	}
+
+	raw := (max - min) / float64(count-1)
+	step := niceTickStep(raw)
+	if timeScale {
+		step = timeTickSteps[len(timeTickSteps)-1]
+		for _, s := range timeTickSteps {
+			if s >= raw {
+				step = s
+				break
+			}
+		}
+	}
+
+	// Compute each tick from its index and round to the step's precision
+	// so fractional steps don't accumulate floating point error
+	scale := math.Pow(10, math.Max(0, -math.Floor(math.Log10(step))))
+	first := math.Ceil(min/step) * step
+	var ticks []float64
+	for i := 0; ; i++ {
+		tick := math.Round((first+float64(i)*step)*scale) / scale
+		if tick > max {
+			break
+		}
+		ticks = append(ticks, tick)
+	}
+	return ticks, step
+}
+
+// niceTickStep rounds a tick step to 1, 2 or 5 times a power of ten
+func niceTickStep(raw float64) float64 {
+	exp := math.Floor(math.Log10(raw))
+	f := raw / math.Pow(10, exp)
+	switch {
+	case f < 1.5:
+		f = 1
+	case f < 3:
+		f = 2
+	case f < 7:
+		f = 5
+	default:
+		f = 10
+	}
+	return f * math.Pow(10, exp)
+}
+
+// formatTick formats a tick label. Numeric ticks use format as a fmt verb,
+// or as many decimals as the step needs. Time scale ticks are Unix
+// timestamps in milliseconds, shown in UTC with format as a time layout or
+// one of "date", "time", "datetime" and "short"; without a format the
+// layout follows the step.
+func formatTick(value, step float64, format string, timeScale bool) string {
+	if !timeScale {
+		if format != "" {
+			return fmt.Sprintf(format, value)
+		}
+		decimals := int(math.Max(0, -math.Floor(math.Log10(step))))
+		return strconv.FormatFloat(value, 'f', decimals, 64)
+	}
+
+	t := time.UnixMilli(int64(math.Round(value))).UTC()
+	switch format {
+	case "date":
+		return t.Format("2006-01-02")
+	case "time":
+		return t.Format("15:04:05")
+	case "datetime":
+		return t.Format("2006-01-02 15:04")
+	case "short":
+		return t.Format("Jan 02")
+	case "":
+		switch {
+		case step >= 86400000:
+			return t.Format("Jan 02")
+		case step >= 60000:
+			return t.Format("15:04")
+		default:
+			return t.Format("15:04:05")
+		}
+	default:
+		return t.Format(format)
+	}
+}
+
+// gridIndices returns the triangle list indices of lineCount separate grid
+// lines, each expanded to four vertices by lineJoinVertices
+func gridIndices(lineCount int) []uint32 {
+	indices := make([]uint32, 0, lineCount*6)
+	for i := 0; i < lineCount; i++ {
+		v := uint32(i * 4)
+		indices = append(indices, v, v+1, v+2, v+1, v+3, v+2)
+	}
+	return indices
+}
+
+// chartArea returns the plot area inside the padding in pixels
+func (cr *ChartRenderer) chartArea() (left, top, width, height float64) {
+	padding := cr.getPadding()
+	left, top = float64(padding["left"]), float64(padding["top"])
+	width = float64(cr.Canvas.Width) - left - float64(padding["right"])
+	height = float64(cr.Canvas.Height) - top - float64(padding["bottom"])
+	return left, top, width, height
+}
+
+// axisGridPixels returns the pixel end points of the grid line at each tick
+// of an axis, matching dataToClip in the chart shaders
+func (cr *ChartRenderer) axisGridPixels(axisType string, ticks []float64) [][2][2]float64 {
+	left, top, width, height := cr.chartArea()
+	lines := make([][2][2]float64, 0, len(ticks))
+	for _, tick := range ticks {
+		if axisType == "y" {
+			ny := (tick - cr.DataYRange[0]) / (cr.DataYRange[1] - cr.DataYRange[0])
+			y := top + (1-ny)*height
+			lines = append(lines, [2][2]float64{{left, y}, {left + width, y}})
+			continue
+		}
+		nx := (tick - cr.DataXRange[0]) / (cr.DataXRange[1] - cr.DataXRange[0])
+		x := left + nx*width
+		lines = append(lines, [2][2]float64{{x, top}, {x, top + height}})
+	}
+	return lines
+}
+
+// axisLabels places the tick labels of an axis next to the plot area, one
+// per grid line. The shaders map pixel y straight to clip space y, which
+// points up, so on screen pixel y is measured from the bottom of the canvas.
+func (cr *ChartRenderer) axisLabels(opts axisOptions, ticks []float64, step float64, lines [][2][2]float64) []axisLabel {
+	left, top, width, height := cr.chartArea()
+	canvasHeight := float64(cr.Canvas.Height)
+
+	labels := make([]axisLabel, 0, len(ticks))
+	for i, tick := range ticks {
+		label := axisLabel{Text: formatTick(tick, step, opts.labelFormat, opts.timeScale), Position: opts.position}
+		if opts.axisType == "y" {
+			label.Y = canvasHeight - lines[i][0][1]
+			label.X = left + width + axisLabelGap
+			if opts.position == AxisLeft {
+				label.X = left - axisLabelGap
+			}
+		} else {
+			label.X = lines[i][0][0]
+			label.Y = canvasHeight - top + axisLabelGap
+			if opts.position == AxisTop {
+				label.Y = canvasHeight - top - height - axisLabelGap
+			}
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// updateDataRanges sets the data ranges from the series before anything is
+// drawn, so the axes can be drawn behind the series. As when rendering the
+// series, the last series with data sets the ranges.
+func (cr *ChartRenderer) updateDataRanges() {
+	for _, series := range cr.CandlestickSeries {
+		if data, ok := series.Properties["data"]; ok {
+			cr.calculateDataRanges(cr.visibleCandles(cr.extractOHLCVData(data)))
+		}
+	}
+	for _, series := range cr.LineSeries {
+		if points, ok := series.Properties["data"].([]interface{}); ok && len(points) >= 2 {
+			cr.calculateLineDataRanges(points)
+		}
+	}
+}
+
+// renderAxes draws the grid lines of each axis at its tick values and
+// updates the tick labels overlaid on the canvas. Axis i writes the uniform
+// buffer slot at firstSlot+i.
+func (cr *ChartRenderer) renderAxes(pass js.Value, firstSlot int) {
+	var labels []axisLabel
+	for i, axis := range cr.AxisSeries {
+		opts := newAxisOptions(axis)
+		span := cr.DataXRange
+		if opts.axisType == "y" {
+			span = cr.DataYRange
+		}
+		ticks, step := axisTicks(span[0], span[1], opts.tickCount, opts.timeScale)
+		if len(ticks) == 0 {
+			continue
+		}
+		lines := cr.axisGridPixels(opts.axisType, ticks)
+		labels = append(labels, cr.axisLabels(opts, ticks, step, lines)...)
+
+		if opts.gridLines {
+			cr.drawAxisGrid(pass, lines, opts.gridColor, (firstSlot+i)*chartUniformStride)
+		}
+	}
+	cr.updateAxisLabels(labels)
+}
+
+// drawAxisGrid draws grid lines with the blended grid pipeline using the
+// uniform buffer slot at uniformOffset
+func (cr *ChartRenderer) drawAxisGrid(pass js.Value, lines [][2][2]float64, color Vec4, uniformOffset int) {
+	width, height := float64(cr.Canvas.Width), float64(cr.Canvas.Height)
+	vertices := make([]float32, 0, len(lines)*8)
+	distances := make([]float32, 0, len(lines)*4)
+	for _, line := range lines {
+		vertices = append(vertices, lineJoinVertices(line[:], axisGridWidth/2.0, width, height)...)
+		distances = append(distances, lineVertexDistances(line[:])...)
+	}
+
+	ctx := cr.Canvas.GPUContext
+	vertexBuffer, err := CreateVertexBuffer(ctx, vertices, "axis-grid-vertices")
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to create axis grid vertex buffer: %v", err))
+		return
+	}
+	cr.AxisBuffers = append(cr.AxisBuffers, vertexBuffer)
+	distanceBuffer, err := CreateVertexBuffer(ctx, distances, "axis-grid-distances")
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to create axis grid distance buffer: %v", err))
+		return
+	}
+	cr.AxisBuffers = append(cr.AxisBuffers, distanceBuffer)
+	indexBuffer, err := CreateIndexBuffer32(ctx, gridIndices(len(lines)), "axis-grid-indices")
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to create axis grid index buffer: %v", err))
+		return
+	}
+	cr.AxisBuffers = append(cr.AxisBuffers, indexBuffer)
+
+	uniformData := cr.createLineUniforms(color, axisGridWidth, false, color, axisGridDepth, [2]float32{})
+	if err := ctx.WriteBuffer(cr.UniformBuffer.Buffer, uniformOffset, uniformData); err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to write axis uniform data: %v", err))
+		return
+	}
+
+	pass.Call("setPipeline", cr.AxisGridPipeline.Pipeline)
+	pass.Call("setBindGroup", 0, cr.createLineIndexedBindGroup(cr.AxisGridPipeline, uniformOffset))
+	pass.Call("setVertexBuffer", 0, vertexBuffer.Buffer)
+	pass.Call("setVertexBuffer", 1, distanceBuffer.Buffer)
+	pass.Call("setIndexBuffer", indexBuffer.Buffer, "uint32")
+	pass.Call("drawIndexed", len(lines)*6, 1, 0, 0, 0)
+}
+
+// updateAxisLabels shows the tick labels in an element laid over the canvas.
+// The labels are rebuilt only when they change.
+func (cr *ChartRenderer) updateAxisLabels(labels []axisLabel) {
+	canvas := cr.Canvas.Canvas
+	if !isElement(canvas) || !isElement(canvas.Get("parentNode")) {
+		return
+	}
+
+	key := fmt.Sprint(labels, canvas.Get("clientWidth").Int(), canvas.Get("clientHeight").Int())
+	if key == cr.axisLabelKey && isElement(cr.AxisOverlay) {
+		return
+	}
+	cr.axisLabelKey = key
+
+	doc := js.Global().Get("document")
+	if !isElement(cr.AxisOverlay) {
+		cr.AxisOverlay = doc.Call("createElement", "div")
+		cr.AxisOverlay.Set("className", "chart-axis-labels")
+		canvas.Get("parentNode").Call("insertBefore", cr.AxisOverlay, canvas.Get("nextSibling"))
+	}
+
+	// The overlay shares the canvas' offset parent, so it covers the canvas
+	// when placed at its offset
+	clientWidth := canvas.Get("clientWidth").Float()
+	clientHeight := canvas.Get("clientHeight").Float()
+	cr.AxisOverlay.Call("setAttribute", "style", fmt.Sprintf(
+		"position: absolute; left: %dpx; top: %dpx; width: %gpx; height: %gpx; pointer-events: none; "+
+			"font: 11px sans-serif; color: rgba(160, 160, 170, 1)",
+		canvas.Get("offsetLeft").Int(), canvas.Get("offsetTop").Int(), clientWidth, clientHeight))
+	cr.AxisOverlay.Set("textContent", "")
+
+	// Labels are placed in canvas pixels, which CSS may scale
+	scaleX, scaleY := 1.0, 1.0
+	if clientWidth > 0 && clientHeight > 0 {
+		scaleX = clientWidth / float64(cr.Canvas.Width)
+		scaleY = clientHeight / float64(cr.Canvas.Height)
+	}
+	for _, label := range labels {
+		elem := doc.Call("createElement", "span")
+		elem.Set("className", "chart-axis-label")
+		elem.Set("textContent", label.Text)
+		elem.Call("setAttribute", "style", fmt.Sprintf(
+			"position: absolute; left: %gpx; top: %gpx; transform: %s; white-space: nowrap",
+			label.X*scaleX, label.Y*scaleY, axisLabelTransform(label.Position)))
+		cr.AxisOverlay.Call("appendChild", elem)
+	}
+}
+
+// axisLabelTransform anchors a label on the side of its tick facing the
+// plot area
+func axisLabelTransform(position string) string {
+	switch position {
+	case AxisLeft:
+		return "translate(-100%, -50%)"
+	case AxisRight:
+		return "translate(0, -50%)"
+	case AxisTop:
+		return "translate(-50%, -100%)"
+	default:
+		return "translate(-50%, 0)"
+	}
+}
+
+// removeAxisLabels removes the tick label overlay
+func (cr *ChartRenderer) removeAxisLabels() {
+	if isElement(cr.AxisOverlay) {
+		cr.AxisOverlay.Call("remove")
+	}
+	cr.AxisOverlay = js.Undefined()
+	cr.axisLabelKey = ""
+}
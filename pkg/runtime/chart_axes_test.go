@@ -0,0 +1,122 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAxisTicks(t *testing.T) {
+	ticks, step := axisTicks(3, 97, 6, false)
+	if step != 20 {
+		t.Errorf("Expected step 20, got %v", step)
+	}
+	want := []float64{20, 40, 60, 80}
+	if len(ticks) != len(want) {
+		t.Fatalf("Expected ticks %v, got %v", want, ticks)
+	}
+	for i, tick := range want {
+		if ticks[i] != tick {
+			t.Errorf("Tick %d: expected %v, got %v", i, tick, ticks[i])
+		}
+	}
+
+	// Fractional steps are rounded to their precision
+	ticks, step = axisTicks(0.1, 0.75, 6, false)
+	if step != 0.1 {
+		t.Errorf("Expected step 0.1, got %v", step)
+	}
+	if len(ticks) != 7 || ticks[2] != 0.3 {
+		t.Errorf("Expected 0.1 to 0.7 without rounding errors, got %v", ticks)
+	}
+
+	if ticks, _ := axisTicks(5, 5, 6, false); ticks != nil {
+		t.Errorf("Expected no ticks for an empty range, got %v", ticks)
+	}
+	if ticks, _ := axisTicks(0, math.Inf(1), 6, false); ticks != nil {
+		t.Errorf("Expected no ticks for an infinite range, got %v", ticks)
+	}
+}
+
+func TestAxisTicksTimeScale(t *testing.T) {
+	// Five days of candles get daily ticks at midnight
+	start := float64(1701388800000) // 2023-12-01 00:00 UTC
+	ticks, step := axisTicks(start+3600000, start+5*86400000, 6, true)
+	if step != 86400000 {
+		t.Errorf("Expected a daily step, got %v", step)
+	}
+	if len(ticks) != 5 || ticks[0] != start+86400000 {
+		t.Errorf("Expected 5 ticks from Dec 02, got %v", ticks)
+	}
+}
+
+func TestFormatTick(t *testing.T) {
+	tests := []struct {
+		value, step float64
+		format      string
+		timeScale   bool
+		want        string
+	}{
+		{40, 20, "", false, "40"},
+		{0.3, 0.1, "", false, "0.3"},
+		{0.25, 0.05, "", false, "0.25"},
+		{37500, 500, "$%.2f", false, "$37500.00"},
+		{1701388800000, 86400000, "", true, "Dec 01"},
+		{1701388800000 + 9*3600000, 3600000, "", true, "09:00"},
+		{1701388800000 + 30000, 15000, "", true, "00:00:30"},
+		{1701388800000, 86400000, "date", true, "2023-12-01"},
+		{1701388800000, 86400000, "2006/01/02", true, "2023/12/01"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTick(tt.value, tt.step, tt.format, tt.timeScale); got != tt.want {
+			t.Errorf("formatTick(%v, %v, %q, %v) = %q, want %q", tt.value, tt.step, tt.format, tt.timeScale, got, tt.want)
+		}
+	}
+}
+
+func TestGridIndices(t *testing.T) {
+	indices := gridIndices(2)
+	want := []uint32{0, 1, 2, 1, 3, 2, 4, 5, 6, 5, 7, 6}
+	if len(indices) != len(want) {
+		t.Fatalf("Expected %d indices, got %d", len(want), len(indices))
+	}
+	for i, index := range want {
+		if indices[i] != index {
+			t.Errorf("Index %d: expected %d, got %d", i, index, indices[i])
+		}
+	}
+}
+
+func TestAxisGridAndLabels(t *testing.T) {
+	cr := &ChartRenderer{
+		Canvas:     &GPUCanvas{Width: 400, Height: 300},
+		Padding:    map[string]float32{"top": 60, "right": 20, "bottom": 40, "left": 80},
+		DataXRange: [2]float64{0, 100},
+		DataYRange: [2]float64{0, 10},
+	}
+
+	// Plot area is x 80..380, y 60..260
+	xOpts := newAxisOptions(XAxis(TickCount(3)))
+	xTicks, xStep := axisTicks(0, 100, xOpts.tickCount, false)
+	xLines := cr.axisGridPixels("x", xTicks)
+	if len(xLines) != 3 || xLines[1] != [2][2]float64{{230, 60}, {230, 260}} {
+		t.Errorf("Expected vertical grid lines at 80, 230 and 380, got %v", xLines)
+	}
+	xLabels := cr.axisLabels(xOpts, xTicks, xStep, xLines)
+	if xLabels[1].Text != "50" || xLabels[1].X != 230 || xLabels[1].Y != 300-60+axisLabelGap {
+		t.Errorf("Unexpected x label %+v", xLabels[1])
+	}
+
+	yOpts := newAxisOptions(YAxis(AxisPosition(AxisLeft), LabelFormat("%.1f")))
+	yTicks, yStep := axisTicks(0, 10, yOpts.tickCount, false)
+	yLines := cr.axisGridPixels("y", yTicks)
+	if yLines[0] != [2][2]float64{{80, 260}, {380, 260}} {
+		t.Errorf("Expected the first horizontal grid line at y 260, got %v", yLines[0])
+	}
+	yLabels := cr.axisLabels(yOpts, yTicks, yStep, yLines)
+	if yLabels[0].Text != "0.0" || yLabels[0].X != 80-axisLabelGap || yLabels[0].Y != 40 {
+		t.Errorf("Unexpected y label %+v", yLabels[0])
+	}
+}
@@ -0,0 +1,205 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+)
+
+// lineMiterLimit caps the length of a mitred joint, in multiples of the half
+// stroke width, so sharp turns don't produce long spikes
+const lineMiterLimit = 4
+
+// lineIndices returns the triangle list indices of a line with pointCount
+// points expanded to two vertices per point. Segment i uses the vertices of
+// points i and i+1 in the same order as the instanced vs_line quad.
+func lineIndices(pointCount int) []uint32 {
+	if pointCount < 2 {
+		return nil
+	}
+	indices := make([]uint32, 0, (pointCount-1)*6)
+	for i := 0; i < pointCount-1; i++ {
+		v := uint32(i * 2)
+		indices = append(indices, v, v+1, v+2, v+1, v+3, v+2)
+	}
+	return indices
+}
+
+// lineJoinVertices expands a polyline in pixel coordinates to two clip space
+// vertices per point, offset by halfWidth along the mitred normal of the
+// joint. The result holds x, y pairs.
+func lineJoinVertices(pixels [][2]float64, halfWidth, width, height float64) []float32 {
+	vertices := make([]float32, 0, len(pixels)*4)
+	for i, p := range pixels {
+		var in, out [2]float64
+		if i > 0 {
+			in = lineDirection(pixels[i-1], p)
+		}
+		if i < len(pixels)-1 {
+			out = lineDirection(p, pixels[i+1])
+		}
+		if i == 0 {
+			in = out
+		}
+		if i == len(pixels)-1 {
+			out = in
+		}
+
+		// The joint normal bisects the two segment normals; its length grows
+		// as the turn sharpens so both edges stay halfWidth from the centre
+		tangent := [2]float64{in[0] + out[0], in[1] + out[1]}
+		if l := math.Hypot(tangent[0], tangent[1]); l > 1e-9 {
+			tangent = [2]float64{tangent[0] / l, tangent[1] / l}
+		} else {
+			tangent = in
+		}
+		normal := [2]float64{-tangent[1], tangent[0]}
+		if normal == [2]float64{} {
+			normal = [2]float64{0, 1}
+		}
+
+		miter := halfWidth
+		if cos := normal[0]*-in[1] + normal[1]*in[0]; cos > 1/float64(lineMiterLimit) {
+			miter = halfWidth / cos
+		} else if in != [2]float64{} {
+			miter = halfWidth * lineMiterLimit
+		}
+
+		for _, side := range []float64{-1, 1} {
+			x := p[0] + side*normal[0]*miter
+			y := p[1] + side*normal[1]*miter
+			vertices = append(vertices, float32(x/width*2-1), float32(y/height*2-1))
+		}
+	}
+	return vertices
+}
+
+// lineDirection returns the unit direction from a to b, or zero when the
+// points coincide
+func lineDirection(a, b [2]float64) [2]float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	l := math.Hypot(dx, dy)
+	if l < 1e-9 {
+		return [2]float64{}
+	}
+	return [2]float64{dx / l, dy / l}
+}
+
+// lineDistances returns the length of a polyline in pixel coordinates up to
+// each of its points, which places the dashes of a dashed line
+func lineDistances(pixels [][2]float64) []float32 {
+	distances := make([]float32, len(pixels))
+	total := 0.0
+	for i := 1; i < len(pixels); i++ {
+		total += math.Hypot(pixels[i][0]-pixels[i-1][0], pixels[i][1]-pixels[i-1][1])
+		distances[i] = float32(total)
+	}
+	return distances
+}
+
+// lineVertexDistances returns the distance of each vertex of
+// lineJoinVertices, where both vertices of a point share its distance
+func lineVertexDistances(pixels [][2]float64) []float32 {
+	distances := lineDistances(pixels)
+	vertices := make([]float32, 0, len(distances)*2)
+	for _, d := range distances {
+		vertices = append(vertices, d, d)
+	}
+	return vertices
+}
+
+// lineDashPattern returns the dash and gap lengths in pixels of a line
+// series from its DashPattern or StrokeStyle; a solid line has none
+func lineDashPattern(props map[string]interface{}, strokeWidth float32) [2]float32 {
+	if pattern, ok := props["dashPattern"].([2]float32); ok {
+		return pattern
+	}
+	switch props["strokeStyle"] {
+	case StrokeDashed:
+		return [2]float32{4 * strokeWidth, 2 * strokeWidth}
+	case StrokeDotted:
+		return [2]float32{strokeWidth, 2 * strokeWidth}
+	default:
+		return [2]float32{}
+	}
+}
+
+// linePixels converts line points from data to pixel coordinates, matching
+// dataToClip in the line shader
+func (cr *ChartRenderer) linePixels(points []interface{}) [][2]float64 {
+	left, top, chartWidth, chartHeight := cr.chartArea()
+
+	pixels := make([][2]float64, 0, len(points))
+	for _, p := range points {
+		pointMap, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		x, _ := pointMap["X"].(float64)
+		y, _ := pointMap["Y"].(float64)
+
+		nx := (x - cr.DataXRange[0]) / (cr.DataXRange[1] - cr.DataXRange[0])
+		ny := (y - cr.DataYRange[0]) / (cr.DataYRange[1] - cr.DataYRange[0])
+		pixels = append(pixels, [2]float64{left + nx*chartWidth, top + (1-ny)*chartHeight})
+	}
+	return pixels
+}
+
+// createLineVertexBuffer uploads the mitred vertices of a line series
+func (cr *ChartRenderer) createLineVertexBuffer(pixels [][2]float64, strokeWidth float32) *GPUBuffer {
+	vertices := lineJoinVertices(pixels, float64(strokeWidth)/2, float64(cr.Canvas.Width), float64(cr.Canvas.Height))
+	buffer, err := CreateVertexBuffer(cr.Canvas.GPUContext, vertices, "line-vertices")
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to create line vertex buffer: %v", err))
+		return nil
+	}
+	return buffer
+}
+
+// createLineDistanceBuffer uploads the distance along the line of each
+// vertex from lineJoinVertices, the second vertex buffer of vs_line_indexed
+func (cr *ChartRenderer) createLineDistanceBuffer(pixels [][2]float64) *GPUBuffer {
+	buffer, err := CreateVertexBuffer(cr.Canvas.GPUContext, lineVertexDistances(pixels), "line-distances")
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to create line distance buffer: %v", err))
+		return nil
+	}
+	return buffer
+}
+
+// createLineIndexBuffer uploads the triangle indices of a line with
+// pointCount points. For a 5000-point line the instanced path runs vs_line
+// 6 × 4999 = 29,994 times, converting both segment ends to clip space each
+// time; the indexed path shades each of the 10,000 shared vertices once and
+// reuses them across the same 29,994 indices, a third of the vertex work,
+// and mitred joints no longer overlap.
+func (cr *ChartRenderer) createLineIndexBuffer(pointCount int) *GPUBuffer {
+	buffer, err := CreateIndexBuffer32(cr.Canvas.GPUContext, lineIndices(pointCount), "line-indices")
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to create line index buffer: %v", err))
+		return nil
+	}
+	return buffer
+}
+
+// lineIndexBuffer returns LineIndexBuffer, growing it for a line of
+// pointCount points. The indices of a shorter line are a prefix of a longer
+// one's, so the buffer is only uploaded for a line longer than any before.
+// The buffer it replaces may still be bound by an earlier series of the
+// frame, so it is released with the frame's line buffers.
+func (cr *ChartRenderer) lineIndexBuffer(pointCount int) *GPUBuffer {
+	size := (pointCount - 1) * 6 * 4
+	if cr.LineIndexBuffer != nil && cr.LineIndexBuffer.Size >= size {
+		return cr.LineIndexBuffer
+	}
+	buffer := cr.createLineIndexBuffer(pointCount)
+	if buffer == nil {
+		return nil
+	}
+	if cr.LineIndexBuffer != nil {
+		cr.LineBuffers = append(cr.LineBuffers, cr.LineIndexBuffer)
+	}
+	cr.LineIndexBuffer = buffer
+	return buffer
+}
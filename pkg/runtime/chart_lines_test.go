@@ -0,0 +1,241 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"math"
+	"syscall/js"
+	"testing"
+)
+
+func TestLineIndicesFor5000Points(t *testing.T) {
+	const points = 5000
+	indices := lineIndices(points)
+
+	// Same triangle count as the instanced path: 6 per segment
+	instancedVertices := 6 * (points - 1)
+	if len(indices) != instancedVertices {
+		t.Fatalf("Expected %d indices, got %d", instancedVertices, len(indices))
+	}
+
+	// But only two unique vertices per point are shaded
+	maxIndex := uint32(0)
+	for _, index := range indices {
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
+	if unique := int(maxIndex) + 1; unique != 2*points {
+		t.Errorf("Expected %d unique vertices, got %d", 2*points, unique)
+	}
+
+	// The first segment matches the vs_line quad order
+	want := []uint32{0, 1, 2, 1, 3, 2}
+	for i, index := range want {
+		if indices[i] != index {
+			t.Errorf("Index %d: expected %d, got %d", i, index, indices[i])
+		}
+	}
+
+	if lineIndices(1) != nil {
+		t.Error("Expected no indices for a single point")
+	}
+}
+
+func TestLineJoinVerticesMiter(t *testing.T) {
+	// A right angle: along +x, then along +y
+	pixels := [][2]float64{{0, 0}, {10, 0}, {10, 10}}
+	const halfWidth = 1.0
+	vertices := lineJoinVertices(pixels, halfWidth, 2, 2)
+
+	if len(vertices) != len(pixels)*4 {
+		t.Fatalf("Expected %d floats, got %d", len(pixels)*4, len(vertices))
+	}
+
+	// Clip space maps back to pixels as (v+1) * size / 2 with size 2
+	pixel := func(v int) (float64, float64) {
+		return float64(vertices[v*2]) + 1, float64(vertices[v*2+1]) + 1
+	}
+
+	// Endpoints are offset by exactly the half width
+	x, y := pixel(0)
+	if math.Abs(x-0) > 1e-4 || math.Abs(math.Abs(y)-halfWidth) > 1e-4 {
+		t.Errorf("Expected start vertex at (0, ±%v), got (%v, %v)", halfWidth, x, y)
+	}
+
+	// The joint is offset along the bisector by halfWidth / cos(45°)
+	x, y = pixel(2)
+	dist := math.Hypot(x-10, y-0)
+	if want := halfWidth * math.Sqrt2; math.Abs(dist-want) > 1e-4 {
+		t.Errorf("Expected miter offset %v, got %v", want, dist)
+	}
+}
+
+func TestChartIndexedLinesProp(t *testing.T) {
+	chart := ChartNode(ChartIndexedLines(true), LineSeries())
+	if indexed, ok := chart.Properties["indexedLines"].(bool); !ok || !indexed {
+		t.Error("Expected ChartIndexedLines to set the indexedLines property")
+	}
+}
+
+func TestLineDistances(t *testing.T) {
+	pixels := [][2]float64{{0, 0}, {3, 4}, {3, 10}}
+	distances := lineDistances(pixels)
+
+	want := []float32{0, 5, 11}
+	for i, d := range want {
+		if distances[i] != d {
+			t.Errorf("Point %d: expected distance %f, got %f", i, d, distances[i])
+		}
+	}
+
+	// Both vertices of a point share its distance
+	vertices := lineVertexDistances(pixels)
+	if len(vertices) != len(pixels)*2 {
+		t.Fatalf("Expected %d vertex distances, got %d", len(pixels)*2, len(vertices))
+	}
+	if vertices[2] != 5 || vertices[3] != 5 {
+		t.Errorf("Expected both vertices of point 1 at 5, got %f and %f", vertices[2], vertices[3])
+	}
+}
+
+func TestLineDashPattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		props []interface{}
+		want  [2]float32
+	}{
+		{"solid by default", nil, [2]float32{}},
+		{"solid", []interface{}{StrokeStyle(StrokeSolid)}, [2]float32{}},
+		{"dashed", []interface{}{StrokeStyle(StrokeDashed)}, [2]float32{8, 4}},
+		{"dotted", []interface{}{StrokeStyle(StrokeDotted)}, [2]float32{2, 4}},
+		{"explicit pattern", []interface{}{StrokeStyle(StrokeDotted), DashPattern(10, 5)}, [2]float32{10, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := LineSeries(tt.props...)
+			if got := lineDashPattern(series.Properties, 2); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// newLineRenderer returns a renderer drawing into a fake pass on a device
+// that records the buffers it creates
+func newLineRenderer(t *testing.T) (*ChartRenderer, js.Value, js.Value) {
+	t.Helper()
+
+	device := js.Global().Call("eval", `(() => {
+		const device = {
+			buffers: [],
+			createBuffer(desc) {
+				const buffer = {label: desc.label, size: desc.size, destroyed: false, destroy() { this.destroyed = true; }};
+				this.buffers.push(buffer);
+				return buffer;
+			},
+			createBindGroup() { return {}; },
+		};
+		device.queue = {writeBuffer() {}};
+		return device;
+	})()`)
+	pass := js.Global().Call("eval", `({
+		setPipeline() {}, setBindGroup() {}, setVertexBuffer() {}, setIndexBuffer() {},
+		draw() {}, drawIndexed() {},
+	})`)
+	pipeline := &RenderPipeline{Pipeline: js.Global().Call("eval", `({getBindGroupLayout() { return {}; }})`)}
+
+	var uniformDestroyed bool
+	cr := &ChartRenderer{
+		Canvas:              &GPUCanvas{GPUContext: &GPUContext{Device: device, Queue: device.Get("queue")}, Width: 300, Height: 150},
+		LinePipeline:        pipeline,
+		LineFillPipeline:    pipeline,
+		LineIndexedPipeline: pipeline,
+		UniformBuffer:       fakeGPUBuffer(t, &uniformDestroyed),
+	}
+	return cr, device, pass
+}
+
+// testLineSeries returns a line series with n points
+func testLineSeries(n int) *GPUNode {
+	points := make([]interface{}, n)
+	for i := range points {
+		points[i] = map[string]interface{}{"X": float64(i), "Y": float64(i % 3)}
+	}
+	series := LineSeries()
+	series.Properties["data"] = points
+	return series
+}
+
+// liveBuffers counts the buffers with a label that haven't been destroyed
+func liveBuffers(device js.Value, label string) int {
+	buffers := device.Get("buffers")
+	live := 0
+	for i := 0; i < buffers.Length(); i++ {
+		if buffers.Index(i).Get("label").String() == label && !buffers.Index(i).Get("destroyed").Bool() {
+			live++
+		}
+	}
+	return live
+}
+
+func TestLineSeriesBuffersReleasedEachFrame(t *testing.T) {
+	cr, device, pass := newLineRenderer(t)
+	series := []*GPUNode{testLineSeries(10), testLineSeries(20)}
+
+	for frame := 0; frame < 3; frame++ {
+		cr.releaseDataBuffers()
+		for i, s := range series {
+			cr.renderLineSeries(pass, s, i*chartUniformStride)
+		}
+		if n := liveBuffers(device, "line-data"); n != len(series) {
+			t.Fatalf("Frame %d: expected %d live line buffers, got %d", frame, len(series), n)
+		}
+	}
+
+	cr.Cleanup()
+	if n := liveBuffers(device, "line-data"); n != 0 {
+		t.Errorf("Expected every line buffer to be destroyed, %d left", n)
+	}
+}
+
+func TestIndexedLineBuffersReleasedEachFrame(t *testing.T) {
+	cr, device, pass := newLineRenderer(t)
+	cr.UseIndexedLines = true
+	series := []*GPUNode{testLineSeries(10), testLineSeries(20)}
+
+	for frame := 0; frame < 3; frame++ {
+		cr.releaseDataBuffers()
+		for i, s := range series {
+			cr.renderLineSeries(pass, s, i*chartUniformStride)
+		}
+		for _, label := range []string{"line-data", "line-vertices", "line-distances"} {
+			if n := liveBuffers(device, label); n != len(series) {
+				t.Fatalf("Frame %d: expected %d live %s buffers, got %d", frame, len(series), label, n)
+			}
+		}
+	}
+
+	// The index buffer grew once, for the longer line, and is reused after
+	created := 0
+	buffers := device.Get("buffers")
+	for i := 0; i < buffers.Length(); i++ {
+		if buffers.Index(i).Get("label").String() == "line-indices" {
+			created++
+		}
+	}
+	if created != 2 {
+		t.Errorf("Expected 2 index buffer uploads over 3 frames, got %d", created)
+	}
+	if n := liveBuffers(device, "line-indices"); n != 1 || cr.LineIndexBuffer.Size != 19*6*4 {
+		t.Errorf("Expected one live index buffer for 20 points, got %d", n)
+	}
+
+	cr.Cleanup()
+	for _, label := range []string{"line-data", "line-vertices", "line-distances", "line-indices"} {
+		if n := liveBuffers(device, label); n != 0 {
+			t.Errorf("Expected every %s buffer to be destroyed, %d left", label, n)
+		}
+	}
+}
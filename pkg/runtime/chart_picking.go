@@ -0,0 +1,89 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"math"
+	"sort"
+)
+
+// CandleAt returns the index of the candle under the pixel at px, py, e.g.
+// to show a tooltip for the candle under the cursor:
+//
+//	index, ok := renderer.CandleAt(e.OffsetX, e.OffsetY)
+//
+// Positions are in canvas pixels from its top left corner. They are mapped
+// back through the data ranges of the last render, so no GPU readback is
+// needed. A candle covers its slot, its body plus half the gap on either
+// side; ok is false outside the plot area, between slots, or without
+// candle data. The index counts from the first candle of the data, not of
+// the viewport.
+func (cr *ChartRenderer) CandleAt(px, py float64) (index int, ok bool) {
+	if !cr.inPlotArea(px, py) {
+		return 0, false
+	}
+	all := cr.pickCandles()
+	start, end := cr.visibleRange(len(all))
+	candles := all[start:end]
+	if len(candles) == 0 {
+		return 0, false
+	}
+
+	x := cr.dataX(px)
+
+	// Timestamps are in ascending order; the nearest candle is the first
+	// one at or after x, or the one before it
+	i := sort.Search(len(candles), func(i int) bool {
+		return float64(candles[i].Timestamp) >= x
+	})
+	if i == len(candles) || (i > 0 && x-float64(candles[i-1].Timestamp) < float64(candles[i].Timestamp)-x) {
+		i--
+	}
+
+	// Candles are drawn 0.8 of a slot wide, centred on their timestamp
+	slot := (cr.DataXRange[1] - cr.DataXRange[0]) / float64(len(candles))
+	if math.Abs(x-float64(candles[i].Timestamp)) > slot/2 {
+		return 0, false
+	}
+	return start + i, true
+}
+
+// ValueAt returns the value of the y axis at the pixel row py, e.g. the
+// price under the cursor
+func (cr *ChartRenderer) ValueAt(py float64) float64 {
+	_, top, _, height := cr.chartArea()
+
+	// The shaders map pixel y straight to clip space y, which points up,
+	// so on screen the plot area is measured from the bottom of the canvas
+	ny := 1 - (float64(cr.Canvas.Height)-py-top)/height
+	return cr.DataYRange[0] + ny*(cr.DataYRange[1]-cr.DataYRange[0])
+}
+
+// dataX returns the value of the x axis at the pixel column px
+func (cr *ChartRenderer) dataX(px float64) float64 {
+	left, _, width, _ := cr.chartArea()
+	nx := (px - left) / width
+	return cr.DataXRange[0] + nx*(cr.DataXRange[1]-cr.DataXRange[0])
+}
+
+// inPlotArea checks if the pixel at px, py is inside the padding of the
+// chart, as drawn on screen
+func (cr *ChartRenderer) inPlotArea(px, py float64) bool {
+	left, top, width, height := cr.chartArea()
+	bottom := float64(cr.Canvas.Height) - top
+	return px >= left && px <= left+width && py >= bottom-height && py <= bottom
+}
+
+// pickCandles returns the candles of the last candlestick series with data,
+// which also set the data ranges
+func (cr *ChartRenderer) pickCandles() []ohlcvData {
+	var candles []ohlcvData
+	for _, series := range cr.CandlestickSeries {
+		if data, ok := series.Properties["data"]; ok {
+			if extracted := cr.extractOHLCVData(data); len(extracted) > 0 {
+				candles = extracted
+			}
+		}
+	}
+	return candles
+}
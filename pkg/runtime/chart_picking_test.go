@@ -0,0 +1,88 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+// pickingCandle matches the fields extractOHLCVData reads
+type pickingCandle struct {
+	Timestamp              int64
+	Open, High, Low, Close float64
+	Volume                 float64
+}
+
+// newPickingRenderer returns a renderer with a 740x560 plot area starting
+// 40px from the left and 10px from the bottom of an 800x600 canvas
+func newPickingRenderer() *ChartRenderer {
+	candles := []pickingCandle{{Timestamp: 0}, {Timestamp: 1000}, {Timestamp: 2000}, {Timestamp: 3000}}
+	return &ChartRenderer{
+		Canvas:            &GPUCanvas{Width: 800, Height: 600},
+		Padding:           map[string]float32{"top": 10, "right": 20, "bottom": 30, "left": 40},
+		DataXRange:        [2]float64{0, 3000},
+		DataYRange:        [2]float64{100, 200},
+		CandlestickSeries: []*GPUNode{CandlestickSeries(ChartData(candles))},
+	}
+}
+
+func TestCandleAt(t *testing.T) {
+	cr := newPickingRenderer()
+
+	// Pixel column of a timestamp
+	px := func(x float64) float64 { return 40 + x/3000*740 }
+
+	tests := []struct {
+		name      string
+		px, py    float64
+		wantIndex int
+		wantOK    bool
+	}{
+		{"first candle", px(0), 300, 0, true},
+		{"on a candle", px(1000), 300, 1, true},
+		{"within the slot", px(1300), 300, 1, true},
+		{"nearer the next candle", px(1700), 300, 2, true},
+		{"last candle", px(3000), 300, 3, true},
+		{"between slots", px(1500), 300, 0, false},
+		{"left of the plot area", 30, 300, 0, false},
+		{"right of the plot area", 790, 300, 0, false},
+		{"above the plot area", px(1000), 20, 0, false},
+		{"below the plot area", px(1000), 595, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, ok := cr.CandleAt(tt.px, tt.py)
+			if ok != tt.wantOK || index != tt.wantIndex {
+				t.Errorf("CandleAt(%.1f, %.1f) = %d, %v, want %d, %v", tt.px, tt.py, index, ok, tt.wantIndex, tt.wantOK)
+			}
+		})
+	}
+
+	if _, ok := (&ChartRenderer{Canvas: cr.Canvas, Padding: cr.Padding}).CandleAt(px(1000), 300); ok {
+		t.Error("Expected no candle without candle data")
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	cr := newPickingRenderer()
+
+	// The shaders measure pixel y up from the bottom of the canvas, so the
+	// top of the range is at the bottom of the plot area
+	tests := []struct {
+		py   float64
+		want float64
+	}{
+		{590, 200},
+		{30, 100},
+		{310, 150},
+		{450, 175},
+	}
+
+	for _, tt := range tests {
+		if got := cr.ValueAt(tt.py); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ValueAt(%.0f) = %f, want %f", tt.py, got, tt.want)
+		}
+	}
+}
@@ -17,6 +17,14 @@ var candlestickShader string
 //go:embed chart/shaders/line.wgsl
 var lineShader string
 
+// chartUniformStride is the size of one series' uniforms. Each series writes
+// its own slot of the uniform buffer so they can differ within a frame; 256
+// is the minimum uniform buffer offset alignment.
+const chartUniformStride = 256
+
+// chartDepthFormat is the depth texture format used when depth is enabled
+const chartDepthFormat = "depth24plus"
+
 // ohlcvData represents extracted OHLCV data
 type ohlcvData struct {
 	Timestamp int64
@@ -34,9 +42,13 @@ type ChartRenderer struct {
 	CandlestickPipeline *RenderPipeline
 	LinePipeline        *RenderPipeline
 	LineFillPipeline    *RenderPipeline
+	LineIndexedPipeline *RenderPipeline
+	AxisGridPipeline    *RenderPipeline
 	UniformBuffer       *GPUBuffer
-	CandleDataBuffer    *GPUBuffer
-	LineDataBuffer      *GPUBuffer
+	LineBuffers         []*GPUBuffer // Line data, vertex and distance buffers of the current frame
+	LineIndexBuffer     *GPUBuffer   // Indices of the longest indexed line so far, shared by every series
+	AxisBuffers         []*GPUBuffer // Grid line vertices and indices of the current frame
+	AxisOverlay         js.Value     // Element holding the tick labels, laid over the canvas
 	BindGroup           js.Value
 	LineBindGroup       js.Value
 	CandlestickModule   js.Value
@@ -49,7 +61,15 @@ type ChartRenderer struct {
 	DataXRange          [2]float64
 	DataYRange          [2]float64
 	CandleWidth         float32
+	DepthEnabled        bool     // Layer series by ZIndex using a depth buffer
+	DepthTexture        js.Value // Depth texture, when DepthEnabled
+	UseIndexedLines     bool     // Draw lines from mitred vertices with drawIndexed
+	ViewportStart       int      // First candle shown, set with SetViewport
+	ViewportCount       int      // Number of candles shown, 0 for all
+	CandleBufferWrites  int      // Writes to candle buffers, to measure redraws
 	initialized         bool
+	axisLabelKey        string                    // Tick labels shown in AxisOverlay
+	candleCaches        map[*GPUNode]*candleCache // Uploaded candles of each series
 }
 
 // NewChartRenderer creates a new chart renderer
@@ -85,6 +105,12 @@ func NewChartRenderer(canvas *GPUCanvas, chart *GPUNode) (*ChartRenderer, error)
 	if pad, ok := chart.Properties["padding"]; ok {
 		renderer.Padding = pad
 	}
+	if depth, ok := chart.Properties["depth"].(bool); ok {
+		renderer.DepthEnabled = depth
+	}
+	if indexed, ok := chart.Properties["indexedLines"].(bool); ok {
+		renderer.UseIndexedLines = indexed
+	}
 
 	// Build chart scene graph
 	if err := renderer.buildChart(chart); err != nil {
@@ -170,8 +196,21 @@ func (cr *ChartRenderer) initialize() error {
 		return fmt.Errorf("failed to create pipelines: %w", err)
 	}
 
-	// Create uniform buffer
-	uniformBuffer, err := CreateUniformBuffer(ctx, 256, "chart-uniforms")
+	// Create depth texture
+	if cr.DepthEnabled {
+		depthTexture, err := cr.Canvas.CreateDepthTexture()
+		if err != nil {
+			return fmt.Errorf("failed to create depth texture: %w", err)
+		}
+		cr.DepthTexture = depthTexture
+	}
+
+	// Create uniform buffer with a slot per series and axis
+	slots := len(cr.CandlestickSeries) + len(cr.LineSeries) + len(cr.AxisSeries)
+	if slots == 0 {
+		slots = 1
+	}
+	uniformBuffer, err := CreateUniformBuffer(ctx, chartUniformStride*slots, "chart-uniforms")
 	if err != nil {
 		return fmt.Errorf("failed to create uniform buffer: %w", err)
 	}
@@ -186,6 +225,11 @@ func (cr *ChartRenderer) initialize() error {
 func (cr *ChartRenderer) createPipelines() error {
 	ctx := cr.Canvas.GPUContext
 
+	depthFormat := ""
+	if cr.DepthEnabled {
+		depthFormat = chartDepthFormat
+	}
+
 	// Candlestick pipeline
 	candlePipeline, err := CreateRenderPipeline(ctx, PipelineConfig{
 		Label:              "candlestick-pipeline",
@@ -194,8 +238,10 @@ func (cr *ChartRenderer) createPipelines() error {
 		VertexEntryPoint:   "vs_main",
 		FragmentEntryPoint: "fs_main",
 		ColorFormat:        cr.Canvas.Format,
+		DepthFormat:        depthFormat,
 		PrimitiveTopology:  PrimitiveTopologyTriangleList,
 		CullMode:           CullModeNone,
+		SampleCount:        cr.Canvas.SampleCount,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create candlestick pipeline: %w", err)
@@ -210,8 +256,10 @@ func (cr *ChartRenderer) createPipelines() error {
 		VertexEntryPoint:   "vs_line",
 		FragmentEntryPoint: "fs_main",
 		ColorFormat:        cr.Canvas.Format,
+		DepthFormat:        depthFormat,
 		PrimitiveTopology:  PrimitiveTopologyTriangleList,
 		CullMode:           CullModeNone,
+		SampleCount:        cr.Canvas.SampleCount,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create line pipeline: %w", err)
@@ -226,14 +274,73 @@ func (cr *ChartRenderer) createPipelines() error {
 		VertexEntryPoint:   "vs_fill",
 		FragmentEntryPoint: "fs_main",
 		ColorFormat:        cr.Canvas.Format,
+		DepthFormat:        depthFormat,
 		PrimitiveTopology:  PrimitiveTopologyTriangleList,
 		CullMode:           CullModeNone,
+		SampleCount:        cr.Canvas.SampleCount,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create line fill pipeline: %w", err)
 	}
 	cr.LineFillPipeline = lineFillPipeline
 
+	// Indexed line pipeline: positions come from a vertex buffer
+	if cr.UseIndexedLines {
+		lineIndexedPipeline, err := CreateRenderPipeline(ctx, PipelineConfig{
+			Label:              "line-indexed-pipeline",
+			VertexShader:       cr.LineModule,
+			FragmentShader:     cr.LineModule,
+			VertexEntryPoint:   "vs_line_indexed",
+			FragmentEntryPoint: "fs_main",
+			VertexBuffers: []map[string]interface{}{
+				CreateVertexBufferLayout(8, []VertexAttribute{
+					{Format: VertexFormatFloat32x2, Offset: 0, ShaderLocation: 0}, // position
+				}),
+				CreateVertexBufferLayout(4, []VertexAttribute{
+					{Format: VertexFormatFloat32, Offset: 0, ShaderLocation: 1}, // distance
+				}),
+			},
+			ColorFormat:       cr.Canvas.Format,
+			DepthFormat:       depthFormat,
+			PrimitiveTopology: PrimitiveTopologyTriangleList,
+			CullMode:          CullModeNone,
+			SampleCount:       cr.Canvas.SampleCount,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create indexed line pipeline: %w", err)
+		}
+		cr.LineIndexedPipeline = lineIndexedPipeline
+	}
+
+	// Axis grid pipeline: indexed lines, blended so grid colors can be
+	// translucent
+	if len(cr.AxisSeries) > 0 {
+		axisGridPipeline, err := CreatePipelineWithBlending(ctx, PipelineConfig{
+			Label:              "axis-grid-pipeline",
+			VertexShader:       cr.LineModule,
+			FragmentShader:     cr.LineModule,
+			VertexEntryPoint:   "vs_line_indexed",
+			FragmentEntryPoint: "fs_main",
+			VertexBuffers: []map[string]interface{}{
+				CreateVertexBufferLayout(8, []VertexAttribute{
+					{Format: VertexFormatFloat32x2, Offset: 0, ShaderLocation: 0}, // position
+				}),
+				CreateVertexBufferLayout(4, []VertexAttribute{
+					{Format: VertexFormatFloat32, Offset: 0, ShaderLocation: 1}, // distance
+				}),
+			},
+			ColorFormat:       cr.Canvas.Format,
+			DepthFormat:       depthFormat,
+			PrimitiveTopology: PrimitiveTopologyTriangleList,
+			CullMode:          CullModeNone,
+			SampleCount:       cr.Canvas.SampleCount,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create axis grid pipeline: %w", err)
+		}
+		cr.AxisGridPipeline = axisGridPipeline
+	}
+
 	return nil
 }
 
@@ -250,11 +357,15 @@ func (cr *ChartRenderer) Render() {
 		log("[ChartRenderer] Initialization complete")
 	}
 
+	// Line and axis buffers are rebuilt every frame; release the ones from the
+	// previous frame now that its commands have been submitted
+	cr.releaseDataBuffers()
+
 	log(fmt.Sprintf("[ChartRenderer] Rendering - Candlestick series: %d, Line series: %d", len(cr.CandlestickSeries), len(cr.LineSeries)))
 
 	// Get canvas texture
 	log("[ChartRenderer] Getting current texture")
-	textureView := cr.Canvas.Context.Call("getCurrentTexture").Call("createView")
+	textureView, resolveTarget := cr.Canvas.colorTarget()
 	if !textureView.Truthy() {
 		logError("[ChartRenderer] Failed to get texture view")
 		return
@@ -280,6 +391,9 @@ func (cr *ChartRenderer) Render() {
 	colorAttachment.Set("clearValue", clearValue)
 	colorAttachment.Set("loadOp", "clear")
 	colorAttachment.Set("storeOp", "store")
+	if resolveTarget.Truthy() {
+		colorAttachment.Set("resolveTarget", resolveTarget)
+	}
 
 	colorAttachments := js.Global().Get("Array").New(1)
 	colorAttachments.SetIndex(0, colorAttachment)
@@ -288,21 +402,49 @@ func (cr *ChartRenderer) Render() {
 	renderPassDesc.Set("label", "chart-render-pass")
 	renderPassDesc.Set("colorAttachments", colorAttachments)
 
+	// Series are layered by depth instead of draw order. The depth texture
+	// follows the size of the canvas.
+	if cr.DepthEnabled && cr.DepthTexture.Truthy() {
+		depthTexture, err := cr.Canvas.resizeDepthTexture(cr.DepthTexture)
+		if err != nil {
+			logError(fmt.Sprintf("Failed to resize depth texture: %v", err))
+			return
+		}
+		cr.DepthTexture = depthTexture
+
+		depthAttachment := js.Global().Get("Object").New()
+		depthAttachment.Set("view", cr.DepthTexture.Call("createView"))
+		depthAttachment.Set("depthClearValue", 1.0)
+		depthAttachment.Set("depthLoadOp", "clear")
+		depthAttachment.Set("depthStoreOp", "store")
+		renderPassDesc.Set("depthStencilAttachment", depthAttachment)
+	}
+
+	// Axes are drawn first, behind the series, so they need the data
+	// ranges before the series set them
+	if len(cr.AxisSeries) > 0 {
+		cr.updateDataRanges()
+	}
+
 	log("[ChartRenderer] Beginning render pass")
 	pass := encoder.Call("beginRenderPass", renderPassDesc)
 
+	// Render axes
+	log(fmt.Sprintf("[ChartRenderer] Rendering %d axes", len(cr.AxisSeries)))
+	cr.renderAxes(pass, len(cr.CandlestickSeries)+len(cr.LineSeries))
+
 	// Render candlestick series
 	log(fmt.Sprintf("[ChartRenderer] Rendering %d candlestick series", len(cr.CandlestickSeries)))
 	for i, series := range cr.CandlestickSeries {
 		log(fmt.Sprintf("[ChartRenderer] Rendering candlestick series %d/%d", i+1, len(cr.CandlestickSeries)))
-		cr.renderCandlestickSeries(pass, series)
+		cr.renderCandlestickSeries(pass, series, i*chartUniformStride)
 	}
 
 	// Render line series
 	log(fmt.Sprintf("[ChartRenderer] Rendering %d line series", len(cr.LineSeries)))
 	for i, series := range cr.LineSeries {
 		log(fmt.Sprintf("[ChartRenderer] Rendering line series %d/%d", i+1, len(cr.LineSeries)))
-		cr.renderLineSeries(pass, series)
+		cr.renderLineSeries(pass, series, (len(cr.CandlestickSeries)+i)*chartUniformStride)
 	}
 
 	log("[ChartRenderer] Ending render pass")
@@ -317,8 +459,9 @@ func (cr *ChartRenderer) Render() {
 	log("[ChartRenderer] Command buffer submitted successfully")
 }
 
-// renderCandlestickSeries renders a candlestick series
-func (cr *ChartRenderer) renderCandlestickSeries(pass js.Value, series *GPUNode) {
+// renderCandlestickSeries renders a candlestick series using the uniform
+// buffer slot at uniformOffset
+func (cr *ChartRenderer) renderCandlestickSeries(pass js.Value, series *GPUNode, uniformOffset int) {
 	log("[ChartRenderer] renderCandlestickSeries() called")
 
 	// Extract data from series properties
@@ -345,20 +488,24 @@ func (cr *ChartRenderer) renderCandlestickSeries(pass js.Value, series *GPUNode)
 			c.Timestamp, c.Open, c.High, c.Low, c.Close, c.Volume))
 	}
 
-	// Calculate data ranges
+	// Calculate data ranges from the candles in the viewport
 	log("[ChartRenderer] Calculating data ranges...")
-	cr.calculateDataRanges(candles)
+	start, end := cr.visibleRange(len(candles))
+	if start == end {
+		return
+	}
+	cr.calculateDataRanges(candles[start:end])
 	log(fmt.Sprintf("[ChartRenderer] Data ranges - X: [%.2f, %.2f], Y: [%.2f, %.2f]",
 		cr.DataXRange[0], cr.DataXRange[1], cr.DataYRange[0], cr.DataYRange[1]))
 
-	// Create data buffer
-	log("[ChartRenderer] Creating candle data buffer...")
-	dataBuffer := cr.createCandleDataBuffer(candles)
-	if dataBuffer == nil {
-		logError("[ChartRenderer] Failed to create candle data buffer")
+	// Upload the candles that changed since the last frame
+	log("[ChartRenderer] Uploading candle data...")
+	dataBuffer, err := cr.uploadCandles(series, candles)
+	if err != nil {
+		logError(fmt.Sprintf("[ChartRenderer] Failed to upload candle data: %v", err))
 		return
 	}
-	log("[ChartRenderer] Candle data buffer created successfully")
+	log("[ChartRenderer] Candle data uploaded successfully")
 
 	// Extract colors
 	upColor := NewVec4(0.18, 0.80, 0.44, 1.0)
@@ -378,7 +525,7 @@ func (cr *ChartRenderer) renderCandlestickSeries(pass js.Value, series *GPUNode)
 	// Calculate candle width in DATA COORDINATES (not pixels!)
 	// The shader expects candleWidth in the same units as the timestamp
 	dataXRange := cr.DataXRange[1] - cr.DataXRange[0]
-	candleWidth := float32(dataXRange/float64(len(candles))) * 0.8
+	candleWidth := float32(dataXRange/float64(end-start)) * 0.8
 
 	padding := cr.getPadding()
 	chartWidth := float32(cr.Canvas.Width) - padding["left"] - padding["right"]
@@ -387,8 +534,8 @@ func (cr *ChartRenderer) renderCandlestickSeries(pass js.Value, series *GPUNode)
 
 	// Create uniforms
 	log("[ChartRenderer] Creating uniforms...")
-	uniformData := cr.createCandleUniforms(upColor, downColor, wickColor, candleWidth)
-	if err := cr.Canvas.GPUContext.WriteBuffer(cr.UniformBuffer.Buffer, 0, uniformData); err != nil {
+	uniformData := cr.createCandleUniforms(upColor, downColor, wickColor, candleWidth, seriesDepth(series))
+	if err := cr.Canvas.GPUContext.WriteBuffer(cr.UniformBuffer.Buffer, uniformOffset, uniformData); err != nil {
 		logError(fmt.Sprintf("[ChartRenderer] Failed to write uniform data: %v", err))
 		return
 	}
@@ -396,19 +543,21 @@ func (cr *ChartRenderer) renderCandlestickSeries(pass js.Value, series *GPUNode)
 
 	// Create bind group
 	log("[ChartRenderer] Creating bind group...")
-	bindGroup := cr.createCandleBindGroup(dataBuffer)
+	bindGroup := cr.createCandleBindGroup(dataBuffer, uniformOffset)
 	log("[ChartRenderer] Bind group created")
 
-	// Draw
-	log(fmt.Sprintf("[ChartRenderer] Issuing draw call - 6 vertices, %d instances", len(candles)*2))
+	// Draw the candles in the viewport; instance_index counts from the
+	// first instance, so the shader indexes the whole buffer
+	log(fmt.Sprintf("[ChartRenderer] Issuing draw call - 6 vertices, %d instances", (end-start)*2))
 	pass.Call("setPipeline", cr.CandlestickPipeline.Pipeline)
 	pass.Call("setBindGroup", 0, bindGroup)
-	pass.Call("draw", 6, len(candles)*2, 0, 0) // 6 vertices per quad, 2 instances per candle (body + wick)
+	pass.Call("draw", 6, (end-start)*2, 0, start*2) // 6 vertices per quad, 2 instances per candle (body + wick)
 	log("[ChartRenderer] Draw call completed")
 }
 
-// renderLineSeries renders a line series
-func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode) {
+// renderLineSeries renders a line series using the uniform buffer slot at
+// uniformOffset
+func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode, uniformOffset int) {
 	log("[ChartRenderer] renderLineSeries() called")
 
 	// Extract data from series properties
@@ -457,6 +606,7 @@ func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode) {
 		logError("[ChartRenderer] Failed to create line data buffer")
 		return
 	}
+	cr.LineBuffers = append(cr.LineBuffers, dataBuffer)
 	log("[ChartRenderer] Line data buffer created successfully")
 
 	// Extract line properties
@@ -478,12 +628,14 @@ func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode) {
 		fillColor = c
 	}
 
+	dash := lineDashPattern(series.Properties, strokeWidth)
+
 	log(fmt.Sprintf("[ChartRenderer] Line properties - Stroke width: %.2f, Fill: %v", strokeWidth, fill))
 
 	// Create uniforms
 	log("[ChartRenderer] Creating line uniforms...")
-	uniformData := cr.createLineUniforms(strokeColor, strokeWidth, fill, fillColor)
-	if err := cr.Canvas.GPUContext.WriteBuffer(cr.UniformBuffer.Buffer, 0, uniformData); err != nil {
+	uniformData := cr.createLineUniforms(strokeColor, strokeWidth, fill, fillColor, seriesDepth(series), dash)
+	if err := cr.Canvas.GPUContext.WriteBuffer(cr.UniformBuffer.Buffer, uniformOffset, uniformData); err != nil {
 		logError(fmt.Sprintf("[ChartRenderer] Failed to write line uniform data: %v", err))
 		return
 	}
@@ -491,7 +643,7 @@ func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode) {
 
 	// Create bind group
 	log("[ChartRenderer] Creating line bind group...")
-	bindGroup := cr.createLineBindGroup(dataBuffer)
+	bindGroup := cr.createLineBindGroup(dataBuffer, uniformOffset)
 	log("[ChartRenderer] Line bind group created")
 
 	// Draw fill first if enabled
@@ -504,6 +656,10 @@ func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode) {
 	}
 
 	// Draw line
+	if cr.UseIndexedLines {
+		cr.drawIndexedLine(pass, points, strokeWidth, uniformOffset)
+		return
+	}
 	log(fmt.Sprintf("[ChartRenderer] Drawing line - 6 vertices, %d instances", len(points)-1))
 	pass.Call("setPipeline", cr.LinePipeline.Pipeline)
 	pass.Call("setBindGroup", 0, bindGroup)
@@ -511,8 +667,88 @@ func (cr *ChartRenderer) renderLineSeries(pass js.Value, series *GPUNode) {
 	log("[ChartRenderer] Line draw completed")
 }
 
+// drawIndexedLine draws a line series from CPU-expanded vertices with
+// drawIndexed, sharing the vertices of each joint between its two segments
+func (cr *ChartRenderer) drawIndexedLine(pass js.Value, points []interface{}, strokeWidth float32, uniformOffset int) {
+	pixels := cr.linePixels(points)
+	vertexBuffer := cr.createLineVertexBuffer(pixels, strokeWidth)
+	distanceBuffer := cr.createLineDistanceBuffer(pixels)
+	for _, buffer := range []*GPUBuffer{vertexBuffer, distanceBuffer} {
+		if buffer != nil {
+			cr.LineBuffers = append(cr.LineBuffers, buffer)
+		}
+	}
+	indexBuffer := cr.lineIndexBuffer(len(pixels))
+	if vertexBuffer == nil || distanceBuffer == nil || indexBuffer == nil {
+		return
+	}
+
+	indexCount := (len(pixels) - 1) * 6
+	log(fmt.Sprintf("[ChartRenderer] Drawing indexed line - %d vertices, %d indices", len(pixels)*2, indexCount))
+	pass.Call("setPipeline", cr.LineIndexedPipeline.Pipeline)
+	pass.Call("setBindGroup", 0, cr.createLineIndexedBindGroup(cr.LineIndexedPipeline, uniformOffset))
+	pass.Call("setVertexBuffer", 0, vertexBuffer.Buffer)
+	pass.Call("setVertexBuffer", 1, distanceBuffer.Buffer)
+	pass.Call("setIndexBuffer", indexBuffer.Buffer, "uint32")
+	pass.Call("drawIndexed", indexCount, 1, 0, 0, 0)
+	log("[ChartRenderer] Indexed line draw completed")
+}
+
+// releaseDataBuffers destroys the per-frame line and axis buffers
+func (cr *ChartRenderer) releaseDataBuffers() {
+	for _, buffer := range cr.LineBuffers {
+		buffer.Destroy()
+	}
+	cr.LineBuffers = nil
+	for _, buffer := range cr.AxisBuffers {
+		buffer.Destroy()
+	}
+	cr.AxisBuffers = nil
+}
+
+// Cleanup releases GPU resources
+func (cr *ChartRenderer) Cleanup() {
+	cr.releaseDataBuffers()
+	cr.releaseCandleCaches()
+	cr.removeAxisLabels()
+
+	if cr.LineIndexBuffer != nil {
+		cr.LineIndexBuffer.Destroy()
+		cr.LineIndexBuffer = nil
+	}
+
+	// Destroy uniform buffer
+	if cr.UniformBuffer != nil {
+		cr.UniformBuffer.Destroy()
+		cr.UniformBuffer = nil
+	}
+
+	// Destroy depth texture
+	if cr.DepthTexture.Truthy() {
+		cr.DepthTexture.Call("destroy")
+		cr.DepthTexture = js.Undefined()
+	}
+
+	cr.initialized = false
+}
+
 // Helper functions
 
+// seriesDepth maps a series' ZIndex to its depth: 0.5 for the default of 0,
+// decreasing (nearer) as ZIndex grows. Each step is 1/512 so ZIndex values
+// between -255 and 255 stay within the depth range.
+func seriesDepth(series *GPUNode) float32 {
+	zIndex, _ := series.Properties["zIndex"].(int)
+	depth := 0.5 - float32(zIndex)/512
+	if depth < 0 {
+		return 0
+	}
+	if depth > 0.99 {
+		return 0.99
+	}
+	return depth
+}
+
 // extractOHLCVData uses reflection to extract OHLCV data from any slice type
 func (cr *ChartRenderer) extractOHLCVData(data interface{}) []ohlcvData {
 	log(fmt.Sprintf("[ChartRenderer] extractOHLCVData called with type: %T", data))
@@ -688,60 +924,27 @@ func (cr *ChartRenderer) calculateLineDataRanges(points []interface{}) {
 	cr.DataYRange = [2]float64{minY, maxY}
 }
 
-func (cr *ChartRenderer) createCandleDataBuffer(candles []ohlcvData) *GPUBuffer {
-	// Each candle: timestamp(f32), open(f32), high(f32), low(f32), close(f32), volume(f32) = 24 bytes
-	bufferSize := len(candles) * 24
-	data := make([]byte, bufferSize)
-
-	for i, c := range candles {
-		offset := i * 24
-		timestamp := float64(c.Timestamp)
-		open := c.Open
-		high := c.High
-		low := c.Low
-		close := c.Close
-		volume := c.Volume
-
-		binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(float32(timestamp)))
-		binary.LittleEndian.PutUint32(data[offset+4:], math.Float32bits(float32(open)))
-		binary.LittleEndian.PutUint32(data[offset+8:], math.Float32bits(float32(high)))
-		binary.LittleEndian.PutUint32(data[offset+12:], math.Float32bits(float32(low)))
-		binary.LittleEndian.PutUint32(data[offset+16:], math.Float32bits(float32(close)))
-		binary.LittleEndian.PutUint32(data[offset+20:], math.Float32bits(float32(volume)))
-	}
-
-	// Create buffer and write data
-	buffer, err := CreateStorageBuffer(cr.Canvas.GPUContext, bufferSize, "candle-data")
-	if err != nil {
-		logError(fmt.Sprintf("[ChartRenderer] Failed to create candle buffer: %v", err))
-		return nil
-	}
-
-	if err := buffer.Write(cr.Canvas.GPUContext, 0, data); err != nil {
-		logError(fmt.Sprintf("[ChartRenderer] Failed to write candle data: %v", err))
-		return nil
-	}
-
-	return buffer
-}
-
 func (cr *ChartRenderer) createLineDataBuffer(points []interface{}) *GPUBuffer {
-	// Each point: x(f32), y(f32) = 8 bytes
-	bufferSize := len(points) * 8
+	// Each point: x(f32), y(f32), distance(f32) = 12 bytes
+	bufferSize := len(points) * 12
 	data := make([]byte, bufferSize)
+	distances := lineDistances(cr.linePixels(points))
 
+	valid := 0
 	for i, p := range points {
 		pointMap, ok := p.(map[string]interface{})
 		if !ok {
 			continue
 		}
 
-		offset := i * 8
+		offset := i * 12
 		x, _ := pointMap["X"].(float64)
 		y, _ := pointMap["Y"].(float64)
 
 		binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(float32(x)))
 		binary.LittleEndian.PutUint32(data[offset+4:], math.Float32bits(float32(y)))
+		binary.LittleEndian.PutUint32(data[offset+8:], math.Float32bits(distances[valid]))
+		valid++
 	}
 
 	// Create buffer and write data
@@ -759,7 +962,7 @@ func (cr *ChartRenderer) createLineDataBuffer(points []interface{}) *GPUBuffer {
 	return buffer
 }
 
-func (cr *ChartRenderer) createCandleUniforms(upColor, downColor, wickColor Vec4, candleWidth float32) []byte {
+func (cr *ChartRenderer) createCandleUniforms(upColor, downColor, wickColor Vec4, candleWidth, depth float32) []byte {
 	padding := cr.getPadding()
 
 	// Uniform layout matches WGSL struct
@@ -808,11 +1011,15 @@ func (cr *ChartRenderer) createCandleUniforms(upColor, downColor, wickColor Vec4
 	binary.LittleEndian.PutUint32(data[offset+4:], math.Float32bits(wickColor.Y))
 	binary.LittleEndian.PutUint32(data[offset+8:], math.Float32bits(wickColor.Z))
 	binary.LittleEndian.PutUint32(data[offset+12:], math.Float32bits(wickColor.W))
+	offset += 16
+
+	// depth: f32
+	binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(depth))
 
 	return data
 }
 
-func (cr *ChartRenderer) createLineUniforms(strokeColor Vec4, strokeWidth float32, fill bool, fillColor Vec4) []byte {
+func (cr *ChartRenderer) createLineUniforms(strokeColor Vec4, strokeWidth float32, fill bool, fillColor Vec4, depth float32, dash [2]float32) []byte {
 	padding := cr.getPadding()
 
 	// Uniform layout matches WGSL struct
@@ -862,11 +1069,20 @@ func (cr *ChartRenderer) createLineUniforms(strokeColor Vec4, strokeWidth float3
 	binary.LittleEndian.PutUint32(data[offset+4:], math.Float32bits(fillColor.Y))
 	binary.LittleEndian.PutUint32(data[offset+8:], math.Float32bits(fillColor.Z))
 	binary.LittleEndian.PutUint32(data[offset+12:], math.Float32bits(fillColor.W))
+	offset += 16
+
+	// depth: f32
+	binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(depth))
+	offset += 8 // dashPattern is aligned to 8 bytes
+
+	// dashPattern: vec2<f32>
+	binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(dash[0]))
+	binary.LittleEndian.PutUint32(data[offset+4:], math.Float32bits(dash[1]))
 
 	return data
 }
 
-func (cr *ChartRenderer) createCandleBindGroup(dataBuffer *GPUBuffer) js.Value {
+func (cr *ChartRenderer) createCandleBindGroup(dataBuffer *GPUBuffer, uniformOffset int) js.Value {
 	entries := js.Global().Get("Array").New(2)
 
 	// Binding 0: Uniform buffer
@@ -874,6 +1090,8 @@ func (cr *ChartRenderer) createCandleBindGroup(dataBuffer *GPUBuffer) js.Value {
 	entry0.Set("binding", 0)
 	bufferBinding0 := js.Global().Get("Object").New()
 	bufferBinding0.Set("buffer", cr.UniformBuffer.Buffer)
+	bufferBinding0.Set("offset", uniformOffset)
+	bufferBinding0.Set("size", chartUniformStride)
 	entry0.Set("resource", bufferBinding0)
 	entries.SetIndex(0, entry0)
 
@@ -892,7 +1110,7 @@ func (cr *ChartRenderer) createCandleBindGroup(dataBuffer *GPUBuffer) js.Value {
 	return cr.Canvas.GPUContext.Device.Call("createBindGroup", bindGroupDesc)
 }
 
-func (cr *ChartRenderer) createLineBindGroup(dataBuffer *GPUBuffer) js.Value {
+func (cr *ChartRenderer) createLineBindGroup(dataBuffer *GPUBuffer, uniformOffset int) js.Value {
 	entries := js.Global().Get("Array").New(2)
 
 	// Binding 0: Uniform buffer
@@ -900,6 +1118,8 @@ func (cr *ChartRenderer) createLineBindGroup(dataBuffer *GPUBuffer) js.Value {
 	entry0.Set("binding", 0)
 	bufferBinding0 := js.Global().Get("Object").New()
 	bufferBinding0.Set("buffer", cr.UniformBuffer.Buffer)
+	bufferBinding0.Set("offset", uniformOffset)
+	bufferBinding0.Set("size", chartUniformStride)
 	entry0.Set("resource", bufferBinding0)
 	entries.SetIndex(0, entry0)
 
@@ -917,3 +1137,25 @@ func (cr *ChartRenderer) createLineBindGroup(dataBuffer *GPUBuffer) js.Value {
 
 	return cr.Canvas.GPUContext.Device.Call("createBindGroup", bindGroupDesc)
 }
+
+// createLineIndexedBindGroup binds the uniform slot at uniformOffset for a
+// pipeline drawing vs_line_indexed, which reads no storage buffer
+func (cr *ChartRenderer) createLineIndexedBindGroup(pipeline *RenderPipeline, uniformOffset int) js.Value {
+	entries := js.Global().Get("Array").New(1)
+
+	// Binding 0: Uniform buffer
+	entry0 := js.Global().Get("Object").New()
+	entry0.Set("binding", 0)
+	bufferBinding0 := js.Global().Get("Object").New()
+	bufferBinding0.Set("buffer", cr.UniformBuffer.Buffer)
+	bufferBinding0.Set("offset", uniformOffset)
+	bufferBinding0.Set("size", chartUniformStride)
+	entry0.Set("resource", bufferBinding0)
+	entries.SetIndex(0, entry0)
+
+	bindGroupDesc := js.Global().Get("Object").New()
+	bindGroupDesc.Set("layout", pipeline.Pipeline.Call("getBindGroupLayout", 0))
+	bindGroupDesc.Set("entries", entries)
+
+	return cr.Canvas.GPUContext.Device.Call("createBindGroup", bindGroupDesc)
+}
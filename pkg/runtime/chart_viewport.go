@@ -0,0 +1,134 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// candleStride is the size in bytes of a Candle in the candlestick shader
+const candleStride = 24
+
+// candleCache holds the candles of a series uploaded to the GPU. It is kept
+// across frames, so only candles that changed are written again.
+type candleCache struct {
+	candles []ohlcvData
+	buffer  *GPUBuffer
+}
+
+// SetViewport shows count candles starting at startIndex. The data ranges
+// are set from the visible candles and only they are drawn, while the
+// candle buffer is left as it is: scrolling over the same data writes no
+// candles to the GPU. A count of 0 shows all candles.
+func (cr *ChartRenderer) SetViewport(startIndex, count int) {
+	if startIndex < 0 {
+		startIndex = 0
+	}
+	if count < 0 {
+		count = 0
+	}
+	cr.ViewportStart = startIndex
+	cr.ViewportCount = count
+	if cr.Canvas != nil {
+		cr.Canvas.RequestRender()
+	}
+}
+
+// visibleRange returns the candles of n shown by the viewport, as the
+// half-open range [start, end)
+func (cr *ChartRenderer) visibleRange(n int) (start, end int) {
+	if cr.ViewportCount <= 0 {
+		return 0, n
+	}
+	start = min(cr.ViewportStart, n)
+	end = min(start+cr.ViewportCount, n)
+	return start, end
+}
+
+// visibleCandles returns the candles shown by the viewport
+func (cr *ChartRenderer) visibleCandles(candles []ohlcvData) []ohlcvData {
+	start, end := cr.visibleRange(len(candles))
+	return candles[start:end]
+}
+
+// uploadCandles returns the candle buffer of a series holding candles. Only
+// the candles from the first one that differs from the last upload are
+// written, so appending data writes the new candles and an unchanged series
+// writes nothing. The buffer grows by doubling, rewriting every candle.
+func (cr *ChartRenderer) uploadCandles(series *GPUNode, candles []ohlcvData) (*GPUBuffer, error) {
+	if cr.candleCaches == nil {
+		cr.candleCaches = make(map[*GPUNode]*candleCache)
+	}
+	cache, ok := cr.candleCaches[series]
+	if !ok {
+		cache = &candleCache{}
+		cr.candleCaches[series] = cache
+	}
+
+	first := firstChangedCandle(cache.candles, candles)
+	size := len(candles) * candleStride
+	if cache.buffer == nil || size > cache.buffer.Size {
+		capacity := max(size, candleStride)
+		if cache.buffer != nil {
+			capacity = max(size, 2*cache.buffer.Size)
+			cache.buffer.Destroy()
+		}
+		buffer, err := CreateStorageBuffer(cr.Canvas.GPUContext, capacity, "candle-data")
+		if err != nil {
+			cache.buffer = nil
+			return nil, fmt.Errorf("failed to create candle buffer: %w", err)
+		}
+		cache.buffer = buffer
+		first = 0
+	}
+
+	if first < len(candles) {
+		if err := cache.buffer.Write(cr.Canvas.GPUContext, first*candleStride, encodeCandles(candles[first:])); err != nil {
+			return nil, fmt.Errorf("failed to write candle data: %w", err)
+		}
+		cr.CandleBufferWrites++
+	}
+
+	cache.candles = append(cache.candles[:0], candles...)
+	return cache.buffer, nil
+}
+
+// firstChangedCandle returns the index of the first candle of candles that
+// differs from uploaded, or len(candles) when none does
+func firstChangedCandle(uploaded, candles []ohlcvData) int {
+	for i, c := range candles {
+		if i >= len(uploaded) || uploaded[i] != c {
+			return i
+		}
+	}
+	return len(candles)
+}
+
+// encodeCandles encodes candles in the layout of the Candle struct of the
+// candlestick shader
+func encodeCandles(candles []ohlcvData) []byte {
+	// Each candle: timestamp(f32), open(f32), high(f32), low(f32), close(f32), volume(f32) = 24 bytes
+	data := make([]byte, len(candles)*candleStride)
+	for i, c := range candles {
+		offset := i * candleStride
+		binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(float32(c.Timestamp)))
+		binary.LittleEndian.PutUint32(data[offset+4:], math.Float32bits(float32(c.Open)))
+		binary.LittleEndian.PutUint32(data[offset+8:], math.Float32bits(float32(c.High)))
+		binary.LittleEndian.PutUint32(data[offset+12:], math.Float32bits(float32(c.Low)))
+		binary.LittleEndian.PutUint32(data[offset+16:], math.Float32bits(float32(c.Close)))
+		binary.LittleEndian.PutUint32(data[offset+20:], math.Float32bits(float32(c.Volume)))
+	}
+	return data
+}
+
+// releaseCandleCaches destroys the candle buffers of every series
+func (cr *ChartRenderer) releaseCandleCaches() {
+	for series, cache := range cr.candleCaches {
+		if cache.buffer != nil {
+			cache.buffer.Destroy()
+		}
+		delete(cr.candleCaches, series)
+	}
+}
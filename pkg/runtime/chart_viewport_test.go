@@ -0,0 +1,117 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// newViewportRenderer returns a renderer whose device records the buffers
+// it creates and the bytes written to them
+func newViewportRenderer() (*ChartRenderer, js.Value) {
+	device := js.Global().Call("eval", `(() => {
+		const device = {
+			buffers: 0,
+			writes: [],
+			createBuffer(desc) { this.buffers++; return {size: desc.size, destroy() {}}; },
+		};
+		device.queue = {
+			writeBuffer(buffer, offset, data, dataOffset, size) { device.writes.push({offset, size}); },
+		};
+		return device;
+	})()`)
+	ctx := &GPUContext{Device: device, Queue: device.Get("queue")}
+	return &ChartRenderer{Canvas: &GPUCanvas{GPUContext: ctx}}, device
+}
+
+func testCandles(n int) []ohlcvData {
+	candles := make([]ohlcvData, n)
+	for i := range candles {
+		candles[i] = ohlcvData{Timestamp: int64(i) * 60000, Open: 100, High: 110, Low: 90, Close: 105}
+	}
+	return candles
+}
+
+func TestViewportDragWritesNoCandles(t *testing.T) {
+	cr, device := newViewportRenderer()
+	series := CandlestickSeries()
+	candles := testCandles(1000)
+
+	// A drag shifts the viewport every frame over the same data
+	const frames = 60
+	for frame := 0; frame < frames; frame++ {
+		cr.SetViewport(frame*5, 200)
+		if _, err := cr.uploadCandles(series, candles); err != nil {
+			t.Fatalf("Frame %d: %v", frame, err)
+		}
+	}
+
+	// Rebuilding the buffer wrote every candle on each of the 60 frames;
+	// now only the first frame does
+	if cr.CandleBufferWrites != 1 {
+		t.Errorf("Expected 1 candle buffer write for %d frames, got %d", frames, cr.CandleBufferWrites)
+	}
+	if n := device.Get("buffers").Int(); n != 1 {
+		t.Errorf("Expected the candle buffer to be reused, got %d buffers", n)
+	}
+}
+
+func TestUploadCandlesWritesChangedCandles(t *testing.T) {
+	cr, device := newViewportRenderer()
+	series := CandlestickSeries()
+	candles := testCandles(100)
+
+	buffer, err := cr.uploadCandles(series, candles)
+	if err != nil {
+		t.Fatalf("uploadCandles failed: %v", err)
+	}
+
+	// Appending within the buffer writes only the new candles
+	candles = testCandles(100)[:90]
+	if _, err := cr.uploadCandles(series, candles); err != nil {
+		t.Fatalf("uploadCandles failed: %v", err)
+	}
+	candles = testCandles(100)
+	candles[95].Close = 80
+	if _, err := cr.uploadCandles(series, candles); err != nil {
+		t.Fatalf("uploadCandles failed: %v", err)
+	}
+	writes := device.Get("writes")
+	if writes.Length() != 2 {
+		t.Fatalf("Expected 2 writes, got %d", writes.Length())
+	}
+	if last := writes.Index(1); last.Get("offset").Int() != 90*candleStride || last.Get("size").Int() != 10*candleStride {
+		t.Errorf("Expected candles 90-99 to be written, got offset %d size %d", last.Get("offset").Int(), last.Get("size").Int())
+	}
+
+	// Outgrowing the buffer doubles it and writes every candle
+	grown, err := cr.uploadCandles(series, testCandles(150))
+	if err != nil {
+		t.Fatalf("uploadCandles failed: %v", err)
+	}
+	if grown == buffer || grown.Size != 2*buffer.Size {
+		t.Errorf("Expected a buffer of %d bytes, got %d", 2*buffer.Size, grown.Size)
+	}
+	if last := writes.Index(writes.Length() - 1); last.Get("offset").Int() != 0 || last.Get("size").Int() != 150*candleStride {
+		t.Errorf("Expected every candle to be written to the new buffer")
+	}
+}
+
+func TestVisibleRange(t *testing.T) {
+	cr := &ChartRenderer{}
+	if start, end := cr.visibleRange(100); start != 0 || end != 100 {
+		t.Errorf("Expected all candles without a viewport, got [%d, %d)", start, end)
+	}
+
+	cr.SetViewport(40, 20)
+	if start, end := cr.visibleRange(100); start != 40 || end != 60 {
+		t.Errorf("Expected [40, 60), got [%d, %d)", start, end)
+	}
+	if start, end := cr.visibleRange(50); start != 40 || end != 50 {
+		t.Errorf("Expected the viewport clamped to [40, 50), got [%d, %d)", start, end)
+	}
+	if start, end := cr.visibleRange(30); start != 30 || end != 30 {
+		t.Errorf("Expected an empty viewport past the data, got [%d, %d)", start, end)
+	}
+}
@@ -73,6 +73,7 @@ func XAxis(options ...interface{}) *GPUNode {
 	node.Properties["gridLines"] = true
 	node.Properties["gridColor"] = NewVec4(0.2, 0.2, 0.25, 0.5)
 	node.Properties["timeScale"] = false
+	node.Properties["tickCount"] = defaultAxisTickCount
 
 	for _, opt := range options {
 		switch o := opt.(type) {
@@ -97,6 +98,7 @@ func YAxis(options ...interface{}) *GPUNode {
 	node.Properties["position"] = "right"
 	node.Properties["gridLines"] = true
 	node.Properties["gridColor"] = NewVec4(0.2, 0.2, 0.25, 0.5)
+	node.Properties["tickCount"] = defaultAxisTickCount
 
 	for _, opt := range options {
 		switch o := opt.(type) {
@@ -183,6 +185,18 @@ func ChartInteractive(enabled bool) GPUProp {
 	return GPUProp{Key: "interactive", Value: enabled}
 }
 
+// ChartDepth enables a depth buffer so series are layered by their ZIndex
+// instead of draw order
+func ChartDepth(enabled bool) GPUProp {
+	return GPUProp{Key: "depth", Value: enabled}
+}
+
+// ChartIndexedLines draws line series from CPU-expanded, mitred vertices
+// with an index buffer instead of one instanced quad per segment
+func ChartIndexedLines(enabled bool) GPUProp {
+	return GPUProp{Key: "indexedLines", Value: enabled}
+}
+
 // AxisPosition sets axis position
 func AxisPosition(pos string) GPUProp {
 	return GPUProp{Key: "position", Value: pos}
@@ -203,6 +217,19 @@ func GridColor(r, g, b, a float32) GPUProp {
 	return GPUProp{Key: "gridColor", Value: NewVec4(r, g, b, a)}
 }
 
+// TickCount sets about how many ticks an axis shows; the exact count
+// depends on where the rounded tick values fall
+func TickCount(n int) GPUProp {
+	return GPUProp{Key: "tickCount", Value: n}
+}
+
+// LabelFormat sets how tick labels are formatted: a fmt verb like "$%.2f"
+// for numeric axes, a time layout or one of "date", "time", "datetime" and
+// "short" for time scales
+func LabelFormat(format string) GPUProp {
+	return GPUProp{Key: "labelFormat", Value: format}
+}
+
 // Label sets axis label
 func Label(text string) GPUProp {
 	return GPUProp{Key: "label", Value: text}
@@ -243,6 +270,26 @@ func StrokeWidth(width float32) GPUProp {
 	return GPUProp{Key: "strokeWidth", Value: width}
 }
 
+// Line stroke styles, set with StrokeStyle
+const (
+	StrokeSolid  = "solid"
+	StrokeDashed = "dashed"
+	StrokeDotted = "dotted"
+)
+
+// StrokeStyle sets how a line is stroked: StrokeSolid (the default),
+// StrokeDashed or StrokeDotted. Dashes are 4 stroke widths long with gaps
+// of 2 stroke widths; dots are 1 stroke width long with the same gaps.
+func StrokeStyle(style string) GPUProp {
+	return GPUProp{Key: "strokeStyle", Value: style}
+}
+
+// DashPattern sets the dash and gap lengths of a line in pixels, e.g. for
+// a threshold line. It dashes the line whatever its StrokeStyle.
+func DashPattern(on, off float32) GPUProp {
+	return GPUProp{Key: "dashPattern", Value: [2]float32{on, off}}
+}
+
 // FillColor sets fill color
 func FillColor(r, g, b, a float32) GPUProp {
 	return GPUProp{Key: "fillColor", Value: NewVec4(r, g, b, a)}
@@ -253,6 +300,12 @@ func FillEnabled(enabled bool) GPUProp {
 	return GPUProp{Key: "fill", Value: enabled}
 }
 
+// ZIndex sets the stacking order of a series when ChartDepth is enabled.
+// Higher values are drawn in front; the default is 0.
+func ZIndex(z int) GPUProp {
+	return GPUProp{Key: "zIndex", Value: z}
+}
+
 // Axis position constants
 const (
 	AxisTop    = "top"
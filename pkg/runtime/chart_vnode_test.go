@@ -3,6 +3,8 @@
 package runtime
 
 import (
+	"encoding/binary"
+	"math"
 	"testing"
 )
 
@@ -269,3 +271,56 @@ type mockChartImpl struct{}
 func (m *mockChartImpl) RenderChart() *GPUNode {
 	return ChartNode()
 }
+
+func TestSeriesZIndexDepth(t *testing.T) {
+	chart := ChartNode(ChartDepth(true),
+		CandlestickSeries(),
+		LineSeries(ZIndex(2), FillEnabled(true)),
+	)
+
+	if depth, ok := chart.Properties["depth"].(bool); !ok || !depth {
+		t.Error("Expected ChartDepth to set the depth property")
+	}
+
+	back := seriesDepth(chart.Children[0])
+	front := seriesDepth(chart.Children[1])
+	if back != 0.5 {
+		t.Errorf("Expected default depth 0.5, got %f", back)
+	}
+	if front >= back {
+		t.Errorf("Expected higher ZIndex to be nearer: front %f, back %f", front, back)
+	}
+
+	// Extreme values are clamped to the depth range
+	if depth := seriesDepth(LineSeries(ZIndex(1000))); depth != 0 {
+		t.Errorf("Expected depth clamped to 0, got %f", depth)
+	}
+	if depth := seriesDepth(LineSeries(ZIndex(-1000))); depth != 0.99 {
+		t.Errorf("Expected depth clamped to 0.99, got %f", depth)
+	}
+}
+
+func TestChartUniformsEncodeDepth(t *testing.T) {
+	cr := &ChartRenderer{
+		Canvas:  &GPUCanvas{Width: 800, Height: 600},
+		Padding: map[string]float32{"top": 10, "right": 10, "bottom": 10, "left": 10},
+	}
+
+	candle := cr.createCandleUniforms(Vec4{}, Vec4{}, Vec4{}, 1, 0.25)
+	line := cr.createLineUniforms(Vec4{}, 2, true, Vec4{}, 0.75, [2]float32{8, 4})
+
+	// depth follows the last vec4 member at byte offset 112
+	if got := math.Float32frombits(binary.LittleEndian.Uint32(candle[112:])); got != 0.25 {
+		t.Errorf("Expected candle depth 0.25, got %f", got)
+	}
+	if got := math.Float32frombits(binary.LittleEndian.Uint32(line[112:])); got != 0.75 {
+		t.Errorf("Expected line depth 0.75, got %f", got)
+	}
+	// dashPattern is a vec2 aligned to 8 bytes, after depth
+	if on, off := math.Float32frombits(binary.LittleEndian.Uint32(line[120:])), math.Float32frombits(binary.LittleEndian.Uint32(line[124:])); on != 8 || off != 4 {
+		t.Errorf("Expected line dash pattern (8, 4), got (%f, %f)", on, off)
+	}
+	if len(candle) > chartUniformStride || len(line) > chartUniformStride {
+		t.Error("Expected uniforms to fit in one slot")
+	}
+}
@@ -0,0 +1,34 @@
+//go:build js && wasm
+
+package runtime
+
+// Children are the nodes nested inside a component's element. A component
+// with a parameter of type Children wraps them, rendering them where its
+// template has a slot named after the parameter:
+//
+//	func Card(title string, children Children) (Component) {
+//	    Div(Class("card")) {
+//	        H2 { `{title}` }
+//	        {children}
+//	    }
+//	}
+//
+//	Card(WithTitle("Orders")) {
+//	    P { "No orders yet" }
+//	}
+type Children []*VNode
+
+// ChildrenSetter is implemented by components with a Children parameter.
+// The parent passes the nested nodes again before each render, since they
+// are built from the parent's state.
+type ChildrenSetter interface {
+	Component
+	SetChildren(children ...*VNode)
+}
+
+// RenderWithChildren passes children to a component and renders it with
+// RenderComponent
+func RenderWithChildren(c ChildrenSetter, children ...*VNode) *VNode {
+	c.SetChildren(children...)
+	return RenderComponent(c)
+}
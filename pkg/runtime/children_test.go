@@ -0,0 +1,41 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// card wraps the nodes nested inside it
+type card struct {
+	children Children
+}
+
+func (c *card) Render() *VNode {
+	return Div(Class("card"), Fragment(c.children...))
+}
+
+func (c *card) Mount(parent js.Value) {}
+func (c *card) Unmount()              {}
+func (c *card) Update()               {}
+
+func (c *card) SetChildren(children ...*VNode) { c.children = children }
+
+func TestRenderWithChildren(t *testing.T) {
+	c := &card{}
+
+	vnode := RenderWithChildren(c, H1(Text("Orders")), P(Text("No orders yet")))
+	if len(vnode.Children) != 2 {
+		t.Fatalf("Expected the fragment to be spliced into 2 children, got %d", len(vnode.Children))
+	}
+	if vnode.Children[0].Tag != "h1" || vnode.Children[1].Tag != "p" {
+		t.Errorf("Expected h1 and p children, got %s and %s", vnode.Children[0].Tag, vnode.Children[1].Tag)
+	}
+
+	// The children of the next render replace the previous ones
+	vnode = RenderWithChildren(c, P(Text("3 orders")))
+	if len(vnode.Children) != 1 || len(c.children) != 1 {
+		t.Errorf("Expected a single child after re-rendering, got %d", len(vnode.Children))
+	}
+}
@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// copiedDuration is how long a CopyButton keeps its copied class after a
+// successful copy, in milliseconds
+const copiedDuration = 1500
+
+// writeClipboardText writes text to the system clipboard with
+// navigator.clipboard.writeText. It is a variable so tests can replace it.
+var writeClipboardText = func(text string) error {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if !isElement(clipboard) {
+		return errors.New("clipboard API not available")
+	}
+	_, err := awaitPromise(clipboard.Call("writeText", text))
+	return err
+}
+
+// CopyButton creates a button that copies a text to the clipboard:
+//
+//	CopyButton(Text(url))
+//	CopyButton(Text(url), Span(Text("Copy link")))
+//
+// The first Text is the text to copy; the other children form the label,
+// "Copy" by default. After a successful copy the button has the class
+// "copied" for a moment, e.g. to show "Copied!" with CSS. The text is kept
+// in a data-copy attribute, so a re-render with a new text copies the new
+// one.
+func CopyButton(options ...interface{}) *VNode {
+	text := ""
+	hasText, hasLabel := false, false
+	opts := []interface{}{Type("button"), Class("copy-button")}
+	for _, opt := range options {
+		if o, ok := opt.(*VNode); ok && o != nil {
+			if !hasText && o.Type == TextNode {
+				text = o.Text
+				hasText = true
+				continue
+			}
+			hasLabel = true
+		}
+		opts = append(opts, opt)
+	}
+	if !hasLabel {
+		opts = append(opts, Text("Copy"))
+	}
+
+	// Handlers are not replaced on re-render, so the text is read from the
+	// mounted button rather than captured
+	var button *VNode
+	copyText := func(Event) {
+		current := text
+		if isElement(button.DOMNode) {
+			current = button.DOMNode.Call("getAttribute", "data-copy").String()
+		}
+		if err := writeClipboardText(current); err != nil {
+			logError("CopyButton: copy failed:", err)
+			return
+		}
+		if isElement(button.DOMNode) {
+			showCopied(button.DOMNode)
+		}
+	}
+	opts = append(opts,
+		Attr{Key: "data-copy", Value: text},
+		OnClick(copyText),
+	)
+
+	button = Button(opts...)
+	return button
+}
+
+// showCopied adds the copied class to a button for copiedDuration
+func showCopied(elem js.Value) {
+	classList := elem.Get("classList")
+	classList.Call("add", "copied")
+
+	var clear js.Func
+	clear = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		classList.Call("remove", "copied")
+		clear.Release()
+		return nil
+	})
+	js.Global().Call("setTimeout", clear, copiedDuration)
+}
@@ -0,0 +1,50 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCopyButtonWritesText(t *testing.T) {
+	var copied []string
+	original := writeClipboardText
+	writeClipboardText = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+	defer func() { writeClipboardText = original }()
+
+	button := CopyButton(Text("https://example.com"))
+
+	if button.Tag != "button" || button.Attributes["class"] != "copy-button" {
+		t.Fatalf("Expected button.copy-button, got %s.%s", button.Tag, button.Attributes["class"])
+	}
+	if button.Attributes["data-copy"] != "https://example.com" {
+		t.Errorf("Expected the text in data-copy, got %q", button.Attributes["data-copy"])
+	}
+	// The copied text is not part of the label
+	if len(button.Children) != 1 || button.Children[0].Text != "Copy" {
+		t.Fatalf("Expected the default label, got %d children", len(button.Children))
+	}
+
+	button.Events["click"].Handler(Event{})
+	if len(copied) != 1 || copied[0] != "https://example.com" {
+		t.Errorf("Expected writeText with the text, got %v", copied)
+	}
+}
+
+func TestCopyButtonLabelAndError(t *testing.T) {
+	original := writeClipboardText
+	writeClipboardText = func(string) error { return errors.New("denied") }
+	defer func() { writeClipboardText = original }()
+
+	button := CopyButton(Text("secret"), Span(Text("Copy token")))
+	if len(button.Children) != 1 || button.Children[0].Tag != "span" {
+		t.Fatalf("Expected the span as the label, got %d children", len(button.Children))
+	}
+
+	// A failed copy is logged rather than panicking
+	button.Events["click"].Handler(Event{})
+}
@@ -0,0 +1,53 @@
+//go:build js && wasm
+// +build js,wasm
+
+package runtime
+
+// Provider carries a typed value down the component tree without threading
+// it through every component's props. Values are stored on the App, so any
+// component bound to the same app can read them during Render.
+type Provider[T any] struct {
+	defaultValue T
+}
+
+// NewProvider creates a context provider with a default value that is
+// returned by UseContext when no value has been provided
+func NewProvider[T any](defaultValue T) *Provider[T] {
+	return &Provider[T]{defaultValue: defaultValue}
+}
+
+// Provide sets the value seen by descendants of the app
+func (p *Provider[T]) Provide(app *App, value T) {
+	if app == nil {
+		return
+	}
+
+	app.contextMu.Lock()
+	defer app.contextMu.Unlock()
+
+	if app.contexts == nil {
+		app.contexts = make(map[interface{}]interface{})
+	}
+	app.contexts[p] = value
+}
+
+// Default returns the provider's default value
+func (p *Provider[T]) Default() T {
+	return p.defaultValue
+}
+
+// UseContext returns the value provided for p on the app, or the provider's
+// default if none was set (or the component is not bound to an app yet)
+func UseContext[T any](app *App, p *Provider[T]) T {
+	if app == nil {
+		return p.defaultValue
+	}
+
+	app.contextMu.RLock()
+	defer app.contextMu.RUnlock()
+
+	if v, ok := app.contexts[p]; ok {
+		return v.(T)
+	}
+	return p.defaultValue
+}
@@ -0,0 +1,77 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+type theme struct {
+	Name string
+}
+
+var themeProvider = NewProvider(theme{Name: "light"})
+
+// themedChild reads the theme from context without receiving it as a prop
+type themedChild struct {
+	app *App
+}
+
+func (c *themedChild) Render() *VNode {
+	t := UseContext(c.app, themeProvider)
+	return Span(ClassAttr(t.Name))
+}
+
+func (c *themedChild) Mount(parent js.Value) {}
+func (c *themedChild) Unmount()              {}
+func (c *themedChild) Update()               {}
+
+// themedParent renders a nested component bound to the same app
+type themedParent struct {
+	app   *App
+	child *themedChild
+}
+
+func (c *themedParent) Render() *VNode {
+	if c.child == nil {
+		c.child = &themedChild{app: c.app}
+	}
+	return Div(c.child.Render())
+}
+
+func (c *themedParent) Mount(parent js.Value) {}
+func (c *themedParent) Unmount()              {}
+func (c *themedParent) Update()               {}
+
+func TestUseContextDefault(t *testing.T) {
+	child := &themedChild{}
+	vnode := child.Render()
+
+	if vnode.Attributes["class"] != "light" {
+		t.Errorf("Expected default theme 'light', got %q", vnode.Attributes["class"])
+	}
+}
+
+func TestProviderAcrossNestedComponent(t *testing.T) {
+	parent := &themedParent{}
+	app := NewApp(parent)
+	parent.app = app
+
+	themeProvider.Provide(app, theme{Name: "dark"})
+
+	vnode := parent.Render()
+	if len(vnode.Children) != 1 {
+		t.Fatalf("Expected 1 child, got %d", len(vnode.Children))
+	}
+
+	if got := vnode.Children[0].Attributes["class"]; got != "dark" {
+		t.Errorf("Expected provided theme 'dark' in nested component, got %q", got)
+	}
+
+	// A different app must not see the value
+	other := NewApp(&themedParent{})
+	if got := UseContext(other, themeProvider); got.Name != "light" {
+		t.Errorf("Expected default theme for unrelated app, got %q", got.Name)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Debounced wraps an event handler so it only runs once events stop
+// arriving for ms milliseconds, e.g. a search box:
+//
+//	OnInput(runtime.Debounced(search, 200))
+//
+// Each event restarts a JS setTimeout; when it fires, the handler runs with
+// the last event (trailing invocation). The timer's js.Func releases itself
+// once it fires or is superseded, so nothing outlives the element's own
+// listener, which Unmount releases as usual.
+func Debounced(handler func(Event), ms int) func(Event) {
+	var (
+		mu      sync.Mutex
+		latest  Event
+		timeout js.Value
+		pending js.Func
+	)
+
+	return func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		latest = e
+		if !pending.IsUndefined() {
+			js.Global().Call("clearTimeout", timeout)
+			pending.Release()
+		}
+
+		var fire js.Func
+		fire = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			mu.Lock()
+			e := latest
+			if pending.Equal(fire.Value) {
+				pending = js.Func{}
+			}
+			mu.Unlock()
+
+			fire.Release()
+			// Timer callbacks must not block the JS event loop
+			go handler(e)
+			return nil
+		})
+		pending = fire
+		timeout = js.Global().Call("setTimeout", fire, ms)
+	}
+}
+
+// Throttled wraps an event handler so it runs at most once every ms
+// milliseconds, e.g. pointer tracking on a canvas:
+//
+//	OnMouseOver(runtime.Throttled(track, 16))
+//
+// The first event runs the handler immediately; events within the following
+// ms milliseconds are dropped.
+func Throttled(handler func(Event), ms int) func(Event) {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	interval := time.Duration(ms) * time.Millisecond
+
+	return func(e Event) {
+		mu.Lock()
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < interval {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+
+		handler(e)
+	}
+}
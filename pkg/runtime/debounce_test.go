@@ -0,0 +1,80 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Rapid events are simulated by calling the wrapped handler in a tight loop,
+// as attachEventHandler would for each DOM event. Debounced schedules its
+// trailing call with the JS setTimeout, which the Node.js test runner
+// provides, so the tests sleep past the delay before checking calls.
+
+// recorder collects the keys of events passed to a handler
+type recorder struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (r *recorder) handle(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = append(r.keys, e.Key)
+}
+
+func (r *recorder) calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.keys...)
+}
+
+func TestDebouncedFiresTrailingCall(t *testing.T) {
+	rec := &recorder{}
+	handler := Debounced(rec.handle, 20)
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		handler(Event{Key: key})
+	}
+
+	if calls := rec.calls(); len(calls) != 0 {
+		t.Fatalf("Expected no calls before the delay, got %v", calls)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	calls := rec.calls()
+	if len(calls) != 1 || calls[0] != "d" {
+		t.Fatalf("Expected a single trailing call with the last event, got %v", calls)
+	}
+
+	// A later burst fires again
+	handler(Event{Key: "e"})
+	time.Sleep(100 * time.Millisecond)
+
+	if calls := rec.calls(); len(calls) != 2 || calls[1] != "e" {
+		t.Errorf("Expected a second trailing call, got %v", calls)
+	}
+}
+
+func TestThrottledDropsEventsWithinInterval(t *testing.T) {
+	rec := &recorder{}
+	handler := Throttled(rec.handle, 50)
+
+	for _, key := range []string{"a", "b", "c"} {
+		handler(Event{Key: key})
+	}
+
+	if calls := rec.calls(); len(calls) != 1 || calls[0] != "a" {
+		t.Fatalf("Expected only the first event to run, got %v", calls)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	handler(Event{Key: "d"})
+
+	if calls := rec.calls(); len(calls) != 2 || calls[1] != "d" {
+		t.Errorf("Expected an event after the interval to run, got %v", calls)
+	}
+}
@@ -25,6 +25,9 @@ const (
 	PatchReplace
 	// PatchUpdateChildren recursively updates children
 	PatchUpdateChildren
+	// PatchKeyedChildren reconciles keyed children in place, moving
+	// existing DOM nodes instead of recreating them
+	PatchKeyedChildren
 )
 
 // Patch represents a change to apply to the DOM
@@ -56,6 +59,11 @@ func Diff(oldNode, newNode *VNode) []Patch {
 		}}
 	}
 
+	// A memoized component reuses the VNode of its last render
+	if oldNode == newNode {
+		return nil
+	}
+
 	// Different types - replace entirely
 	if oldNode.Type != newNode.Type {
 		return []Patch{{
@@ -103,8 +111,42 @@ func Diff(oldNode, newNode *VNode) []Patch {
 		patches = append(patches, childPatches...)
 
 	case FragmentNode:
-		childPatches := DiffChildren(oldNode, newNode)
-		patches = append(patches, childPatches...)
+		// Empty fragments mount as a placeholder comment, so switching
+		// between empty and non-empty replaces the node
+		if (len(oldNode.Children) == 0) != (len(newNode.Children) == 0) {
+			return []Patch{{
+				Type:    PatchReplace,
+				OldNode: oldNode,
+				NewNode: newNode,
+			}}
+		}
+		// The children have no element of their own, so they are
+		// reconciled in place among their parent's other nodes
+		if len(newNode.Children) > 0 {
+			patches = append(patches, Patch{
+				Type:    PatchKeyedChildren,
+				OldNode: oldNode,
+				NewNode: newNode,
+			})
+		}
+
+	case PortalNode:
+		if oldNode.Target != newNode.Target {
+			return []Patch{{
+				Type:    PatchReplace,
+				OldNode: oldNode,
+				NewNode: newNode,
+			}}
+		}
+		// The children share the target with other nodes, so they are
+		// reconciled in place relative to each other rather than by index
+		if len(oldNode.Children) > 0 || len(newNode.Children) > 0 {
+			patches = append(patches, Patch{
+				Type:    PatchKeyedChildren,
+				OldNode: oldNode,
+				NewNode: newNode,
+			})
+		}
 	}
 
 	return patches
@@ -119,6 +161,16 @@ func DiffChildren(oldParent, newParent *VNode) []Patch {
 		return nil
 	}
 
+	// Keyed children are reconciled directly against the DOM so matched
+	// nodes can be moved (keeping focus and input state) rather than replaced
+	if hasKeyedChildren(oldChildren) || hasKeyedChildren(newChildren) {
+		return []Patch{{
+			Type:    PatchKeyedChildren,
+			OldNode: oldParent,
+			NewNode: newParent,
+		}}
+	}
+
 	var patches []Patch
 
 	// Build key maps for keyed reconciliation
@@ -252,11 +304,131 @@ func ApplyPatch(patch Patch) error {
 
 	case PatchReplace:
 		return ReplaceNode(patch.OldNode, patch.NewNode)
+
+	case PatchKeyedChildren:
+		parent := childrenParent(patch.OldNode)
+		if parent.IsUndefined() || parent.IsNull() {
+			return nil
+		}
+		return diffChildren(parent, patch.OldNode.Children, patch.NewNode.Children)
+	}
+
+	return nil
+}
+
+// diffChildren reconciles oldChildren against newChildren under parent.
+// Children with a key are matched by key and their DOM nodes moved into the
+// new order; unkeyed children are matched by index as in DiffChildren. Only
+// unmatched new children are created and unmatched old children removed.
+func diffChildren(parent js.Value, oldChildren, newChildren []*VNode) error {
+	oldKeyMap := make(map[interface{}]int)
+	for i, child := range oldChildren {
+		if child.Key != nil {
+			oldKeyMap[child.Key] = i
+		}
+	}
+
+	matched := make([]bool, len(oldChildren))
+	sources := make([]*VNode, len(newChildren))
+
+	for newIdx, newChild := range newChildren {
+		oldIdx := -1
+		if newChild.Key != nil {
+			if idx, exists := oldKeyMap[newChild.Key]; exists && !matched[idx] {
+				oldIdx = idx
+			}
+		} else if newIdx < len(oldChildren) && oldChildren[newIdx].Key == nil {
+			oldIdx = newIdx
+		}
+
+		if oldIdx >= 0 {
+			matched[oldIdx] = true
+			sources[newIdx] = oldChildren[oldIdx]
+		}
+	}
+
+	// Children occupy a contiguous run inside parent (a fragment may share
+	// it with siblings), so insert relative to the node after the old run
+	anchor := js.Null()
+	for i := len(oldChildren) - 1; i >= 0; i-- {
+		if nodes := domNodes(oldChildren[i]); len(nodes) > 0 {
+			anchor = nodes[len(nodes)-1].Get("nextSibling")
+			break
+		}
+	}
+
+	// Walk backwards so each child can be placed before its successor
+	next := anchor
+	for i := len(newChildren) - 1; i >= 0; i-- {
+		newChild := newChildren[i]
+
+		if oldChild := sources[i]; oldChild != nil {
+			if err := ApplyPatches(Diff(oldChild, newChild)); err != nil {
+				return err
+			}
+			CopyDOMRefs(oldChild, newChild)
+
+			// A keyed fragment moves all of its nodes
+			if nodes := domNodes(newChild); len(nodes) > 0 && !nodes[len(nodes)-1].Get("nextSibling").Equal(next) {
+				MoveNode(newChild, parent, next)
+			}
+		} else {
+			if next.IsNull() {
+				if err := Mount(newChild, parent); err != nil {
+					return err
+				}
+			} else if err := InsertBefore(parent, newChild, next); err != nil {
+				return err
+			}
+		}
+
+		if nodes := domNodes(newChild); len(nodes) > 0 {
+			next = nodes[0]
+		}
+	}
+
+	for i, wasMatched := range matched {
+		if !wasMatched {
+			Unmount(oldChildren[i])
+		}
 	}
 
 	return nil
 }
 
+// childrenParent returns the DOM node that holds a VNode's children.
+// Fragments have no element of their own, so their children's parent is
+// used; a portal's children are in its target.
+func childrenParent(vnode *VNode) js.Value {
+	if vnode.Type == PortalNode {
+		return portalTarget(vnode)
+	}
+	if vnode.Type != FragmentNode {
+		return vnode.DOMNode
+	}
+	if nodes := domNodes(vnode); len(nodes) > 0 {
+		return nodes[0].Get("parentNode")
+	}
+	return js.Undefined()
+}
+
+// domNodes returns the DOM nodes a mounted VNode occupies in its parent. A
+// non-empty fragment's own node is emptied when it is inserted, so the nodes
+// of its children are returned instead.
+func domNodes(vnode *VNode) []js.Value {
+	if vnode.Type != FragmentNode || len(vnode.Children) == 0 {
+		if vnode.DOMNode.IsUndefined() || vnode.DOMNode.IsNull() {
+			return nil
+		}
+		return []js.Value{vnode.DOMNode}
+	}
+	var nodes []js.Value
+	for _, child := range vnode.Children {
+		nodes = append(nodes, domNodes(child)...)
+	}
+	return nodes
+}
+
 // ApplyPatches applies a list of patches to the DOM
 func ApplyPatches(patches []Patch) error {
 	for _, patch := range patches {
@@ -267,6 +439,19 @@ func ApplyPatches(patches []Patch) error {
 	return nil
 }
 
+// Reconcile updates the DOM rendered from oldNode to match newNode, touching
+// only what differs between them: changed attributes go through
+// UpdateElement, changed text through SetTextContent and nodes of another
+// type through ReplaceNode. newNode takes over the DOM nodes of oldNode, so
+// it is the tree to diff the next render against.
+func Reconcile(oldNode, newNode *VNode) error {
+	if err := ApplyPatches(Diff(oldNode, newNode)); err != nil {
+		return err
+	}
+	CopyDOMRefs(oldNode, newNode)
+	return nil
+}
+
 // CopyDOMRefs copies DOMNode references from old VNode tree to new VNode tree
 // This preserves DOM references after updates so subsequent diffs can find nodes
 func CopyDOMRefs(oldNode, newNode *VNode) {
@@ -279,9 +464,27 @@ func CopyDOMRefs(oldNode, newNode *VNode) {
 		newNode.DOMNode = oldNode.DOMNode
 	}
 
-	// Recursively copy for children (match by position)
+	// Recursively copy for children (match by key, otherwise by position)
 	oldChildren := oldNode.Children
 	newChildren := newNode.Children
+
+	if hasKeyedChildren(oldChildren) || hasKeyedChildren(newChildren) {
+		oldKeyMap := make(map[interface{}]*VNode)
+		for _, child := range oldChildren {
+			if child.Key != nil {
+				oldKeyMap[child.Key] = child
+			}
+		}
+		for i, child := range newChildren {
+			if child.Key != nil {
+				CopyDOMRefs(oldKeyMap[child.Key], child)
+			} else if i < len(oldChildren) && oldChildren[i].Key == nil {
+				CopyDOMRefs(oldChildren[i], child)
+			}
+		}
+		return
+	}
+
 	minLen := len(oldChildren)
 	if len(newChildren) < minLen {
 		minLen = len(newChildren)
@@ -294,6 +497,15 @@ func CopyDOMRefs(oldNode, newNode *VNode) {
 
 // Helper functions
 
+func hasKeyedChildren(children []*VNode) bool {
+	for _, child := range children {
+		if child.Key != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func attrsChanged(old, new map[string]string) bool {
 	if len(old) != len(new) {
 		return true
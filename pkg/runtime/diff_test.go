@@ -0,0 +1,405 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strconv"
+	"syscall/js"
+	"testing"
+)
+
+// fakeDocumentJS is a minimal DOM implementation, enough for Mount and the
+// diff/patch functions to run outside a browser
+const fakeDocumentJS = `(function() {
+	function Node(type, tag) {
+		this.nodeType = type;
+		this.tagName = tag;
+		this.childNodes = [];
+		this.parentNode = null;
+		this.attributes = {};
+		this.textContent = "";
+	}
+	Object.defineProperty(Node.prototype, "nextSibling", {
+		get: function() {
+			if (!this.parentNode) return null;
+			var siblings = this.parentNode.childNodes;
+			var i = siblings.indexOf(this);
+			return i + 1 < siblings.length ? siblings[i + 1] : null;
+		}
+	});
+	Node.prototype.detach = function() {
+		if (this.parentNode) {
+			var siblings = this.parentNode.childNodes;
+			siblings.splice(siblings.indexOf(this), 1);
+			this.parentNode = null;
+		}
+	};
+	Node.prototype.insertBefore = function(child, ref) {
+		if (child.nodeType === 11) {
+			var moved = child.childNodes.slice();
+			for (var i = 0; i < moved.length; i++) this.insertBefore(moved[i], ref);
+			return child;
+		}
+		child.detach();
+		var idx = ref ? this.childNodes.indexOf(ref) : -1;
+		if (idx < 0) idx = this.childNodes.length;
+		this.childNodes.splice(idx, 0, child);
+		child.parentNode = this;
+		return child;
+	};
+	Node.prototype.appendChild = function(child) { return this.insertBefore(child, null); };
+	Node.prototype.removeChild = function(child) { child.detach(); return child; };
+	Node.prototype.replaceChild = function(newChild, oldChild) {
+		this.insertBefore(newChild, oldChild);
+		oldChild.detach();
+		return oldChild;
+	};
+	Node.prototype.setAttribute = function(k, v) { this.attributes[k] = String(v); };
+	Node.prototype.removeAttribute = function(k) { delete this.attributes[k]; };
+	Node.prototype.addEventListener = function() {};
+	return {
+		createElement: function(tag) { return new Node(1, tag); },
+		createTextNode: function(text) { var n = new Node(3); n.textContent = text; return n; },
+		createComment: function(text) { var n = new Node(8); n.textContent = text; return n; },
+		createDocumentFragment: function() { return new Node(11); }
+	};
+})()`
+
+// installFakeDocument replaces the global document for the duration of a test
+func installFakeDocument(t *testing.T) {
+	t.Helper()
+
+	global := js.Global()
+	previous := global.Get("document")
+	global.Set("document", global.Call("eval", fakeDocumentJS))
+	t.Cleanup(func() { global.Set("document", previous) })
+}
+
+func keyedList(keys ...string) *VNode {
+	items := make([]interface{}, len(keys))
+	for i, key := range keys {
+		items[i] = Span(WithKey(key), Text(key))
+	}
+	return Div(items...)
+}
+
+func TestKeyedReorderReusesDOMNodes(t *testing.T) {
+	installFakeDocument(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	oldTree := keyedList("a", "b", "c")
+	if err := Mount(oldTree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	original := make(map[string]js.Value)
+	for _, child := range oldTree.Children {
+		original[child.Key.(string)] = child.DOMNode
+	}
+
+	newTree := keyedList("c", "a", "b")
+	if err := ApplyPatches(Diff(oldTree, newTree)); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+	CopyDOMRefs(oldTree, newTree)
+
+	domChildren := oldTree.DOMNode.Get("childNodes")
+	if domChildren.Length() != 3 {
+		t.Fatalf("Expected 3 DOM children, got %d", domChildren.Length())
+	}
+
+	for i, key := range []string{"c", "a", "b"} {
+		node := domChildren.Index(i)
+		if !node.Equal(original[key]) {
+			t.Errorf("Position %d: expected reused DOM node for key %q", i, key)
+		}
+		if !newTree.Children[i].DOMNode.Equal(original[key]) {
+			t.Errorf("Position %d: new VNode for key %q does not reference the reused DOM node", i, key)
+		}
+	}
+}
+
+func TestKeyedDiffCreatesAndRemovesDelta(t *testing.T) {
+	installFakeDocument(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	oldTree := keyedList("a", "b", "c")
+	if err := Mount(oldTree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	nodeA := oldTree.Children[0].DOMNode
+	nodeC := oldTree.Children[2].DOMNode
+
+	newTree := keyedList("c", "d", "a")
+	if err := ApplyPatches(Diff(oldTree, newTree)); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+
+	domChildren := oldTree.DOMNode.Get("childNodes")
+	if domChildren.Length() != 3 {
+		t.Fatalf("Expected 3 DOM children, got %d", domChildren.Length())
+	}
+
+	if !domChildren.Index(0).Equal(nodeC) {
+		t.Error("Expected node c to be reused at position 0")
+	}
+	if got := domChildren.Index(1).Get("childNodes").Index(0).Get("textContent").String(); got != "d" {
+		t.Errorf("Expected new node d at position 1, got %q", got)
+	}
+	if !domChildren.Index(2).Equal(nodeA) {
+		t.Error("Expected node a to be reused at position 2")
+	}
+}
+
+func TestUnkeyedChildrenUseIndexDiff(t *testing.T) {
+	oldTree := Div(Span(Text("a")), Span(Text("b")))
+	newTree := Div(Span(Text("b")), Span(Text("a")))
+
+	for _, patch := range Diff(oldTree, newTree) {
+		if patch.Type == PatchKeyedChildren || patch.Type == PatchMove {
+			t.Errorf("Unexpected patch type %d for unkeyed children", patch.Type)
+		}
+	}
+}
+
+// rowList renders a table body where each key expands into a row and its
+// detail row
+func rowList(keys ...string) *VNode {
+	items := make([]interface{}, len(keys))
+	for i, key := range keys {
+		items[i] = KeyedFragment(key, El("tr", Text(key)), El("tr", Class("detail"), Text(key+" detail")))
+	}
+	return El("tbody", items...)
+}
+
+func TestKeyedFragmentsReorderAsGroups(t *testing.T) {
+	installFakeDocument(t)
+
+	root := js.Global().Get("document").Call("createElement", "table")
+	oldTree := rowList("a", "b", "c")
+	if len(oldTree.Children) != 3 {
+		t.Fatalf("Expected keyed fragments to stay grouped, got %d children", len(oldTree.Children))
+	}
+	if err := Mount(oldTree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	original := make(map[string][2]js.Value)
+	for _, child := range oldTree.Children {
+		original[child.Key.(string)] = [2]js.Value{child.Children[0].DOMNode, child.Children[1].DOMNode}
+	}
+
+	// c moves to the front, b is removed and d is added between
+	newTree := rowList("c", "a", "d")
+	if err := Reconcile(oldTree, newTree); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	domChildren := oldTree.DOMNode.Get("childNodes")
+	if domChildren.Length() != 6 {
+		t.Fatalf("Expected 6 rows, got %d", domChildren.Length())
+	}
+	for i, key := range []string{"c", "a", "d"} {
+		row, detail := domChildren.Index(2*i), domChildren.Index(2*i+1)
+		if got := row.Get("childNodes").Index(0).Get("textContent").String(); got != key {
+			t.Errorf("Row %d: expected %q, got %q", 2*i, key, got)
+		}
+		if got := detail.Get("childNodes").Index(0).Get("textContent").String(); got != key+" detail" {
+			t.Errorf("Row %d: expected the detail of %q, got %q", 2*i+1, key, got)
+		}
+		if nodes, ok := original[key]; ok && (!row.Equal(nodes[0]) || !detail.Equal(nodes[1])) {
+			t.Errorf("Expected the rows of %q to be reused", key)
+		}
+		if !newTree.Children[i].Children[0].DOMNode.Equal(row) {
+			t.Errorf("Expected the new VNode of %q to reference its row", key)
+		}
+	}
+
+	// The new tree can be diffed again, e.g. reversing it
+	lastTree := rowList("d", "a", "c")
+	if err := Reconcile(newTree, lastTree); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	for i, key := range []string{"d", "d detail", "a", "a detail", "c", "c detail"} {
+		if got := domChildren.Index(i).Get("childNodes").Index(0).Get("textContent").String(); got != key {
+			t.Errorf("Row %d: expected %q, got %q", i, key, got)
+		}
+	}
+}
+
+func TestEmptyFragmentSwapsWithElement(t *testing.T) {
+	installFakeDocument(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	oldTree := Div(Span(Text("a")), Fragment())
+	if err := Mount(oldTree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	domChildren := oldTree.DOMNode.Get("childNodes")
+	if got := domChildren.Index(1).Get("nodeType").Int(); got != 8 {
+		t.Fatalf("Expected empty fragment to mount as a comment, got node type %d", got)
+	}
+
+	newTree := Div(Span(Text("a")), Button(Text("b")))
+	if err := ApplyPatches(Diff(oldTree, newTree)); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+	CopyDOMRefs(oldTree, newTree)
+
+	if domChildren.Length() != 2 {
+		t.Fatalf("Expected 2 DOM children, got %d", domChildren.Length())
+	}
+	if got := domChildren.Index(1).Get("tagName").String(); got != "button" {
+		t.Errorf("Expected button to replace the placeholder, got %q", got)
+	}
+
+	// Switching back to a multi-node fragment inserts its children in place
+	nextTree := Div(Span(Text("a")), Fragment(Span(Text("c")), Span(Text("d"))))
+	if err := ApplyPatches(Diff(newTree, nextTree)); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+	CopyDOMRefs(newTree, nextTree)
+
+	if domChildren.Length() != 3 {
+		t.Fatalf("Expected 3 DOM children, got %d", domChildren.Length())
+	}
+
+	// And an empty fragment replaces them with the placeholder again
+	lastTree := Div(Span(Text("a")), Fragment())
+	if err := ApplyPatches(Diff(nextTree, lastTree)); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+
+	if domChildren.Length() != 2 {
+		t.Fatalf("Expected 2 DOM children, got %d", domChildren.Length())
+	}
+	if got := domChildren.Index(1).Get("nodeType").Int(); got != 8 {
+		t.Errorf("Expected placeholder comment, got node type %d", got)
+	}
+}
+
+func TestNestedFragmentsFlatten(t *testing.T) {
+	installFakeDocument(t)
+
+	tree := Div(
+		Span(Text("a")),
+		Fragment(Span(Text("b")), Fragment(Span(Text("c")), Fragment(Span(Text("d"))))),
+		Fragment(),
+	)
+
+	// The nested fragments become siblings; only the empty placeholder stays
+	if len(tree.Children) != 5 {
+		t.Fatalf("Expected 5 children, got %d", len(tree.Children))
+	}
+	for i, want := range []string{"a", "b", "c", "d"} {
+		child := tree.Children[i]
+		if child.Type != ElementNode || child.Children[0].Text != want {
+			t.Errorf("Child %d: expected span %q, got type %d", i, want, child.Type)
+		}
+	}
+	if last := tree.Children[4]; last.Type != FragmentNode || len(last.Children) != 0 {
+		t.Errorf("Expected the empty fragment to be kept as a placeholder")
+	}
+
+	fragment := Fragment(Fragment(Text("x"), Text("y")), Text("z"))
+	if len(fragment.Children) != 3 || fragment.Children[0].Type != TextNode {
+		t.Errorf("Expected a fragment within a fragment to flatten to 3 text nodes, got %d children", len(fragment.Children))
+	}
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	if err := Mount(tree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+	if got := tree.DOMNode.Get("childNodes").Length(); got != 5 {
+		t.Errorf("Expected 5 DOM children, got %d", got)
+	}
+}
+
+// ticker is written like a generated component: Mount keeps the rendered
+// tree and Update reconciles a fresh render against it
+type ticker struct {
+	count int
+	vnode *VNode
+}
+
+func (c *ticker) Render() *VNode {
+	return Div(Class("ticker"), H1(Text("Ticks")), Span(Text(strconv.Itoa(c.count))))
+}
+
+func (c *ticker) Mount(parent js.Value) {
+	c.vnode = c.Render()
+	Mount(c.vnode, parent)
+}
+
+func (c *ticker) Unmount() {}
+
+func (c *ticker) Update() {
+	if c.vnode == nil {
+		return
+	}
+	next := c.Render()
+	Reconcile(c.vnode, next)
+	c.vnode = next
+}
+
+// countTextWrites makes every DOM node of a mounted tree count the writes
+// to its textContent, returning the total so far
+func countTextWrites(vnode *VNode) func() int {
+	counter := js.Global().Call("eval", `(function() {
+		var counter = {writes: 0};
+		counter.watch = function(node) {
+			var text = node.textContent;
+			Object.defineProperty(node, "textContent", {
+				get: function() { return text; },
+				set: function(v) { counter.writes++; text = v; }
+			});
+		};
+		return counter;
+	})()`)
+
+	var watch func(*VNode)
+	watch = func(v *VNode) {
+		counter.Call("watch", v.DOMNode)
+		for _, child := range v.Children {
+			watch(child)
+		}
+	}
+	watch(vnode)
+
+	return func() int { return counter.Get("writes").Int() }
+}
+
+func TestReconcilePatchesOnlyChangedText(t *testing.T) {
+	installFakeDocument(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	c := &ticker{count: 1}
+	c.Mount(root)
+
+	before := c.vnode
+	span := before.Children[1].DOMNode
+	writes := countTextWrites(before)
+
+	c.count = 2
+	patches := Diff(before, c.Render())
+	if len(patches) != 1 || patches[0].Type != PatchUpdateText {
+		t.Fatalf("Expected a single text patch, got %+v", patches)
+	}
+
+	c.Update()
+
+	if got := writes(); got != 1 {
+		t.Errorf("Expected SetTextContent to write once, got %d writes", got)
+	}
+	if got := span.Get("childNodes").Index(0).Get("textContent").String(); got != "2" {
+		t.Errorf("Expected the text to read 2, got %q", got)
+	}
+	if !c.vnode.Children[1].DOMNode.Equal(span) {
+		t.Error("Expected the new tree to reference the existing span")
+	}
+	if !root.Get("childNodes").Index(0).Equal(before.DOMNode) {
+		t.Error("Expected the root element to be kept")
+	}
+}
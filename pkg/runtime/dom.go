@@ -5,7 +5,9 @@ package runtime
 
 import (
 	"fmt"
+	"sync"
 	"syscall/js"
+	"time"
 )
 
 // console provides access to browser console for debugging
@@ -42,10 +44,22 @@ func Mount(vnode *VNode, parent js.Value) error {
 
 	vnode.DOMNode = domNode
 	parent.Call("appendChild", domNode)
+	mounted(vnode)
 	log("DOM: Successfully mounted", vnode.Tag)
 	return nil
 }
 
+// mounted calls the OnMount hooks of a VNode and its descendants, children
+// first, once its DOM node has been inserted into its parent
+func mounted(vnode *VNode) {
+	for _, child := range vnode.Children {
+		mounted(child)
+	}
+	if vnode.OnMount != nil && !vnode.DOMNode.IsUndefined() {
+		vnode.OnMount(vnode.DOMNode)
+	}
+}
+
 // createDOMNode creates a real DOM node from a VNode
 func createDOMNode(vnode *VNode) (js.Value, error) {
 	doc := js.Global().Get("document")
@@ -57,20 +71,12 @@ func createDOMNode(vnode *VNode) (js.Value, error) {
 	case ElementNode:
 		elem := doc.Call("createElement", vnode.Tag)
 
-		// Set attributes
+		// Set attributes and properties, routed by the domProperties registry
 		for key, value := range vnode.Attributes {
-			elem.Call("setAttribute", key, value)
+			setAttribute(elem, key, value)
 		}
-
-		// Set properties (skip functions, they're stored in VNode for later use)
 		for key, value := range vnode.Properties {
-			// Skip function properties - they're not DOM attributes
-			switch value.(type) {
-			case func(float64, interface{}), func(*GPUCanvas), func(*GPUCanvas, float64):
-				// Skip function callbacks - they're handled by the runtime
-				continue
-			}
-			elem.Set(key, value)
+			setProperty(elem, key, value)
 		}
 
 		// Attach event handlers
@@ -130,6 +136,12 @@ func createDOMNode(vnode *VNode) (js.Value, error) {
 		return elem, nil
 
 	case FragmentNode:
+		// An empty fragment mounts as a comment so it keeps a position in
+		// its parent and can later be replaced, e.g. by the other if branch
+		if len(vnode.Children) == 0 {
+			return doc.Call("createComment", ""), nil
+		}
+
 		frag := doc.Call("createDocumentFragment")
 		for _, child := range vnode.Children {
 			childNode, err := createDOMNode(child)
@@ -141,12 +153,76 @@ func createDOMNode(vnode *VNode) (js.Value, error) {
 		}
 		return frag, nil
 
+	case PortalNode:
+		return createPortal(vnode)
+
 	default:
 		return js.Undefined(), fmt.Errorf("unsupported vnode type: %d", vnode.Type)
 	}
 }
 
-// attachEventHandler attaches a Go event handler to a DOM element
+// newEvent wraps a native DOM event, copying the target value and the
+// keyboard and wheel fields that are present
+func newEvent(jsEvent js.Value) Event {
+	// Create Go Event wrapper
+	event := Event{
+		Native: jsEvent,
+		Type:   jsEvent.Get("type").String(),
+	}
+
+	// Extract target information
+	target := jsEvent.Get("target")
+	event.Target = EventTarget{
+		Native: target,
+	}
+
+	// Get value if it exists
+	if target.Get("value").Type() == js.TypeString {
+		event.Target.Value = target.Get("value").String()
+		log("DOM: Event target value:", event.Target.Value)
+	}
+
+	// Get checked if it exists
+	if target.Get("checked").Type() == js.TypeBoolean {
+		event.Target.Checked = target.Get("checked").Bool()
+	}
+
+	// Extract keyboard event fields if they exist
+	if jsEvent.Get("key").Type() == js.TypeString {
+		event.Key = jsEvent.Get("key").String()
+	}
+	if jsEvent.Get("code").Type() == js.TypeString {
+		event.Code = jsEvent.Get("code").String()
+	}
+	if jsEvent.Get("ctrlKey").Type() == js.TypeBoolean {
+		event.CtrlKey = jsEvent.Get("ctrlKey").Bool()
+	}
+	if jsEvent.Get("shiftKey").Type() == js.TypeBoolean {
+		event.ShiftKey = jsEvent.Get("shiftKey").Bool()
+	}
+	if jsEvent.Get("altKey").Type() == js.TypeBoolean {
+		event.AltKey = jsEvent.Get("altKey").Bool()
+	}
+	if jsEvent.Get("metaKey").Type() == js.TypeBoolean {
+		event.MetaKey = jsEvent.Get("metaKey").Bool()
+	}
+
+	// Extract wheel event fields if they exist
+	if jsEvent.Get("deltaX").Type() == js.TypeNumber {
+		event.DeltaX = jsEvent.Get("deltaX").Float()
+	}
+	if jsEvent.Get("deltaY").Type() == js.TypeNumber {
+		event.DeltaY = jsEvent.Get("deltaY").Float()
+	}
+	if jsEvent.Get("deltaMode").Type() == js.TypeNumber {
+		event.DeltaMode = jsEvent.Get("deltaMode").Int()
+	}
+
+	return event
+}
+
+// attachEventHandler attaches a Go event handler to a DOM element, as an
+// active listener unless the handler is Passive
 func attachEventHandler(elem js.Value, eventName string, handler EventHandler, vnode *VNode) {
 	jsFunc := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		if len(args) == 0 {
@@ -156,48 +232,13 @@ func attachEventHandler(elem js.Value, eventName string, handler EventHandler, v
 
 		jsEvent := args[0]
 		log("DOM: Event fired:", eventName, "on element:", elem.Get("tagName"))
-
-		// Create Go Event wrapper
-		event := Event{
-			Native: jsEvent,
-			Type:   jsEvent.Get("type").String(),
-		}
-
-		// Extract target information
-		target := jsEvent.Get("target")
-		event.Target = EventTarget{
-			Native: target,
-		}
-
-		// Get value if it exists
-		if target.Get("value").Type() == js.TypeString {
-			event.Target.Value = target.Get("value").String()
-			log("DOM: Event target value:", event.Target.Value)
-		}
-
-		// Get checked if it exists
-		if target.Get("checked").Type() == js.TypeBoolean {
-			event.Target.Checked = target.Get("checked").Bool()
-		}
-
-		// Extract keyboard event fields if they exist
-		if jsEvent.Get("key").Type() == js.TypeString {
-			event.Key = jsEvent.Get("key").String()
-		}
-		if jsEvent.Get("code").Type() == js.TypeString {
-			event.Code = jsEvent.Get("code").String()
+		event := newEvent(jsEvent)
+		// The handler runs after dispatch, too late to cancel the event
+		if handler.PreventDefault || (handler.PreventDefaultIf != nil && handler.PreventDefaultIf(event)) {
+			jsEvent.Call("preventDefault")
 		}
-		if jsEvent.Get("ctrlKey").Type() == js.TypeBoolean {
-			event.CtrlKey = jsEvent.Get("ctrlKey").Bool()
-		}
-		if jsEvent.Get("shiftKey").Type() == js.TypeBoolean {
-			event.ShiftKey = jsEvent.Get("shiftKey").Bool()
-		}
-		if jsEvent.Get("altKey").Type() == js.TypeBoolean {
-			event.AltKey = jsEvent.Get("altKey").Bool()
-		}
-		if jsEvent.Get("metaKey").Type() == js.TypeBoolean {
-			event.MetaKey = jsEvent.Get("metaKey").Bool()
+		if handler.StopPropagation {
+			jsEvent.Call("stopPropagation")
 		}
 
 		log("DOM: Calling event handler in goroutine")
@@ -219,14 +260,37 @@ func attachEventHandler(elem js.Value, eventName string, handler EventHandler, v
 	handler.jsFunc = jsFunc
 	vnode.Events[eventName] = handler
 
-	elem.Call("addEventListener", eventName, jsFunc)
+	elem.Call("addEventListener", eventName, jsFunc, map[string]interface{}{
+		"passive": handler.Passive && !handler.PreventDefault && handler.PreventDefaultIf == nil,
+	})
 }
 
 // Unmount removes a VNode from the DOM and cleans up resources
 func Unmount(vnode *VNode) {
-	if vnode == nil || vnode.DOMNode.IsUndefined() {
+	unmount(vnode, true)
+}
+
+// unmount cleans up the resources of a VNode and its children. detach
+// removes its DOM node from its parent; the children of an element are left
+// in it while a leave transition delays its removal.
+func unmount(vnode *VNode, detach bool) {
+	if vnode == nil {
 		return
 	}
+	forgetRender(vnode)
+	if vnode.DOMNode.IsUndefined() {
+		return
+	}
+
+	var delay time.Duration
+	if vnode.OnBeforeUnmount != nil {
+		delay = vnode.OnBeforeUnmount(vnode.DOMNode)
+	}
+
+	// Release GPU resources owned by a WebGPU canvas
+	if vnode.Type == ElementNode && vnode.Tag == "canvas" {
+		teardownGPUCanvas(vnode.DOMNode)
+	}
 
 	// Clean up event handlers
 	for _, handler := range vnode.Events {
@@ -235,15 +299,20 @@ func Unmount(vnode *VNode) {
 		}
 	}
 
-	// Recursively unmount children
+	// Recursively unmount children. The children of a portal are in its
+	// target, so they are removed even when the portal stays in place.
+	detachChildren := (detach && delay <= 0) || vnode.Type == PortalNode
 	for _, child := range vnode.Children {
-		Unmount(child)
+		unmount(child, detachChildren)
 	}
 
 	// Remove from DOM
-	parent := vnode.DOMNode.Get("parentNode")
-	if !parent.IsUndefined() && !parent.IsNull() {
-		parent.Call("removeChild", vnode.DOMNode)
+	switch {
+	case !detach:
+	case delay > 0:
+		removeAfter(vnode.DOMNode, delay)
+	default:
+		removeDOMNode(vnode.DOMNode)
 	}
 
 	vnode.DOMNode = js.Undefined()
@@ -260,20 +329,28 @@ func UpdateElement(vnode *VNode, oldAttrs, newAttrs map[string]string, oldProps,
 	// Remove old attributes
 	for key := range oldAttrs {
 		if _, exists := newAttrs[key]; !exists {
-			elem.Call("removeAttribute", key)
+			removeAttribute(elem, key)
 		}
 	}
 
 	// Set new/updated attributes
 	for key, value := range newAttrs {
 		if oldVal, exists := oldAttrs[key]; !exists || oldVal != value {
-			elem.Call("setAttribute", key, value)
+			setAttribute(elem, key, value)
+		}
+	}
+
+	// Remove old properties
+	for key := range oldProps {
+		if _, exists := newProps[key]; !exists {
+			removeProperty(elem, key)
 		}
 	}
 
-	// Update properties
+	// Set properties. DOM properties like value and checked are compared
+	// with the element, which the user may have changed since the last render.
 	for key, value := range newProps {
-		elem.Set(key, value)
+		setProperty(elem, key, value)
 	}
 
 	// Note: Event handlers are NOT updated here
@@ -296,7 +373,14 @@ func ReplaceNode(oldVNode, newVNode *VNode) error {
 		return fmt.Errorf("old vnode has no DOM node")
 	}
 
-	parent := oldVNode.DOMNode.Get("parentNode")
+	// A mounted fragment's DOM node is emptied on insertion, so its
+	// children mark where it is
+	oldDOMNode := oldVNode.DOMNode
+	if nodes := domNodes(oldVNode); len(nodes) > 0 {
+		oldDOMNode = nodes[0]
+	}
+
+	parent := oldDOMNode.Get("parentNode")
 	if parent.IsUndefined() || parent.IsNull() {
 		return fmt.Errorf("old vnode has no parent")
 	}
@@ -309,11 +393,11 @@ func ReplaceNode(oldVNode, newVNode *VNode) error {
 
 	newVNode.DOMNode = newDOMNode
 
-	// Replace in DOM
-	parent.Call("replaceChild", newDOMNode, oldVNode.DOMNode)
-
-	// Clean up old node
+	// Insert the new node in front of the old one, which Unmount removes,
+	// after the delay of its OnBeforeUnmount hook if it has one
+	parent.Call("insertBefore", newDOMNode, oldDOMNode)
 	Unmount(oldVNode)
+	mounted(newVNode)
 
 	return nil
 }
@@ -327,19 +411,80 @@ func InsertBefore(parent js.Value, vnode *VNode, referenceNode js.Value) error {
 
 	vnode.DOMNode = domNode
 	parent.Call("insertBefore", domNode, referenceNode)
+	mounted(vnode)
 	return nil
 }
 
-// MoveNode moves a VNode to a new position
+// MoveNode moves a VNode to a new position. A fragment moves the nodes of
+// its children, keeping their order.
 func MoveNode(vnode *VNode, parent js.Value, beforeNode js.Value) {
-	if vnode.DOMNode.IsUndefined() {
+	for _, node := range domNodes(vnode) {
+		if beforeNode.IsUndefined() || beforeNode.IsNull() {
+			parent.Call("appendChild", node)
+		} else {
+			parent.Call("insertBefore", node, beforeNode)
+		}
+	}
+}
+
+// gpuRenderer is implemented by SceneRenderer and ChartRenderer
+type gpuRenderer interface {
+	Render()
+	Cleanup()
+}
+
+// gpuCanvasBinding ties a canvas element to the GPU canvas and renderer
+// created for it, so they can be torn down when the element is unmounted
+type gpuCanvasBinding struct {
+	canvas   *GPUCanvas
+	renderer gpuRenderer
+}
+
+// gpuCanvasIDProp is the JS property used to find a canvas's binding
+const gpuCanvasIDProp = "__guixGPUCanvasID"
+
+var gpuCanvasBindings = struct {
+	sync.Mutex
+	nextID   int
+	bindings map[int]*gpuCanvasBinding
+}{bindings: make(map[int]*gpuCanvasBinding)}
+
+// registerGPUCanvas records the renderer owning a canvas element
+func registerGPUCanvas(canvasElem js.Value, canvas *GPUCanvas, renderer gpuRenderer) {
+	gpuCanvasBindings.Lock()
+	defer gpuCanvasBindings.Unlock()
+
+	gpuCanvasBindings.nextID++
+	id := gpuCanvasBindings.nextID
+	gpuCanvasBindings.bindings[id] = &gpuCanvasBinding{canvas: canvas, renderer: renderer}
+	canvasElem.Set(gpuCanvasIDProp, id)
+}
+
+// teardownGPUCanvas stops the render loop of a canvas element and destroys
+// the GPU resources held by its renderer
+func teardownGPUCanvas(canvasElem js.Value) {
+	idValue := canvasElem.Get(gpuCanvasIDProp)
+	if idValue.Type() != js.TypeNumber {
+		return
+	}
+	id := idValue.Int()
+
+	gpuCanvasBindings.Lock()
+	binding, ok := gpuCanvasBindings.bindings[id]
+	delete(gpuCanvasBindings.bindings, id)
+	gpuCanvasBindings.Unlock()
+
+	canvasElem.Delete(gpuCanvasIDProp)
+	if !ok {
 		return
 	}
 
-	if beforeNode.IsUndefined() || beforeNode.IsNull() {
-		parent.Call("appendChild", vnode.DOMNode)
-	} else {
-		parent.Call("insertBefore", vnode.DOMNode, beforeNode)
+	log("WebGPU: Tearing down canvas resources")
+	if binding.canvas != nil {
+		binding.canvas.Unmount()
+	}
+	if binding.renderer != nil {
+		binding.renderer.Cleanup()
 	}
 }
 
@@ -354,9 +499,9 @@ func initializeWebGPUCanvas(canvasElem js.Value, scene Scene, vnode *VNode) {
 		return
 	}
 
-	// Initialize WebGPU
+	// Share the device with the other canvases on the page
 	log("WebGPU: Initializing WebGPU context")
-	gpuCtx, err := InitWebGPU()
+	gpuCtx, err := GetOrInitGPUContext()
 	if err != nil {
 		logError("WebGPU: Failed to initialize:", err)
 		showCanvasError(canvasElem, fmt.Sprintf("Failed to initialize WebGPU: %v", err))
@@ -386,6 +531,7 @@ func initializeWebGPUCanvas(canvasElem js.Value, scene Scene, vnode *VNode) {
 		DevicePixelRatio: 1.0,
 		AlphaMode:        "premultiplied",
 		FrameLoop:        "always",
+		SampleCount:      4,
 	}
 
 	log("WebGPU: Creating GPU canvas with config:", fmt.Sprintf("width=%d, height=%d", width, height))
@@ -425,6 +571,8 @@ func initializeWebGPUCanvas(canvasElem js.Value, scene Scene, vnode *VNode) {
 		}
 	}
 
+	registerGPUCanvas(canvasElem, canvas, renderer)
+
 	// Set render function
 	canvas.SetRenderFunc(func(c *GPUCanvas, delta float64) {
 		// Call update callback if provided
@@ -451,9 +599,9 @@ func initializeWebGPUChartCanvas(canvasElem js.Value, chart Chart, vnode *VNode)
 		return
 	}
 
-	// Initialize WebGPU
+	// Share the device with the other canvases on the page
 	log("WebGPU: Initializing WebGPU context for chart")
-	gpuCtx, err := InitWebGPU()
+	gpuCtx, err := GetOrInitGPUContext()
 	if err != nil {
 		logError("WebGPU: Failed to initialize:", err)
 		showCanvasError(canvasElem, fmt.Sprintf("Failed to initialize WebGPU: %v", err))
@@ -483,6 +631,7 @@ func initializeWebGPUChartCanvas(canvasElem js.Value, chart Chart, vnode *VNode)
 		DevicePixelRatio: 1.0,
 		AlphaMode:        "premultiplied",
 		FrameLoop:        "always",
+		SampleCount:      4,
 	}
 
 	log("WebGPU: Creating GPU canvas for chart with config:", fmt.Sprintf("width=%d, height=%d", width, height))
@@ -513,6 +662,8 @@ func initializeWebGPUChartCanvas(canvasElem js.Value, chart Chart, vnode *VNode)
 
 	log("WebGPU: Chart renderer created successfully")
 
+	registerGPUCanvas(canvasElem, canvas, renderer)
+
 	// Set render function
 	canvas.SetRenderFunc(func(c *GPUCanvas, delta float64) {
 		renderer.Render()
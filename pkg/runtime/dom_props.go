@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+)
+
+// domProperty is a prop that must be set as a DOM property. Its attribute
+// only holds the initial state: once the user types into an input or
+// toggles a checkbox, setAttribute no longer changes what is shown.
+type domProperty struct {
+	Name string      // Name of the JS property, e.g. readOnly for readonly
+	Zero interface{} // Value restored when the prop is removed; its type is the property's
+}
+
+// domProperties lists the prop names routed to DOM properties. Any other
+// attribute is set with setAttribute.
+var domProperties = map[string]domProperty{
+	"value":         {Name: "value", Zero: ""},
+	"checked":       {Name: "checked", Zero: false},
+	"selected":      {Name: "selected", Zero: false},
+	"disabled":      {Name: "disabled", Zero: false},
+	"indeterminate": {Name: "indeterminate", Zero: false},
+	"readonly":      {Name: "readOnly", Zero: false},
+	"multiple":      {Name: "multiple", Zero: false},
+	"muted":         {Name: "muted", Zero: false},
+}
+
+// setAttribute sets an attribute of a vnode on elem. Attributes naming a
+// DOM property set the property instead; boolean ones are true when present.
+func setAttribute(elem js.Value, key, value string) {
+	prop, ok := domProperties[key]
+	if !ok {
+		elem.Call("setAttribute", key, value)
+		return
+	}
+	if _, isBool := prop.Zero.(bool); isBool {
+		setProperty(elem, key, true)
+		return
+	}
+	setProperty(elem, key, value)
+}
+
+// removeAttribute removes an attribute of a vnode from elem, resetting the
+// DOM property it names
+func removeAttribute(elem js.Value, key string) {
+	if _, ok := domProperties[key]; ok {
+		removeProperty(elem, key)
+		return
+	}
+	elem.Call("removeAttribute", key)
+}
+
+// setProperty sets a property of a vnode on elem. Registered DOM properties
+// are only written when the element's current value differs, so re-rendering
+// an input doesn't move its cursor. Names that can't be JS properties, like
+// aria-* and data-*, are set as attributes. Go callbacks are skipped; the
+// runtime handles them.
+func setProperty(elem js.Value, key string, value interface{}) {
+	if isFunction(value) {
+		return
+	}
+	if prop, ok := domProperties[key]; ok {
+		if !elem.Get(prop.Name).Equal(js.ValueOf(value)) {
+			elem.Set(prop.Name, value)
+		}
+		return
+	}
+	if strings.Contains(key, "-") {
+		elem.Call("setAttribute", key, fmt.Sprint(value))
+		return
+	}
+	elem.Set(key, value)
+}
+
+// removeProperty removes a property of a vnode from elem. DOM properties
+// are reset to their zero value, since deleting them has no effect.
+func removeProperty(elem js.Value, key string) {
+	if prop, ok := domProperties[key]; ok {
+		elem.Set(prop.Name, prop.Zero)
+		return
+	}
+	if strings.Contains(key, "-") {
+		elem.Call("removeAttribute", key)
+		return
+	}
+	elem.Delete(key)
+}
@@ -0,0 +1,179 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// fakeGPUBuffer returns a GPUBuffer backed by a JS object whose destroy()
+// call is recorded in destroyed
+func fakeGPUBuffer(t *testing.T, destroyed *bool) *GPUBuffer {
+	t.Helper()
+
+	destroy := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		*destroyed = true
+		return nil
+	})
+	t.Cleanup(destroy.Release)
+
+	obj := js.Global().Get("Object").New()
+	obj.Set("destroy", destroy)
+	return &GPUBuffer{Buffer: obj}
+}
+
+func TestUnmountCanvasDestroysChartBuffers(t *testing.T) {
+	var uniformDestroyed bool
+	var candleDestroyed, lineDestroyed [2]bool
+
+	// Two series of each type
+	renderer := &ChartRenderer{
+		UniformBuffer: fakeGPUBuffer(t, &uniformDestroyed),
+		LineBuffers:   []*GPUBuffer{fakeGPUBuffer(t, &lineDestroyed[0]), fakeGPUBuffer(t, &lineDestroyed[1])},
+		candleCaches: map[*GPUNode]*candleCache{
+			CandlestickSeries(): {buffer: fakeGPUBuffer(t, &candleDestroyed[0])},
+			CandlestickSeries(): {buffer: fakeGPUBuffer(t, &candleDestroyed[1])},
+		},
+	}
+
+	canvasElem := js.Global().Get("Object").New()
+	registerGPUCanvas(canvasElem, &GPUCanvas{}, renderer)
+
+	vnode := Canvas()
+	vnode.DOMNode = canvasElem
+	Unmount(vnode)
+
+	if !uniformDestroyed {
+		t.Error("Expected uniform buffer to be destroyed on unmount")
+	}
+	for i := range candleDestroyed {
+		if !candleDestroyed[i] {
+			t.Errorf("Expected the data buffer of candle series %d to be destroyed on unmount", i)
+		}
+		if !lineDestroyed[i] {
+			t.Errorf("Expected the data buffer of line series %d to be destroyed on unmount", i)
+		}
+	}
+
+	if renderer.UniformBuffer != nil || len(renderer.candleCaches) != 0 || renderer.LineBuffers != nil {
+		t.Error("Expected renderer buffers to be cleared after cleanup")
+	}
+
+	if !canvasElem.Get(gpuCanvasIDProp).IsUndefined() {
+		t.Error("Expected canvas binding to be removed after unmount")
+	}
+}
+
+func TestUnmountCanvasDestroysSceneBuffers(t *testing.T) {
+	var uniformDestroyed, vertexDestroyed, indexDestroyed bool
+
+	renderer := &SceneRenderer{
+		UniformBuffer: fakeGPUBuffer(t, &uniformDestroyed),
+		Meshes: []*MeshInstance{
+			{
+				VertexBuffer: fakeGPUBuffer(t, &vertexDestroyed),
+				IndexBuffer:  fakeGPUBuffer(t, &indexDestroyed),
+			},
+		},
+	}
+
+	canvasElem := js.Global().Get("Object").New()
+	registerGPUCanvas(canvasElem, &GPUCanvas{}, renderer)
+
+	vnode := Canvas()
+	vnode.DOMNode = canvasElem
+	Unmount(vnode)
+
+	if !uniformDestroyed || !vertexDestroyed || !indexDestroyed {
+		t.Errorf("Expected all scene buffers destroyed, got uniform=%v vertex=%v index=%v",
+			uniformDestroyed, vertexDestroyed, indexDestroyed)
+	}
+}
+
+// fakeElement returns a JS object standing in for a DOM element. Its
+// attributes are recorded in the returned map.
+func fakeElement(t *testing.T) (js.Value, map[string]string) {
+	t.Helper()
+
+	attrs := make(map[string]string)
+	setAttr := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		attrs[args[0].String()] = args[1].String()
+		return nil
+	})
+	removeAttr := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		delete(attrs, args[0].String())
+		return nil
+	})
+	t.Cleanup(setAttr.Release)
+	t.Cleanup(removeAttr.Release)
+
+	elem := js.Global().Get("Object").New()
+	elem.Set("setAttribute", setAttr)
+	elem.Set("removeAttribute", removeAttr)
+	return elem, attrs
+}
+
+func TestUpdateElementSetsValueProperty(t *testing.T) {
+	elem, attrs := fakeElement(t)
+	old := Input(Type("text"), Value("draft"))
+	old.DOMNode = elem
+	elem.Set("value", "draft")
+
+	// The user edits the input, then the component saves and clears it
+	elem.Set("value", "draft typed")
+	updated := Input(Type("text"), Value(""))
+	UpdateElement(old, old.Attributes, updated.Attributes, old.Properties, updated.Properties)
+
+	if got := elem.Get("value").String(); got != "" {
+		t.Errorf("Expected the value property to be cleared, got %q", got)
+	}
+	if _, ok := attrs["value"]; ok {
+		t.Error("Expected value not to be set as an attribute")
+	}
+}
+
+func TestUpdateElementSetsCheckedProperty(t *testing.T) {
+	elem, attrs := fakeElement(t)
+	old := Input(Type("checkbox"))
+	old.DOMNode = elem
+
+	checked := Input(Type("checkbox"), Checked(true))
+	UpdateElement(old, old.Attributes, checked.Attributes, old.Properties, checked.Properties)
+	if !elem.Get("checked").Bool() {
+		t.Error("Expected the checked property to be set")
+	}
+	if _, ok := attrs["checked"]; ok {
+		t.Error("Expected checked not to be set as an attribute")
+	}
+
+	// Removing the prop unchecks the input
+	UpdateElement(old, checked.Attributes, old.Attributes, checked.Properties, old.Properties)
+	if elem.Get("checked").Bool() {
+		t.Error("Expected the checked property to be reset when the prop is removed")
+	}
+}
+
+func TestSetAttributeRoutesDOMProperties(t *testing.T) {
+	elem, attrs := fakeElement(t)
+
+	setAttribute(elem, "type", "checkbox")
+	setAttribute(elem, "value", "on")
+	setAttribute(elem, "readonly", "")
+
+	if attrs["type"] != "checkbox" || len(attrs) != 1 {
+		t.Errorf("Expected only type as an attribute, got %v", attrs)
+	}
+	if elem.Get("value").String() != "on" {
+		t.Errorf("Expected the value property to be on, got %v", elem.Get("value"))
+	}
+	// Boolean properties are true when the attribute is present
+	if !elem.Get("readOnly").Bool() {
+		t.Error("Expected readonly to set the readOnly property")
+	}
+
+	removeAttribute(elem, "readonly")
+	if elem.Get("readOnly").Bool() {
+		t.Error("Expected removing readonly to reset the readOnly property")
+	}
+}
@@ -0,0 +1,96 @@
+//go:build js && wasm
+
+package runtime
+
+import "syscall/js"
+
+// KeyboardEvent is the event of a keyboard handler, e.g.
+// OnKeyDown(func(e KeyboardEvent) { ... }). Key, Code and the modifier keys
+// are promoted from Event.
+type KeyboardEvent struct {
+	Event
+	Repeat   bool // The key is held down and auto-repeating
+	Location int  // Location of the key on the keyboard (0 standard, 1 left, 2 right, 3 numpad)
+}
+
+// MouseEvent is the event of a mouse handler, e.g.
+// OnClick(func(e MouseEvent) { ... })
+type MouseEvent struct {
+	Event
+	ClientX float64 // Horizontal position in the viewport
+	ClientY float64 // Vertical position in the viewport
+	OffsetX float64 // Horizontal position within the target element
+	OffsetY float64 // Vertical position within the target element
+	Button  int     // Button that changed state (0 main, 1 auxiliary, 2 secondary)
+	Buttons int     // Bitmask of the buttons held down
+}
+
+// WheelEvent is the event of a wheel handler, e.g.
+// OnWheel(func(e WheelEvent) { ... }). DeltaX, DeltaY and DeltaMode are
+// promoted from Event.
+type WheelEvent struct {
+	MouseEvent
+}
+
+// KeyboardHandler adapts a handler taking a KeyboardEvent to an event prop
+func KeyboardHandler(handler func(KeyboardEvent)) func(Event) {
+	return func(e Event) {
+		handler(newKeyboardEvent(e))
+	}
+}
+
+// MouseHandler adapts a handler taking a MouseEvent to an event prop
+func MouseHandler(handler func(MouseEvent)) func(Event) {
+	return func(e Event) {
+		handler(newMouseEvent(e))
+	}
+}
+
+// WheelHandler adapts a handler taking a WheelEvent to an event prop
+func WheelHandler(handler func(WheelEvent)) func(Event) {
+	return func(e Event) {
+		handler(WheelEvent{MouseEvent: newMouseEvent(e)})
+	}
+}
+
+// newKeyboardEvent copies the keyboard fields of the native event
+func newKeyboardEvent(e Event) KeyboardEvent {
+	event := KeyboardEvent{Event: e}
+	if e.Native.IsUndefined() || e.Native.IsNull() {
+		return event
+	}
+	if v := e.Native.Get("repeat"); v.Type() == js.TypeBoolean {
+		event.Repeat = v.Bool()
+	}
+	if v := e.Native.Get("location"); v.Type() == js.TypeNumber {
+		event.Location = v.Int()
+	}
+	return event
+}
+
+// newMouseEvent copies the pointer position and buttons of the native event
+func newMouseEvent(e Event) MouseEvent {
+	event := MouseEvent{Event: e}
+	if e.Native.IsUndefined() || e.Native.IsNull() {
+		return event
+	}
+	if v := e.Native.Get("clientX"); v.Type() == js.TypeNumber {
+		event.ClientX = v.Float()
+	}
+	if v := e.Native.Get("clientY"); v.Type() == js.TypeNumber {
+		event.ClientY = v.Float()
+	}
+	if v := e.Native.Get("offsetX"); v.Type() == js.TypeNumber {
+		event.OffsetX = v.Float()
+	}
+	if v := e.Native.Get("offsetY"); v.Type() == js.TypeNumber {
+		event.OffsetY = v.Float()
+	}
+	if v := e.Native.Get("button"); v.Type() == js.TypeNumber {
+		event.Button = v.Int()
+	}
+	if v := e.Native.Get("buttons"); v.Type() == js.TypeNumber {
+		event.Buttons = v.Int()
+	}
+	return event
+}
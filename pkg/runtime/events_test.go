@@ -0,0 +1,164 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+func TestNewEventWheelFields(t *testing.T) {
+	native := js.Global().Call("eval", `({
+		type: "wheel",
+		target: {},
+		deltaX: 1.5,
+		deltaY: -120,
+		deltaMode: 1,
+		ctrlKey: true
+	})`)
+
+	event := newEvent(native)
+
+	if event.Type != "wheel" {
+		t.Errorf("Expected type wheel, got %q", event.Type)
+	}
+	if event.DeltaX != 1.5 || event.DeltaY != -120 || event.DeltaMode != 1 {
+		t.Errorf("Expected deltas (1.5, -120, 1), got (%v, %v, %v)", event.DeltaX, event.DeltaY, event.DeltaMode)
+	}
+	if !event.CtrlKey {
+		t.Error("Expected ctrlKey to be copied for pinch-zoom wheel events")
+	}
+}
+
+func TestNewEventWithoutWheelFields(t *testing.T) {
+	native := js.Global().Call("eval", `({type: "scroll", target: {value: "x"}})`)
+
+	event := newEvent(native)
+
+	if event.DeltaX != 0 || event.DeltaY != 0 || event.DeltaMode != 0 {
+		t.Errorf("Expected zero deltas for non-wheel event, got (%v, %v, %v)", event.DeltaX, event.DeltaY, event.DeltaMode)
+	}
+	if event.Target.Value != "x" {
+		t.Errorf("Expected target value x, got %q", event.Target.Value)
+	}
+}
+
+func TestWheelScrollContextMenuHandlers(t *testing.T) {
+	noop := func(Event) {}
+	for name, handler := range map[string]EventHandler{
+		"wheel":       OnWheel(noop),
+		"scroll":      OnScroll(noop),
+		"contextmenu": OnContextMenu(noop),
+	} {
+		if handler.Name != name {
+			t.Errorf("Expected event name %q, got %q", name, handler.Name)
+		}
+	}
+}
+
+func TestKeyboardHandlerReceivesKeyAndCode(t *testing.T) {
+	native := js.Global().Call("eval", `({
+		type: "keydown",
+		target: {},
+		key: "a",
+		code: "KeyA",
+		repeat: true,
+		location: 0
+	})`)
+
+	var got KeyboardEvent
+	OnKeyDown(KeyboardHandler(func(e KeyboardEvent) { got = e })).Handler(newEvent(native))
+
+	if got.Key != "a" || got.Code != "KeyA" {
+		t.Errorf("Expected key a with code KeyA, got %q %q", got.Key, got.Code)
+	}
+	if !got.Repeat {
+		t.Error("Expected repeat to be copied from the native event")
+	}
+}
+
+func TestWheelHandlerReceivesPositionAndDeltas(t *testing.T) {
+	native := js.Global().Call("eval", `({
+		type: "wheel",
+		target: {},
+		deltaY: 40,
+		clientX: 12,
+		clientY: 34,
+		buttons: 0
+	})`)
+
+	var got WheelEvent
+	OnWheel(WheelHandler(func(e WheelEvent) { got = e })).Handler(newEvent(native))
+
+	if got.DeltaY != 40 || got.ClientX != 12 || got.ClientY != 34 {
+		t.Errorf("Expected deltaY 40 at (12, 34), got %v at (%v, %v)", got.DeltaY, got.ClientX, got.ClientY)
+	}
+}
+
+func TestEventPreventDefaultAndStopPropagation(t *testing.T) {
+	native := js.Global().Call("eval", `({
+		type: "click",
+		target: {},
+		calls: [],
+		preventDefault() { this.calls.push("preventDefault"); },
+		stopPropagation() { this.calls.push("stopPropagation"); }
+	})`)
+
+	event := newEvent(native)
+	event.PreventDefault()
+	event.StopPropagation()
+
+	if got := js.Global().Get("JSON").Call("stringify", native.Get("calls")).String(); got != `["preventDefault","stopPropagation"]` {
+		t.Errorf("Expected preventDefault and stopPropagation to be called, got %s", got)
+	}
+
+	// Events without a native value, e.g. in tests, are left alone
+	Event{}.PreventDefault()
+	Event{}.StopPropagation()
+}
+
+func TestAttachEventHandlerListenerOptions(t *testing.T) {
+	noop := func(Event) {}
+	for _, tc := range []struct {
+		name    string
+		handler EventHandler
+		passive bool
+		calls   string
+	}{
+		{"active by default", OnWheel(noop), false, `[]`},
+		{"passive", Passive(OnWheel(noop)), true, `[]`},
+		{"prevent default", PreventDefault(OnWheel(noop)), false, `["preventDefault"]`},
+		{"passive ignored with prevent default", Passive(PreventDefault(OnWheel(noop))), false, `["preventDefault"]`},
+		{"stop propagation", StopPropagation(OnClick(noop)), false, `["stopPropagation"]`},
+		{"prevent default if accepted", PreventDefaultIf(OnKeyDown(noop), func(e Event) bool { return e.Key == "ArrowDown" }), false, `["preventDefault"]`},
+		{"prevent default if rejected", PreventDefaultIf(OnKeyDown(noop), func(e Event) bool { return e.Key == "Tab" }), false, `[]`},
+		{"passive ignored with prevent default if", Passive(PreventDefaultIf(OnWheel(noop), func(Event) bool { return false })), false, `[]`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			elem := js.Global().Call("eval", `({
+				addEventListener(name, fn, options) { this.listener = fn; this.options = options; }
+			})`)
+			vnode := &VNode{Events: make(map[string]EventHandler)}
+			attachEventHandler(elem, tc.handler.Name, tc.handler, vnode)
+			defer vnode.Events[tc.handler.Name].jsFunc.Release()
+
+			if passive := elem.Get("options").Get("passive").Bool(); passive != tc.passive {
+				t.Errorf("Expected passive %v, got %v", tc.passive, passive)
+			}
+
+			// The event is cancelled while the browser dispatches it
+			native := js.Global().Call("eval", `({
+				type: "wheel",
+				key: "ArrowDown",
+				target: {},
+				calls: [],
+				preventDefault() { this.calls.push("preventDefault"); },
+				stopPropagation() { this.calls.push("stopPropagation"); }
+			})`)
+			elem.Call("listener", native)
+			if got := js.Global().Get("JSON").Call("stringify", native.Get("calls")).String(); got != tc.calls {
+				t.Errorf("Expected calls %s during dispatch, got %s", tc.calls, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"syscall/js"
+	"time"
+)
+
+// FetchOptions configures a Fetch request
+type FetchOptions struct {
+	Method  string            // HTTP method; empty means GET
+	Headers map[string]string // Request headers
+	Body    []byte            // Request body, sent as is
+	Timeout time.Duration     // Aborts the request after Timeout; 0 waits for the response
+}
+
+// Response is the response to a Fetch request
+type Response struct {
+	Status     int
+	StatusText string
+	Headers    map[string]string // Keyed by lowercase header name
+	Body       []byte
+}
+
+// OK reports whether the status is in the 2xx range
+func (r *Response) OK() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+// JSON decodes the body as JSON into v
+func (r *Response) JSON(v interface{}) error {
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// HTTPError is the error of a Fetch request answered with a non-2xx status
+type HTTPError struct {
+	Response *Response
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d %s", e.Response.Status, e.Response.StatusText)
+}
+
+// Fetch sends an HTTP request with the browser's fetch API and reads the
+// whole response. It blocks until the response arrives, so components call
+// it from a goroutine and send the result to a channel:
+//
+//	go func() {
+//	    resp, err := runtime.Fetch("/api/users", runtime.FetchOptions{Timeout: 5 * time.Second})
+//	    if err != nil {
+//	        errors <- err
+//	        return
+//	    }
+//	    var list []User
+//	    if err := resp.JSON(&list); err != nil {
+//	        errors <- err
+//	        return
+//	    }
+//	    users <- list
+//	}()
+//
+// Network failures, e.g. CORS errors, and timeouts return a nil Response.
+// A non-2xx status returns the Response with an *HTTPError, so its body can
+// still be read.
+func Fetch(url string, opts FetchOptions) (*Response, error) {
+	fetch := js.Global().Get("fetch")
+	if fetch.Type() != js.TypeFunction {
+		return nil, fmt.Errorf("fetch API not available")
+	}
+
+	init := map[string]interface{}{}
+	if opts.Method != "" {
+		init["method"] = opts.Method
+	}
+	if len(opts.Headers) > 0 {
+		headers := make(map[string]interface{}, len(opts.Headers))
+		for name, value := range opts.Headers {
+			headers[name] = value
+		}
+		init["headers"] = headers
+	}
+	if opts.Body != nil {
+		body := js.Global().Get("Uint8Array").New(len(opts.Body))
+		js.CopyBytesToJS(body, opts.Body)
+		init["body"] = body
+	}
+
+	// The timeout aborts the request, rejecting the pending promise
+	if opts.Timeout > 0 {
+		controller := js.Global().Get("AbortController").New()
+		init["signal"] = controller.Get("signal")
+
+		abort := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			controller.Call("abort")
+			return nil
+		})
+		timeout := js.Global().Call("setTimeout", abort, opts.Timeout.Milliseconds())
+		defer func() {
+			js.Global().Call("clearTimeout", timeout)
+			abort.Release()
+		}()
+	}
+
+	response, err := awaitPromise(fetch.Invoke(url, init))
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", url, err)
+	}
+	buffer, err := awaitPromise(response.Call("arrayBuffer"))
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: failed to read body: %w", url, err)
+	}
+
+	resp := &Response{
+		Status:     response.Get("status").Int(),
+		StatusText: response.Get("statusText").String(),
+		Headers:    responseHeaders(response.Get("headers")),
+	}
+	bytes := js.Global().Get("Uint8Array").New(buffer)
+	resp.Body = make([]byte, bytes.Length())
+	js.CopyBytesToGo(resp.Body, bytes)
+
+	if !resp.OK() {
+		return resp, &HTTPError{Response: resp}
+	}
+	return resp, nil
+}
+
+// responseHeaders copies a Headers object into a map
+func responseHeaders(headers js.Value) map[string]string {
+	result := make(map[string]string)
+	if !isElement(headers) {
+		return result
+	}
+	entries := headers.Call("entries")
+	for entry := entries.Call("next"); !entry.Get("done").Bool(); entry = entries.Call("next") {
+		pair := entry.Get("value")
+		result[strings.ToLower(pair.Index(0).String())] = pair.Index(1).String()
+	}
+	return result
+}
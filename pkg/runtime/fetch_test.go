@@ -0,0 +1,97 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// installFakeFetch replaces fetch with a JS function, restoring it when the
+// test ends
+func installFakeFetch(t *testing.T, fn string) {
+	t.Helper()
+
+	global := js.Global()
+	original := global.Get("fetch")
+	global.Set("fetch", global.Call("eval", fn))
+	t.Cleanup(func() { global.Set("fetch", original) })
+}
+
+func TestFetchReadsResponse(t *testing.T) {
+	installFakeFetch(t, `(url, init) => {
+		globalThis.fetchRequest = {url, method: init.method, auth: init.headers.Authorization, body: new TextDecoder().decode(init.body)};
+		return Promise.resolve(new Response('{"name":"Ada"}', {
+			status: 201,
+			statusText: "Created",
+			headers: {"Content-Type": "application/json"}
+		}));
+	}`)
+
+	resp, err := Fetch("/api/users", FetchOptions{
+		Method:  "POST",
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Body:    []byte(`{"name":"Ada"}`),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	request := js.Global().Get("fetchRequest")
+	if request.Get("url").String() != "/api/users" || request.Get("method").String() != "POST" {
+		t.Errorf("Expected POST /api/users, got %s %s", request.Get("method"), request.Get("url"))
+	}
+	if request.Get("auth").String() != "Bearer token" || request.Get("body").String() != `{"name":"Ada"}` {
+		t.Errorf("Expected the headers and body to be sent, got %s %s", request.Get("auth"), request.Get("body"))
+	}
+
+	if resp.Status != 201 || resp.StatusText != "Created" || !resp.OK() {
+		t.Errorf("Expected 201 Created, got %d %s", resp.Status, resp.StatusText)
+	}
+	if resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected the content type header, got %v", resp.Headers)
+	}
+	var user struct{ Name string }
+	if err := resp.JSON(&user); err != nil || user.Name != "Ada" {
+		t.Errorf("Expected to decode name Ada, got %q (%v)", user.Name, err)
+	}
+}
+
+func TestFetchNon2xxStatus(t *testing.T) {
+	installFakeFetch(t, `() => Promise.resolve(new Response("no such user", {status: 404, statusText: "Not Found"}))`)
+
+	resp, err := Fetch("/api/users/7", FetchOptions{})
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || err.Error() != "HTTP 404 Not Found" {
+		t.Fatalf("Expected an HTTPError for 404, got %v", err)
+	}
+	if resp == nil || string(resp.Body) != "no such user" {
+		t.Errorf("Expected the response body to be readable, got %+v", resp)
+	}
+}
+
+func TestFetchNetworkError(t *testing.T) {
+	installFakeFetch(t, `() => Promise.reject(new TypeError("Failed to fetch"))`)
+
+	resp, err := Fetch("https://example.com", FetchOptions{})
+
+	if resp != nil || err == nil || !strings.Contains(err.Error(), "Failed to fetch") {
+		t.Errorf("Expected the network error without a response, got %+v, %v", resp, err)
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	installFakeFetch(t, `(url, init) => new Promise((resolve, reject) => {
+		init.signal.addEventListener("abort", () => reject(new Error("The operation was aborted")));
+	})`)
+
+	resp, err := Fetch("/slow", FetchOptions{Timeout: 10 * time.Millisecond})
+
+	if resp != nil || err == nil || !strings.Contains(err.Error(), "aborted") {
+		t.Errorf("Expected the request to be aborted, got %+v, %v", resp, err)
+	}
+}
@@ -65,6 +65,31 @@ func CreateIndexBuffer(ctx *GPUContext, data []uint16, label string) (*GPUBuffer
 	}, nil
 }
 
+// CreateIndexBuffer32 creates a uint32 index buffer and uploads data, for
+// geometry with more than 65535 vertices
+func CreateIndexBuffer32(ctx *GPUContext, data []uint32, label string) (*GPUBuffer, error) {
+	size := len(data) * 4 // 4 bytes per uint32
+	usage := GPUBufferUsageIndex | GPUBufferUsageCopyDst
+
+	buffer, err := ctx.CreateBuffer(size, usage, label)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert uint32 slice to bytes
+	bytes := uint32SliceToBytes(data)
+	if err := ctx.WriteBuffer(buffer, 0, bytes); err != nil {
+		return nil, err
+	}
+
+	return &GPUBuffer{
+		Buffer: buffer,
+		Size:   size,
+		Usage:  usage,
+		Label:  label,
+	}, nil
+}
+
 // CreateUniformBuffer creates a uniform buffer with specified size
 func CreateUniformBuffer(ctx *GPUContext, size int, label string) (*GPUBuffer, error) {
 	// Align to 256 bytes (WebGPU uniform buffer alignment requirement)
@@ -21,8 +21,24 @@ type GPUCanvas struct {
 	Running       bool
 	FrameCount    int
 	LastTime      float64
+	FrameLoop     string // "always", "demand", "never"
+	SampleCount   int    // Samples per pixel of render targets: 4 with MSAA, otherwise 1
+
+	renderRequested bool          // A demand-mode frame is scheduled
+	msaaTexture     js.Value      // Multisampled color target, resolved into the canvas texture
+	resize          *canvasResize // Observer of ObserveResize
 }
 
+// Frame loop modes of a GPU canvas
+const (
+	// FrameLoopAlways renders every animation frame
+	FrameLoopAlways = "always"
+	// FrameLoopDemand renders a frame only after RequestRender
+	FrameLoopDemand = "demand"
+	// FrameLoopNever renders a single frame when started
+	FrameLoopNever = "never"
+)
+
 // GPUCanvasConfig holds configuration for creating a GPU canvas
 type GPUCanvasConfig struct {
 	Width            int
@@ -30,6 +46,7 @@ type GPUCanvasConfig struct {
 	DevicePixelRatio float64
 	AlphaMode        string // "opaque", "premultiplied"
 	FrameLoop        string // "always", "demand", "never"
+	SampleCount      int    // 4 to antialias edges with MSAA; 0 or 1 renders single-sampled
 }
 
 // DefaultGPUCanvasConfig returns default canvas configuration
@@ -40,9 +57,27 @@ func DefaultGPUCanvasConfig() GPUCanvasConfig {
 		DevicePixelRatio: 1.0,
 		AlphaMode:        "premultiplied",
 		FrameLoop:        "always",
+		SampleCount:      4,
 	}
 }
 
+// multisampleFormats are the canvas formats that can be rendered with 4x MSAA
+var multisampleFormats = map[string]bool{
+	"bgra8unorm":  true,
+	"rgba8unorm":  true,
+	"rgba16float": true,
+}
+
+// supportedSampleCount returns the sample count to render a canvas of the
+// given format with: 4 when MSAA is requested and the format supports it,
+// otherwise 1. WebGPU supports no other sample counts.
+func supportedSampleCount(requested int, format string) int {
+	if requested > 1 && multisampleFormats[format] {
+		return 4
+	}
+	return 1
+}
+
 // CreateGPUCanvas creates a new GPU-enabled canvas element
 func CreateGPUCanvas(config GPUCanvasConfig) (*GPUCanvas, error) {
 	log("[Canvas] Creating GPU canvas")
@@ -84,23 +119,27 @@ func CreateGPUCanvas(config GPUCanvasConfig) (*GPUCanvas, error) {
 
 	// Configure the canvas context
 	log("[Canvas] Configuring canvas context")
+	// CopySrc lets Snapshot copy frames out of the canvas texture
 	configObj := map[string]interface{}{
 		"device":    gpuCtx.Device,
 		"format":    format,
 		"alphaMode": config.AlphaMode,
+		"usage":     GPUTextureUsageRenderAttachment | GPUTextureUsageCopySrc,
 	}
 	gpuCanvasCtx.Call("configure", configObj)
 
 	gpuCanvas := &GPUCanvas{
-		Canvas:     canvas,
-		Context:    gpuCanvasCtx,
-		GPUContext: gpuCtx,
-		Width:      config.Width,
-		Height:     config.Height,
-		Format:     format,
-		Running:    false,
-		FrameCount: 0,
-		LastTime:   0,
+		Canvas:      canvas,
+		Context:     gpuCanvasCtx,
+		GPUContext:  gpuCtx,
+		Width:       config.Width,
+		Height:      config.Height,
+		Format:      format,
+		Running:     false,
+		FrameCount:  0,
+		LastTime:    0,
+		FrameLoop:   config.FrameLoop,
+		SampleCount: supportedSampleCount(config.SampleCount, format),
 	}
 
 	log("[Canvas] GPU canvas created successfully")
@@ -136,23 +175,27 @@ func createGPUCanvasFromElement(canvasElem js.Value, config GPUCanvasConfig, gpu
 
 	// Configure the canvas context
 	log("[Canvas] Configuring canvas context")
+	// CopySrc lets Snapshot copy frames out of the canvas texture
 	configObj := map[string]interface{}{
 		"device":    gpuCtx.Device,
 		"format":    format,
 		"alphaMode": config.AlphaMode,
+		"usage":     GPUTextureUsageRenderAttachment | GPUTextureUsageCopySrc,
 	}
 	gpuCanvasCtx.Call("configure", configObj)
 
 	gpuCanvas := &GPUCanvas{
-		Canvas:     canvasElem,
-		Context:    gpuCanvasCtx,
-		GPUContext: gpuCtx,
-		Width:      config.Width,
-		Height:     config.Height,
-		Format:     format,
-		Running:    false,
-		FrameCount: 0,
-		LastTime:   0,
+		Canvas:      canvasElem,
+		Context:     gpuCanvasCtx,
+		GPUContext:  gpuCtx,
+		Width:       config.Width,
+		Height:      config.Height,
+		Format:      format,
+		Running:     false,
+		FrameCount:  0,
+		LastTime:    0,
+		FrameLoop:   config.FrameLoop,
+		SampleCount: supportedSampleCount(config.SampleCount, format),
 	}
 
 	log("[Canvas] GPU canvas created successfully from element")
@@ -164,26 +207,93 @@ func (gc *GPUCanvas) SetRenderFunc(renderFunc func(*GPUCanvas, float64)) {
 	gc.RenderFunc = renderFunc
 }
 
-// Start begins the render loop
+// Start begins rendering as the canvas FrameLoop mode schedules it: every
+// animation frame by default, on RequestRender in "demand" mode, or once in
+// "never" mode
 func (gc *GPUCanvas) Start() {
 	if gc.Running {
 		return
 	}
 
-	log("[Canvas] Starting render loop")
-	gc.Running = true
-	gc.startRenderLoop()
+	switch gc.FrameLoop {
+	case FrameLoopNever:
+		log("[Canvas] Rendering a single frame")
+		gc.RenderOnce()
+	case FrameLoopDemand:
+		log("[Canvas] Rendering on demand")
+		gc.Running = true
+	default:
+		log("[Canvas] Starting render loop")
+		gc.Running = true
+		gc.startRenderLoop()
+	}
 }
 
 // Stop halts the render loop
 func (gc *GPUCanvas) Stop() {
 	gc.Running = false
+	gc.renderRequested = false
 	if gc.AnimationID.Truthy() {
 		js.Global().Call("cancelAnimationFrame", gc.AnimationID)
 		gc.AnimationID = js.Undefined()
 	}
 }
 
+// RequestRender schedules a frame of a started canvas in "demand" mode, e.g.
+// after the data of a static chart changes. Requests made before the frame
+// runs are coalesced into it. In other modes it does nothing.
+func (gc *GPUCanvas) RequestRender() {
+	if gc.FrameLoop != FrameLoopDemand || !gc.Running || gc.renderRequested {
+		return
+	}
+
+	if !gc.FrameCallback.Value.Truthy() {
+		gc.FrameCallback = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			gc.renderRequested = false
+			if gc.Running {
+				gc.renderFrame(frameTime(args))
+			}
+			return nil
+		})
+	}
+	gc.renderRequested = true
+	gc.AnimationID = js.Global().Call("requestAnimationFrame", gc.FrameCallback)
+}
+
+// renderFrame calls the render function with the time since the last frame
+func (gc *GPUCanvas) renderFrame(currentTime float64) {
+	// Calculate delta time
+	delta := currentTime - gc.LastTime
+	if gc.LastTime == 0 {
+		delta = 0
+	}
+	gc.LastTime = currentTime
+
+	// Call user render function
+	if gc.RenderFunc != nil {
+		if gc.FrameCount%60 == 0 {
+			// Log every 60 frames to avoid spam
+			log(fmt.Sprintf("[Canvas] Render frame %d, delta: %.2fms", gc.FrameCount, delta))
+		}
+		gc.RenderFunc(gc, delta)
+	} else {
+		if gc.FrameCount%60 == 0 {
+			logError("[Canvas] RenderFunc is nil!")
+		}
+	}
+
+	gc.FrameCount++
+}
+
+// frameTime returns the timestamp requestAnimationFrame passes its callback,
+// or the current time
+func frameTime(args []js.Value) float64 {
+	if len(args) > 0 {
+		return args[0].Float()
+	}
+	return js.Global().Get("performance").Call("now").Float()
+}
+
 // startRenderLoop initiates the requestAnimationFrame loop
 func (gc *GPUCanvas) startRenderLoop() {
 	log("[Canvas] startRenderLoop called")
@@ -196,35 +306,7 @@ func (gc *GPUCanvas) startRenderLoop() {
 			return nil
 		}
 
-		// Get current time from args
-		var currentTime float64
-		if len(args) > 0 {
-			currentTime = args[0].Float()
-		} else {
-			currentTime = js.Global().Get("performance").Call("now").Float()
-		}
-
-		// Calculate delta time
-		delta := currentTime - gc.LastTime
-		if gc.LastTime == 0 {
-			delta = 0
-		}
-		gc.LastTime = currentTime
-
-		// Call user render function
-		if gc.RenderFunc != nil {
-			if gc.FrameCount%60 == 0 {
-				// Log every 60 frames to avoid spam
-				log(fmt.Sprintf("[Canvas] Render frame %d, delta: %.2fms", gc.FrameCount, delta))
-			}
-			gc.RenderFunc(gc, delta)
-		} else {
-			if gc.FrameCount%60 == 0 {
-				logError("[Canvas] RenderFunc is nil!")
-			}
-		}
-
-		gc.FrameCount++
+		gc.renderFrame(frameTime(args))
 
 		// Request next frame
 		gc.AnimationID = js.Global().Call("requestAnimationFrame", renderFrame)
@@ -255,14 +337,61 @@ func (gc *GPUCanvas) GetCurrentTextureView() js.Value {
 	return texture.Call("createView")
 }
 
-// BeginRenderPass begins a render pass with the current texture as the color attachment
+// colorTarget returns the view a render pass draws into and, with MSAA, the
+// view of the canvas texture its samples are resolved into. The multisampled
+// texture is recreated when the canvas size changes.
+func (gc *GPUCanvas) colorTarget() (view, resolveTarget js.Value) {
+	texture := gc.GetCurrentTexture()
+	if !texture.Truthy() {
+		return js.Undefined(), js.Undefined()
+	}
+	if gc.SampleCount <= 1 {
+		return texture.Call("createView"), js.Undefined()
+	}
+
+	width, height := texture.Get("width").Int(), texture.Get("height").Int()
+	if !gc.msaaTexture.Truthy() ||
+		gc.msaaTexture.Get("width").Int() != width || gc.msaaTexture.Get("height").Int() != height {
+		gc.destroyMSAATexture()
+		gc.msaaTexture = gc.createRenderTarget(width, height, gc.Format, "canvas-msaa-texture")
+	}
+	return gc.msaaTexture.Call("createView"), texture.Call("createView")
+}
+
+// createRenderTarget creates a texture to render into with the canvas
+// sample count
+func (gc *GPUCanvas) createRenderTarget(width, height int, format, label string) js.Value {
+	return gc.GPUContext.Device.Call("createTexture", map[string]interface{}{
+		"label": label,
+		"size": map[string]interface{}{
+			"width":              width,
+			"height":             height,
+			"depthOrArrayLayers": 1,
+		},
+		"format":      format,
+		"sampleCount": max(gc.SampleCount, 1),
+		"usage":       GPUTextureUsageRenderAttachment,
+	})
+}
+
+// destroyMSAATexture releases the multisampled color target
+func (gc *GPUCanvas) destroyMSAATexture() {
+	if gc.msaaTexture.Truthy() {
+		gc.msaaTexture.Call("destroy")
+		gc.msaaTexture = js.Undefined()
+	}
+}
+
+// BeginRenderPass begins a render pass with the current texture as the color
+// attachment. With MSAA it draws into the multisampled texture and resolves
+// into the current texture.
 func (gc *GPUCanvas) BeginRenderPass(encoder js.Value, clearColor [4]float32, loadOp string) js.Value {
 	if !encoder.Truthy() {
 		logError("Command encoder is undefined")
 		return js.Undefined()
 	}
 
-	textureView := gc.GetCurrentTextureView()
+	textureView, resolveTarget := gc.colorTarget()
 	if !textureView.Truthy() {
 		logError("Failed to get current texture view")
 		return js.Undefined()
@@ -279,6 +408,9 @@ func (gc *GPUCanvas) BeginRenderPass(encoder js.Value, clearColor [4]float32, lo
 		"loadOp":  loadOp,
 		"storeOp": "store",
 	}
+	if resolveTarget.Truthy() {
+		colorAttachment["resolveTarget"] = resolveTarget
+	}
 
 	// Only add clearValue if using "clear" loadOp
 	if loadOp == "clear" {
@@ -298,7 +430,8 @@ func (gc *GPUCanvas) BeginRenderPass(encoder js.Value, clearColor [4]float32, lo
 	return encoder.Call("beginRenderPass", renderPassDescriptor)
 }
 
-// BeginRenderPassWithDepth begins a render pass with depth testing
+// BeginRenderPassWithDepth begins a render pass with depth testing. The
+// depth texture must have the canvas sample count, as from CreateDepthTexture.
 func (gc *GPUCanvas) BeginRenderPassWithDepth(
 	encoder js.Value,
 	clearColor [4]float32,
@@ -311,7 +444,7 @@ func (gc *GPUCanvas) BeginRenderPassWithDepth(
 		return js.Undefined()
 	}
 
-	textureView := gc.GetCurrentTextureView()
+	textureView, resolveTarget := gc.colorTarget()
 	if !textureView.Truthy() {
 		logError("Failed to get current texture view")
 		return js.Undefined()
@@ -341,6 +474,9 @@ func (gc *GPUCanvas) BeginRenderPassWithDepth(
 			"a": clearColor[3],
 		},
 	}
+	if resolveTarget.Truthy() {
+		colorAttachment["resolveTarget"] = resolveTarget
+	}
 
 	// Create render pass descriptor
 	renderPassDescriptor := map[string]interface{}{
@@ -370,6 +506,9 @@ func (gc *GPUCanvas) Resize(width, height int) error {
 	}
 	gc.Context.Call("configure", configObj)
 
+	// Resizing clears the canvas, so a canvas rendering on demand redraws
+	gc.RequestRender()
+
 	return nil
 }
 
@@ -391,8 +530,11 @@ func (gc *GPUCanvas) Mount(selector string) error {
 // Unmount removes the canvas from the DOM
 func (gc *GPUCanvas) Unmount() {
 	gc.Stop()
+	gc.stopObservingResize()
+	gc.destroyMSAATexture()
 	if gc.FrameCallback.Value.Truthy() {
 		gc.FrameCallback.Release()
+		gc.FrameCallback = js.Func{}
 	}
 	if gc.Canvas.Truthy() {
 		parent := gc.Canvas.Get("parentNode")
@@ -410,15 +552,18 @@ func (gc *GPUCanvas) GetAspectRatio() float32 {
 	return float32(gc.Width) / float32(gc.Height)
 }
 
-// CreateDepthTexture creates a depth texture for the canvas
+// CreateDepthTexture creates a depth texture for the canvas, with its
+// sample count
 func (gc *GPUCanvas) CreateDepthTexture() (js.Value, error) {
-	return gc.GPUContext.CreateTexture(
-		gc.Width,
-		gc.Height,
-		"depth24plus",
-		GPUTextureUsageRenderAttachment,
-		"depth-texture",
-	)
+	if gc.GPUContext.Device.IsUndefined() {
+		return js.Undefined(), fmt.Errorf("GPU device not initialized")
+	}
+
+	texture := gc.createRenderTarget(gc.Width, gc.Height, "depth24plus", "depth-texture")
+	if !texture.Truthy() {
+		return js.Undefined(), fmt.Errorf("failed to create texture")
+	}
+	return texture, nil
 }
 
 // RenderOnce renders a single frame without starting the animation loop
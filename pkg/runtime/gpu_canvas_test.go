@@ -0,0 +1,265 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"sync"
+	"syscall/js"
+	"testing"
+)
+
+// stubAnimationFrames replaces requestAnimationFrame with a stub that queues
+// callbacks, returning a function that runs the queued frames
+func stubAnimationFrames(t *testing.T) (runFrames func(time float64)) {
+	t.Helper()
+
+	global := js.Global()
+	raf, caf := global.Get("requestAnimationFrame"), global.Get("cancelAnimationFrame")
+	var queued []js.Value
+	request := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		queued = append(queued, args[0])
+		return len(queued)
+	})
+	cancel := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return nil
+	})
+	global.Set("requestAnimationFrame", request)
+	global.Set("cancelAnimationFrame", cancel)
+	t.Cleanup(func() {
+		global.Set("requestAnimationFrame", raf)
+		global.Set("cancelAnimationFrame", caf)
+		request.Release()
+		cancel.Release()
+	})
+
+	return func(time float64) {
+		frames := queued
+		queued = nil
+		for _, frame := range frames {
+			frame.Invoke(time)
+		}
+	}
+}
+
+func TestGPUCanvasDemandFrameLoop(t *testing.T) {
+	runFrames := stubAnimationFrames(t)
+
+	var deltas []float64
+	gc := &GPUCanvas{FrameLoop: FrameLoopDemand}
+	gc.SetRenderFunc(func(_ *GPUCanvas, delta float64) {
+		deltas = append(deltas, delta)
+	})
+
+	gc.Start()
+	runFrames(16)
+	if len(deltas) != 0 {
+		t.Fatalf("Expected no frame before RequestRender, got %d", len(deltas))
+	}
+
+	// Requests before the frame runs are coalesced
+	gc.RequestRender()
+	gc.RequestRender()
+	runFrames(100)
+	if len(deltas) != 1 {
+		t.Fatalf("Expected 1 frame after RequestRender, got %d", len(deltas))
+	}
+
+	runFrames(116)
+	if len(deltas) != 1 {
+		t.Errorf("Expected no frame without a new request, got %d", len(deltas))
+	}
+
+	gc.RequestRender()
+	runFrames(250)
+	if len(deltas) != 2 || deltas[1] != 150 {
+		t.Errorf("Expected a second frame 150ms after the first, got %v", deltas)
+	}
+
+	// A stopped canvas ignores requests
+	gc.Stop()
+	gc.RequestRender()
+	runFrames(300)
+	if len(deltas) != 2 {
+		t.Errorf("Expected no frame after Stop, got %d", len(deltas))
+	}
+	gc.FrameCallback.Release()
+}
+
+func TestGPUCanvasNeverFrameLoop(t *testing.T) {
+	runFrames := stubAnimationFrames(t)
+
+	frames := 0
+	gc := &GPUCanvas{FrameLoop: FrameLoopNever}
+	gc.SetRenderFunc(func(*GPUCanvas, float64) { frames++ })
+
+	gc.Start()
+	gc.RequestRender()
+	runFrames(16)
+	if frames != 1 || gc.Running {
+		t.Errorf("Expected a single frame without a loop, got %d frames, running %v", frames, gc.Running)
+	}
+}
+
+func TestSupportedSampleCount(t *testing.T) {
+	tests := []struct {
+		requested int
+		format    string
+		want      int
+	}{
+		{4, "bgra8unorm", 4},
+		{4, "rgba8unorm", 4},
+		{8, "bgra8unorm", 4},
+		{1, "bgra8unorm", 1},
+		{0, "bgra8unorm", 1},
+		{4, "rgba32float", 1},
+	}
+	for _, tt := range tests {
+		if got := supportedSampleCount(tt.requested, tt.format); got != tt.want {
+			t.Errorf("supportedSampleCount(%d, %q) = %d, want %d", tt.requested, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestGPUCanvasMSAAColorTarget(t *testing.T) {
+	fake := js.Global().Call("eval", `({
+		created: [],
+		texture: {width: 300, height: 150, createView() { return {of: "canvas"}; }},
+		getCurrentTexture() { return this.texture; },
+		createTexture(desc) {
+			const texture = {
+				width: desc.size.width, height: desc.size.height, sampleCount: desc.sampleCount,
+				createView() { return {of: "msaa"}; },
+				destroy() { this.destroyed = true; },
+			};
+			this.created.push(texture);
+			return texture;
+		},
+	})`)
+	gc := &GPUCanvas{
+		Context:     fake,
+		GPUContext:  &GPUContext{Device: fake},
+		Format:      "bgra8unorm",
+		SampleCount: 4,
+	}
+
+	view, resolveTarget := gc.colorTarget()
+	if view.Get("of").String() != "msaa" || resolveTarget.Get("of").String() != "canvas" {
+		t.Fatalf("Expected to draw into the MSAA texture and resolve into the canvas texture")
+	}
+	if msaa := fake.Get("created").Index(0); msaa.Get("sampleCount").Int() != 4 {
+		t.Errorf("Expected a 4x MSAA texture, got sample count %d", msaa.Get("sampleCount").Int())
+	}
+
+	gc.colorTarget()
+	if n := fake.Get("created").Length(); n != 1 {
+		t.Errorf("Expected the MSAA texture to be reused, got %d textures", n)
+	}
+
+	fake.Get("texture").Set("width", 600)
+	gc.colorTarget()
+	created := fake.Get("created")
+	if created.Length() != 2 || !created.Index(0).Get("destroyed").Truthy() {
+		t.Errorf("Expected the MSAA texture to be recreated on resize")
+	}
+
+	gc.SampleCount = 1
+	if view, resolveTarget := gc.colorTarget(); view.Get("of").String() != "canvas" || resolveTarget.Truthy() {
+		t.Errorf("Expected a single-sampled canvas to draw into the canvas texture directly")
+	}
+}
+
+// installFakeGPU replaces navigator.gpu with a fake whose adapter counts the
+// devices it creates, and clears the global GPU context
+func installFakeGPU(t *testing.T) js.Value {
+	t.Helper()
+
+	global := js.Global()
+	previousNavigator := global.Get("navigator")
+	previousContext := GlobalGPUContext
+	fake := global.Call("eval", `({
+		devices: 0,
+		getPreferredCanvasFormat() { return "bgra8unorm"; },
+		requestAdapter() {
+			const gpu = this;
+			return Promise.resolve({
+				requestDevice() {
+					gpu.devices++;
+					return Promise.resolve({
+						queue: {},
+						addEventListener() {},
+						destroy() { this.destroyed = true; },
+					});
+				},
+			});
+		},
+	})`)
+	defineNavigator := global.Call("eval", `(value) => Object.defineProperty(globalThis, "navigator", {value, configurable: true, writable: true})`)
+	defineNavigator.Invoke(map[string]interface{}{"gpu": fake})
+	GlobalGPUContext = nil
+
+	t.Cleanup(func() {
+		defineNavigator.Invoke(previousNavigator)
+		GlobalGPUContext = previousContext
+	})
+	return fake
+}
+
+func TestGPUCanvasesShareDevice(t *testing.T) {
+	gpu := installFakeGPU(t)
+
+	// Two charts mounting at once each ask for the context from their
+	// own goroutine
+	var wg sync.WaitGroup
+	contexts := make([]*GPUContext, 2)
+	for i := range contexts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, err := GetOrInitGPUContext()
+			if err != nil {
+				t.Errorf("GetOrInitGPUContext failed: %v", err)
+			}
+			contexts[i] = ctx
+		}(i)
+	}
+	wg.Wait()
+
+	if contexts[0] == nil || contexts[0] != contexts[1] {
+		t.Fatalf("Expected both canvases to get the same context")
+	}
+	if n := gpu.Get("devices").Int(); n != 1 {
+		t.Errorf("Expected one device to be requested, got %d", n)
+	}
+
+	newCanvasElem := func() js.Value {
+		return js.Global().Call("eval", `({
+			style: {},
+			context: {configure(config) { this.device = config.device; }},
+			getContext() { return this.context; },
+		})`)
+	}
+	a, err := createGPUCanvasFromElement(newCanvasElem(), GPUCanvasConfig{Width: 300, Height: 150}, contexts[0])
+	if err != nil {
+		t.Fatalf("Failed to create canvas a: %v", err)
+	}
+	b, err := createGPUCanvasFromElement(newCanvasElem(), GPUCanvasConfig{Width: 300, Height: 150}, contexts[1])
+	if err != nil {
+		t.Fatalf("Failed to create canvas b: %v", err)
+	}
+	device := contexts[0].Device
+	if !a.Context.Get("device").Equal(device) || !b.Context.Get("device").Equal(device) {
+		t.Errorf("Expected both canvases to be configured with the shared device")
+	}
+
+	// Unmounting one canvas leaves the device to the other
+	a.Unmount()
+	if device.Get("destroyed").Truthy() || GlobalGPUContext != contexts[0] {
+		t.Errorf("Expected the shared device to outlive an unmounted canvas")
+	}
+
+	// Destroying a context that isn't the global one keeps the global one
+	(&GPUContext{Device: js.Global().Call("eval", `({destroy() {}})`)}).Destroy()
+	if GlobalGPUContext != contexts[0] {
+		t.Errorf("Expected destroying another context to keep the global context")
+	}
+}
@@ -0,0 +1,122 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// RunCompute records a compute pass that dispatches the pipeline over the
+// given number of workgroups in x, y and z, and submits it:
+//
+//	err := RunCompute(ctx, pipeline, []js.Value{bindGroup}, [3]int{64, 1, 1})
+//
+// Bind groups are set at the index of their position in bindGroups. Every
+// workgroup count must be at least 1. The pass runs asynchronously on the
+// GPU; ReadBuffer waits for it before reading results back.
+func RunCompute(ctx *GPUContext, pipeline *ComputePipeline, bindGroups []js.Value, workgroups [3]int) error {
+	if pipeline == nil || !pipeline.Pipeline.Truthy() {
+		return fmt.Errorf("compute pipeline is required")
+	}
+	for _, count := range workgroups {
+		if count < 1 {
+			return fmt.Errorf("workgroup counts must be at least 1, got %v", workgroups)
+		}
+	}
+
+	encoder, err := ctx.CreateCommandEncoder(labelWithSuffix(pipeline.Label, "Encoder"))
+	if err != nil {
+		return err
+	}
+
+	passDescriptor := map[string]interface{}{}
+	if label := labelWithSuffix(pipeline.Label, "Pass"); label != "" {
+		passDescriptor["label"] = label
+	}
+	pass := encoder.Call("beginComputePass", mapToJSObject(passDescriptor))
+	pass.Call("setPipeline", pipeline.Pipeline)
+	for i, bindGroup := range bindGroups {
+		pass.Call("setBindGroup", i, bindGroup)
+	}
+	pass.Call("dispatchWorkgroups", workgroups[0], workgroups[1], workgroups[2])
+	pass.Call("end")
+
+	ctx.Submit(encoder.Call("finish"))
+	return nil
+}
+
+// ReadBuffer copies the first size bytes of a GPU buffer back to Go, e.g.
+// the results of RunCompute. The buffer needs one of these usage flags:
+//
+//   - GPUBufferUsageMapRead: the buffer is mapped and read directly. WebGPU
+//     only allows combining it with GPUBufferUsageCopyDst, so it can't be a
+//     storage buffer.
+//   - GPUBufferUsageCopySrc: the buffer is copied into a temporary MapRead
+//     staging buffer first. Create storage buffers holding results with
+//     GPUBufferUsageStorage | GPUBufferUsageCopySrc; CreateStorageBuffer
+//     doesn't set CopySrc.
+//
+// Mapping waits for the submitted GPU work using the buffer to finish, so
+// ReadBuffer blocks and must not be called from a JS callback.
+func ReadBuffer(ctx *GPUContext, buffer *GPUBuffer, size int) ([]byte, error) {
+	if buffer == nil || !buffer.Buffer.Truthy() {
+		return nil, fmt.Errorf("buffer is required")
+	}
+	// Mapped ranges and copies must be a multiple of 4 bytes
+	alignedSize := (size + 3) &^ 3
+	if size <= 0 || alignedSize > buffer.Size {
+		return nil, fmt.Errorf("read size %d is out of range for a %d byte buffer", size, buffer.Size)
+	}
+
+	mapped := buffer.Buffer
+	switch {
+	case buffer.Usage&GPUBufferUsageMapRead != 0:
+	case buffer.Usage&GPUBufferUsageCopySrc != 0:
+		staging, err := copyToStagingBuffer(ctx, buffer, alignedSize)
+		if err != nil {
+			return nil, err
+		}
+		defer staging.Call("destroy")
+		mapped = staging
+	default:
+		return nil, fmt.Errorf("buffer %q needs GPUBufferUsageMapRead or GPUBufferUsageCopySrc to be read", buffer.Label)
+	}
+
+	if _, err := awaitPromise(mapped.Call("mapAsync", GPUMapModeRead, 0, alignedSize)); err != nil {
+		return nil, fmt.Errorf("failed to map buffer %q: %w", buffer.Label, err)
+	}
+	defer mapped.Call("unmap")
+
+	data := make([]byte, size)
+	js.CopyBytesToGo(data, js.Global().Get("Uint8Array").New(mapped.Call("getMappedRange", 0, alignedSize), 0, size))
+	return data, nil
+}
+
+// copyToStagingBuffer copies the first size bytes of a buffer into a new
+// buffer that can be mapped for reading. size must be a multiple of 4.
+func copyToStagingBuffer(ctx *GPUContext, buffer *GPUBuffer, size int) (js.Value, error) {
+	staging, err := ctx.CreateBuffer(size, GPUBufferUsageMapRead|GPUBufferUsageCopyDst, labelWithSuffix(buffer.Label, "Staging"))
+	if err != nil {
+		return js.Undefined(), err
+	}
+
+	encoder, err := ctx.CreateCommandEncoder(labelWithSuffix(buffer.Label, "Readback"))
+	if err != nil {
+		staging.Call("destroy")
+		return js.Undefined(), err
+	}
+	encoder.Call("copyBufferToBuffer", buffer.Buffer, 0, staging, 0, size)
+	ctx.Submit(encoder.Call("finish"))
+
+	return staging, nil
+}
+
+// labelWithSuffix derives the label of a helper object from the label of
+// the object it belongs to, or returns "" when that has no label
+func labelWithSuffix(label, suffix string) string {
+	if label == "" {
+		return ""
+	}
+	return label + " " + suffix
+}
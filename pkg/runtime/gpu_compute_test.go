@@ -0,0 +1,51 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strings"
+	"syscall/js"
+	"testing"
+)
+
+func TestRunComputeValidatesWorkgroups(t *testing.T) {
+	pipeline := &ComputePipeline{Pipeline: js.Global().Get("Object").New(), Label: "Sum"}
+
+	err := RunCompute(&GPUContext{}, pipeline, nil, [3]int{64, 0, 1})
+	if err == nil || !strings.Contains(err.Error(), "at least 1") {
+		t.Errorf("Expected a workgroup count error, got %v", err)
+	}
+
+	if err := RunCompute(&GPUContext{}, nil, nil, [3]int{1, 1, 1}); err == nil {
+		t.Error("Expected an error without a pipeline")
+	}
+}
+
+func TestReadBufferValidatesUsage(t *testing.T) {
+	buffer := &GPUBuffer{
+		Buffer: js.Global().Get("Object").New(),
+		Size:   16,
+		Usage:  GPUBufferUsageStorage | GPUBufferUsageCopyDst,
+		Label:  "Results",
+	}
+
+	// Storage buffers need CopySrc to be read back through a staging buffer
+	_, err := ReadBuffer(&GPUContext{}, buffer, 16)
+	if err == nil || !strings.Contains(err.Error(), "GPUBufferUsageCopySrc") {
+		t.Errorf("Expected a usage error, got %v", err)
+	}
+
+	buffer.Usage |= GPUBufferUsageCopySrc
+	if _, err := ReadBuffer(&GPUContext{}, buffer, 32); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("Expected a size error, got %v", err)
+	}
+}
+
+func TestLabelWithSuffix(t *testing.T) {
+	if got := labelWithSuffix("Sum", "Pass"); got != "Sum Pass" {
+		t.Errorf("Expected \"Sum Pass\", got %q", got)
+	}
+	if got := labelWithSuffix("", "Pass"); got != "" {
+		t.Errorf("Expected no label for an unlabelled object, got %q", got)
+	}
+}
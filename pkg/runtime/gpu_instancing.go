@@ -0,0 +1,319 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// instanceFloats is the size of an instance in the instance storage buffer,
+// in floats: its model matrix
+const instanceFloats = 16
+
+// instancedVertexShader is VertexShaderWithMVP reading the model matrix of
+// each instance from the instances storage array. The uniforms hold the
+// view-projection matrix and the model matrix of the node, which applies to
+// every instance.
+const instancedVertexShader = `
+struct Uniforms {
+    viewProjection: mat4x4f,
+    model: mat4x4f,
+    color: vec4f,
+}
+
+struct VertexInput {
+    @location(0) position: vec3f,
+    @location(1) normal: vec3f,
+}
+
+struct VertexOutput {
+    @builtin(position) position: vec4f,
+    @location(0) normal: vec3f,
+    @location(1) worldPosition: vec3f,
+    @location(2) color: vec4f,
+}
+
+@group(0) @binding(0) var<uniform> uniforms: Uniforms;
+@group(0) @binding(2) var<storage, read> instances: array<mat4x4f>;
+
+@vertex
+fn vs_main(input: VertexInput, @builtin(instance_index) instanceIndex: u32) -> VertexOutput {
+    let model = uniforms.model * instances[instanceIndex];
+    let world = model * vec4f(input.position, 1.0);
+
+    var output: VertexOutput;
+    output.position = uniforms.viewProjection * world;
+    output.normal = (model * vec4f(input.normal, 0.0)).xyz;
+    output.worldPosition = world.xyz;
+    output.color = vec4f(1.0);
+    return output;
+}
+`
+
+// InstancedMeshInstance is an instanced mesh node with its GPU resources
+type InstancedMeshInstance struct {
+	Transform      Transform   // Transform of the node, applied to every instance
+	Instances      []Transform // Transform of each instance
+	Geometry       Geometry
+	Material       *Material
+	VertexBuffer   *GPUBuffer
+	IndexBuffer    *GPUBuffer
+	IndexCount     int
+	InstanceBuffer *GPUBuffer // Model matrix of each instance
+	UniformBuffer  *GPUBuffer
+}
+
+// InstancedMesh creates a mesh node drawn once for each transform. The
+// model matrices of the instances are uploaded to a GPU storage buffer and
+// all of them are drawn with a single draw call, which is much faster than
+// a Mesh per instance for hundreds of copies of the same object:
+//
+//	cubes := make([]Transform, 0, 400)
+//	for i := 0; i < 400; i++ {
+//	    t := NewTransform()
+//	    t.Position = Vec3{float32(i%20) - 10, 0, float32(i/20) - 10}
+//	    cubes = append(cubes, t)
+//	}
+//	InstancedMesh(BoxGeometryNode(0.5, 0.5, 0.5), StandardMaterial(Color(0.3, 0.6, 1, 1)), cubes)
+//
+// Position, Rotation and ScaleValue transform all the instances together.
+func InstancedMesh(geometry Geometry, material *Material, transforms []Transform, options ...interface{}) *GPUNode {
+	node := &GPUNode{
+		Type:       InstancedMeshNodeType,
+		Tag:        "instanced-mesh",
+		Properties: make(map[string]interface{}),
+		Transform:  NewTransform(),
+		Geometry:   geometry,
+		Material:   material,
+		Instances:  transforms,
+	}
+
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case GPUProp:
+			switch o.Key {
+			case "position", "rotation", "scale":
+				applyTransformProp(&node.Transform, o)
+			default:
+				node.Properties[o.Key] = o.Value
+			}
+		}
+	}
+
+	return node
+}
+
+// instanceBufferSize returns the size in bytes of the storage buffer
+// holding count instances
+func instanceBufferSize(count int) int {
+	return max(count, 1) * instanceFloats * 4
+}
+
+// packInstances returns the model matrices of transforms, one after another
+func packInstances(transforms []Transform) []float32 {
+	data := make([]float32, 0, len(transforms)*instanceFloats)
+	for _, t := range transforms {
+		m := t.Matrix()
+		data = append(data, m[:]...)
+	}
+	return data
+}
+
+// createInstancedMeshInstance creates the buffers of an instanced mesh node
+// and uploads its instances
+func (sr *SceneRenderer) createInstancedMeshInstance(node *GPUNode) (*InstancedMeshInstance, error) {
+	ctx := sr.Canvas.GPUContext
+	indices := node.Geometry.GetIndices()
+
+	vertexBuffer, err := CreateVertexBuffer(ctx, node.Geometry.GetVertices(), "instanced-mesh-vertices")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vertex buffer: %w", err)
+	}
+	indexBuffer, err := CreateIndexBuffer(ctx, indices, "instanced-mesh-indices")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index buffer: %w", err)
+	}
+	uniformBuffer, err := CreateUniformBuffer(ctx, meshUniformSize, "instanced-mesh-uniforms")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create uniform buffer: %w", err)
+	}
+
+	material := node.Material
+	if material == nil {
+		material = &Material{
+			Color:     Vec4{0.8, 0.8, 0.8, 1.0},
+			Metalness: 0.0,
+			Roughness: 0.5,
+		}
+	}
+
+	instanced := &InstancedMeshInstance{
+		Transform:     node.Transform,
+		Geometry:      node.Geometry,
+		Material:      material,
+		VertexBuffer:  vertexBuffer,
+		IndexBuffer:   indexBuffer,
+		IndexCount:    len(indices),
+		UniformBuffer: uniformBuffer,
+	}
+	if err := instanced.setInstances(ctx, node.Instances); err != nil {
+		return nil, err
+	}
+	return instanced, nil
+}
+
+// setInstances uploads the model matrices of transforms, growing the
+// instance buffer when they don't fit
+func (im *InstancedMeshInstance) setInstances(ctx *GPUContext, transforms []Transform) error {
+	size := instanceBufferSize(len(transforms))
+	if im.InstanceBuffer == nil || im.InstanceBuffer.Size < size {
+		buffer, err := CreateStorageBuffer(ctx, size, "mesh-instances")
+		if err != nil {
+			return fmt.Errorf("failed to create instance buffer: %w", err)
+		}
+		if im.InstanceBuffer != nil {
+			im.InstanceBuffer.Destroy()
+		}
+		im.InstanceBuffer = buffer
+	}
+
+	im.Instances = transforms
+	if len(transforms) == 0 {
+		return nil
+	}
+	if err := im.InstanceBuffer.WriteFloat32(ctx, 0, packInstances(transforms)); err != nil {
+		return fmt.Errorf("failed to write instances: %w", err)
+	}
+	return nil
+}
+
+// createInstancedPipelines creates the opaque and transparent pipelines
+// drawing instanced meshes. They light meshes like the scene pipelines and
+// add the instances storage array to the bind group.
+func (sr *SceneRenderer) createInstancedPipelines() error {
+	ctx := sr.Canvas.GPUContext
+
+	vertexShaderModule, err := CreateShaderModule(ctx, instancedVertexShader, "instanced-vertex-shader")
+	if err != nil {
+		return fmt.Errorf("failed to create instanced vertex shader: %w", err)
+	}
+	fragmentShaderModule, err := CreateShaderModule(ctx, FragmentShaderWithLighting, "instanced-fragment-shader")
+	if err != nil {
+		return fmt.Errorf("failed to create instanced fragment shader: %w", err)
+	}
+
+	bindGroupLayout, err := CreateBindGroupLayout(ctx, []map[string]interface{}{
+		CreateBindGroupLayoutEntry(0, GPUShaderStageVertex|GPUShaderStageFragment, "uniform"),
+		CreateBindGroupLayoutEntry(1, GPUShaderStageFragment, "uniform"),
+		CreateBindGroupLayoutEntry(2, GPUShaderStageVertex, "read-only-storage"),
+	}, "instanced-bind-group-layout")
+	if err != nil {
+		return fmt.Errorf("failed to create instanced bind group layout: %w", err)
+	}
+
+	config := PipelineConfig{
+		Label:              "scene-instanced-pipeline",
+		VertexShader:       vertexShaderModule.Module,
+		FragmentShader:     fragmentShaderModule.Module,
+		VertexEntryPoint:   "vs_main",
+		FragmentEntryPoint: "fs_main",
+		VertexBuffers: []map[string]interface{}{CreateVertexBufferLayout(24, []VertexAttribute{
+			{Format: VertexFormatFloat32x3, Offset: 0, ShaderLocation: 0},  // position
+			{Format: VertexFormatFloat32x3, Offset: 12, ShaderLocation: 1}, // normal
+		})},
+		ColorFormat:       sr.Canvas.Format,
+		DepthFormat:       "depth24plus",
+		PrimitiveTopology: PrimitiveTopologyTriangleList,
+		CullMode:          CullModeBack,
+		BindGroupLayouts:  []js.Value{bindGroupLayout},
+		SampleCount:       sr.Canvas.SampleCount,
+	}
+
+	pipeline, err := CreateRenderPipeline(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to create instanced pipeline: %w", err)
+	}
+	sr.InstancedPipeline = pipeline
+
+	config.Label = "scene-instanced-transparent-pipeline"
+	config.DepthWriteDisabled = true
+	transparent, err := CreatePipelineWithBlending(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to create instanced transparent pipeline: %w", err)
+	}
+	sr.InstancedTransparent = transparent
+
+	return nil
+}
+
+// drawInstancedMeshes draws the opaque or the transparent instanced meshes,
+// each with a single indexed draw of all its instances. Instances of a
+// transparent mesh are not sorted by distance to the camera.
+func (sr *SceneRenderer) drawInstancedMeshes(renderPass js.Value, viewProjection Mat4, lightBinding js.Value, transparent bool) {
+	if sr.InstancedPipeline == nil {
+		return
+	}
+	ctx := sr.Canvas.GPUContext
+
+	pipeline := sr.InstancedPipeline
+	if transparent {
+		pipeline = sr.InstancedTransparent
+	}
+
+	pipelineSet := false
+	for _, instanced := range sr.Instanced {
+		if instanced.Material.IsTransparent() != transparent || len(instanced.Instances) == 0 {
+			continue
+		}
+		if !pipelineSet {
+			renderPass.Call("setPipeline", pipeline.Pipeline)
+			pipelineSet = true
+		}
+
+		uniforms := packMeshUniforms(viewProjection, instanced.Transform.Matrix(), instanced.Material.Color)
+		if err := instanced.UniformBuffer.WriteFloat32(ctx, 0, uniforms); err != nil {
+			logError(fmt.Sprintf("Failed to write instanced mesh uniforms: %v", err))
+			continue
+		}
+
+		bindGroup, err := CreateBindGroup(
+			ctx,
+			pipeline.Pipeline.Call("getBindGroupLayout", 0),
+			[]map[string]interface{}{
+				CreateBindGroupEntry(0, CreateBufferBinding(instanced.UniformBuffer.Buffer, 0, meshUniformSize)),
+				CreateBindGroupEntry(1, lightBinding),
+				CreateBindGroupEntry(2, CreateBufferBinding(instanced.InstanceBuffer.Buffer, 0, instanced.InstanceBuffer.Size)),
+			},
+			"instanced-mesh-bind-group",
+		)
+		if err != nil {
+			logError(fmt.Sprintf("Failed to create instanced mesh bind group: %v", err))
+			continue
+		}
+
+		renderPass.Call("setBindGroup", 0, bindGroup)
+		renderPass.Call("setVertexBuffer", 0, instanced.VertexBuffer.Buffer)
+		renderPass.Call("setIndexBuffer", instanced.IndexBuffer.Buffer, "uint16")
+		renderPass.Call("drawIndexed", instanced.IndexCount, len(instanced.Instances), 0, 0, 0)
+	}
+}
+
+// UpdateInstances replaces the instance transforms of an instanced mesh by
+// index, in the order the instanced meshes appear in the scene
+func (sr *SceneRenderer) UpdateInstances(index int, transforms []Transform) {
+	if index < 0 || index >= len(sr.Instanced) {
+		return
+	}
+	if err := sr.Instanced[index].setInstances(sr.Canvas.GPUContext, transforms); err != nil {
+		logError(fmt.Sprintf("Failed to update instances: %v", err))
+	}
+}
+
+// destroy releases the instanced mesh buffers
+func (im *InstancedMeshInstance) destroy() {
+	im.VertexBuffer.Destroy()
+	im.IndexBuffer.Destroy()
+	im.InstanceBuffer.Destroy()
+	im.UniformBuffer.Destroy()
+}
@@ -0,0 +1,65 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestInstanceBufferSize(t *testing.T) {
+	tests := []struct {
+		count, want int
+	}{
+		{500, 32000},
+		{1, 64},
+		// WebGPU rejects empty buffer bindings
+		{0, 64},
+	}
+	for _, tt := range tests {
+		if got := instanceBufferSize(tt.count); got != tt.want {
+			t.Errorf("instanceBufferSize(%d) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestPackInstances(t *testing.T) {
+	first, second := NewTransform(), NewTransform()
+	first.Position = Vec3{1, 2, 3}
+	second.Position = Vec3{-4, 0, 5}
+	second.Scale = Vec3{2, 2, 2}
+
+	data := packInstances([]Transform{first, second})
+	if len(data) != 2*instanceFloats {
+		t.Fatalf("Expected %d floats, got %d", 2*instanceFloats, len(data))
+	}
+	if data[12] != 1 || data[13] != 2 || data[14] != 3 {
+		t.Errorf("Expected the first translation at float 12, got %v", data[12:15])
+	}
+	next := data[instanceFloats:]
+	if next[0] != 2 || next[12] != -4 || next[14] != 5 {
+		t.Errorf("Expected the second model matrix after the first, got %v", next)
+	}
+
+	if data := packInstances(nil); len(data) != 0 {
+		t.Errorf("Expected no floats without instances, got %d", len(data))
+	}
+}
+
+func TestInstancedMeshBuilder(t *testing.T) {
+	geometry := BoxGeometryNode(1, 1, 1)
+	material := StandardMaterial(Color(0.3, 0.6, 1, 1))
+	transforms := []Transform{NewTransform(), NewTransform(), NewTransform()}
+
+	node := InstancedMesh(geometry, material, transforms, Position(0, 1, 0))
+
+	if node.Type != InstancedMeshNodeType {
+		t.Errorf("Expected an instanced mesh node, got type %d", node.Type)
+	}
+	if node.Geometry != geometry || node.Material != material {
+		t.Error("Expected the geometry and material to be kept")
+	}
+	if len(node.Instances) != 3 {
+		t.Errorf("Expected 3 instances, got %d", len(node.Instances))
+	}
+	if node.Transform.Position != (Vec3{0, 1, 0}) {
+		t.Errorf("Expected position (0, 1, 0), got %v", node.Transform.Position)
+	}
+}
@@ -0,0 +1,105 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// maxSceneLights is the number of directional, point and spot lights
+	// the lighting shader iterates; further lights are ignored
+	maxSceneLights = 8
+
+	// lightFloats is the size of a light in the lights uniform, in floats:
+	// position and kind, direction and inner cone cosine, color and outer
+	// cone cosine
+	lightFloats = 12
+
+	// lightsUniformSize is the size of the lights uniform in bytes: the
+	// ambient color and light count, then the lights
+	lightsUniformSize = (4 + maxSceneLights*lightFloats) * 4
+
+	// meshUniformSize is the size of a mesh's uniforms in bytes: the
+	// model-view-projection and model matrices and the material color
+	meshUniformSize = (16 + 16 + 4) * 4
+
+	// meshUniformStride is the distance between the uniforms of consecutive
+	// meshes, the minimum uniform buffer offset alignment
+	meshUniformStride = 256
+
+	// spotSoftEdge is the fraction of a spot light's cone angle inside which
+	// it shines at full intensity, fading out towards the cone angle
+	spotSoftEdge = 0.8
+)
+
+// Light kinds in the lights uniform
+const (
+	lightKindDirectional uint32 = iota
+	lightKindPoint
+	lightKindSpot
+)
+
+// defaultLights returns the lights of scenes that declare none: a dim
+// ambient light and a directional light from the upper right front
+func defaultLights() (*Light, []*Light) {
+	ambient := &Light{Type: "ambient", Color: Vec3{1, 1, 1}, Intensity: 0.3}
+	directional := &Light{Type: "directional", Color: Vec3{1, 1, 1}, Intensity: 0.7, Direction: Vec3{-1, -1, -1}}
+	return ambient, []*Light{directional}
+}
+
+// packLights returns the contents of the lights uniform. Colors are
+// premultiplied by the light intensity, directions are normalized and spot
+// cone angles are passed as cosines.
+func packLights(ambient *Light, lights []*Light) []float32 {
+	if ambient == nil && len(lights) == 0 {
+		ambient, lights = defaultLights()
+	}
+	if len(lights) > maxSceneLights {
+		logError(fmt.Sprintf("[Renderer] %d lights in scene, only the first %d are used", len(lights), maxSceneLights))
+		lights = lights[:maxSceneLights]
+	}
+
+	data := make([]float32, lightsUniformSize/4)
+	if ambient != nil {
+		copy(data, vec3Floats(ambient.Color.Mul(ambient.Intensity)))
+	}
+	data[3] = math.Float32frombits(uint32(len(lights)))
+
+	for i, light := range lights {
+		kind := lightKindDirectional
+		switch light.Type {
+		case "point":
+			kind = lightKindPoint
+		case "spot":
+			kind = lightKindSpot
+		}
+
+		direction := light.Direction.Normalize()
+		cosOuter := float32(math.Cos(float64(light.ConeAngle)))
+		cosInner := float32(math.Cos(float64(light.ConeAngle * spotSoftEdge)))
+
+		slot := data[4+i*lightFloats:]
+		copy(slot[0:], vec3Floats(light.Position))
+		slot[3] = math.Float32frombits(kind)
+		copy(slot[4:], vec3Floats(direction))
+		slot[7] = cosInner
+		copy(slot[8:], vec3Floats(light.Color.Mul(light.Intensity)))
+		slot[11] = cosOuter
+	}
+	return data
+}
+
+// packMeshUniforms returns the uniforms of a mesh
+func packMeshUniforms(mvp, model Mat4, color Vec4) []float32 {
+	data := make([]float32, 0, meshUniformSize/4)
+	data = append(data, mvp[:]...)
+	data = append(data, model[:]...)
+	return append(data, color.X, color.Y, color.Z, color.W)
+}
+
+// vec3Floats returns the components of a vector
+func vec3Floats(v Vec3) []float32 {
+	return []float32{v.X, v.Y, v.Z}
+}
@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPackLights(t *testing.T) {
+	ambient := &Light{Type: "ambient", Color: Vec3{1, 1, 1}, Intensity: 0.2}
+	lights := []*Light{
+		{Type: "directional", Color: Vec3{1, 1, 1}, Intensity: 1, Direction: Vec3{0, -2, 0}},
+		{Type: "point", Color: Vec3{1, 0, 0}, Intensity: 0.5, Position: Vec3{1, 2, 3}},
+		{Type: "spot", Color: Vec3{0, 0, 1}, Intensity: 1, Position: Vec3{0, 5, 0}, Direction: Vec3{0, -1, 0}, ConeAngle: math.Pi / 3},
+	}
+
+	data := packLights(ambient, lights)
+	if len(data)*4 != lightsUniformSize {
+		t.Fatalf("Expected %d bytes, got %d", lightsUniformSize, len(data)*4)
+	}
+
+	// Ambient color is premultiplied by its intensity
+	if data[0] != 0.2 || data[1] != 0.2 || data[2] != 0.2 {
+		t.Errorf("Expected ambient 0.2, got %v", data[0:3])
+	}
+	if count := math.Float32bits(data[3]); count != 3 {
+		t.Errorf("Expected 3 lights, got %d", count)
+	}
+
+	light := func(i int) []float32 { return data[4+i*lightFloats : 4+(i+1)*lightFloats] }
+	kinds := []uint32{lightKindDirectional, lightKindPoint, lightKindSpot}
+	for i, kind := range kinds {
+		if got := math.Float32bits(light(i)[3]); got != kind {
+			t.Errorf("Light %d: expected kind %d, got %d", i, kind, got)
+		}
+	}
+
+	// Directions are normalized
+	if d := light(0)[4:7]; d[0] != 0 || d[1] != -1 || d[2] != 0 {
+		t.Errorf("Expected normalized direction (0, -1, 0), got %v", d)
+	}
+	if p := light(1)[0:3]; p[0] != 1 || p[1] != 2 || p[2] != 3 {
+		t.Errorf("Expected point light position (1, 2, 3), got %v", p)
+	}
+	if c := light(1)[8:11]; c[0] != 0.5 || c[1] != 0 || c[2] != 0 {
+		t.Errorf("Expected point light color (0.5, 0, 0), got %v", c)
+	}
+
+	// The spot light's cone fades between the inner and outer cosines
+	spot := light(2)
+	if cosOuter := spot[11]; math.Abs(float64(cosOuter)-0.5) > 1e-6 {
+		t.Errorf("Expected outer cone cosine 0.5, got %v", cosOuter)
+	}
+	if cosInner := spot[7]; cosInner <= spot[11] || cosInner >= 1 {
+		t.Errorf("Expected inner cone cosine between %v and 1, got %v", spot[11], cosInner)
+	}
+}
+
+func TestPackLightsDefaultsAndLimit(t *testing.T) {
+	// Scenes without lights get the default ambient and directional light
+	data := packLights(nil, nil)
+	if count := math.Float32bits(data[3]); count != 1 || data[0] != 0.3 {
+		t.Errorf("Expected the default lights, got ambient %v and %d lights", data[0], count)
+	}
+
+	// Lights past the limit are dropped
+	lights := make([]*Light, maxSceneLights+2)
+	for i := range lights {
+		lights[i] = &Light{Type: "point", Intensity: 1}
+	}
+	data = packLights(nil, lights)
+	if count := math.Float32bits(data[3]); count != maxSceneLights {
+		t.Errorf("Expected %d lights, got %d", maxSceneLights, count)
+	}
+}
+
+func TestPackMeshUniforms(t *testing.T) {
+	model := Translation(1, 2, 3)
+	data := packMeshUniforms(Identity(), model, Vec4{1, 0.5, 0.25, 0.5})
+
+	if len(data)*4 != meshUniformSize || meshUniformSize > meshUniformStride {
+		t.Fatalf("Expected %d bytes within the %d byte stride, got %d", meshUniformSize, meshUniformStride, len(data)*4)
+	}
+	if data[16+12] != 1 || data[16+13] != 2 || data[16+14] != 3 {
+		t.Errorf("Expected the model translation after the MVP matrix, got %v", data[16:32])
+	}
+	if color := data[32:]; color[0] != 1 || color[3] != 0.5 {
+		t.Errorf("Expected the material color last, got %v", color)
+	}
+}
@@ -256,15 +256,31 @@ func (t Transform) Matrix() Mat4 {
 	return result
 }
 
+// ProjectionType selects how a camera projects the scene
+type ProjectionType int
+
+const (
+	// PerspectiveProjection shrinks objects with distance, using FOV and Aspect
+	PerspectiveProjection ProjectionType = iota
+	// OrthographicProjection keeps sizes regardless of distance, showing the
+	// box bounded by Left, Right, Bottom and Top. Used for 2D and isometric scenes.
+	OrthographicProjection
+)
+
 // Camera represents a 3D camera
 type Camera struct {
-	Position Vec3
-	Target   Vec3
-	Up       Vec3
-	FOV      float32 // Field of view in radians
-	Aspect   float32 // Aspect ratio (width/height)
-	Near     float32 // Near clipping plane
-	Far      float32 // Far clipping plane
+	Position   Vec3
+	Target     Vec3
+	Up         Vec3
+	Projection ProjectionType
+	FOV        float32 // Field of view in radians
+	Aspect     float32 // Aspect ratio (width/height)
+	Left       float32 // Left edge of an orthographic view volume
+	Right      float32 // Right edge of an orthographic view volume
+	Bottom     float32 // Bottom edge of an orthographic view volume
+	Top        float32 // Top edge of an orthographic view volume
+	Near       float32 // Near clipping plane
+	Far        float32 // Far clipping plane
 }
 
 // NewPerspectiveCamera creates a new perspective camera
@@ -280,6 +296,25 @@ func NewPerspectiveCamera(fov, aspect, near, far float32) Camera {
 	}
 }
 
+// NewOrthographicCamera creates a new orthographic camera showing the given
+// view volume. The canvas aspect ratio doesn't stretch it, so the bounds
+// should match the canvas shape.
+func NewOrthographicCamera(left, right, bottom, top, near, far float32) Camera {
+	return Camera{
+		Position:   Vec3{0, 0, 5},
+		Target:     Vec3{0, 0, 0},
+		Up:         Vec3{0, 1, 0},
+		Projection: OrthographicProjection,
+		Aspect:     1.0,
+		Left:       left,
+		Right:      right,
+		Bottom:     bottom,
+		Top:        top,
+		Near:       near,
+		Far:        far,
+	}
+}
+
 // ViewMatrix returns the camera's view matrix
 func (c Camera) ViewMatrix() Mat4 {
 	return LookAt(c.Position, c.Target, c.Up)
@@ -287,6 +322,9 @@ func (c Camera) ViewMatrix() Mat4 {
 
 // ProjectionMatrix returns the camera's projection matrix
 func (c Camera) ProjectionMatrix() Mat4 {
+	if c.Projection == OrthographicProjection {
+		return Orthographic(c.Left, c.Right, c.Bottom, c.Top, c.Near, c.Far)
+	}
 	return Perspective(c.FOV, c.Aspect, c.Near, c.Far)
 }
 
@@ -0,0 +1,71 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+// project applies a column-major matrix to a point and divides by w
+func project(m Mat4, p Vec3) Vec3 {
+	x := m[0]*p.X + m[4]*p.Y + m[8]*p.Z + m[12]
+	y := m[1]*p.X + m[5]*p.Y + m[9]*p.Z + m[13]
+	z := m[2]*p.X + m[6]*p.Y + m[10]*p.Z + m[14]
+	w := m[3]*p.X + m[7]*p.Y + m[11]*p.Z + m[15]
+	return Vec3{x / w, y / w, z / w}
+}
+
+func closeTo(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-5
+}
+
+func TestOrthographicMatrix(t *testing.T) {
+	got := Orthographic(-4, 4, -2, 2, 1, 11)
+	want := Mat4{
+		0.25, 0, 0, 0,
+		0, 0.5, 0, 0,
+		0, 0, -0.2, 0,
+		0, 0, -1.2, 1,
+	}
+	for i := range want {
+		if !closeTo(got[i], want[i]) {
+			t.Fatalf("Orthographic()[%d] = %v, want %v\nmatrix: %v", i, got[i], want[i], got)
+		}
+	}
+
+	corners := []struct {
+		point Vec3
+		want  Vec3
+	}{
+		{Vec3{-4, -2, -1}, Vec3{-1, -1, -1}},
+		{Vec3{4, 2, -11}, Vec3{1, 1, 1}},
+		{Vec3{0, 0, -6}, Vec3{0, 0, 0}},
+	}
+	for _, c := range corners {
+		got := project(Orthographic(-4, 4, -2, 2, 1, 11), c.point)
+		if !closeTo(got.X, c.want.X) || !closeTo(got.Y, c.want.Y) || !closeTo(got.Z, c.want.Z) {
+			t.Errorf("%v projects to %v, want %v", c.point, got, c.want)
+		}
+	}
+}
+
+func TestCameraProjectionType(t *testing.T) {
+	ortho := NewOrthographicCamera(-4, 4, -2, 2, 1, 11)
+	ortho.Position = Vec3{0, 0, 10}
+	ortho.Aspect = 3 // ignored by orthographic cameras
+
+	if ortho.Projection != OrthographicProjection {
+		t.Fatalf("Expected OrthographicProjection, got %d", ortho.Projection)
+	}
+	got := project(ortho.ViewProjectionMatrix(), Vec3{4, 2, 0})
+	if !closeTo(got.X, 1) || !closeTo(got.Y, 1) || !closeTo(got.Z, 0.8) {
+		t.Errorf("Expected (4, 2, 0) at the top right corner, got %v", got)
+	}
+
+	// Perspective is the zero value, so existing cameras keep their projection
+	perspective := Camera{FOV: DegreesToRadians(60), Aspect: 1, Near: 0.1, Far: 100}
+	if perspective.ProjectionMatrix() != Perspective(DegreesToRadians(60), 1, 0.1, 100) {
+		t.Error("Expected a perspective projection by default")
+	}
+}
@@ -0,0 +1,442 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"syscall/js"
+)
+
+const (
+	// particleFloats is the size of a particle in the particle storage
+	// buffer, in floats: position and remaining life, velocity and seed
+	particleFloats = 8
+
+	// particleStride is the size of a particle in bytes
+	particleStride = particleFloats * 4
+
+	// particleWorkgroupSize is the number of particles each workgroup of the
+	// update shader handles, WORKGROUP_SIZE in WGSL
+	particleWorkgroupSize = 64
+
+	// particleParamsSize is the size of the update shader's params uniform
+	// in bytes: delta time, time and particle count, padded to 16 bytes
+	particleParamsSize = 16
+
+	// particleUniformSize is the size of the particle render uniforms in
+	// bytes: the view-projection and model matrices, the camera right axis
+	// and particle size, the camera up axis and the particle color
+	particleUniformSize = (16 + 16 + 4 + 4 + 4) * 4
+
+	// particleVerticesPerInstance is the number of vertices of the quad
+	// each particle is drawn as
+	particleVerticesPerInstance = 6
+)
+
+// particleShaderPrelude declares the particle state and the bindings of an
+// update shader. It is prepended to the source passed to Shader.
+var particleShaderPrelude = fmt.Sprintf(`
+struct Particle {
+    position: vec3f,
+    life: f32,
+    velocity: vec3f,
+    seed: f32,
+}
+
+struct ParticleParams {
+    deltaTime: f32,
+    time: f32,
+    count: u32,
+}
+
+const WORKGROUP_SIZE: u32 = %du;
+
+@group(0) @binding(0) var<storage, read_write> particles: array<Particle>;
+@group(0) @binding(1) var<uniform> params: ParticleParams;
+`, particleWorkgroupSize)
+
+// DefaultParticleShader is the update shader of particle systems without a
+// Shader: a fountain of particles launched upwards from the origin that fall
+// back down and respawn when their life runs out
+const DefaultParticleShader = `
+@compute @workgroup_size(WORKGROUP_SIZE)
+fn update(@builtin(global_invocation_id) id: vec3u) {
+    let i = id.x;
+    if (i >= params.count) {
+        return;
+    }
+
+    var p = particles[i];
+    p.life -= params.deltaTime;
+    if (p.life <= 0.0) {
+        let angle = fract(p.seed * 7.13 + params.time) * 6.2831853;
+        let speed = 2.5 + fract(p.seed * 3.7);
+        p.position = vec3f(0.0);
+        p.velocity = vec3f(cos(angle) * 0.6, speed, sin(angle) * 0.6);
+        p.life = 2.0 + p.seed;
+    }
+    p.velocity.y -= 2.5 * params.deltaTime;
+    p.position += p.velocity * params.deltaTime;
+    particles[i] = p;
+}
+`
+
+// particleRenderShader draws each particle instance as a round,
+// camera-facing quad that fades out with the particle's remaining life
+const particleRenderShader = `
+struct Particle {
+    position: vec3f,
+    life: f32,
+    velocity: vec3f,
+    seed: f32,
+}
+
+struct ParticleUniforms {
+    viewProjection: mat4x4f,
+    model: mat4x4f,
+    right: vec3f,
+    size: f32,
+    up: vec3f,
+    color: vec4f,
+}
+
+struct VertexOutput {
+    @builtin(position) position: vec4f,
+    @location(0) corner: vec2f,
+    @location(1) fade: f32,
+}
+
+@group(0) @binding(0) var<storage, read> particles: array<Particle>;
+@group(0) @binding(1) var<uniform> uniforms: ParticleUniforms;
+
+@vertex
+fn vs_main(@builtin(vertex_index) vertexIndex: u32, @builtin(instance_index) instanceIndex: u32) -> VertexOutput {
+    var corners = array<vec2f, 6>(
+        vec2f(-1.0, -1.0), vec2f(1.0, -1.0), vec2f(1.0, 1.0),
+        vec2f(-1.0, -1.0), vec2f(1.0, 1.0), vec2f(-1.0, 1.0),
+    );
+    let corner = corners[vertexIndex];
+    let particle = particles[instanceIndex];
+
+    var world = (uniforms.model * vec4f(particle.position, 1.0)).xyz;
+    world += (uniforms.right * corner.x + uniforms.up * corner.y) * uniforms.size * 0.5;
+
+    var output: VertexOutput;
+    output.position = uniforms.viewProjection * vec4f(world, 1.0);
+    output.corner = corner;
+    output.fade = clamp(particle.life, 0.0, 1.0);
+    return output;
+}
+
+@fragment
+fn fs_main(input: VertexOutput) -> @location(0) vec4f {
+    let alpha = uniforms.color.a * input.fade * (1.0 - smoothstep(0.5, 1.0, length(input.corner)));
+    if (alpha <= 0.0) {
+        discard;
+    }
+    return vec4f(uniforms.color.rgb, alpha);
+}
+`
+
+// ParticleEmitter configures a particle system node
+type ParticleEmitter struct {
+	Count  int     // Number of particles
+	Shader string  // WGSL update shader, see Shader
+	Size   float32 // Width of a particle quad in world units
+	Color  Vec4    // RGBA color
+}
+
+// ParticleInstance is a particle system with its GPU resources
+type ParticleInstance struct {
+	Emitter       *ParticleEmitter
+	Transform     Transform
+	Buffer        *GPUBuffer // Particle state, updated on the GPU only
+	ParamsBuffer  *GPUBuffer
+	UniformBuffer *GPUBuffer
+	Update        *ComputePipeline
+	UpdateGroup   js.Value
+	Time          float64 // Seconds since the first frame
+}
+
+// Count sets the number of particles of a particle system
+func Count(value int) GPUProp {
+	return GPUProp{Key: "count", Value: value}
+}
+
+// Shader sets the WGSL compute shader updating the particles of a particle
+// system. The shader is prepended with the declarations of the Particle
+// struct, the particles storage array, the params uniform (deltaTime and
+// time in seconds, count) and WORKGROUP_SIZE; its entry point is update:
+//
+//	@compute @workgroup_size(WORKGROUP_SIZE)
+//	fn update(@builtin(global_invocation_id) id: vec3u) {
+//	    if (id.x >= params.count) { return; }
+//	    particles[id.x].position.y += params.deltaTime;
+//	}
+func Shader(source string) GPUProp {
+	return GPUProp{Key: "shader", Value: source}
+}
+
+// ParticleSize sets the width of the quads particles are drawn as
+func ParticleSize(value float32) GPUProp {
+	return GPUProp{Key: "particleSize", Value: value}
+}
+
+// ParticleSystem creates a particle system node. Particle state lives in a
+// GPU storage buffer that a compute pass updates every frame before the
+// particles are drawn as instanced quads, so it never goes through Go:
+//
+//	ParticleSystem(Count(1000), Shader(fountain), Color(1, 0.6, 0.2, 1))
+func ParticleSystem(options ...interface{}) *GPUNode {
+	node := &GPUNode{
+		Type:       ParticleSystemNodeType,
+		Tag:        "particle-system",
+		Properties: make(map[string]interface{}),
+		Transform:  NewTransform(),
+		Particles: &ParticleEmitter{
+			Count:  1000,
+			Shader: DefaultParticleShader,
+			Size:   0.05,
+			Color:  Vec4{1, 1, 1, 1},
+		},
+	}
+
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case GPUProp:
+			switch o.Key {
+			case "position", "rotation", "scale":
+				applyTransformProp(&node.Transform, o)
+			case "count":
+				if v, ok := o.Value.(int); ok {
+					node.Particles.Count = v
+				}
+			case "shader":
+				if v, ok := o.Value.(string); ok {
+					node.Particles.Shader = v
+				}
+			case "particleSize":
+				if v, ok := o.Value.(float32); ok {
+					node.Particles.Size = v
+				}
+			case "color":
+				if v, ok := o.Value.(Vec4); ok {
+					node.Particles.Color = v
+				}
+			default:
+				node.Properties[o.Key] = o.Value
+			}
+		}
+	}
+
+	return node
+}
+
+// particleBufferSize returns the size in bytes of the storage buffer
+// holding count particles
+func particleBufferSize(count int) int {
+	return max(count, 1) * particleStride
+}
+
+// particleWorkgroups returns the number of workgroups the update shader is
+// dispatched over to cover count particles
+func particleWorkgroups(count int) int {
+	return max((count+particleWorkgroupSize-1)/particleWorkgroupSize, 1)
+}
+
+// initialParticles returns the initial state of count particles: at the
+// origin with a pseudo-random seed in [0, 1), and a life that runs out
+// within the first second so they spawn gradually
+func initialParticles(count int) []float32 {
+	data := make([]float32, max(count, 1)*particleFloats)
+	for i := 0; i < count; i++ {
+		_, seed := math.Modf(math.Abs(math.Sin(float64(i)*12.9898) * 43758.5453))
+		p := data[i*particleFloats:]
+		p[3] = float32(i) / float32(count)
+		p[7] = float32(seed)
+	}
+	return data
+}
+
+// packParticleUniforms returns the render uniforms of a particle system.
+// right and up are the camera axes in world space the quads are aligned to.
+func packParticleUniforms(viewProjection, model Mat4, right, up Vec3, emitter *ParticleEmitter) []float32 {
+	data := make([]float32, 0, particleUniformSize/4)
+	data = append(data, viewProjection[:]...)
+	data = append(data, model[:]...)
+	data = append(data, right.X, right.Y, right.Z, emitter.Size)
+	data = append(data, up.X, up.Y, up.Z, 0)
+	c := emitter.Color
+	return append(data, c.X, c.Y, c.Z, c.W)
+}
+
+// createParticleInstance creates the particle buffers and update pipeline
+// of a particle system node
+func (sr *SceneRenderer) createParticleInstance(node *GPUNode) (*ParticleInstance, error) {
+	ctx := sr.Canvas.GPUContext
+	emitter := node.Particles
+
+	buffer, err := CreateStorageBuffer(ctx, particleBufferSize(emitter.Count), "particles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle buffer: %w", err)
+	}
+	if err := buffer.WriteFloat32(ctx, 0, initialParticles(emitter.Count)); err != nil {
+		return nil, fmt.Errorf("failed to write particles: %w", err)
+	}
+
+	paramsBuffer, err := CreateUniformBuffer(ctx, particleParamsSize, "particle-params")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle params buffer: %w", err)
+	}
+	uniformBuffer, err := CreateUniformBuffer(ctx, particleUniformSize, "particle-uniforms")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle uniform buffer: %w", err)
+	}
+
+	module, err := CreateShaderModule(ctx, particleShaderPrelude+emitter.Shader, "particle-update-shader")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle update shader: %w", err)
+	}
+
+	// The layout is explicit so shaders that don't read params still match
+	// the bind group
+	layout, err := CreateBindGroupLayout(ctx, []map[string]interface{}{
+		CreateBindGroupLayoutEntry(0, GPUShaderStageCompute, "storage"),
+		CreateBindGroupLayoutEntry(1, GPUShaderStageCompute, "uniform"),
+	}, "particle-update-layout")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle update layout: %w", err)
+	}
+
+	update, err := CreateComputePipeline(ctx, ComputePipelineConfig{
+		Label:            "particle-update",
+		ComputeShader:    module.Module,
+		EntryPoint:       "update",
+		BindGroupLayouts: []js.Value{layout},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle update pipeline: %w", err)
+	}
+
+	updateGroup, err := CreateBindGroup(ctx, layout, []map[string]interface{}{
+		CreateBindGroupEntry(0, CreateBufferBinding(buffer.Buffer, 0, buffer.Size)),
+		CreateBindGroupEntry(1, CreateBufferBinding(paramsBuffer.Buffer, 0, particleParamsSize)),
+	}, "particle-update-bind-group")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create particle update bind group: %w", err)
+	}
+
+	return &ParticleInstance{
+		Emitter:       emitter,
+		Transform:     node.Transform,
+		Buffer:        buffer,
+		ParamsBuffer:  paramsBuffer,
+		UniformBuffer: uniformBuffer,
+		Update:        update,
+		UpdateGroup:   updateGroup,
+	}, nil
+}
+
+// createParticlePipeline creates the pipeline drawing particles. Particles
+// are blended without writing depth, like transparent meshes.
+func (sr *SceneRenderer) createParticlePipeline() error {
+	ctx := sr.Canvas.GPUContext
+
+	module, err := CreateShaderModule(ctx, particleRenderShader, "particle-shader")
+	if err != nil {
+		return fmt.Errorf("failed to create particle shader: %w", err)
+	}
+
+	pipeline, err := CreatePipelineWithBlending(ctx, PipelineConfig{
+		Label:              "scene-particle-pipeline",
+		VertexShader:       module.Module,
+		FragmentShader:     module.Module,
+		VertexEntryPoint:   "vs_main",
+		FragmentEntryPoint: "fs_main",
+		ColorFormat:        sr.Canvas.Format,
+		DepthFormat:        "depth24plus",
+		PrimitiveTopology:  PrimitiveTopologyTriangleList,
+		DepthWriteDisabled: true,
+		SampleCount:        sr.Canvas.SampleCount,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create particle pipeline: %w", err)
+	}
+
+	sr.ParticlePipeline = pipeline
+	return nil
+}
+
+// updateParticles dispatches the update shader of each particle system.
+// delta is the time since the last frame in milliseconds.
+func (sr *SceneRenderer) updateParticles(delta float64) {
+	ctx := sr.Canvas.GPUContext
+	seconds := delta / 1000
+
+	for _, particles := range sr.Particles {
+		particles.Time += seconds
+		params := []float32{
+			float32(seconds),
+			float32(particles.Time),
+			math.Float32frombits(uint32(particles.Emitter.Count)),
+			0,
+		}
+		if err := particles.ParamsBuffer.WriteFloat32(ctx, 0, params); err != nil {
+			logError(fmt.Sprintf("Failed to write particle params: %v", err))
+			continue
+		}
+
+		workgroups := [3]int{particleWorkgroups(particles.Emitter.Count), 1, 1}
+		if err := RunCompute(ctx, particles.Update, []js.Value{particles.UpdateGroup}, workgroups); err != nil {
+			logError(fmt.Sprintf("Failed to update particles: %v", err))
+		}
+	}
+}
+
+// drawParticles draws each particle system as instanced quads facing the
+// camera
+func (sr *SceneRenderer) drawParticles(renderPass js.Value, viewProjection Mat4) {
+	if len(sr.Particles) == 0 || sr.ParticlePipeline == nil {
+		return
+	}
+	ctx := sr.Canvas.GPUContext
+
+	camera := sr.ActiveCamera
+	forward := camera.Target.Sub(camera.Position).Normalize()
+	right := forward.Cross(camera.Up).Normalize()
+	up := right.Cross(forward)
+
+	renderPass.Call("setPipeline", sr.ParticlePipeline.Pipeline)
+	for _, particles := range sr.Particles {
+		uniforms := packParticleUniforms(viewProjection, particles.Transform.Matrix(), right, up, particles.Emitter)
+		if err := particles.UniformBuffer.WriteFloat32(ctx, 0, uniforms); err != nil {
+			logError(fmt.Sprintf("Failed to write particle uniforms: %v", err))
+			continue
+		}
+
+		bindGroup, err := CreateBindGroup(
+			ctx,
+			sr.ParticlePipeline.Pipeline.Call("getBindGroupLayout", 0),
+			[]map[string]interface{}{
+				CreateBindGroupEntry(0, CreateBufferBinding(particles.Buffer.Buffer, 0, particles.Buffer.Size)),
+				CreateBindGroupEntry(1, CreateBufferBinding(particles.UniformBuffer.Buffer, 0, particleUniformSize)),
+			},
+			"particle-bind-group",
+		)
+		if err != nil {
+			logError(fmt.Sprintf("Failed to create particle bind group: %v", err))
+			continue
+		}
+
+		renderPass.Call("setBindGroup", 0, bindGroup)
+		renderPass.Call("draw", particleVerticesPerInstance, particles.Emitter.Count, 0, 0)
+	}
+}
+
+// destroy releases the particle buffers
+func (p *ParticleInstance) destroy() {
+	p.Buffer.Destroy()
+	p.ParamsBuffer.Destroy()
+	p.UniformBuffer.Destroy()
+}
@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestParticleBufferSize(t *testing.T) {
+	tests := []struct {
+		count, want int
+	}{
+		{1000, 32000},
+		{1, 32},
+		// WebGPU rejects empty buffer bindings
+		{0, 32},
+	}
+	for _, tt := range tests {
+		if got := particleBufferSize(tt.count); got != tt.want {
+			t.Errorf("particleBufferSize(%d) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestParticleWorkgroups(t *testing.T) {
+	tests := []struct {
+		count, want int
+	}{
+		{1000, 16},
+		{1024, 16},
+		{1025, 17},
+		{1, 1},
+		{0, 1},
+	}
+	for _, tt := range tests {
+		if got := particleWorkgroups(tt.count); got != tt.want {
+			t.Errorf("particleWorkgroups(%d) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestInitialParticles(t *testing.T) {
+	data := initialParticles(4)
+	if len(data) != 4*particleFloats {
+		t.Fatalf("Expected %d floats, got %d", 4*particleFloats, len(data))
+	}
+	for i := 0; i < 4; i++ {
+		p := data[i*particleFloats:]
+		if life := p[3]; life != float32(i)/4 {
+			t.Errorf("Particle %d: expected a staggered life of %v, got %v", i, float32(i)/4, life)
+		}
+		if seed := p[7]; seed < 0 || seed >= 1 {
+			t.Errorf("Particle %d: expected a seed in [0, 1), got %v", i, seed)
+		}
+	}
+}
+
+func TestParticleSystemBuilder(t *testing.T) {
+	node := ParticleSystem(Count(500), Shader("fn update() {}"), ParticleSize(0.2), Color(1, 0.5, 0, 1), Position(0, 2, 0))
+
+	if node.Type != ParticleSystemNodeType {
+		t.Errorf("Expected a particle system node, got type %d", node.Type)
+	}
+	p := node.Particles
+	if p.Count != 500 || p.Shader != "fn update() {}" || p.Size != 0.2 || p.Color != (Vec4{1, 0.5, 0, 1}) {
+		t.Errorf("Unexpected particle configuration %+v", *p)
+	}
+	if node.Transform.Position != (Vec3{0, 2, 0}) {
+		t.Errorf("Expected position (0, 2, 0), got %v", node.Transform.Position)
+	}
+
+	if ParticleSystem().Particles.Shader != DefaultParticleShader {
+		t.Error("Expected the default shader without a Shader prop")
+	}
+}
+
+func TestPackParticleUniforms(t *testing.T) {
+	emitter := &ParticleEmitter{Size: 0.1, Color: Vec4{1, 0, 0, 0.5}}
+	data := packParticleUniforms(Identity(), Identity(), Vec3{1, 0, 0}, Vec3{0, 1, 0}, emitter)
+
+	if len(data)*4 != particleUniformSize {
+		t.Fatalf("Expected %d bytes, got %d", particleUniformSize, len(data)*4)
+	}
+	if data[32] != 1 || data[35] != 0.1 {
+		t.Errorf("Expected the right axis and size at float 32, got %v", data[32:36])
+	}
+	if data[37] != 1 {
+		t.Errorf("Expected the up axis at float 36, got %v", data[36:40])
+	}
+	if data[40] != 1 || data[43] != 0.5 {
+		t.Errorf("Expected the color at float 40, got %v", data[40:44])
+	}
+}
@@ -69,6 +69,11 @@ type PipelineConfig struct {
 	PrimitiveTopology  string
 	CullMode           string
 	BindGroupLayouts   []js.Value
+	Blend              *BlendState        // Used by CreatePipelineWithBlending; nil means DefaultBlendState
+	DepthWriteDisabled bool               // Test against the depth buffer without writing it, as for transparent geometry
+	SampleCount        int                // Samples per pixel of the render target, e.g. GPUCanvas.SampleCount; 0 means 1
+	VertexConstants    map[string]float64 // Override constants of the vertex shader
+	FragmentConstants  map[string]float64 // Override constants of the fragment shader
 }
 
 // DefaultPipelineConfig returns a default pipeline configuration
@@ -82,7 +87,10 @@ func DefaultPipelineConfig() PipelineConfig {
 	}
 }
 
-// CreateRenderPipeline creates a render pipeline with the specified configuration
+// CreateRenderPipeline creates a render pipeline with the specified
+// configuration. GPU validation errors, like a shader entry point that
+// doesn't exist, are returned, so it waits for the device; call it from a
+// goroutine, not a JS callback.
 func CreateRenderPipeline(ctx *GPUContext, config PipelineConfig) (*RenderPipeline, error) {
 	if ctx.Device.IsUndefined() {
 		return nil, fmt.Errorf("GPU device not initialized")
@@ -116,10 +124,11 @@ func CreateRenderPipeline(ctx *GPUContext, config PipelineConfig) (*RenderPipeli
 	}
 
 	// Create vertex state
-	vertexState := map[string]interface{}{
-		"module":     config.VertexShader,
-		"entryPoint": config.VertexEntryPoint,
-	}
+	vertexState := ShaderStage{
+		Module:     config.VertexShader,
+		EntryPoint: config.VertexEntryPoint,
+		Constants:  config.VertexConstants,
+	}.descriptor()
 	if len(config.VertexBuffers) > 0 {
 		vertexState["buffers"] = config.VertexBuffers
 	}
@@ -129,11 +138,12 @@ func CreateRenderPipeline(ctx *GPUContext, config PipelineConfig) (*RenderPipeli
 	target := js.Global().Get("Object").New()
 	target.Set("format", config.ColorFormat)
 
-	fragmentState := map[string]interface{}{
-		"module":     config.FragmentShader,
-		"entryPoint": config.FragmentEntryPoint,
-		"targets":    []interface{}{target},
-	}
+	fragmentState := ShaderStage{
+		Module:     config.FragmentShader,
+		EntryPoint: config.FragmentEntryPoint,
+		Constants:  config.FragmentConstants,
+	}.descriptor()
+	fragmentState["targets"] = []interface{}{target}
 
 	// Create primitive state
 	primitiveState := map[string]interface{}{
@@ -165,16 +175,28 @@ func CreateRenderPipeline(ctx *GPUContext, config PipelineConfig) (*RenderPipeli
 		// Create depth-stencil as js.Value to avoid nested map conversion issues
 		depthStencil := js.Global().Get("Object").New()
 		depthStencil.Set("format", config.DepthFormat)
-		depthStencil.Set("depthWriteEnabled", true)
+		depthStencil.Set("depthWriteEnabled", !config.DepthWriteDisabled)
 		depthStencil.Set("depthCompare", "less")
 		pipelineDescriptor["depthStencil"] = depthStencil
 	}
 
+	// Match the sample count of an MSAA render target
+	if config.SampleCount > 1 {
+		pipelineDescriptor["multisample"] = map[string]interface{}{"count": config.SampleCount}
+	}
+
 	// Convert pipeline descriptor to JavaScript object
 	jsPipelineDescriptor := mapToJSObject(pipelineDescriptor)
 
-	// Create the pipeline
-	pipeline := ctx.Device.Call("createRenderPipeline", jsPipelineDescriptor)
+	// Create the pipeline in an error scope, so shader and layout mistakes
+	// are returned instead of logged as uncaptured errors
+	var pipeline js.Value
+	if err := ctx.WithErrorScope(func() error {
+		pipeline = ctx.Device.Call("createRenderPipeline", jsPipelineDescriptor)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create render pipeline: %w", err)
+	}
 	if !pipeline.Truthy() {
 		return nil, fmt.Errorf("failed to create render pipeline")
 	}
@@ -199,9 +221,11 @@ type ComputePipelineConfig struct {
 	ComputeShader    js.Value
 	EntryPoint       string
 	BindGroupLayouts []js.Value
+	Constants        map[string]float64 // Override constants of the compute shader
 }
 
-// CreateComputePipeline creates a compute pipeline
+// CreateComputePipeline creates a compute pipeline. Like
+// CreateRenderPipeline, it returns GPU validation errors.
 func CreateComputePipeline(ctx *GPUContext, config ComputePipelineConfig) (*ComputePipeline, error) {
 	if ctx.Device.IsUndefined() {
 		return nil, fmt.Errorf("GPU device not initialized")
@@ -236,10 +260,11 @@ func CreateComputePipeline(ctx *GPUContext, config ComputePipelineConfig) (*Comp
 	}
 
 	// Create compute state
-	computeState := map[string]interface{}{
-		"module":     config.ComputeShader,
-		"entryPoint": config.EntryPoint,
-	}
+	computeState := ShaderStage{
+		Module:     config.ComputeShader,
+		EntryPoint: config.EntryPoint,
+		Constants:  config.Constants,
+	}.descriptor()
 
 	// Create pipeline descriptor
 	pipelineDescriptor := map[string]interface{}{
@@ -259,8 +284,15 @@ func CreateComputePipeline(ctx *GPUContext, config ComputePipelineConfig) (*Comp
 	// Convert pipeline descriptor to JavaScript object
 	jsPipelineDescriptor := mapToJSObject(pipelineDescriptor)
 
-	// Create the pipeline
-	pipeline := ctx.Device.Call("createComputePipeline", jsPipelineDescriptor)
+	// Create the pipeline in an error scope, so shader and layout mistakes
+	// are returned instead of logged as uncaptured errors
+	var pipeline js.Value
+	if err := ctx.WithErrorScope(func() error {
+		pipeline = ctx.Device.Call("createComputePipeline", jsPipelineDescriptor)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create compute pipeline: %w", err)
+	}
 	if !pipeline.Truthy() {
 		return nil, fmt.Errorf("failed to create compute pipeline")
 	}
@@ -323,46 +355,98 @@ const (
 	BlendOperationMax             = "max"
 )
 
-// CreatePipelineWithBlending creates a render pipeline with alpha blending
+// BlendComponent describes how the color or alpha channels of a fragment
+// are combined with the render target
+type BlendComponent struct {
+	SrcFactor string
+	DstFactor string
+	Operation string
+}
+
+// BlendState configures blending of the color and alpha channels
+type BlendState struct {
+	Color BlendComponent
+	Alpha BlendComponent
+}
+
+// DefaultBlendState returns standard alpha blending of straight (not
+// premultiplied) colors
+func DefaultBlendState() *BlendState {
+	return &BlendState{
+		Color: BlendComponent{SrcFactor: BlendFactorSrcAlpha, DstFactor: BlendFactorOneMinusSrcAlpha, Operation: BlendOperationAdd},
+		Alpha: BlendComponent{SrcFactor: BlendFactorOne, DstFactor: BlendFactorOneMinusSrcAlpha, Operation: BlendOperationAdd},
+	}
+}
+
+// BlendStateAdditive returns additive blending, which brightens the target
+// and suits glow and particle effects
+func BlendStateAdditive() *BlendState {
+	return &BlendState{
+		Color: BlendComponent{SrcFactor: BlendFactorSrcAlpha, DstFactor: BlendFactorOne, Operation: BlendOperationAdd},
+		Alpha: BlendComponent{SrcFactor: BlendFactorOne, DstFactor: BlendFactorOne, Operation: BlendOperationAdd},
+	}
+}
+
+// BlendStatePremultiplied returns blending for colors already multiplied by
+// their alpha, e.g. textures uploaded with premultiplied alpha
+func BlendStatePremultiplied() *BlendState {
+	return &BlendState{
+		Color: BlendComponent{SrcFactor: BlendFactorOne, DstFactor: BlendFactorOneMinusSrcAlpha, Operation: BlendOperationAdd},
+		Alpha: BlendComponent{SrcFactor: BlendFactorOne, DstFactor: BlendFactorOneMinusSrcAlpha, Operation: BlendOperationAdd},
+	}
+}
+
+// descriptor returns the GPUBlendState descriptor of the blend state
+func (b *BlendState) descriptor() map[string]interface{} {
+	return map[string]interface{}{
+		"color": b.Color.descriptor(),
+		"alpha": b.Alpha.descriptor(),
+	}
+}
+
+// descriptor returns the GPUBlendComponent descriptor of the component
+func (c BlendComponent) descriptor() map[string]interface{} {
+	return map[string]interface{}{
+		"srcFactor": c.SrcFactor,
+		"dstFactor": c.DstFactor,
+		"operation": c.Operation,
+	}
+}
+
+// CreatePipelineWithBlending creates a render pipeline with blending. The
+// config's Blend state is used when set, standard alpha blending otherwise.
+// Like CreateRenderPipeline, it returns GPU validation errors.
 func CreatePipelineWithBlending(ctx *GPUContext, config PipelineConfig) (*RenderPipeline, error) {
 	if ctx.Device.IsUndefined() {
 		return nil, fmt.Errorf("GPU device not initialized")
 	}
 
 	// Create vertex state
-	vertexState := map[string]interface{}{
-		"module":     config.VertexShader,
-		"entryPoint": config.VertexEntryPoint,
-	}
+	vertexState := ShaderStage{
+		Module:     config.VertexShader,
+		EntryPoint: config.VertexEntryPoint,
+		Constants:  config.VertexConstants,
+	}.descriptor()
 	if len(config.VertexBuffers) > 0 {
 		vertexState["buffers"] = config.VertexBuffers
 	}
 
 	// Create fragment state with blending
-	// Create blend components as js.Value to avoid nested map conversion issues
-	colorBlend := js.Global().Get("Object").New()
-	colorBlend.Set("srcFactor", BlendFactorSrcAlpha)
-	colorBlend.Set("dstFactor", BlendFactorOneMinusSrcAlpha)
-	colorBlend.Set("operation", BlendOperationAdd)
-
-	alphaBlend := js.Global().Get("Object").New()
-	alphaBlend.Set("srcFactor", BlendFactorOne)
-	alphaBlend.Set("dstFactor", BlendFactorOneMinusSrcAlpha)
-	alphaBlend.Set("operation", BlendOperationAdd)
-
-	blend := js.Global().Get("Object").New()
-	blend.Set("color", colorBlend)
-	blend.Set("alpha", alphaBlend)
+	blendState := config.Blend
+	if blendState == nil {
+		blendState = DefaultBlendState()
+	}
 
 	target := js.Global().Get("Object").New()
 	target.Set("format", config.ColorFormat)
-	target.Set("blend", blend)
+	target.Set("blend", mapToJSObject(blendState.descriptor()))
 
-	fragmentState := map[string]interface{}{
-		"module":     config.FragmentShader,
-		"entryPoint": config.FragmentEntryPoint,
-		"targets":    []interface{}{target},
-	}
+	fragmentState := ShaderStage{
+		Module:     config.FragmentShader,
+		EntryPoint: config.FragmentEntryPoint,
+		Constants:  config.FragmentConstants,
+	}.descriptor()
+	fragmentState["targets"] = []interface{}{target}
 
 	// Create primitive state
 	primitiveState := map[string]interface{}{
@@ -389,16 +473,28 @@ func CreatePipelineWithBlending(ctx *GPUContext, config PipelineConfig) (*Render
 		// Create depth-stencil as js.Value to avoid nested map conversion issues
 		depthStencil := js.Global().Get("Object").New()
 		depthStencil.Set("format", config.DepthFormat)
-		depthStencil.Set("depthWriteEnabled", true)
+		depthStencil.Set("depthWriteEnabled", !config.DepthWriteDisabled)
 		depthStencil.Set("depthCompare", "less")
 		pipelineDescriptor["depthStencil"] = depthStencil
 	}
 
+	// Match the sample count of an MSAA render target
+	if config.SampleCount > 1 {
+		pipelineDescriptor["multisample"] = map[string]interface{}{"count": config.SampleCount}
+	}
+
 	// Convert pipeline descriptor to JavaScript object
 	jsPipelineDescriptor := mapToJSObject(pipelineDescriptor)
 
-	// Create the pipeline
-	pipeline := ctx.Device.Call("createRenderPipeline", jsPipelineDescriptor)
+	// Create the pipeline in an error scope, so shader and layout mistakes
+	// are returned instead of logged as uncaptured errors
+	var pipeline js.Value
+	if err := ctx.WithErrorScope(func() error {
+		pipeline = ctx.Device.Call("createRenderPipeline", jsPipelineDescriptor)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create render pipeline with blending: %w", err)
+	}
 	if !pipeline.Truthy() {
 		return nil, fmt.Errorf("failed to create render pipeline with blending")
 	}
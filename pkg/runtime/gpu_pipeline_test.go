@@ -0,0 +1,132 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"syscall/js"
+	"testing"
+)
+
+func TestBlendStateDescriptors(t *testing.T) {
+	tests := []struct {
+		name  string
+		state *BlendState
+		color [3]string
+		alpha [3]string
+	}{
+		{
+			name:  "default",
+			state: DefaultBlendState(),
+			color: [3]string{BlendFactorSrcAlpha, BlendFactorOneMinusSrcAlpha, BlendOperationAdd},
+			alpha: [3]string{BlendFactorOne, BlendFactorOneMinusSrcAlpha, BlendOperationAdd},
+		},
+		{
+			name:  "additive",
+			state: BlendStateAdditive(),
+			color: [3]string{BlendFactorSrcAlpha, BlendFactorOne, BlendOperationAdd},
+			alpha: [3]string{BlendFactorOne, BlendFactorOne, BlendOperationAdd},
+		},
+		{
+			name:  "premultiplied",
+			state: BlendStatePremultiplied(),
+			color: [3]string{BlendFactorOne, BlendFactorOneMinusSrcAlpha, BlendOperationAdd},
+			alpha: [3]string{BlendFactorOne, BlendFactorOneMinusSrcAlpha, BlendOperationAdd},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			descriptor := tt.state.descriptor()
+			for key, want := range map[string][3]string{"color": tt.color, "alpha": tt.alpha} {
+				component, ok := descriptor[key].(map[string]interface{})
+				if !ok {
+					t.Fatalf("Expected %s component map, got %T", key, descriptor[key])
+				}
+				got := [3]string{
+					component["srcFactor"].(string),
+					component["dstFactor"].(string),
+					component["operation"].(string),
+				}
+				if got != want {
+					t.Errorf("%s: expected src/dst/op %v, got %v", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+// fakeErrorScopeDevice returns a device whose popErrorScope resolves to a
+// validation error with message, or to null when message is empty
+func fakeErrorScopeDevice(message string) js.Value {
+	device := js.Global().Call("eval", `({
+		scopes: [],
+		pushErrorScope(filter) { this.scopes.push(filter); },
+		popErrorScope() {
+			this.scopes.pop();
+			return Promise.resolve(this.message ? {message: this.message} : null);
+		},
+		createComputePipeline(desc) { return {label: desc.label}; },
+	})`)
+	if message != "" {
+		device.Set("message", message)
+	}
+	return device
+}
+
+func TestWithErrorScope(t *testing.T) {
+	device := fakeErrorScopeDevice("")
+	ctx := &GPUContext{Device: device}
+
+	var filter string
+	err := ctx.WithErrorScope(func() error {
+		filter = device.Get("scopes").Index(0).String()
+		return nil
+	})
+	if err != nil || filter != "validation" {
+		t.Errorf("Expected a validation scope without errors, got filter %q, error %v", filter, err)
+	}
+
+	fnErr := errors.New("failed")
+	if err := ctx.WithErrorScope(func() error { return fnErr }); err != fnErr {
+		t.Errorf("Expected the error of fn, got %v", err)
+	}
+	if n := device.Get("scopes").Length(); n != 0 {
+		t.Errorf("Expected every scope to be popped, got %d open", n)
+	}
+}
+
+func TestCreateComputePipelineReturnsValidationError(t *testing.T) {
+	ctx := &GPUContext{Device: fakeErrorScopeDevice("entry point \"main\" not found")}
+
+	_, err := CreateComputePipeline(ctx, ComputePipelineConfig{
+		Label:         "broken",
+		ComputeShader: js.Global().Get("Object").New(),
+		EntryPoint:    "main",
+	})
+	if err == nil || !strings.Contains(err.Error(), `entry point "main" not found`) {
+		t.Errorf("Expected the GPU validation error, got %v", err)
+	}
+}
+
+func TestCreateComputePipelineSetsConstants(t *testing.T) {
+	device := fakeErrorScopeDevice("")
+	js.Global().Call("eval", `(device) => {
+		device.createComputePipeline = function(desc) { this.descriptor = desc; return {}; };
+	}`).Invoke(device)
+	ctx := &GPUContext{Device: device}
+
+	_, err := CreateComputePipeline(ctx, ComputePipelineConfig{
+		ComputeShader: js.Global().Get("Object").New(),
+		Constants:     map[string]float64{"workgroupSize": 128},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pipeline: %v", err)
+	}
+
+	constants := device.Get("descriptor").Get("compute").Get("constants")
+	if !constants.Truthy() || constants.Get("workgroupSize").Float() != 128 {
+		t.Errorf("Expected the override constant workgroupSize = 128 in the compute stage")
+	}
+}
@@ -4,20 +4,33 @@ package runtime
 
 import (
 	"fmt"
+	"sort"
 	"syscall/js"
 )
 
 // SceneRenderer manages rendering of a 3D scene
 type SceneRenderer struct {
-	Canvas        *GPUCanvas
-	Scene         *GPUNode
-	ActiveCamera  *Camera
-	Pipeline      *RenderPipeline
-	UniformBuffer *GPUBuffer
-	DepthTexture  js.Value
-	Meshes        []*MeshInstance
-	Lights        []*Light
-	AmbientLight  *Light
+	Canvas           *GPUCanvas
+	Scene            *GPUNode
+	ActiveCamera     *Camera
+	Pipeline         *RenderPipeline // Opaque meshes, writing depth
+	Transparent      *RenderPipeline // Transparent meshes, blended without writing depth
+	ParticlePipeline *RenderPipeline // Particle systems, created when the scene has any
+	// Meshes with vertex colors, created when the scene has any
+	ColoredPipeline    *RenderPipeline
+	ColoredTransparent *RenderPipeline
+	// Instanced meshes, created when the scene has any
+	InstancedPipeline    *RenderPipeline
+	InstancedTransparent *RenderPipeline
+	UniformBuffer        *GPUBuffer // Uniforms of each mesh, meshUniformStride apart
+	LightBuffer          *GPUBuffer
+	DepthTexture         js.Value
+	Meshes               []*MeshInstance
+	Particles            []*ParticleInstance
+	Instanced            []*InstancedMeshInstance
+	Lights               []*Light
+	AmbientLight         *Light
+	LastFrame            float64 // Canvas time of the last rendered frame, in milliseconds
 }
 
 // ReactiveBinding holds pointers to values that should be synced to transform
@@ -33,9 +46,11 @@ type MeshInstance struct {
 	Geometry        Geometry
 	Material        *Material
 	VertexBuffer    *GPUBuffer
+	VertexStride    int // Bytes per vertex; vertices with a color are longer
 	IndexBuffer     *GPUBuffer
 	IndexCount      int
 	ReactiveBinding *ReactiveBinding // Reactive binding for auto-updates
+	PropBindings    []func() GPUProp // Transform props re-evaluated each frame
 }
 
 // NewSceneRenderer creates a new scene renderer
@@ -75,15 +90,23 @@ func NewSceneRenderer(canvas *GPUCanvas, scene *GPUNode) (*SceneRenderer, error)
 	}
 	renderer.DepthTexture = depthTexture
 
-	// Create uniform buffer for MVP matrix
+	// Create uniform buffer with a slot per mesh, since all meshes are drawn
+	// in one pass after the uniforms are written
 	log("[Renderer] Creating uniform buffer")
-	uniformBuffer, err := CreateUniformBuffer(canvas.GPUContext, 256, "mvp-uniforms")
+	uniformBuffer, err := CreateUniformBuffer(canvas.GPUContext, meshUniformStride*max(len(renderer.Meshes), 1), "mesh-uniforms")
 	if err != nil {
 		logError(fmt.Sprintf("[Renderer] Failed to create uniform buffer: %v", err))
 		return nil, fmt.Errorf("failed to create uniform buffer: %w", err)
 	}
 	renderer.UniformBuffer = uniformBuffer
 
+	lightBuffer, err := CreateUniformBuffer(canvas.GPUContext, lightsUniformSize, "light-uniforms")
+	if err != nil {
+		logError(fmt.Sprintf("[Renderer] Failed to create light buffer: %v", err))
+		return nil, fmt.Errorf("failed to create light buffer: %w", err)
+	}
+	renderer.LightBuffer = lightBuffer
+
 	// Create render pipeline
 	log("[Renderer] Creating render pipeline")
 	if err := renderer.createPipeline(); err != nil {
@@ -113,6 +136,26 @@ func (sr *SceneRenderer) buildScene(node *GPUNode) error {
 			}
 		}
 
+	case InstancedMeshNodeType:
+		if node.Geometry != nil {
+			instanced, err := sr.createInstancedMeshInstance(node)
+			if err != nil {
+				logError(fmt.Sprintf("Failed to create instanced mesh: %v", err))
+			} else {
+				sr.Instanced = append(sr.Instanced, instanced)
+			}
+		}
+
+	case ParticleSystemNodeType:
+		if node.Particles != nil {
+			particles, err := sr.createParticleInstance(node)
+			if err != nil {
+				logError(fmt.Sprintf("Failed to create particle system: %v", err))
+			} else {
+				sr.Particles = append(sr.Particles, particles)
+			}
+		}
+
 	case CameraNodeType:
 		// Set active camera
 		if node.Camera != nil {
@@ -146,6 +189,12 @@ func (sr *SceneRenderer) createMeshInstance(node *GPUNode) (*MeshInstance, error
 	vertices := node.Geometry.GetVertices()
 	indices := node.Geometry.GetIndices()
 
+	// Vertex colors are interleaved after the position and normal
+	stride := vertexStride(node.Geometry)
+	if stride == coloredVertexStride {
+		vertices = interleaveColors(vertices, vertexColors(node.Geometry))
+	}
+
 	// Create vertex buffer
 	vertexBuffer, err := CreateVertexBuffer(sr.Canvas.GPUContext, vertices, "mesh-vertices")
 	if err != nil {
@@ -175,73 +224,160 @@ func (sr *SceneRenderer) createMeshInstance(node *GPUNode) (*MeshInstance, error
 			binding = b
 		}
 	}
+	propBindings, _ := node.Properties["bindProps"].([]func() GPUProp)
 
 	return &MeshInstance{
 		Transform:       node.Transform,
 		Geometry:        node.Geometry,
 		Material:        material,
 		VertexBuffer:    vertexBuffer,
+		VertexStride:    stride,
 		IndexBuffer:     indexBuffer,
 		IndexCount:      len(indices),
 		ReactiveBinding: binding,
+		PropBindings:    propBindings,
 	}, nil
 }
 
-// createPipeline creates the render pipeline with shaders
+// createPipeline creates the render pipelines with shaders
 func (sr *SceneRenderer) createPipeline() error {
+	pipeline, transparent, err := sr.createMeshPipelines(VertexShaderWithMVP, meshVertexStride, "scene")
+	if err != nil {
+		return err
+	}
+	sr.Pipeline = pipeline
+	sr.Transparent = transparent
+
+	if sr.hasVertexColors() {
+		colored, coloredTransparent, err := sr.createMeshPipelines(VertexShaderWithColor, coloredVertexStride, "scene-colored")
+		if err != nil {
+			return err
+		}
+		sr.ColoredPipeline = colored
+		sr.ColoredTransparent = coloredTransparent
+	}
+
+	if len(sr.Instanced) > 0 {
+		if err := sr.createInstancedPipelines(); err != nil {
+			return err
+		}
+	}
+
+	if len(sr.Particles) > 0 {
+		return sr.createParticlePipeline()
+	}
+
+	return nil
+}
+
+// createMeshPipelines creates the opaque and transparent pipelines of
+// meshes whose vertices are stride bytes long, read by vertexShader
+func (sr *SceneRenderer) createMeshPipelines(vertexShader string, stride int, label string) (*RenderPipeline, *RenderPipeline, error) {
 	ctx := sr.Canvas.GPUContext
 
 	// Create vertex shader
-	vertexShaderModule, err := CreateShaderModule(ctx, VertexShaderWithMVP, "vertex-shader")
+	vertexShaderModule, err := CreateShaderModule(ctx, vertexShader, label+"-vertex-shader")
 	if err != nil {
-		return fmt.Errorf("failed to create vertex shader: %w", err)
+		return nil, nil, fmt.Errorf("failed to create vertex shader: %w", err)
 	}
 
 	// Create fragment shader
-	fragmentShaderModule, err := CreateShaderModule(ctx, FragmentShaderWithLighting, "fragment-shader")
+	fragmentShaderModule, err := CreateShaderModule(ctx, FragmentShaderWithLighting, label+"-fragment-shader")
 	if err != nil {
-		return fmt.Errorf("failed to create fragment shader: %w", err)
+		return nil, nil, fmt.Errorf("failed to create fragment shader: %w", err)
 	}
 
-	// Create vertex buffer layout
-	vertexBufferLayout := CreateVertexBufferLayout(24, []VertexAttribute{
-		{Format: VertexFormatFloat32x3, Offset: 0, ShaderLocation: 0},  // position
-		{Format: VertexFormatFloat32x3, Offset: 12, ShaderLocation: 1}, // normal
-	})
-
-	// Create bind group layout for uniforms
+	// Create bind group layout for mesh and light uniforms
 	bindGroupLayoutEntries := []map[string]interface{}{
-		CreateBindGroupLayoutEntry(0, GPUShaderStageVertex, "uniform"),
+		CreateBindGroupLayoutEntry(0, GPUShaderStageVertex|GPUShaderStageFragment, "uniform"),
+		CreateBindGroupLayoutEntry(1, GPUShaderStageFragment, "uniform"),
 	}
 
-	bindGroupLayout, err := CreateBindGroupLayout(ctx, bindGroupLayoutEntries, "uniform-bind-group-layout")
+	bindGroupLayout, err := CreateBindGroupLayout(ctx, bindGroupLayoutEntries, label+"-bind-group-layout")
 	if err != nil {
-		return fmt.Errorf("failed to create bind group layout: %w", err)
+		return nil, nil, fmt.Errorf("failed to create bind group layout: %w", err)
 	}
 
 	// Create pipeline
 	config := PipelineConfig{
-		Label:              "scene-pipeline",
+		Label:              label + "-pipeline",
 		VertexShader:       vertexShaderModule.Module,
 		FragmentShader:     fragmentShaderModule.Module,
 		VertexEntryPoint:   "vs_main",
 		FragmentEntryPoint: "fs_main",
-		VertexBuffers:      []map[string]interface{}{vertexBufferLayout},
+		VertexBuffers:      []map[string]interface{}{meshVertexLayout(stride)},
 		ColorFormat:        sr.Canvas.Format,
 		DepthFormat:        "depth24plus",
 		PrimitiveTopology:  PrimitiveTopologyTriangleList,
 		CullMode:           CullModeBack,
 		BindGroupLayouts:   []js.Value{bindGroupLayout},
+		SampleCount:        sr.Canvas.SampleCount,
 	}
 
 	pipeline, err := CreateRenderPipeline(ctx, config)
 	if err != nil {
-		return fmt.Errorf("failed to create pipeline: %w", err)
+		return nil, nil, fmt.Errorf("failed to create pipeline: %w", err)
 	}
 
-	sr.Pipeline = pipeline
+	// Transparent meshes are tested against the depth of opaque ones, but
+	// don't hide each other since they are drawn back to front
+	config.Label = label + "-transparent-pipeline"
+	config.DepthWriteDisabled = true
+	transparent, err := CreatePipelineWithBlending(ctx, config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create transparent pipeline: %w", err)
+	}
 
-	return nil
+	return pipeline, transparent, nil
+}
+
+// hasVertexColors checks if any mesh of the scene has vertex colors
+func (sr *SceneRenderer) hasVertexColors() bool {
+	for _, mesh := range sr.Meshes {
+		if mesh.VertexStride == coloredVertexStride {
+			return true
+		}
+	}
+	return false
+}
+
+// pipelineFor returns the pipeline matching a mesh's material and vertices
+func (sr *SceneRenderer) pipelineFor(mesh *MeshInstance) *RenderPipeline {
+	transparent := mesh.Material != nil && mesh.Material.IsTransparent()
+	if mesh.VertexStride == coloredVertexStride {
+		if transparent {
+			return sr.ColoredTransparent
+		}
+		return sr.ColoredPipeline
+	}
+	if transparent {
+		return sr.Transparent
+	}
+	return sr.Pipeline
+}
+
+// drawOrder returns meshes in the order they are drawn: opaque meshes
+// first, then transparent meshes from the farthest to the nearest to the
+// camera, so each blends over what is behind it
+func drawOrder(meshes []*MeshInstance, camera Vec3) []*MeshInstance {
+	ordered := make([]*MeshInstance, 0, len(meshes))
+	var transparent []*MeshInstance
+	for _, mesh := range meshes {
+		if mesh.Material != nil && mesh.Material.IsTransparent() {
+			transparent = append(transparent, mesh)
+		} else {
+			ordered = append(ordered, mesh)
+		}
+	}
+
+	distance := func(mesh *MeshInstance) float32 {
+		d := mesh.Transform.Position.Sub(camera)
+		return d.Dot(d)
+	}
+	sort.SliceStable(transparent, func(i, j int) bool {
+		return distance(transparent[i]) > distance(transparent[j])
+	})
+	return append(ordered, transparent...)
 }
 
 // syncReactiveBindings updates mesh transforms from reactive bindings
@@ -258,6 +394,9 @@ func (sr *SceneRenderer) syncReactiveBindings() {
 				mesh.Transform.Rotation.Z = float32(*binding.RotationZ)
 			}
 		}
+		for _, eval := range mesh.PropBindings {
+			applyTransformProp(&mesh.Transform, eval())
+		}
 	}
 }
 
@@ -273,6 +412,14 @@ func (sr *SceneRenderer) Render() {
 
 	ctx := sr.Canvas.GPUContext
 
+	// Update particles on the GPU before they are drawn
+	var delta float64
+	if sr.LastFrame != 0 {
+		delta = sr.Canvas.LastTime - sr.LastFrame
+	}
+	sr.LastFrame = sr.Canvas.LastTime
+	sr.updateParticles(delta)
+
 	// Create command encoder
 	encoder, err := ctx.CreateCommandEncoder("scene-encoder")
 	if err != nil {
@@ -288,6 +435,14 @@ func (sr *SceneRenderer) Render() {
 		}
 	}
 
+	// The depth texture follows the size of the canvas
+	depthTexture, err := sr.Canvas.resizeDepthTexture(sr.DepthTexture)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to resize depth texture: %v", err))
+		return
+	}
+	sr.DepthTexture = depthTexture
+
 	// Begin render pass
 	renderPass := sr.Canvas.BeginRenderPassWithDepth(
 		encoder,
@@ -302,37 +457,76 @@ func (sr *SceneRenderer) Render() {
 		return
 	}
 
-	// Set pipeline
-	renderPass.Call("setPipeline", sr.Pipeline.Pipeline)
-
 	// Update camera aspect ratio
 	sr.ActiveCamera.Aspect = sr.Canvas.GetAspectRatio()
 
 	// Get view-projection matrix
 	viewProjection := sr.ActiveCamera.ViewProjectionMatrix()
 
-	// Render each mesh
-	for _, mesh := range sr.Meshes {
+	// Update lights
+	if err := sr.LightBuffer.WriteFloat32(ctx, 0, packLights(sr.AmbientLight, sr.Lights)); err != nil {
+		logError(fmt.Sprintf("Failed to write lights: %v", err))
+	}
+	lightBinding := CreateBufferBinding(sr.LightBuffer.Buffer, 0, lightsUniformSize)
+
+	// Opaque meshes are drawn first, then transparent ones blend over them
+	meshes := drawOrder(sr.Meshes, sr.ActiveCamera.Position)
+	opaque := 0
+	for opaque < len(meshes) && !meshes[opaque].Material.IsTransparent() {
+		opaque++
+	}
+	sr.drawMeshes(renderPass, meshes[:opaque], 0, viewProjection, lightBinding)
+	sr.drawInstancedMeshes(renderPass, viewProjection, lightBinding, false)
+	sr.drawMeshes(renderPass, meshes[opaque:], opaque, viewProjection, lightBinding)
+	sr.drawInstancedMeshes(renderPass, viewProjection, lightBinding, true)
+
+	// Particles blend over meshes
+	sr.drawParticles(renderPass, viewProjection)
+
+	// End render pass
+	renderPass.Call("end")
+
+	// Finish and submit
+	commandBuffer := encoder.Call("finish")
+	ctx.Submit(commandBuffer)
+}
+
+// drawMeshes draws meshes in order, switching pipelines with the material.
+// Each mesh's uniforms go in its own slot, counting slots from first.
+func (sr *SceneRenderer) drawMeshes(renderPass js.Value, meshes []*MeshInstance, first int, viewProjection Mat4, lightBinding js.Value) {
+	ctx := sr.Canvas.GPUContext
+
+	var current *RenderPipeline
+	for i, mesh := range meshes {
+		pipeline := sr.pipelineFor(mesh)
+		if pipeline != current {
+			renderPass.Call("setPipeline", pipeline.Pipeline)
+			current = pipeline
+		}
+
 		// Calculate model-view-projection matrix
 		model := mesh.Transform.Matrix()
 		mvp := viewProjection.Multiply(model)
 
-		// Update uniform buffer
-		if err := sr.UniformBuffer.Write(ctx, 0, mvp.ToBytes()); err != nil {
+		// Update the mesh's uniform slot
+		offset := (first + i) * meshUniformStride
+		uniforms := packMeshUniforms(mvp, model, mesh.Material.Color)
+		if err := sr.UniformBuffer.WriteFloat32(ctx, offset, uniforms); err != nil {
 			logError(fmt.Sprintf("Failed to write uniforms: %v", err))
 			continue
 		}
 
 		// Create bind group for this mesh
 		// Note: WebGPU requires a GPUBufferBinding object (with buffer, offset, size) not just the buffer
-		bufferBinding := CreateBufferBinding(sr.UniformBuffer.Buffer, 0, sr.UniformBuffer.Size)
+		bufferBinding := CreateBufferBinding(sr.UniformBuffer.Buffer, offset, meshUniformSize)
 		bindGroupEntries := []map[string]interface{}{
 			CreateBindGroupEntry(0, bufferBinding),
+			CreateBindGroupEntry(1, lightBinding),
 		}
 
 		bindGroup, err := CreateBindGroup(
 			ctx,
-			sr.Pipeline.Pipeline.Call("getBindGroupLayout", 0),
+			pipeline.Pipeline.Call("getBindGroupLayout", 0),
 			bindGroupEntries,
 			"mesh-bind-group",
 		)
@@ -353,13 +547,6 @@ func (sr *SceneRenderer) Render() {
 		// Draw indexed
 		renderPass.Call("drawIndexed", mesh.IndexCount, 1, 0, 0, 0)
 	}
-
-	// End render pass
-	renderPass.Call("end")
-
-	// Finish and submit
-	commandBuffer := encoder.Call("finish")
-	ctx.Submit(commandBuffer)
 }
 
 // UpdateMeshTransform updates the transform of a mesh by index
@@ -381,10 +568,21 @@ func (sr *SceneRenderer) Cleanup() {
 		}
 	}
 
-	// Destroy uniform buffer
+	for _, instanced := range sr.Instanced {
+		instanced.destroy()
+	}
+
+	for _, particles := range sr.Particles {
+		particles.destroy()
+	}
+
+	// Destroy uniform buffers
 	if sr.UniformBuffer != nil {
 		sr.UniformBuffer.Destroy()
 	}
+	if sr.LightBuffer != nil {
+		sr.LightBuffer.Destroy()
+	}
 
 	// Destroy depth texture
 	if sr.DepthTexture.Truthy() {
@@ -0,0 +1,127 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"syscall/js"
+)
+
+// resizeDebounceMs is how long the container of a canvas must keep its size
+// before ObserveResize resizes the canvas
+const resizeDebounceMs = 100
+
+// canvasResize holds the ResizeObserver of a canvas and its pending timer
+type canvasResize struct {
+	observer js.Value
+	callback js.Func
+	timer    js.Func
+	timeout  js.Value
+}
+
+// ObserveResize resizes the canvas to its container whenever the container
+// changes size, e.g. for a chart filling a responsive layout:
+//
+//	canvas.Mount("#chart")
+//	canvas.ObserveResize(func(w, h int) { fmt.Println("chart resized to", w, h) })
+//
+// Resize events are debounced, so dragging the window resizes the canvas
+// once it stops. The canvas is reconfigured with Resize and onResize, which
+// may be nil, is called with the new size; renderers recreate their depth
+// texture on the next frame. The canvas must be mounted first. Unmount
+// stops observing; calling ObserveResize again replaces the previous
+// observer.
+func (gc *GPUCanvas) ObserveResize(onResize func(w, h int)) {
+	container := gc.Canvas.Get("parentNode")
+	if !container.Truthy() {
+		logError("[Canvas] Cannot observe resizes of a canvas that is not mounted")
+		return
+	}
+	observerClass := js.Global().Get("ResizeObserver")
+	if !observerClass.Truthy() {
+		logError("[Canvas] ResizeObserver is not supported")
+		return
+	}
+
+	gc.stopObservingResize()
+	resize := &canvasResize{}
+
+	var width, height int
+	resize.callback = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		entries := args[0]
+		if entries.Length() == 0 {
+			return nil
+		}
+		rect := entries.Index(entries.Length() - 1).Get("contentRect")
+		width = int(math.Round(rect.Get("width").Float()))
+		height = int(math.Round(rect.Get("height").Float()))
+
+		// Each event restarts the timer
+		if resize.timer.Value.Truthy() {
+			js.Global().Call("clearTimeout", resize.timeout)
+			resize.timer.Release()
+		}
+		var timer js.Func
+		timer = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if resize.timer.Value.Equal(timer.Value) {
+				resize.timer = js.Func{}
+			}
+			timer.Release()
+
+			// Collapsed containers and unchanged sizes leave the canvas as is
+			if width <= 0 || height <= 0 || (width == gc.Width && height == gc.Height) {
+				return nil
+			}
+			w, h := width, height
+			// Timer callbacks must not block the JS event loop
+			go func() {
+				if err := gc.Resize(w, h); err != nil {
+					logError(fmt.Sprintf("[Canvas] Failed to resize: %v", err))
+					return
+				}
+				if onResize != nil {
+					onResize(w, h)
+				}
+			}()
+			return nil
+		})
+		resize.timer = timer
+		resize.timeout = js.Global().Call("setTimeout", timer, resizeDebounceMs)
+		return nil
+	})
+
+	resize.observer = observerClass.New(resize.callback)
+	resize.observer.Call("observe", container)
+	gc.resize = resize
+}
+
+// stopObservingResize disconnects the ResizeObserver of ObserveResize and
+// releases its callbacks, cancelling a pending resize
+func (gc *GPUCanvas) stopObservingResize() {
+	resize := gc.resize
+	if resize == nil {
+		return
+	}
+	gc.resize = nil
+
+	resize.observer.Call("disconnect")
+	resize.callback.Release()
+	if resize.timer.Value.Truthy() {
+		js.Global().Call("clearTimeout", resize.timeout)
+		resize.timer.Release()
+		resize.timer = js.Func{}
+	}
+}
+
+// resizeDepthTexture returns a depth texture the size of the canvas: depth
+// if it already is, otherwise a new one, destroying depth
+func (gc *GPUCanvas) resizeDepthTexture(depth js.Value) (js.Value, error) {
+	if depth.Truthy() && depth.Get("width").Int() == gc.Width && depth.Get("height").Int() == gc.Height {
+		return depth, nil
+	}
+	if depth.Truthy() {
+		depth.Call("destroy")
+	}
+	return gc.CreateDepthTexture()
+}
@@ -0,0 +1,92 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// installFakeResizeObserver replaces ResizeObserver with a fake recording
+// its instances, returned as a JS array
+func installFakeResizeObserver(t *testing.T) js.Value {
+	t.Helper()
+
+	global := js.Global()
+	original := global.Get("ResizeObserver")
+	observers := global.Call("eval", `[]`)
+	class := global.Call("eval", `(observers) => class {
+		constructor(callback) { this.callback = callback; observers.push(this); }
+		observe(target) { this.target = target; }
+		disconnect() { this.disconnected = true; }
+	}`).Invoke(observers)
+	global.Set("ResizeObserver", class)
+	t.Cleanup(func() { global.Set("ResizeObserver", original) })
+	return observers
+}
+
+// resizeEntries returns the entries of a ResizeObserver callback for a
+// content box of width by height
+func resizeEntries(width, height float64) js.Value {
+	return js.Global().Call("eval", `(width, height) => [{contentRect: {width, height}}]`).Invoke(width, height)
+}
+
+func TestGPUCanvasObserveResize(t *testing.T) {
+	observers := installFakeResizeObserver(t)
+
+	container := js.Global().Call("eval", `({removeChild() {}})`)
+	canvas := js.Global().Call("eval", `({style: {}})`)
+	canvas.Set("parentNode", container)
+	context := js.Global().Call("eval", `({configure(config) { this.configured = (this.configured || 0) + 1; }})`)
+	gc := &GPUCanvas{
+		Canvas:     canvas,
+		Context:    context,
+		GPUContext: &GPUContext{Device: js.Global().Call("eval", `({})`)},
+		Width:      300,
+		Height:     150,
+	}
+
+	resized := make(chan [2]int, 4)
+	gc.ObserveResize(func(w, h int) { resized <- [2]int{w, h} })
+
+	observer := observers.Index(0)
+	if !observer.Get("target").Equal(container) {
+		t.Fatalf("Expected the container of the canvas to be observed")
+	}
+
+	// A burst of events resizes the canvas once, to the last size
+	callback := observer.Get("callback")
+	callback.Invoke(resizeEntries(400, 200))
+	callback.Invoke(resizeEntries(640.4, 480))
+
+	select {
+	case size := <-resized:
+		if size != [2]int{640, 480} {
+			t.Errorf("Expected to resize to 640x480, got %v", size)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the canvas to be resized")
+	}
+	if gc.Width != 640 || gc.Height != 480 || canvas.Get("width").Int() != 640 {
+		t.Errorf("Expected the canvas to be 640x480, got %dx%d", gc.Width, gc.Height)
+	}
+	if n := context.Get("configured").Int(); n != 1 {
+		t.Errorf("Expected the context to be configured once, got %d", n)
+	}
+
+	// Unmount cancels a pending resize and disconnects the observer
+	callback.Invoke(resizeEntries(800, 600))
+	gc.Unmount()
+	if !observer.Get("disconnected").Truthy() {
+		t.Error("Expected Unmount to disconnect the observer")
+	}
+	select {
+	case size := <-resized:
+		t.Errorf("Expected no resize after Unmount, got %v", size)
+	case <-time.After(2 * resizeDebounceMs * time.Millisecond):
+	}
+	if gc.resize != nil {
+		t.Error("Expected Unmount to release the observer")
+	}
+}
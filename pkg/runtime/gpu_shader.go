@@ -4,6 +4,8 @@ package runtime
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"syscall/js"
 )
 
@@ -14,13 +16,31 @@ type ShaderModule struct {
 	Source string
 }
 
-// ShaderStage represents a shader stage configuration
+// ShaderStage represents a shader stage configuration. Constants set the
+// pipeline-overridable constants of the shader, its override declarations,
+// by name.
 type ShaderStage struct {
 	Module     js.Value
 	EntryPoint string
 	Constants  map[string]float64
 }
 
+// descriptor returns the GPUProgrammableStage of the stage
+func (s ShaderStage) descriptor() map[string]interface{} {
+	stage := map[string]interface{}{
+		"module":     s.Module,
+		"entryPoint": s.EntryPoint,
+	}
+	if len(s.Constants) > 0 {
+		constants := make(map[string]interface{}, len(s.Constants))
+		for name, value := range s.Constants {
+			constants[name] = value
+		}
+		stage["constants"] = constants
+	}
+	return stage
+}
+
 // VertexBufferLayout describes the layout of vertex data
 type VertexBufferLayout struct {
 	ArrayStride int
@@ -268,10 +288,14 @@ fn fs_main() -> @location(0) vec4f {
 }
 `
 
-	// VertexShaderWithMVP is a vertex shader with MVP matrix
+	// VertexShaderWithMVP is a vertex shader with MVP matrix. It passes the
+	// world space normal and position on for lighting, and white as the
+	// vertex color.
 	VertexShaderWithMVP = `
 struct Uniforms {
     modelViewProjection: mat4x4f,
+    model: mat4x4f,
+    color: vec4f,
 }
 
 struct VertexInput {
@@ -282,6 +306,8 @@ struct VertexInput {
 struct VertexOutput {
     @builtin(position) position: vec4f,
     @location(0) normal: vec3f,
+    @location(1) worldPosition: vec3f,
+    @location(2) color: vec4f,
 }
 
 @group(0) @binding(0) var<uniform> uniforms: Uniforms;
@@ -290,25 +316,108 @@ struct VertexOutput {
 fn vs_main(input: VertexInput) -> VertexOutput {
     var output: VertexOutput;
     output.position = uniforms.modelViewProjection * vec4f(input.position, 1.0);
-    output.normal = input.normal;
+    output.normal = (uniforms.model * vec4f(input.normal, 0.0)).xyz;
+    output.worldPosition = (uniforms.model * vec4f(input.position, 1.0)).xyz;
+    output.color = vec4f(1.0);
     return output;
 }
 `
 
-	// FragmentShaderWithLighting is a fragment shader with simple lighting
+	// VertexShaderWithColor is VertexShaderWithMVP reading an RGBA color per
+	// vertex, at location 2, and passing it on to be multiplied with the
+	// material color
+	VertexShaderWithColor = `
+struct Uniforms {
+    modelViewProjection: mat4x4f,
+    model: mat4x4f,
+    color: vec4f,
+}
+
+struct VertexInput {
+    @location(0) position: vec3f,
+    @location(1) normal: vec3f,
+    @location(2) color: vec4f,
+}
+
+struct VertexOutput {
+    @builtin(position) position: vec4f,
+    @location(0) normal: vec3f,
+    @location(1) worldPosition: vec3f,
+    @location(2) color: vec4f,
+}
+
+@group(0) @binding(0) var<uniform> uniforms: Uniforms;
+
+@vertex
+fn vs_main(input: VertexInput) -> VertexOutput {
+    var output: VertexOutput;
+    output.position = uniforms.modelViewProjection * vec4f(input.position, 1.0);
+    output.normal = (uniforms.model * vec4f(input.normal, 0.0)).xyz;
+    output.worldPosition = (uniforms.model * vec4f(input.position, 1.0)).xyz;
+    output.color = input.color;
+    return output;
+}
+`
+
+	// FragmentShaderWithLighting is a fragment shader lighting the material
+	// color, multiplied by the vertex color, with the ambient light and up to 8 directional, point and spot
+	// lights. Point and spot lights fade with distance; spot lights also fade
+	// out towards the edge of their cone.
 	FragmentShaderWithLighting = `
+struct Uniforms {
+    modelViewProjection: mat4x4f,
+    model: mat4x4f,
+    color: vec4f,
+}
+
+struct Light {
+    position: vec3f,
+    kind: u32, // 0 directional, 1 point, 2 spot
+    direction: vec3f,
+    cosInner: f32,
+    color: vec3f,
+    cosOuter: f32,
+}
+
+struct Lights {
+    ambient: vec3f,
+    count: u32,
+    lights: array<Light, 8>,
+}
+
 struct VertexOutput {
     @builtin(position) position: vec4f,
     @location(0) normal: vec3f,
+    @location(1) worldPosition: vec3f,
+    @location(2) color: vec4f,
 }
 
+@group(0) @binding(0) var<uniform> uniforms: Uniforms;
+@group(0) @binding(1) var<uniform> lights: Lights;
+
 @fragment
 fn fs_main(input: VertexOutput) -> @location(0) vec4f {
-    let lightDir = normalize(vec3f(1.0, 1.0, 1.0));
-    let diffuse = max(dot(normalize(input.normal), lightDir), 0.0);
-    let ambient = 0.3;
-    let color = vec3f(1.0, 0.5, 0.2);
-    return vec4f(color * (ambient + diffuse * 0.7), 1.0);
+    let normal = normalize(input.normal);
+    var light = lights.ambient;
+
+    for (var i = 0u; i < min(lights.count, 8u); i++) {
+        let l = lights.lights[i];
+        var toLight = -l.direction;
+        var attenuation = 1.0;
+        if (l.kind != 0u) {
+            let offset = l.position - input.worldPosition;
+            let distance = length(offset);
+            toLight = offset / max(distance, 0.0001);
+            attenuation = 1.0 / (1.0 + 0.09 * distance + 0.032 * distance * distance);
+            if (l.kind == 2u) {
+                attenuation *= smoothstep(l.cosOuter, l.cosInner, dot(-toLight, l.direction));
+            }
+        }
+        light += l.color * max(dot(normal, toLight), 0.0) * attenuation;
+    }
+
+    let color = uniforms.color * input.color;
+    return vec4f(color.rgb * light, color.a);
 }
 `
 )
@@ -327,9 +436,29 @@ func ValidateShaderCompilation(module js.Value) error {
 	return nil
 }
 
-// ShaderPreprocessor can be used to inject common definitions into shaders
+// ShaderPreprocessor can be used to inject common definitions into shaders.
+// Defines become module-level constants and overrides pipeline-overridable
+// constants, declared before the functions of the shader:
+//
+//	sp := NewShaderPreprocessor()
+//	sp.AddDefine("PI", "3.14159")
+//	sp.AddOverride("workgroupSize", "u32", "64")
+//	source := sp.Process(shader)
+//	// const PI = 3.14159;
+//	// override workgroupSize: u32 = 64;
+//
+// An override is set per pipeline through the Constants of its ShaderStage.
 type ShaderPreprocessor struct {
-	Defines map[string]string
+	Defines   map[string]string
+	Overrides []ShaderOverride
+}
+
+// ShaderOverride is a pipeline-overridable constant of a shader. Without a
+// default, the pipeline must set it.
+type ShaderOverride struct {
+	Name    string
+	Type    string
+	Default string
 }
 
 // NewShaderPreprocessor creates a new shader preprocessor
@@ -344,13 +473,34 @@ func (sp *ShaderPreprocessor) AddDefine(name, value string) {
 	sp.Defines[name] = value
 }
 
-// Process processes a shader source with defines
+// AddOverride adds a pipeline-overridable constant of type typ. An empty
+// value declares it without a default.
+func (sp *ShaderPreprocessor) AddOverride(name, typ, value string) {
+	sp.Overrides = append(sp.Overrides, ShaderOverride{Name: name, Type: typ, Default: value})
+}
+
+// Process processes a shader source with defines and overrides. Defines are
+// declared in order of name, so the same defines always give the same
+// source; overrides follow in the order they were added.
 func (sp *ShaderPreprocessor) Process(source string) string {
-	// Simple implementation - prepend defines
-	result := ""
-	for name, value := range sp.Defines {
-		result += fmt.Sprintf("const %s = %s;\n", name, value)
+	names := make([]string, 0, len(sp.Defines))
+	for name := range sp.Defines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "const %s = %s;\n", name, sp.Defines[name])
+	}
+	for _, o := range sp.Overrides {
+		if o.Default == "" {
+			fmt.Fprintf(&b, "override %s: %s;\n", o.Name, o.Type)
+		} else {
+			fmt.Fprintf(&b, "override %s: %s = %s;\n", o.Name, o.Type, o.Default)
+		}
 	}
-	result += "\n" + source
-	return result
+	b.WriteString("\n")
+	b.WriteString(source)
+	return b.String()
 }
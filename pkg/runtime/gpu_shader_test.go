@@ -0,0 +1,24 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestShaderPreprocessorDeclarations(t *testing.T) {
+	sp := NewShaderPreprocessor()
+	sp.AddDefine("TAU", "6.28318")
+	sp.AddDefine("PI", "3.14159")
+	sp.AddOverride("workgroupSize", "u32", "")
+	sp.AddOverride("gamma", "f32", "2.2")
+
+	got := sp.Process("fn main() {}\n")
+	want := "const PI = 3.14159;\n" +
+		"const TAU = 6.28318;\n" +
+		"override workgroupSize: u32;\n" +
+		"override gamma: f32 = 2.2;\n" +
+		"\n" +
+		"fn main() {}\n"
+	if got != want {
+		t.Errorf("Expected declarations before the functions:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,118 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"syscall/js"
+)
+
+// textureRowAlignment is the alignment WebGPU requires of bytesPerRow when
+// copying a texture to a buffer
+const textureRowAlignment = 256
+
+// Snapshot renders a frame and returns it encoded as PNG, e.g. to compare
+// renderer output in tests or to download it through a data URL:
+//
+//	data, err := canvas.Snapshot()
+//	url := "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
+//
+// The frame is copied from the canvas texture into a buffer that is mapped
+// for reading, so Snapshot blocks until the GPU finishes and must not be
+// called from a JS callback such as the render loop.
+func (gc *GPUCanvas) Snapshot() ([]byte, error) {
+	// The canvas texture is replaced once presented, so the frame is
+	// rendered and copied in the same task
+	gc.RenderOnce()
+
+	texture := gc.Context.Call("getCurrentTexture")
+	if !texture.Truthy() {
+		return nil, fmt.Errorf("canvas has no current texture")
+	}
+	width, height := texture.Get("width").Int(), texture.Get("height").Int()
+	bytesPerRow := alignedBytesPerRow(width)
+	size := bytesPerRow * height
+
+	ctx := gc.GPUContext
+	buffer, err := ctx.CreateBuffer(size, GPUBufferUsageMapRead|GPUBufferUsageCopyDst, "canvas-snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer buffer.Call("destroy")
+
+	encoder, err := ctx.CreateCommandEncoder("canvas-snapshot")
+	if err != nil {
+		return nil, err
+	}
+	source := js.Global().Get("Object").New()
+	source.Set("texture", texture)
+	encoder.Call("copyTextureToBuffer",
+		source,
+		mapToJSObject(map[string]interface{}{
+			"buffer":       buffer,
+			"bytesPerRow":  bytesPerRow,
+			"rowsPerImage": height,
+		}),
+		mapToJSObject(map[string]interface{}{
+			"width":  width,
+			"height": height,
+		}),
+	)
+	ctx.Submit(encoder.Call("finish"))
+
+	if _, err := awaitPromise(buffer.Call("mapAsync", GPUMapModeRead)); err != nil {
+		return nil, fmt.Errorf("failed to map snapshot buffer: %w", err)
+	}
+	data := make([]byte, size)
+	js.CopyBytesToGo(data, js.Global().Get("Uint8Array").New(buffer.Call("getMappedRange")))
+	buffer.Call("unmap")
+
+	img, err := pixelsToImage(data, width, height, bytesPerRow, gc.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// alignedBytesPerRow returns the bytes per row of a texture copy of an
+// 8-bit RGBA or BGRA texture width pixels wide
+func alignedBytesPerRow(width int) int {
+	return (width*4 + textureRowAlignment - 1) &^ (textureRowAlignment - 1)
+}
+
+// pixelsToImage converts the rows of a texture copy, bytesPerRow apart, to
+// an image. Canvas colors are premultiplied by alpha like image.RGBA, so
+// only BGRA channels need reordering.
+func pixelsToImage(data []byte, width, height, bytesPerRow int, format string) (*image.RGBA, error) {
+	var bgra bool
+	switch format {
+	case "rgba8unorm", "rgba8unorm-srgb":
+	case "bgra8unorm", "bgra8unorm-srgb":
+		bgra = true
+	default:
+		return nil, fmt.Errorf("snapshots of %q textures are not supported", format)
+	}
+	if len(data) < bytesPerRow*(height-1)+width*4 {
+		return nil, fmt.Errorf("%d bytes is too short for a %dx%d texture", len(data), width, height)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+width*4]
+		copy(row, data[y*bytesPerRow:])
+		if bgra {
+			for x := 0; x < len(row); x += 4 {
+				row[x], row[x+2] = row[x+2], row[x]
+			}
+		}
+	}
+	return img, nil
+}
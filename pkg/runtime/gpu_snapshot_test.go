@@ -0,0 +1,73 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestAlignedBytesPerRow(t *testing.T) {
+	tests := []struct {
+		width, want int
+	}{
+		{1, 256},
+		{64, 256},
+		{65, 512},
+		{800, 3328},
+	}
+	for _, tt := range tests {
+		if got := alignedBytesPerRow(tt.width); got != tt.want {
+			t.Errorf("alignedBytesPerRow(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPixelsToImage(t *testing.T) {
+	// A 2x2 BGRA texture with rows padded to 256 bytes
+	bytesPerRow := alignedBytesPerRow(2)
+	data := make([]byte, bytesPerRow*2)
+	copy(data, []byte{255, 0, 0, 255, 0, 255, 0, 255})
+	copy(data[bytesPerRow:], []byte{0, 0, 255, 255, 0, 0, 0, 128})
+
+	img, err := pixelsToImage(data, 2, 2, bytesPerRow, "bgra8unorm")
+	if err != nil {
+		t.Fatalf("pixelsToImage failed: %v", err)
+	}
+
+	expected := map[[2]int]color.RGBA{
+		{0, 0}: {0, 0, 255, 255},
+		{1, 0}: {0, 255, 0, 255},
+		{0, 1}: {255, 0, 0, 255},
+		{1, 1}: {0, 0, 0, 128},
+	}
+	for pos, want := range expected {
+		if got := img.RGBAAt(pos[0], pos[1]); got != want {
+			t.Errorf("Pixel %v: expected %v, got %v", pos, want, got)
+		}
+	}
+
+	// The image encodes to a PNG that decodes to the same pixels
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	decoded, err := png.Decode(&out)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	if r, g, b, _ := decoded.At(0, 1).RGBA(); r>>8 != 255 || g != 0 || b != 0 {
+		t.Errorf("Expected a red pixel at (0, 1) after decoding, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestPixelsToImageErrors(t *testing.T) {
+	if _, err := pixelsToImage(make([]byte, 256), 1, 1, 256, "rgba16float"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+	if _, err := pixelsToImage(make([]byte, 256), 1, 2, 256, "rgba8unorm"); err == nil {
+		t.Error("Expected an error for truncated data")
+	}
+}
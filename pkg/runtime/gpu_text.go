@@ -0,0 +1,464 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"syscall/js"
+)
+
+const (
+	// firstGlyph and lastGlyph are the printable ASCII characters baked into
+	// a glyph atlas; other characters are drawn as '?'
+	firstGlyph = ' '
+	lastGlyph  = '~'
+	glyphCount = lastGlyph - firstGlyph + 1
+
+	// atlasWidth is the width in pixels of a glyph atlas texture
+	atlasWidth = 512
+	// glyphPadding keeps glyphs apart in the atlas, so linear filtering
+	// doesn't bleed neighbours into a quad
+	glyphPadding = 2
+	// minAtlasSize is the smallest font size in pixels an atlas is baked at
+	minAtlasSize = 16
+
+	// textVertexFloats is the size of a text vertex: position (2), UV (2)
+	// and color (4)
+	textVertexFloats = 8
+	// textUniformSize holds the viewport size, padded to 16 bytes
+	textUniformSize = 16
+)
+
+// textShader draws the glyph quads of DrawText. Positions are in canvas
+// pixels from the top left corner; the atlas holds the coverage of each
+// glyph in its alpha channel.
+const textShader = `
+struct Uniforms {
+    viewport: vec2f,
+}
+
+struct VertexInput {
+    @location(0) position: vec2f,
+    @location(1) uv: vec2f,
+    @location(2) color: vec4f,
+}
+
+struct VertexOutput {
+    @builtin(position) position: vec4f,
+    @location(0) uv: vec2f,
+    @location(1) color: vec4f,
+}
+
+@group(0) @binding(0) var<uniform> uniforms: Uniforms;
+@group(0) @binding(1) var atlas: texture_2d<f32>;
+@group(0) @binding(2) var atlasSampler: sampler;
+
+@vertex
+fn vs_main(input: VertexInput) -> VertexOutput {
+    let ndc = input.position / uniforms.viewport * vec2f(2.0, -2.0) + vec2f(-1.0, 1.0);
+
+    var output: VertexOutput;
+    output.position = vec4f(ndc, 0.0, 1.0);
+    output.uv = input.uv;
+    output.color = input.color;
+    return output;
+}
+
+@fragment
+fn fs_main(input: VertexOutput) -> @location(0) vec4f {
+    let coverage = textureSample(atlas, atlasSampler, input.uv).a;
+    return vec4f(input.color.rgb, input.color.a * coverage);
+}
+`
+
+// glyph is the cell of a character in a glyph atlas, in atlas pixels
+type glyph struct {
+	X, Y    float64
+	Advance float64 // Width of the character
+}
+
+// GlyphAtlas is a texture holding the printable ASCII characters of a font,
+// drawn white on transparent by a 2D canvas
+type GlyphAtlas struct {
+	Family     string  // CSS font family, e.g. "sans-serif"
+	Size       float64 // Font size in pixels the glyphs were drawn at
+	LineHeight float64 // Height of a glyph cell in pixels
+	Width      int
+	Height     int
+	Texture    js.Value
+	glyphs     [glyphCount]glyph
+}
+
+// font returns the CSS font of the atlas
+func (a *GlyphAtlas) font() string {
+	return fmt.Sprintf("%gpx %s", a.Size, a.Family)
+}
+
+// glyphFor returns the cell of a character, '?' for characters not in the
+// atlas
+func (a *GlyphAtlas) glyphFor(r rune) glyph {
+	if r < firstGlyph || r > lastGlyph {
+		r = '?'
+	}
+	return a.glyphs[r-firstGlyph]
+}
+
+// layoutGlyphs places glyphs of the given advances in rows of an atlas
+// width pixels wide, each cell lineHeight tall. It returns the height of
+// the atlas.
+func layoutGlyphs(glyphs []glyph, lineHeight float64, width int) int {
+	x, y := float64(glyphPadding), float64(glyphPadding)
+	for i := range glyphs {
+		advance := math.Ceil(glyphs[i].Advance)
+		if x+advance+glyphPadding > float64(width) {
+			x = glyphPadding
+			y += math.Ceil(lineHeight) + glyphPadding
+		}
+		glyphs[i].X, glyphs[i].Y = x, y
+		x += advance + glyphPadding
+	}
+	return int(y + math.Ceil(lineHeight) + glyphPadding)
+}
+
+// atlasSize returns the font size an atlas is baked at to draw text of
+// size pixels: the next power of two, so growing text regenerates the atlas
+// a few times at most, and downscaled glyphs stay sharp
+func atlasSize(size float64) float64 {
+	baked := float64(minAtlasSize)
+	for baked < size {
+		baked *= 2
+	}
+	return baked
+}
+
+// NewGlyphAtlas draws the printable ASCII characters of a font family at
+// size pixels into a texture
+func NewGlyphAtlas(ctx *GPUContext, family string, size float64) (*GlyphAtlas, error) {
+	atlas := &GlyphAtlas{Family: family, Size: size}
+	font := atlas.font()
+
+	var glyphs [glyphCount]glyph
+	for i := range glyphs {
+		w, h := MeasureText(string(rune(firstGlyph+i)), font)
+		glyphs[i].Advance = w
+		atlas.LineHeight = max(atlas.LineHeight, h)
+	}
+	atlas.Width = atlasWidth
+	atlas.Height = layoutGlyphs(glyphs[:], atlas.LineHeight, atlasWidth)
+	atlas.glyphs = glyphs
+
+	canvas, err := atlas.draw()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := GPUTextureUsageTextureBinding | GPUTextureUsageCopyDst | GPUTextureUsageRenderAttachment
+	texture, err := ctx.CreateTexture(atlas.Width, atlas.Height, "rgba8unorm", usage, 1, "glyph-atlas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create glyph atlas texture: %w", err)
+	}
+	ctx.Queue.Call("copyExternalImageToTexture",
+		map[string]interface{}{"source": canvas},
+		map[string]interface{}{"texture": texture},
+		[]interface{}{atlas.Width, atlas.Height},
+	)
+	atlas.Texture = texture
+	return atlas, nil
+}
+
+// draw draws the glyphs of the atlas on a new 2D canvas
+func (a *GlyphAtlas) draw() (js.Value, error) {
+	var canvas js.Value
+	if offscreen := js.Global().Get("OffscreenCanvas"); isElement(offscreen) {
+		canvas = offscreen.New(a.Width, a.Height)
+	} else if document := js.Global().Get("document"); isElement(document) {
+		canvas = document.Call("createElement", "canvas")
+		canvas.Set("width", a.Width)
+		canvas.Set("height", a.Height)
+	} else {
+		return js.Undefined(), fmt.Errorf("no canvas to draw the glyph atlas with")
+	}
+
+	context := canvas.Call("getContext", "2d")
+	if !context.Truthy() {
+		return js.Undefined(), fmt.Errorf("failed to get a 2D context for the glyph atlas")
+	}
+	context.Set("font", a.font())
+	context.Set("fillStyle", "white")
+	context.Set("textBaseline", "top")
+	for i, g := range a.glyphs {
+		context.Call("fillText", string(rune(firstGlyph+i)), g.X, g.Y)
+	}
+	return canvas, nil
+}
+
+// Destroy releases the atlas texture
+func (a *GlyphAtlas) Destroy() {
+	if a.Texture.Truthy() {
+		a.Texture.Call("destroy")
+		a.Texture = js.Undefined()
+	}
+}
+
+// textQuads appends two triangles for each character of text to vertices.
+// The text starts at x, y, its top left corner in pixels, with glyphs scaled
+// from the atlas to size pixels; newlines start a new line.
+func textQuads(vertices []float32, atlas *GlyphAtlas, text string, x, y, size float64, color Vec4) []float32 {
+	scale := size / atlas.Size
+	lineHeight := atlas.LineHeight * scale
+	width, height := float64(atlas.Width), float64(atlas.Height)
+
+	penX, penY := x, y
+	for _, r := range text {
+		if r == '\n' {
+			penX = x
+			penY += lineHeight
+			continue
+		}
+		g := atlas.glyphFor(r)
+		advance := g.Advance * scale
+
+		x0, y0 := float32(penX), float32(penY)
+		x1, y1 := float32(penX+advance), float32(penY+lineHeight)
+		u0, v0 := float32(g.X/width), float32(g.Y/height)
+		u1, v1 := float32((g.X+g.Advance)/width), float32((g.Y+atlas.LineHeight)/height)
+
+		for _, corner := range [6][4]float32{
+			{x0, y0, u0, v0}, {x1, y0, u1, v0}, {x0, y1, u0, v1},
+			{x1, y0, u1, v0}, {x1, y1, u1, v1}, {x0, y1, u0, v1},
+		} {
+			vertices = append(vertices, corner[0], corner[1], corner[2], corner[3], color.X, color.Y, color.Z, color.W)
+		}
+		penX += advance
+	}
+	return vertices
+}
+
+// TextRenderer draws text into render passes of a canvas from a glyph
+// atlas, e.g. labels of a 3D HUD without DOM overlays:
+//
+//	text := NewTextRenderer(canvas, "sans-serif")
+//	canvas.SetRenderFunc(func(c *GPUCanvas, delta float64) {
+//	    pass := c.BeginRenderPass(encoder, clear, "load")
+//	    text.DrawText(pass, "FPS: 60", 8, 8, 14, Vec4{1, 1, 1, 1})
+//	    pass.Call("end")
+//	})
+//
+// The atlas is baked on first use at the next power of two of the text size
+// and baked again when larger text is drawn or SetFont changes the family.
+// Text drawn in a frame is kept in one vertex buffer, which is reused from
+// the next frame.
+type TextRenderer struct {
+	Canvas *GPUCanvas
+	Atlas  *GlyphAtlas
+	Family string
+	// DepthFormat is the depth format of the passes text is drawn in, e.g.
+	// "depth24plus" for a scene pass; empty for passes without depth
+	DepthFormat string
+
+	pipeline      *RenderPipeline
+	bindGroup     js.Value
+	sampler       js.Value
+	uniformBuffer *GPUBuffer
+	vertexBuffer  *GPUBuffer
+	retired       []*GPUBuffer // Buffers outgrown in the frame, destroyed in the next
+	vertices      []float32    // Vertices drawn in the current frame
+	frame         int          // Canvas frame the vertices belong to
+}
+
+// NewTextRenderer creates a text renderer drawing into canvas in a CSS font
+// family
+func NewTextRenderer(canvas *GPUCanvas, family string) *TextRenderer {
+	return &TextRenderer{Canvas: canvas, Family: family, frame: -1}
+}
+
+// SetFont changes the font family, baking the atlas again on the next draw
+func (tr *TextRenderer) SetFont(family string) {
+	if family == tr.Family {
+		return
+	}
+	tr.Family = family
+	tr.releaseAtlas()
+}
+
+// DrawText draws text with its top left corner at x, y in canvas pixels,
+// size pixels tall, in color
+func (tr *TextRenderer) DrawText(pass js.Value, text string, x, y, size float64, color Vec4) error {
+	if text == "" || size <= 0 {
+		return nil
+	}
+	if err := tr.ensureAtlas(size); err != nil {
+		return err
+	}
+	if err := tr.ensurePipeline(); err != nil {
+		return err
+	}
+
+	// Vertices of earlier frames were drawn and can be overwritten
+	if tr.frame != tr.Canvas.FrameCount {
+		tr.frame = tr.Canvas.FrameCount
+		tr.vertices = tr.vertices[:0]
+		for _, buffer := range tr.retired {
+			buffer.Destroy()
+		}
+		tr.retired = nil
+	}
+
+	first := len(tr.vertices) / textVertexFloats
+	tr.vertices = textQuads(tr.vertices, tr.Atlas, text, x, y, size, color)
+	count := len(tr.vertices)/textVertexFloats - first
+	if count == 0 {
+		return nil
+	}
+
+	ctx := tr.Canvas.GPUContext
+	if err := tr.ensureVertexBuffer(len(tr.vertices) * 4); err != nil {
+		return err
+	}
+	offset := first * textVertexFloats
+	if err := tr.vertexBuffer.WriteFloat32(ctx, offset*4, tr.vertices[offset:]); err != nil {
+		return fmt.Errorf("failed to write text vertices: %w", err)
+	}
+	viewport := []float32{float32(tr.Canvas.Width), float32(tr.Canvas.Height), 0, 0}
+	if err := tr.uniformBuffer.WriteFloat32(ctx, 0, viewport); err != nil {
+		return fmt.Errorf("failed to write text uniforms: %w", err)
+	}
+
+	pass.Call("setPipeline", tr.pipeline.Pipeline)
+	pass.Call("setBindGroup", 0, tr.bindGroup)
+	pass.Call("setVertexBuffer", 0, tr.vertexBuffer.Buffer)
+	pass.Call("draw", count, 1, first, 0)
+	return nil
+}
+
+// ensureAtlas bakes the atlas when there is none or text of size would be
+// upscaled from it
+func (tr *TextRenderer) ensureAtlas(size float64) error {
+	if tr.Atlas != nil && tr.Atlas.Size >= size {
+		return nil
+	}
+	tr.releaseAtlas()
+	atlas, err := NewGlyphAtlas(tr.Canvas.GPUContext, tr.Family, atlasSize(size))
+	if err != nil {
+		return err
+	}
+	tr.Atlas = atlas
+	return nil
+}
+
+// releaseAtlas destroys the atlas and the bind group sampling it
+func (tr *TextRenderer) releaseAtlas() {
+	if tr.Atlas != nil {
+		tr.Atlas.Destroy()
+		tr.Atlas = nil
+	}
+	tr.bindGroup = js.Undefined()
+}
+
+// ensurePipeline creates the text pipeline and the bind group of the atlas
+func (tr *TextRenderer) ensurePipeline() error {
+	ctx := tr.Canvas.GPUContext
+	if tr.pipeline == nil {
+		module, err := CreateShaderModule(ctx, textShader, "text-shader")
+		if err != nil {
+			return fmt.Errorf("failed to create text shader: %w", err)
+		}
+		pipeline, err := CreatePipelineWithBlending(ctx, PipelineConfig{
+			Label:              "text-pipeline",
+			VertexShader:       module.Module,
+			FragmentShader:     module.Module,
+			VertexEntryPoint:   "vs_main",
+			FragmentEntryPoint: "fs_main",
+			VertexBuffers: []map[string]interface{}{CreateVertexBufferLayout(textVertexFloats*4, []VertexAttribute{
+				{Format: VertexFormatFloat32x2, Offset: 0, ShaderLocation: 0},  // position
+				{Format: VertexFormatFloat32x2, Offset: 8, ShaderLocation: 1},  // uv
+				{Format: VertexFormatFloat32x4, Offset: 16, ShaderLocation: 2}, // color
+			})},
+			ColorFormat:        tr.Canvas.Format,
+			DepthFormat:        tr.DepthFormat,
+			DepthWriteDisabled: true,
+			PrimitiveTopology:  PrimitiveTopologyTriangleList,
+			SampleCount:        tr.Canvas.SampleCount,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create text pipeline: %w", err)
+		}
+		tr.pipeline = pipeline
+
+		uniformBuffer, err := CreateUniformBuffer(ctx, textUniformSize, "text-uniforms")
+		if err != nil {
+			return fmt.Errorf("failed to create text uniforms: %w", err)
+		}
+		tr.uniformBuffer = uniformBuffer
+		tr.sampler = ctx.Device.Call("createSampler", map[string]interface{}{
+			"label":     "text-sampler",
+			"minFilter": "linear",
+			"magFilter": "linear",
+		})
+	}
+
+	if !tr.bindGroup.Truthy() {
+		bindGroup, err := CreateBindGroup(
+			ctx,
+			tr.pipeline.Pipeline.Call("getBindGroupLayout", 0),
+			[]map[string]interface{}{
+				CreateBindGroupEntry(0, CreateBufferBinding(tr.uniformBuffer.Buffer, 0, textUniformSize)),
+				CreateBindGroupEntry(1, tr.Atlas.Texture.Call("createView")),
+				CreateBindGroupEntry(2, tr.sampler),
+			},
+			"text-bind-group",
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create text bind group: %w", err)
+		}
+		tr.bindGroup = bindGroup
+	}
+	return nil
+}
+
+// ensureVertexBuffer makes the vertex buffer hold size bytes. An outgrown
+// buffer may still be drawn from in the current pass, so it is destroyed
+// in the next frame.
+func (tr *TextRenderer) ensureVertexBuffer(size int) error {
+	if tr.vertexBuffer != nil && tr.vertexBuffer.Size >= size {
+		return nil
+	}
+	capacity := max(size, 4096)
+	if tr.vertexBuffer != nil {
+		capacity = max(size, 2*tr.vertexBuffer.Size)
+		tr.retired = append(tr.retired, tr.vertexBuffer)
+	}
+
+	usage := GPUBufferUsageVertex | GPUBufferUsageCopyDst
+	buffer, err := tr.Canvas.GPUContext.CreateBuffer(capacity, usage, "text-vertices")
+	if err != nil {
+		tr.vertexBuffer = nil
+		return fmt.Errorf("failed to create text vertex buffer: %w", err)
+	}
+	tr.vertexBuffer = &GPUBuffer{Buffer: buffer, Size: capacity, Usage: usage, Label: "text-vertices"}
+
+	// Text drawn earlier in the frame moves to the new buffer
+	if len(tr.vertices) > 0 {
+		return tr.vertexBuffer.WriteFloat32(tr.Canvas.GPUContext, 0, tr.vertices)
+	}
+	return nil
+}
+
+// Destroy releases the GPU resources of the text renderer
+func (tr *TextRenderer) Destroy() {
+	tr.releaseAtlas()
+	if tr.vertexBuffer != nil {
+		tr.vertexBuffer.Destroy()
+		tr.vertexBuffer = nil
+	}
+	for _, buffer := range tr.retired {
+		buffer.Destroy()
+	}
+	tr.retired = nil
+	if tr.uniformBuffer != nil {
+		tr.uniformBuffer.Destroy()
+		tr.uniformBuffer = nil
+	}
+	tr.pipeline = nil
+}
@@ -0,0 +1,70 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestLayoutGlyphs(t *testing.T) {
+	glyphs := []glyph{{Advance: 10}, {Advance: 9.5}, {Advance: 10}}
+
+	// 2 + 10 + 2 + 10 + 2 fits two glyphs in a row of 30 pixels
+	height := layoutGlyphs(glyphs, 12, 30)
+
+	want := []glyph{{X: 2, Y: 2, Advance: 10}, {X: 14, Y: 2, Advance: 9.5}, {X: 2, Y: 16, Advance: 10}}
+	for i := range want {
+		if glyphs[i] != want[i] {
+			t.Errorf("Expected glyph %d at %+v, got %+v", i, want[i], glyphs[i])
+		}
+	}
+	if height != 30 {
+		t.Errorf("Expected an atlas 30 pixels tall, got %d", height)
+	}
+}
+
+func TestAtlasSize(t *testing.T) {
+	for size, want := range map[float64]float64{1: 16, 16: 16, 17: 32, 40: 64} {
+		if got := atlasSize(size); got != want {
+			t.Errorf("Expected text of %g pixels baked at %g, got %g", size, want, got)
+		}
+	}
+}
+
+func TestTextQuads(t *testing.T) {
+	atlas := &GlyphAtlas{Size: 16, LineHeight: 16, Width: 100, Height: 50}
+	atlas.glyphs['A'-firstGlyph] = glyph{X: 10, Y: 20, Advance: 8}
+	atlas.glyphs['?'-firstGlyph] = glyph{X: 30, Y: 20, Advance: 6}
+	color := Vec4{X: 1, Y: 0.5, Z: 0, W: 1}
+
+	vertices := textQuads(nil, atlas, "A\né", 5, 7, 32, color)
+	if len(vertices) != 2*6*textVertexFloats {
+		t.Fatalf("Expected 2 quads, got %d floats", len(vertices))
+	}
+
+	// Glyphs are scaled from 16 to 32 pixels
+	first := vertices[:textVertexFloats]
+	if want := []float32{5, 7, 0.1, 0.4, 1, 0.5, 0, 1}; !equalFloats(first, want) {
+		t.Errorf("Expected the top left corner of A to be %v, got %v", want, first)
+	}
+	bottomRight := vertices[4*textVertexFloats : 5*textVertexFloats]
+	if want := []float32{21, 39, 0.18, 0.72, 1, 0.5, 0, 1}; !equalFloats(bottomRight, want) {
+		t.Errorf("Expected the bottom right corner of A to be %v, got %v", want, bottomRight)
+	}
+
+	// The newline starts a line below, and é is drawn as '?'
+	second := vertices[6*textVertexFloats : 7*textVertexFloats]
+	if want := []float32{5, 39, 0.3, 0.4, 1, 0.5, 0, 1}; !equalFloats(second, want) {
+		t.Errorf("Expected the second line to start with ? at %v, got %v", want, second)
+	}
+}
+
+func equalFloats(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if d := a[i] - b[i]; d > 1e-5 || d < -1e-5 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"math/bits"
+	"syscall/js"
+)
+
+// mipmapShader draws a triangle covering the target mip level, sampling the
+// previous level with a linear filter. Each output texel averages the 2x2
+// texels of the larger level under it.
+const mipmapShader = `
+@group(0) @binding(0) var source: texture_2d<f32>;
+@group(0) @binding(1) var sourceSampler: sampler;
+
+struct VertexOutput {
+    @builtin(position) position: vec4f,
+    @location(0) uv: vec2f,
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let uv = vec2f(f32((index << 1u) & 2u), f32(index & 2u));
+
+    var output: VertexOutput;
+    output.position = vec4f(uv * vec2f(2.0, -2.0) + vec2f(-1.0, 1.0), 0.0, 1.0);
+    output.uv = uv;
+    return output;
+}
+
+@fragment
+fn fs_main(input: VertexOutput) -> @location(0) vec4f {
+    return textureSample(source, sourceSampler, input.uv);
+}
+`
+
+// MipLevelCount returns the number of mip levels of a full mip chain for a
+// texture of the given size, down to 1x1
+func MipLevelCount(width, height int) int {
+	return max(bits.Len(uint(max(width, height))), 1)
+}
+
+// GenerateMipmaps fills mip levels 1 and up of a texture by downsampling
+// each level from the one before it, so minified textures don't alias:
+//
+//	usage := GPUTextureUsageTextureBinding | GPUTextureUsageCopyDst | GPUTextureUsageRenderAttachment
+//	levels := MipLevelCount(width, height)
+//	texture, err := ctx.CreateTexture(width, height, "rgba8unorm", usage, levels, "albedo")
+//	// ... upload the image to level 0 ...
+//	err = GenerateMipmaps(ctx, texture, width, height, "rgba8unorm")
+//
+// Each level is drawn in a render pass sampling the level above, so the
+// texture needs GPUTextureUsageRenderAttachment and
+// GPUTextureUsageTextureBinding, and its format must be renderable and
+// filterable, like rgba8unorm or bgra8unorm. Levels the texture wasn't
+// created with are skipped. The passes are submitted without waiting for
+// them to finish.
+func GenerateMipmaps(ctx *GPUContext, texture js.Value, width, height int, format string) error {
+	if !texture.Truthy() {
+		return fmt.Errorf("texture is required")
+	}
+
+	levels := min(MipLevelCount(width, height), texture.Get("mipLevelCount").Int())
+	if levels < 2 {
+		return nil
+	}
+
+	pipeline, err := ctx.mipmapPipeline(format)
+	if err != nil {
+		return err
+	}
+
+	sampler := ctx.Device.Call("createSampler", map[string]interface{}{
+		"label":     "mipmap-sampler",
+		"minFilter": "linear",
+		"magFilter": "linear",
+	})
+
+	encoder, err := ctx.CreateCommandEncoder("mipmap-encoder")
+	if err != nil {
+		return err
+	}
+
+	for level := 1; level < levels; level++ {
+		source := texture.Call("createView", map[string]interface{}{
+			"baseMipLevel":  level - 1,
+			"mipLevelCount": 1,
+		})
+		target := texture.Call("createView", map[string]interface{}{
+			"baseMipLevel":  level,
+			"mipLevelCount": 1,
+		})
+
+		bindGroup, err := CreateBindGroup(
+			ctx,
+			pipeline.Pipeline.Call("getBindGroupLayout", 0),
+			[]map[string]interface{}{
+				CreateBindGroupEntry(0, source),
+				CreateBindGroupEntry(1, sampler),
+			},
+			"mipmap-bind-group",
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create bind group for mip level %d: %w", level, err)
+		}
+
+		pass := encoder.Call("beginRenderPass", map[string]interface{}{
+			"colorAttachments": []interface{}{map[string]interface{}{
+				"view":    target,
+				"loadOp":  "clear",
+				"storeOp": "store",
+			}},
+		})
+		pass.Call("setPipeline", pipeline.Pipeline)
+		pass.Call("setBindGroup", 0, bindGroup)
+		pass.Call("draw", 3)
+		pass.Call("end")
+	}
+
+	ctx.Submit(encoder.Call("finish"))
+	return nil
+}
+
+// mipmapPipeline returns the GenerateMipmaps pipeline for a texture format,
+// creating it on first use
+func (ctx *GPUContext) mipmapPipeline(format string) (*RenderPipeline, error) {
+	if pipeline, ok := ctx.mipmapPipelines[format]; ok {
+		return pipeline, nil
+	}
+
+	module, err := CreateShaderModule(ctx, mipmapShader, "mipmap-shader")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mipmap shader: %w", err)
+	}
+
+	pipeline, err := CreateRenderPipeline(ctx, PipelineConfig{
+		Label:              "mipmap-pipeline-" + format,
+		VertexShader:       module.Module,
+		FragmentShader:     module.Module,
+		VertexEntryPoint:   "vs_main",
+		FragmentEntryPoint: "fs_main",
+		ColorFormat:        format,
+		PrimitiveTopology:  PrimitiveTopologyTriangleList,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mipmap pipeline: %w", err)
+	}
+
+	if ctx.mipmapPipelines == nil {
+		ctx.mipmapPipelines = make(map[string]*RenderPipeline)
+	}
+	ctx.mipmapPipelines[format] = pipeline
+	return pipeline, nil
+}
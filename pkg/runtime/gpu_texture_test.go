@@ -0,0 +1,23 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestMipLevelCount(t *testing.T) {
+	tests := []struct {
+		width, height, want int
+	}{
+		{256, 256, 9},
+		{512, 128, 10},
+		// Odd sizes round down at each level: 300, 150, 75, 37, 18, 9, 4, 2, 1
+		{300, 200, 9},
+		{1, 1, 1},
+		{0, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := MipLevelCount(tt.width, tt.height); got != tt.want {
+			t.Errorf("MipLevelCount(%d, %d) = %d, want %d", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+//go:build js && wasm
+
+package runtime
+
+// Vertex strides of mesh vertex buffers, in bytes
+const (
+	meshVertexStride    = 24 // position + normal
+	coloredVertexStride = 40 // position + normal + RGBA color
+)
+
+// ColoredGeometry is a Geometry with a color per vertex. Colors holds 4
+// floats, RGBA, for each vertex of GetVertices; they multiply the color of
+// the mesh's material.
+type ColoredGeometry interface {
+	Geometry
+	GetColors() []float32
+}
+
+// VertexColorGeometry adds vertex colors to a geometry
+type VertexColorGeometry struct {
+	Geometry
+	Colors []float32
+}
+
+// GetColors returns the RGBA color of each vertex
+func (vg *VertexColorGeometry) GetColors() []float32 {
+	return vg.Colors
+}
+
+// VertexColors colors each vertex of a geometry, e.g. a box shading from
+// red at its first vertex to blue at its last:
+//
+//	colors := make([]float32, 0, 24*4)
+//	for i := 0; i < 24; i++ {
+//	    t := float32(i) / 23
+//	    colors = append(colors, 1-t, 0, t, 1)
+//	}
+//	Mesh(VertexColors(BoxGeometryNode(1, 1, 1), colors), StandardMaterial(Color(1, 1, 1, 1)))
+//
+// Vertices without a color are white.
+func VertexColors(geometry Geometry, colors []float32) Geometry {
+	return &VertexColorGeometry{Geometry: geometry, Colors: colors}
+}
+
+// vertexColors returns the vertex colors of a geometry, or nil if it has
+// none
+func vertexColors(geometry Geometry) []float32 {
+	if colored, ok := geometry.(ColoredGeometry); ok {
+		return colored.GetColors()
+	}
+	return nil
+}
+
+// vertexStride returns the size in bytes of a vertex of a geometry in its
+// vertex buffer
+func vertexStride(geometry Geometry) int {
+	if len(vertexColors(geometry)) > 0 {
+		return coloredVertexStride
+	}
+	return meshVertexStride
+}
+
+// interleaveColors appends the RGBA color of each vertex to its position
+// and normal. Vertices past the end of colors are white.
+func interleaveColors(vertices, colors []float32) []float32 {
+	count := len(vertices) / 6
+	data := make([]float32, 0, count*10)
+	for i := 0; i < count; i++ {
+		data = append(data, vertices[i*6:i*6+6]...)
+		if (i+1)*4 <= len(colors) {
+			data = append(data, colors[i*4:i*4+4]...)
+		} else {
+			data = append(data, 1, 1, 1, 1)
+		}
+	}
+	return data
+}
+
+// meshVertexLayout returns the vertex buffer layout of mesh vertices of
+// stride bytes. Colored vertices have their color at location 2.
+func meshVertexLayout(stride int) map[string]interface{} {
+	attributes := []VertexAttribute{
+		{Format: VertexFormatFloat32x3, Offset: 0, ShaderLocation: 0},  // position
+		{Format: VertexFormatFloat32x3, Offset: 12, ShaderLocation: 1}, // normal
+	}
+	if stride == coloredVertexStride {
+		attributes = append(attributes, VertexAttribute{Format: VertexFormatFloat32x4, Offset: 24, ShaderLocation: 2}) // color
+	}
+	return CreateVertexBufferLayout(stride, attributes)
+}
@@ -0,0 +1,59 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"reflect"
+	"syscall/js"
+	"testing"
+)
+
+func TestVertexStride(t *testing.T) {
+	box := BoxGeometryNode(1, 1, 1)
+	colors := make([]float32, 24*4)
+
+	tests := []struct {
+		name     string
+		geometry Geometry
+		want     int
+	}{
+		{"plain geometry", box, 24},
+		{"vertex colors", VertexColors(box, colors), 40},
+		{"no colors", VertexColors(box, nil), 24},
+	}
+	for _, tt := range tests {
+		if got := vertexStride(tt.geometry); got != tt.want {
+			t.Errorf("%s: expected stride %d, got %d", tt.name, tt.want, got)
+		}
+	}
+
+	layout := meshVertexLayout(coloredVertexStride)
+	if layout["arrayStride"] != 40 {
+		t.Errorf("Expected a layout stride of 40, got %v", layout["arrayStride"])
+	}
+	attributes := layout["attributes"].([]interface{})
+	if len(attributes) != 3 {
+		t.Fatalf("Expected 3 attributes, got %d", len(attributes))
+	}
+	color := attributes[2].(js.Value)
+	if color.Get("shaderLocation").Int() != 2 || color.Get("offset").Int() != 24 || color.Get("format").String() != "float32x4" {
+		t.Errorf("Expected the color at location 2, offset 24, as float32x4")
+	}
+}
+
+func TestInterleaveColors(t *testing.T) {
+	vertices := []float32{
+		0, 0, 0, 0, 0, 1,
+		1, 0, 0, 0, 0, 1,
+	}
+	colors := []float32{1, 0, 0, 1}
+
+	want := []float32{
+		0, 0, 0, 0, 0, 1, 1, 0, 0, 1,
+		// Without a color the vertex is white
+		1, 0, 0, 0, 0, 1, 1, 1, 1, 1,
+	}
+	if got := interleaveColors(vertices, colors); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
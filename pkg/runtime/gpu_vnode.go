@@ -2,6 +2,8 @@
 
 package runtime
 
+import "sync"
+
 // GPUNodeType represents the type of a GPU node
 type GPUNodeType uint8
 
@@ -18,6 +20,10 @@ const (
 	LightNodeType
 	// GroupNodeType represents a group/container
 	GroupNodeType
+	// ParticleSystemNodeType represents a GPU particle system
+	ParticleSystemNodeType
+	// InstancedMeshNodeType represents a mesh drawn once per instance
+	InstancedMeshNodeType
 )
 
 // GPUNode represents a GPU/3D scene graph node
@@ -34,6 +40,8 @@ type GPUNode struct {
 	Geometry   Geometry                  // Geometry (for mesh nodes)
 	Camera     *Camera                   // Camera (for camera nodes)
 	Light      *Light                    // Light (for light nodes)
+	Particles  *ParticleEmitter          // Particle configuration (for particle system nodes)
+	Instances  []Transform               // Instance transforms (for instanced mesh nodes)
 }
 
 // Geometry interface for different geometry types
@@ -49,6 +57,15 @@ type Material struct {
 	Roughness float32       // 0-1
 	Emissive  Vec3          // Emissive color
 	Shader    *CustomShader // Custom shader override
+
+	// Transparent materials are blended over the scene after opaque ones.
+	// A color alpha below 1 makes a material transparent as well.
+	Transparent bool
+}
+
+// IsTransparent reports whether meshes with the material are blended
+func (m *Material) IsTransparent() bool {
+	return m.Transparent || m.Color.W < 1
 }
 
 // CustomShader represents custom vertex/fragment shaders
@@ -66,6 +83,7 @@ type Light struct {
 	Intensity float32 // Light intensity
 	Position  Vec3    // Position (for point/spot)
 	Direction Vec3    // Direction (for directional/spot)
+	ConeAngle float32 // Half angle of the cone in radians (for spot)
 }
 
 // GPU property types
@@ -116,11 +134,26 @@ func Roughness(value float32) GPUProp {
 	return GPUProp{Key: "roughness", Value: value}
 }
 
+// Transparent marks a material as transparent
+func Transparent(enabled bool) GPUProp {
+	return GPUProp{Key: "transparent", Value: enabled}
+}
+
 // Intensity sets light intensity
 func Intensity(value float32) GPUProp {
 	return GPUProp{Key: "intensity", Value: value}
 }
 
+// Direction sets the direction a directional or spot light shines in
+func Direction(x, y, z float32) GPUProp {
+	return GPUProp{Key: "direction", Value: NewVec3(x, y, z)}
+}
+
+// ConeAngle sets the half angle of a spot light's cone (in radians)
+func ConeAngle(value float32) GPUProp {
+	return GPUProp{Key: "coneAngle", Value: value}
+}
+
 // FOV sets camera field of view (in radians)
 func FOV(value float32) GPUProp {
 	return GPUProp{Key: "fov", Value: value}
@@ -176,6 +209,18 @@ func MaterialProp(mat *Material) GPUProp {
 	return WithMaterial(mat)
 }
 
+// MaterialOf wraps a material for property passing. It takes either a
+// *Material or material props, which build a StandardMaterial, so scenes can
+// write Material(Color(1, 0, 0, 1)).
+func MaterialOf(options ...interface{}) GPUProp {
+	for _, opt := range options {
+		if mat, ok := opt.(*Material); ok {
+			return WithMaterial(mat)
+		}
+	}
+	return WithMaterial(StandardMaterial(options...))
+}
+
 // BindRotation creates a reactive binding for rotation values
 // The mesh will automatically update its rotation from these pointers each frame
 func BindRotation(x, y, z *float64) GPUProp {
@@ -186,10 +231,62 @@ func BindRotation(x, y, z *float64) GPUProp {
 	}}
 }
 
+// BindProp re-evaluates a transform prop (Position, Rotation or ScaleValue)
+// on every frame. Scene components use it for props that read channels,
+// e.g. Rotation(0, <-angle, 0).
+func BindProp(eval func() GPUProp) GPUProp {
+	return GPUProp{Key: "bindProp", Value: eval}
+}
+
+// LatestValue holds the most recent value received from a channel
+type LatestValue[T any] struct {
+	ch    <-chan T
+	value T
+	mu    sync.Mutex
+}
+
+// Latest creates a LatestValue reading from ch
+func Latest[T any](ch <-chan T) *LatestValue[T] {
+	return &LatestValue[T]{ch: ch}
+}
+
+// Value drains pending values without blocking and returns the latest one,
+// or the zero value until the channel first delivers
+func (l *LatestValue[T]) Value() T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for {
+		select {
+		case v, ok := <-l.ch:
+			if !ok {
+				return l.value
+			}
+			l.value = v
+		default:
+			return l.value
+		}
+	}
+}
+
+// applyTransformProp applies a position, rotation or scale prop to a transform
+func applyTransformProp(t *Transform, prop GPUProp) {
+	v, ok := prop.Value.(Vec3)
+	if !ok {
+		return
+	}
+	switch prop.Key {
+	case "position":
+		t.Position = v
+	case "rotation":
+		t.Rotation = v
+	case "scale":
+		t.Scale = v
+	}
+}
+
 // GPU Node Builders
-// Note: Declarative GPU canvas builders are currently unused.
-// The working implementation uses CreateGPUCanvas() directly.
-// These will be integrated with the .gx parser in future updates.
+// Scene components written in .gx compile to calls of these builders, e.g.
+// Scene { Mesh(...) Camera(...) } becomes SceneNode(Mesh(...), PerspectiveCamera(...)).
 
 // Scene interface for all Guix 3D scenes (parallel to Component for UI)
 // Scene components implement this interface to render their 3D scene graph
@@ -252,6 +349,12 @@ func Mesh(options ...interface{}) *GPUNode {
 				if m, ok := o.Value.(*Material); ok {
 					node.Material = m
 				}
+			case "bindProp":
+				if eval, ok := o.Value.(func() GPUProp); ok {
+					applyTransformProp(&node.Transform, eval())
+					bindings, _ := node.Properties["bindProps"].([]func() GPUProp)
+					node.Properties["bindProps"] = append(bindings, eval)
+				}
 			default:
 				node.Properties[o.Key] = o.Value
 			}
@@ -263,20 +366,32 @@ func Mesh(options ...interface{}) *GPUNode {
 
 // PerspectiveCamera creates a perspective camera node
 func PerspectiveCamera(options ...interface{}) *GPUNode {
+	camera := NewPerspectiveCamera(DegreesToRadians(60), 1.0, 0.1, 100)
+	return cameraNode(&camera, options)
+}
+
+// OrthographicCamera creates an orthographic camera node showing the box
+// from left to right, bottom to top and near to far in front of it. Sizes
+// don't change with distance, as needed for 2D and isometric scenes:
+//
+//	OrthographicCamera(-8, 8, -4.5, 4.5, 0.1, 100, Position(0, 0, 10))
+//
+// The bounds aren't stretched to the canvas, so keep their ratio equal to
+// its aspect ratio.
+func OrthographicCamera(left, right, bottom, top, near, far float32, options ...interface{}) *GPUNode {
+	camera := NewOrthographicCamera(left, right, bottom, top, near, far)
+	return cameraNode(&camera, options)
+}
+
+// cameraNode creates a camera node for camera, applying the position,
+// lookAt, fov, near and far options to it
+func cameraNode(camera *Camera, options []interface{}) *GPUNode {
 	node := &GPUNode{
 		Type:       CameraNodeType,
 		Tag:        "camera",
 		Properties: make(map[string]interface{}),
 		Transform:  NewTransform(),
-		Camera: &Camera{
-			Position: Vec3{0, 0, 5},
-			Target:   Vec3{0, 0, 0},
-			Up:       Vec3{0, 1, 0},
-			FOV:      DegreesToRadians(60),
-			Aspect:   1.0,
-			Near:     0.1,
-			Far:      100,
-		},
+		Camera:     camera,
 	}
 
 	for _, opt := range options {
@@ -384,6 +499,10 @@ func DirectionalLight(options ...interface{}) *GPUNode {
 				if v, ok := o.Value.(float32); ok {
 					node.Light.Intensity = v
 				}
+			case "direction":
+				if v, ok := o.Value.(Vec3); ok {
+					node.Light.Direction = v
+				}
 			default:
 				node.Properties[o.Key] = o.Value
 			}
@@ -436,6 +555,60 @@ func PointLight(options ...interface{}) *GPUNode {
 	return node
 }
 
+// SpotLight creates a spot light node, which lights a cone around its
+// direction that fades out towards the cone angle
+func SpotLight(options ...interface{}) *GPUNode {
+	node := &GPUNode{
+		Type:       LightNodeType,
+		Tag:        "spot-light",
+		Properties: make(map[string]interface{}),
+		Transform:  NewTransform(),
+		Light: &Light{
+			Type:      "spot",
+			Color:     Vec3{1, 1, 1},
+			Intensity: 1.0,
+			Position:  Vec3{0, 5, 0},
+			Direction: Vec3{0, -1, 0},
+			ConeAngle: DegreesToRadians(30),
+		},
+	}
+
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case GPUProp:
+			switch o.Key {
+			case "position":
+				if v, ok := o.Value.(Vec3); ok {
+					node.Light.Position = v
+					node.Transform.Position = v
+				}
+			case "color":
+				if v, ok := o.Value.(Vec4); ok {
+					node.Light.Color = Vec3{v.X, v.Y, v.Z}
+				} else if v, ok := o.Value.(Vec3); ok {
+					node.Light.Color = v
+				}
+			case "intensity":
+				if v, ok := o.Value.(float32); ok {
+					node.Light.Intensity = v
+				}
+			case "direction":
+				if v, ok := o.Value.(Vec3); ok {
+					node.Light.Direction = v
+				}
+			case "coneAngle":
+				if v, ok := o.Value.(float32); ok {
+					node.Light.ConeAngle = v
+				}
+			default:
+				node.Properties[o.Key] = o.Value
+			}
+		}
+	}
+
+	return node
+}
+
 // Group creates a container for grouping nodes
 func Group(options ...interface{}) *GPUNode {
 	node := &GPUNode{
@@ -499,6 +672,10 @@ func StandardMaterial(options ...interface{}) *Material {
 				if v, ok := prop.Value.(float32); ok {
 					mat.Roughness = v
 				}
+			case "transparent":
+				if v, ok := prop.Value.(bool); ok {
+					mat.Transparent = v
+				}
 			}
 		}
 	}
@@ -0,0 +1,46 @@
+//go:build js && wasm
+
+package runtime
+
+// SrcSet sets the srcset attribute of an img
+func SrcSet(value string) Attr {
+	return Attr{Key: "srcset", Value: value}
+}
+
+// Sizes sets the sizes attribute of an img
+func Sizes(value string) Attr {
+	return Attr{Key: "sizes", Value: value}
+}
+
+// Lazy sets the loading attribute: "lazy" defers loading until the element
+// is near the viewport, "eager" loads it immediately
+func Lazy(lazy bool) Attr {
+	if lazy {
+		return Attr{Key: "loading", Value: "lazy"}
+	}
+	return Attr{Key: "loading", Value: "eager"}
+}
+
+// OnLoad creates a load event handler
+func OnLoad(handler func(Event)) EventHandler {
+	return EventHandler{
+		Name:    "load",
+		Handler: handler,
+	}
+}
+
+// SwapPlaceholder returns a load handler that removes an Image's placeholder
+// background once the full image has loaded, then calls next if it is not nil
+func SwapPlaceholder(next func(Event)) func(Event) {
+	return func(e Event) {
+		if !e.Native.IsUndefined() && !e.Native.IsNull() {
+			style := e.Native.Get("currentTarget").Get("style")
+			style.Call("removeProperty", "background-image")
+			style.Call("removeProperty", "background-size")
+			style.Call("removeProperty", "background-position")
+		}
+		if next != nil {
+			next(e)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+func TestImageAttributes(t *testing.T) {
+	img := Img(Src("photo.jpg"), SrcSet("photo-480.jpg 480w"), Sizes("480px"), Lazy(true))
+
+	expected := map[string]string{
+		"src":     "photo.jpg",
+		"srcset":  "photo-480.jpg 480w",
+		"sizes":   "480px",
+		"loading": "lazy",
+	}
+	for key, value := range expected {
+		if got := img.Attributes[key]; got != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, got)
+		}
+	}
+
+	if got := Img(Lazy(false)).Attributes["loading"]; got != "eager" {
+		t.Errorf("Expected loading=eager, got %q", got)
+	}
+}
+
+func TestSwapPlaceholderCallsNext(t *testing.T) {
+	called := false
+	handler := SwapPlaceholder(func(e Event) { called = true })
+
+	handler(Event{Native: js.Undefined(), Type: "load"})
+	if !called {
+		t.Error("Expected the next load handler to be called")
+	}
+
+	// A nil next handler is allowed
+	SwapPlaceholder(nil)(Event{Native: js.Undefined(), Type: "load"})
+}
@@ -0,0 +1,35 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Join joins the elements of a slice or array with a separator, for
+// templates rendering a list as text:
+//
+//	Span(Text(Join(tags, ", ")))
+//
+// Elements are formatted with fmt.Sprint; any other value is formatted
+// as a whole.
+func Join(slice interface{}, sep string) string {
+	switch s := slice.(type) {
+	case []string:
+		return strings.Join(s, sep)
+	case nil:
+		return ""
+	}
+
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(slice)
+	}
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,27 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice interface{}
+		want  string
+	}{
+		{"strings", []string{"go", "wasm", "ui"}, "go, wasm, ui"},
+		{"ints", []int{1, 2, 3}, "1, 2, 3"},
+		{"floats", [2]float64{0.5, 1.25}, "0.5, 1.25"},
+		{"empty", []string{}, ""},
+		{"nil", nil, ""},
+		{"scalar", 42, "42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Join(tt.slice, ", "); got != tt.want {
+				t.Errorf("Join(%v) = %q, want %q", tt.slice, got, tt.want)
+			}
+		})
+	}
+}
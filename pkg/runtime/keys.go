@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// keyStateQueueSize bounds the key changes waiting to be received, so a
+// render loop that stalls drops changes instead of blocking the event loop
+const keyStateQueueSize = 64
+
+// KeyState is a change in whether a key is held, sent by BindKeys
+type KeyState struct {
+	Key  string // Key value, lower-cased for letters so Shift doesn't split a key
+	Code string // Physical key code, e.g. "KeyA"
+	Down bool   // True when the key was pressed, false when released
+}
+
+// BindKeys listens for keydown and keyup on the document and sends a
+// KeyState to ch whenever a key is pressed or released. Auto-repeated
+// keydowns are dropped, so a render loop can track the held keys from the
+// changes alone. It returns a function that removes the listeners; it is
+// generated for components declared with @keys -> ch.
+func BindKeys(ch chan KeyState) func() {
+	doc := js.Global().Get("document")
+	if ch == nil || doc.IsUndefined() || doc.IsNull() {
+		return func() {}
+	}
+
+	// Changes are forwarded in order by a single goroutine; handlers must
+	// not block the JS event loop
+	queue := make(chan KeyState, keyStateQueueSize)
+	go func() {
+		for state := range queue {
+			ch <- state
+		}
+	}()
+
+	held := make(map[string]bool)
+	listener := func(down bool) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) == 0 {
+				return nil
+			}
+			event := newEvent(args[0])
+			state := KeyState{Key: normalizeKey(event.Key), Code: event.Code, Down: down}
+			if held[state.Code] == down {
+				return nil
+			}
+			if down {
+				held[state.Code] = true
+			} else {
+				delete(held, state.Code)
+			}
+
+			select {
+			case queue <- state:
+			default:
+			}
+			return nil
+		})
+	}
+
+	keydown := listener(true)
+	keyup := listener(false)
+	doc.Call("addEventListener", "keydown", keydown)
+	doc.Call("addEventListener", "keyup", keyup)
+
+	released := false
+	return func() {
+		if released {
+			return
+		}
+		released = true
+		doc.Call("removeEventListener", "keydown", keydown)
+		doc.Call("removeEventListener", "keyup", keyup)
+		keydown.Release()
+		keyup.Release()
+		close(queue)
+	}
+}
+
+// normalizeKey lower-cases single letter keys, e.g. "A" with Shift held
+func normalizeKey(key string) string {
+	if len(key) == 1 {
+		return strings.ToLower(key)
+	}
+	return key
+}
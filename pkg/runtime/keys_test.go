@@ -0,0 +1,69 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// installKeyDocument replaces the global document with one that records its
+// listeners, so tests can dispatch key events
+func installKeyDocument(t *testing.T) js.Value {
+	t.Helper()
+
+	global := js.Global()
+	previous := global.Get("document")
+	doc := global.Call("eval", `({
+		listeners: {},
+		addEventListener: function(name, fn) { this.listeners[name] = fn; },
+		removeEventListener: function(name) { delete this.listeners[name]; }
+	})`)
+	global.Set("document", doc)
+	t.Cleanup(func() { global.Set("document", previous) })
+	return doc
+}
+
+func dispatchKey(doc js.Value, eventType, key, code string) {
+	event := js.Global().Call("eval", `({target: {}})`)
+	event.Set("type", eventType)
+	event.Set("key", key)
+	event.Set("code", code)
+	doc.Get("listeners").Call(eventType, event)
+}
+
+func TestBindKeysSendsStateChanges(t *testing.T) {
+	doc := installKeyDocument(t)
+	keys := make(chan KeyState, 8)
+
+	unbind := BindKeys(keys)
+	dispatchKey(doc, "keydown", "A", "KeyA")
+	// Auto-repeat while held is not a state change
+	dispatchKey(doc, "keydown", "A", "KeyA")
+	dispatchKey(doc, "keydown", "ArrowUp", "ArrowUp")
+	dispatchKey(doc, "keyup", "a", "KeyA")
+
+	expected := []KeyState{
+		{Key: "a", Code: "KeyA", Down: true},
+		{Key: "ArrowUp", Code: "ArrowUp", Down: true},
+		{Key: "a", Code: "KeyA", Down: false},
+	}
+	for i, want := range expected {
+		select {
+		case got := <-keys:
+			if got != want {
+				t.Errorf("Change %d: expected %+v, got %+v", i, want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected change %d to be sent", i)
+		}
+	}
+
+	unbind()
+	if !doc.Get("listeners").Get("keydown").IsUndefined() || !doc.Get("listeners").Get("keyup").IsUndefined() {
+		t.Error("Expected unbind to remove the key listeners")
+	}
+	// Unbinding twice is harmless
+	unbind()
+}
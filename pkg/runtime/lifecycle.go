@@ -0,0 +1,64 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// MountHook is called with the DOM node of an element once it has been
+// inserted with its children (see OnMount)
+type MountHook func(elem js.Value)
+
+// UnmountHook is called with the DOM node of an element before it is
+// removed. The element is removed after the returned duration; zero removes
+// it right away (see OnBeforeUnmount).
+type UnmountHook func(elem js.Value) time.Duration
+
+// OnMount calls fn with the DOM node of the element when it is mounted,
+// e.g. to start an enter transition:
+//
+//	Div(Class("toast"), OnMount(fadeIn)) { "Saved" }
+//
+// fn runs once the element is in the document, so it can be measured or
+// focused; the hooks of its children run before its own. The browser hasn't
+// styled the element yet, so a class added on the next animation frame
+// animates from the initial styles.
+func OnMount(fn func(elem js.Value)) MountHook {
+	return MountHook(fn)
+}
+
+// OnBeforeUnmount calls fn with the DOM node of the element before it is
+// removed. fn returns how long to keep the element in the document, e.g.
+// for a leave transition:
+//
+//	func fadeOut(elem js.Value) time.Duration {
+//	    elem.Get("classList").Call("add", "leaving")
+//	    return 300 * time.Millisecond
+//	}
+//
+// The element's event handlers are released right away, and its children
+// stay in it until it is removed.
+func OnBeforeUnmount(fn func(elem js.Value) time.Duration) UnmountHook {
+	return UnmountHook(fn)
+}
+
+// removeAfter removes a DOM node from its parent once delay has passed
+func removeAfter(node js.Value, delay time.Duration) {
+	var remove js.Func
+	remove = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		removeDOMNode(node)
+		remove.Release()
+		return nil
+	})
+	js.Global().Call("setTimeout", remove, delay.Milliseconds())
+}
+
+// removeDOMNode removes a DOM node from its parent, if it has one
+func removeDOMNode(node js.Value) {
+	parent := node.Get("parentNode")
+	if !parent.IsUndefined() && !parent.IsNull() {
+		parent.Call("removeChild", node)
+	}
+}
@@ -0,0 +1,124 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+func TestOnMountReceivesElement(t *testing.T) {
+	installFakeDocument(t)
+
+	var mounted, parent js.Value
+	var class string
+	var children int
+	var order []string
+	tree := Div(Class("toast"), OnMount(func(elem js.Value) {
+		mounted = elem
+		parent = elem.Get("parentNode")
+		class = elem.Get("attributes").Get("class").String()
+		children = elem.Get("childNodes").Length()
+		order = append(order, "div")
+	}), Span(OnMount(func(js.Value) { order = append(order, "span") }), Text("Saved")))
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	if err := Mount(tree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	if mounted.IsUndefined() || !mounted.Equal(tree.DOMNode) {
+		t.Fatalf("Expected the hook to receive the created element")
+	}
+	if !parent.Equal(root) {
+		t.Errorf("Expected the hook to run once the element is inserted into its parent")
+	}
+	if class != "toast" || children != 1 {
+		t.Errorf("Expected the element with its attributes and children, got class %q and %d children", class, children)
+	}
+	if len(order) != 2 || order[0] != "span" || order[1] != "div" {
+		t.Errorf("Expected the child's hook to run first, got %v", order)
+	}
+}
+
+func TestOnBeforeUnmountDelaysRemoval(t *testing.T) {
+	installFakeDocument(t)
+
+	var leaving js.Value
+	tree := Div(OnBeforeUnmount(func(elem js.Value) time.Duration {
+		leaving = elem
+		return 20 * time.Millisecond
+	}), Span(Text("Saved")))
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	if err := Mount(tree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+	elem := tree.DOMNode
+
+	Unmount(tree)
+	if !leaving.Equal(elem) {
+		t.Fatalf("Expected the hook to receive the element before its removal")
+	}
+	if root.Get("childNodes").Length() != 1 || elem.Get("childNodes").Length() != 1 {
+		t.Fatalf("Expected the element to stay with its children until the delay passes")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if root.Get("childNodes").Length() != 0 {
+		t.Errorf("Expected the element to be removed after the delay")
+	}
+}
+
+func TestOnBeforeUnmountWithoutDelay(t *testing.T) {
+	installFakeDocument(t)
+
+	called := false
+	tree := Div(OnBeforeUnmount(func(elem js.Value) time.Duration {
+		called = true
+		return 0
+	}))
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	if err := Mount(tree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	Unmount(tree)
+	if !called || root.Get("childNodes").Length() != 0 {
+		t.Errorf("Expected the hook to run and the element to be removed right away")
+	}
+}
+
+func TestReplaceNodeDelaysRemoval(t *testing.T) {
+	installFakeDocument(t)
+
+	var inserted js.Value
+	oldTree := Div(OnBeforeUnmount(func(js.Value) time.Duration { return 20 * time.Millisecond }), Text("old"))
+	newTree := P(OnMount(func(elem js.Value) { inserted = elem.Get("parentNode") }), Text("new"))
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	if err := Mount(oldTree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+	oldElem := oldTree.DOMNode
+
+	if err := ReplaceNode(oldTree, newTree); err != nil {
+		t.Fatalf("ReplaceNode failed: %v", err)
+	}
+	if !inserted.Equal(root) {
+		t.Errorf("Expected the new element's hook to run once it is inserted")
+	}
+
+	// The old element leaves after its delay, behind the new one
+	nodes := root.Get("childNodes")
+	if nodes.Length() != 2 || !nodes.Index(0).Equal(newTree.DOMNode) || !nodes.Index(1).Equal(oldElem) {
+		t.Fatalf("Expected the new element followed by the leaving old one, got %d children", nodes.Length())
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if nodes := root.Get("childNodes"); nodes.Length() != 1 || !nodes.Index(0).Equal(newTree.DOMNode) {
+		t.Errorf("Expected only the new element after the delay, got %d children", nodes.Length())
+	}
+}
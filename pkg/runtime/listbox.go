@@ -0,0 +1,180 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ListBoxOption configures a ListBox
+type ListBoxOption[T any] func(*listBoxConfig[T])
+
+type listBoxConfig[T any] struct {
+	items    []T
+	selected chan T
+}
+
+// Items sets the items of a ListBox. Items are labelled with fmt.Sprint.
+func Items[T any](items []T) ListBoxOption[T] {
+	return func(cfg *listBoxConfig[T]) {
+		cfg.items = items
+	}
+}
+
+// Selected sets the channel that receives the item chosen with Enter,
+// Space or a click
+func Selected[T any](ch chan T) ListBoxOption[T] {
+	return func(cfg *listBoxConfig[T]) {
+		cfg.selected = ch
+	}
+}
+
+// listBoxState is the focus and selection of a ListBox across renders
+type listBoxState struct {
+	focused  int
+	selected int
+	mu       sync.Mutex
+}
+
+// listBoxKeys are the keys a ListBox handles
+var listBoxKeys = map[string]bool{
+	"ArrowDown": true, "ArrowUp": true, "Home": true, "End": true, "Enter": true, " ": true,
+}
+
+var (
+	listBoxStates   = make(map[interface{}]*listBoxState)
+	listBoxStatesMu sync.Mutex
+)
+
+// listBoxStateFor returns the state of the ListBox feeding the given
+// Selected channel. A ListBox without a channel starts fresh every render.
+func listBoxStateFor[T any](selected chan T) *listBoxState {
+	if selected == nil {
+		return &listBoxState{selected: -1}
+	}
+
+	listBoxStatesMu.Lock()
+	defer listBoxStatesMu.Unlock()
+	state, ok := listBoxStates[selected]
+	if !ok {
+		state = &listBoxState{selected: -1}
+		listBoxStates[selected] = state
+	}
+	return state
+}
+
+// ListBox creates a keyboard-navigable list with role="listbox":
+//
+//	ListBox(Items(fruits), Selected(choice))
+//
+// Options use a roving tabindex, so only the focused option is in the tab
+// order. ArrowUp/ArrowDown move the focus, Home/End jump to the first and
+// last option, and Enter or Space sends the focused item to the Selected
+// channel.
+func ListBox[T any](options ...ListBoxOption[T]) *VNode {
+	cfg := &listBoxConfig[T]{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	state := listBoxStateFor(cfg.selected)
+	state.mu.Lock()
+	if state.focused >= len(cfg.items) {
+		state.focused = len(cfg.items) - 1
+	}
+	if state.focused < 0 {
+		state.focused = 0
+	}
+	focused, selected := state.focused, state.selected
+	state.mu.Unlock()
+
+	choose := func(index int) {
+		state.mu.Lock()
+		state.focused = index
+		state.selected = index
+		state.mu.Unlock()
+		if cfg.selected != nil {
+			// Event handlers must not block the JS event loop
+			go func(item T) { cfg.selected <- item }(cfg.items[index])
+		}
+	}
+
+	opts := []interface{}{
+		Role("listbox"),
+		PreventDefaultIf(OnKeyDown(func(e Event) {
+			if len(cfg.items) == 0 {
+				return
+			}
+
+			state.mu.Lock()
+			current := state.focused
+			state.mu.Unlock()
+
+			next := current
+			switch e.Key {
+			case "ArrowDown":
+				next = min(current+1, len(cfg.items)-1)
+			case "ArrowUp":
+				next = max(current-1, 0)
+			case "Home":
+				next = 0
+			case "End":
+				next = len(cfg.items) - 1
+			case "Enter", " ":
+				choose(current)
+				return
+			default:
+				return
+			}
+
+			state.mu.Lock()
+			state.focused = next
+			state.mu.Unlock()
+			moveListBoxFocus(e, current, next)
+		}), func(e Event) bool {
+			// Space and the arrow keys would otherwise scroll the page
+			return len(cfg.items) > 0 && listBoxKeys[e.Key]
+		}),
+	}
+
+	for i, item := range cfg.items {
+		index := i
+		tabIndex := -1
+		if i == focused {
+			tabIndex = 0
+		}
+		opts = append(opts, Li(
+			Role("option"),
+			TabIndex(tabIndex),
+			Attr{Key: "aria-selected", Value: strconv.FormatBool(i == selected)},
+			OnClick(func(e Event) { choose(index) }),
+			Text(fmt.Sprint(item)),
+		))
+	}
+
+	return Ul(opts...)
+}
+
+// moveListBoxFocus updates the roving tabindex in the DOM and focuses the
+// new option without waiting for a re-render
+func moveListBoxFocus(e Event, from, to int) {
+	if e.Native.IsUndefined() || e.Native.IsNull() {
+		return
+	}
+	list := e.Native.Get("currentTarget")
+	if list.IsUndefined() || list.IsNull() {
+		return
+	}
+
+	options := list.Get("children")
+	if from < options.Length() {
+		options.Index(from).Call("setAttribute", "tabindex", "-1")
+	}
+	if to < options.Length() {
+		option := options.Index(to)
+		option.Call("setAttribute", "tabindex", "0")
+		option.Call("focus")
+	}
+}
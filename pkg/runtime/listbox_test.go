@@ -0,0 +1,121 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"testing"
+	"time"
+)
+
+// focusedOption returns the index of the option in the tab order
+func focusedOption(t *testing.T, list *VNode) int {
+	t.Helper()
+	focused := -1
+	for i, option := range list.Children {
+		if option.Attributes["tabindex"] == "0" {
+			if focused != -1 {
+				t.Fatalf("Options %d and %d are both in the tab order", focused, i)
+			}
+			focused = i
+		}
+	}
+	return focused
+}
+
+func pressKey(list *VNode, key string) {
+	list.Events["keydown"].Handler(Event{Key: key})
+}
+
+func TestListBoxRoles(t *testing.T) {
+	list := ListBox(Items([]string{"apple", "banana"}))
+
+	if list.Tag != "ul" || list.Attributes["role"] != "listbox" {
+		t.Fatalf("Expected ul with role=listbox, got %s role=%q", list.Tag, list.Attributes["role"])
+	}
+	for i, option := range list.Children {
+		if option.Tag != "li" || option.Attributes["role"] != "option" {
+			t.Errorf("Option %d: expected li with role=option, got %s role=%q", i, option.Tag, option.Attributes["role"])
+		}
+		if option.Attributes["aria-selected"] != "false" {
+			t.Errorf("Option %d: expected aria-selected=false, got %q", i, option.Attributes["aria-selected"])
+		}
+	}
+	if got := focusedOption(t, list); got != 0 {
+		t.Errorf("Expected first option to be focusable, got %d", got)
+	}
+}
+
+func TestListBoxArrowKeysMoveFocus(t *testing.T) {
+	items := []string{"apple", "banana", "cherry"}
+	selected := make(chan string)
+
+	list := ListBox(Items(items), Selected(selected))
+	pressKey(list, "ArrowDown")
+	pressKey(list, "ArrowDown")
+
+	// The focused index survives a re-render
+	list = ListBox(Items(items), Selected(selected))
+	if got := focusedOption(t, list); got != 2 {
+		t.Fatalf("Expected option 2 to be focused, got %d", got)
+	}
+
+	// Movement stops at the last option
+	pressKey(list, "ArrowDown")
+	list = ListBox(Items(items), Selected(selected))
+	if got := focusedOption(t, list); got != 2 {
+		t.Errorf("Expected focus to stay on option 2, got %d", got)
+	}
+
+	pressKey(list, "ArrowUp")
+	list = ListBox(Items(items), Selected(selected))
+	if got := focusedOption(t, list); got != 1 {
+		t.Errorf("Expected option 1 to be focused, got %d", got)
+	}
+
+	pressKey(list, "Home")
+	list = ListBox(Items(items), Selected(selected))
+	if got := focusedOption(t, list); got != 0 {
+		t.Errorf("Expected Home to focus option 0, got %d", got)
+	}
+}
+
+func TestListBoxEnterSelects(t *testing.T) {
+	items := []string{"apple", "banana", "cherry"}
+	selected := make(chan string)
+
+	list := ListBox(Items(items), Selected(selected))
+	pressKey(list, "ArrowDown")
+	pressKey(list, "Enter")
+
+	select {
+	case item := <-selected:
+		if item != "banana" {
+			t.Errorf("Expected banana to be selected, got %q", item)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the selection to be sent to the channel")
+	}
+
+	list = ListBox(Items(items), Selected(selected))
+	if got := list.Children[1].Attributes["aria-selected"]; got != "true" {
+		t.Errorf("Expected option 1 to be aria-selected, got %q", got)
+	}
+}
+
+func TestListBoxCancelsNavigationKeys(t *testing.T) {
+	list := ListBox(Items([]string{"apple", "banana"}))
+	keydown := list.Events["keydown"]
+
+	// The keys the list handles are cancelled while the event is dispatched,
+	// so Space and the arrows don't scroll the page; Tab still leaves the list
+	for key, want := range map[string]bool{"ArrowDown": true, "ArrowUp": true, " ": true, "Enter": true, "Tab": false, "a": false} {
+		if got := keydown.PreventDefaultIf(Event{Key: key}); got != want {
+			t.Errorf("Key %q: expected cancel %v, got %v", key, want, got)
+		}
+	}
+
+	empty := ListBox(Items([]string{}))
+	if empty.Events["keydown"].PreventDefaultIf(Event{Key: " "}) {
+		t.Error("Expected an empty list not to cancel Space")
+	}
+}
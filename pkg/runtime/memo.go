@@ -0,0 +1,62 @@
+//go:build js && wasm
+
+package runtime
+
+// Props is a snapshot of the values a component renders from
+type Props interface{}
+
+// Memoizable is implemented by components that can tell whether rendering
+// again would change their output. Props returns a snapshot of the values
+// Render reads, and ShouldUpdate compares the snapshot of the last render
+// with the current one. MemoCache returns the cache of the last render, a
+// field of the component, so it is freed with it. Components declared with
+// @memo implement it.
+type Memoizable interface {
+	Component
+	Props() Props
+	ShouldUpdate(old, new Props) bool
+	MemoCache() *MemoCache
+}
+
+// MemoCache holds the last render of a Memoizable component. The zero value
+// is empty.
+type MemoCache struct {
+	props Props
+	vnode *VNode
+}
+
+// RenderComponent renders a component. A Memoizable component whose
+// ShouldUpdate reports no change returns the VNode of its last render, which
+// the diff skips without walking it.
+func RenderComponent(c Component) *VNode {
+	m, ok := c.(Memoizable)
+	if !ok {
+		return c.Render()
+	}
+
+	props := m.Props()
+	cache := m.MemoCache()
+	if cache.vnode != nil && !m.ShouldUpdate(cache.props, props) {
+		return cache.vnode
+	}
+
+	vnode := c.Render()
+	if vnode != nil {
+		vnode.Component = c
+	}
+	cache.props, cache.vnode = props, vnode
+	return vnode
+}
+
+// forgetRender drops the last render of the component a VNode was rendered
+// by once the VNode is unmounted, so the component renders again instead of
+// returning a tree whose DOM nodes are gone
+func forgetRender(vnode *VNode) {
+	m, ok := vnode.Component.(Memoizable)
+	if !ok {
+		return
+	}
+	if cache := m.MemoCache(); cache.vnode == vnode {
+		*cache = MemoCache{}
+	}
+}
@@ -0,0 +1,140 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strconv"
+	"syscall/js"
+	"testing"
+)
+
+// widget is a memoized component counting its renders
+type widget struct {
+	value   int
+	renders int
+	memo    MemoCache
+}
+
+func (w *widget) Render() *VNode {
+	w.renders++
+	return Div(Text(strconv.Itoa(w.value)))
+}
+
+func (w *widget) Mount(parent js.Value) {}
+func (w *widget) Unmount()              {}
+func (w *widget) Update()               {}
+
+func (w *widget) Props() Props { return w.value }
+
+func (w *widget) ShouldUpdate(old, new Props) bool { return old != new }
+
+func (w *widget) MemoCache() *MemoCache { return &w.memo }
+
+// dashboard renders its widgets through RenderComponent
+type dashboard struct {
+	widgets []*widget
+}
+
+func (d *dashboard) Render() *VNode {
+	children := make([]interface{}, len(d.widgets))
+	for i, w := range d.widgets {
+		children[i] = RenderComponent(w)
+	}
+	return Div(children...)
+}
+
+func (d *dashboard) Mount(parent js.Value) {}
+func (d *dashboard) Unmount()              {}
+func (d *dashboard) Update()               {}
+
+func TestRenderComponentSkipsUnchanged(t *testing.T) {
+	d := &dashboard{}
+	for i := 0; i < 50; i++ {
+		d.widgets = append(d.widgets, &widget{value: i})
+	}
+
+	old := RenderComponent(d)
+
+	// One widget changes per tick
+	const ticks = 10
+	for tick := 0; tick < ticks; tick++ {
+		d.widgets[0].value++
+		vnode := RenderComponent(d)
+
+		patches := Diff(old, vnode)
+		if len(patches) != 1 || patches[0].Type != PatchUpdateText {
+			t.Fatalf("Tick %d: expected a single text patch, got %d patches", tick, len(patches))
+		}
+		old = vnode
+	}
+
+	if got := d.widgets[0].renders; got != ticks+1 {
+		t.Errorf("Expected the changing widget to render %d times, got %d", ticks+1, got)
+	}
+	renders := 0
+	for _, w := range d.widgets[1:] {
+		renders += w.renders
+	}
+	if renders != 49 {
+		t.Errorf("Expected the 49 unchanged widgets to render once each, got %d renders", renders)
+	}
+}
+
+func TestRenderComponentPlain(t *testing.T) {
+	// Components without Props/ShouldUpdate render every time
+	d := &dashboard{}
+	if RenderComponent(d) == RenderComponent(d) {
+		t.Error("Expected a new VNode for a component that is not Memoizable")
+	}
+}
+
+// panel shows its widget while open
+type panel struct {
+	open   bool
+	widget *widget
+}
+
+func (p *panel) Render() *VNode {
+	if p.open {
+		return Div(RenderComponent(p.widget))
+	}
+	return Div()
+}
+
+func (p *panel) Mount(parent js.Value) {}
+func (p *panel) Unmount()              {}
+func (p *panel) Update()               {}
+
+func TestRenderComponentAfterRemount(t *testing.T) {
+	installFakeDocument(t)
+
+	p := &panel{open: true, widget: &widget{value: 1}}
+	tree := p.Render()
+	if err := Mount(tree, js.Global().Get("document").Call("createElement", "div")); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	// Closing the panel unmounts the widget and drops its last render
+	p.open = false
+	closed := p.Render()
+	if err := Reconcile(tree, closed); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if p.widget.memo.vnode != nil {
+		t.Error("Expected the unmounted widget's render to be dropped")
+	}
+
+	// Reopening renders it again instead of reusing the unmounted tree
+	p.open = true
+	reopened := p.Render()
+	if err := Reconcile(closed, reopened); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if p.widget.renders != 2 {
+		t.Errorf("Expected the remounted widget to render again, got %d renders", p.widget.renders)
+	}
+	child := reopened.Children[0]
+	if child == tree.Children[0] || !reopened.DOMNode.Get("childNodes").Index(0).Equal(child.DOMNode) {
+		t.Error("Expected the remounted widget to have a new tree in the DOM")
+	}
+}
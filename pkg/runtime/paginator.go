@@ -0,0 +1,151 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNoMorePages is returned by LoadNext once the fetch function has reported
+// that the last page was reached
+var ErrNoMorePages = errors.New("paginator: no more pages")
+
+// PageFetcher loads a single page (starting at 0) and reports whether more
+// pages are available after it
+type PageFetcher[T any] func(page int) (items []T, hasMore bool, err error)
+
+// Paginator accumulates pages of items loaded through a PageFetcher.
+// It is intended for infinite lists and tables: call LoadNext from a goroutine
+// (e.g. when the user scrolls near the end) and read Updates() to re-render.
+type Paginator[T any] struct {
+	fetch   PageFetcher[T]
+	items   []T
+	page    int
+	hasMore bool
+	loading bool
+	gen     int // Incremented by Reset, so fetches started before it are dropped
+	updates chan []T
+	mu      sync.Mutex
+}
+
+// NewPaginator creates a paginator for the given fetch function
+func NewPaginator[T any](fetch PageFetcher[T]) *Paginator[T] {
+	return &Paginator[T]{
+		fetch:   fetch,
+		hasMore: true,
+		updates: make(chan []T, 1),
+	}
+}
+
+// LoadNext fetches the next page and appends its items.
+// Concurrent calls while a page is loading are ignored.
+func (p *Paginator[T]) LoadNext() error {
+	p.mu.Lock()
+	if p.loading {
+		p.mu.Unlock()
+		return nil
+	}
+	if !p.hasMore {
+		p.mu.Unlock()
+		return ErrNoMorePages
+	}
+	p.loading = true
+	page, gen := p.page, p.gen
+	p.mu.Unlock()
+
+	items, hasMore, err := p.fetch(page)
+
+	p.mu.Lock()
+	// A Reset during the fetch discards its page
+	if gen != p.gen {
+		p.mu.Unlock()
+		return nil
+	}
+	p.loading = false
+	if err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	p.items = append(p.items, items...)
+	p.page++
+	p.hasMore = hasMore
+	snapshot := p.snapshotLocked()
+	p.mu.Unlock()
+
+	if len(items) > 0 {
+		p.emit(snapshot)
+	}
+	return nil
+}
+
+// Items returns a copy of all items loaded so far
+func (p *Paginator[T]) Items() []T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.snapshotLocked()
+}
+
+// Loading reports whether a page is currently being fetched
+func (p *Paginator[T]) Loading() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.loading
+}
+
+// HasMore reports whether another page can be loaded
+func (p *Paginator[T]) HasMore() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hasMore
+}
+
+// Pages returns the number of pages loaded so far
+func (p *Paginator[T]) Pages() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.page
+}
+
+// Updates returns a channel that receives the full item list whenever new
+// items arrive. Only the latest list is kept if the reader falls behind.
+func (p *Paginator[T]) Updates() <-chan []T {
+	return p.updates
+}
+
+// Reset discards all loaded items so loading starts again from page 0, and
+// sends the emptied list on Updates. A page still being fetched is dropped
+// when it arrives.
+func (p *Paginator[T]) Reset() {
+	p.mu.Lock()
+	p.items = nil
+	p.page = 0
+	p.hasMore = true
+	p.loading = false
+	p.gen++
+	snapshot := p.snapshotLocked()
+	p.mu.Unlock()
+
+	p.emit(snapshot)
+}
+
+func (p *Paginator[T]) snapshotLocked() []T {
+	out := make([]T, len(p.items))
+	copy(out, p.items)
+	return out
+}
+
+// emit sends items without blocking, replacing any undelivered update
+func (p *Paginator[T]) emit(items []T) {
+	for {
+		select {
+		case p.updates <- items:
+			return
+		default:
+		}
+		select {
+		case <-p.updates:
+		default:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPaginatorAccumulatesPages(t *testing.T) {
+	pages := [][]int{{1, 2, 3}, {4, 5}, {6}}
+	p := NewPaginator(func(page int) ([]int, bool, error) {
+		return pages[page], page < len(pages)-1, nil
+	})
+
+	for i := 0; i < len(pages); i++ {
+		if err := p.LoadNext(); err != nil {
+			t.Fatalf("LoadNext page %d failed: %v", i, err)
+		}
+	}
+
+	items := p.Items()
+	if len(items) != 6 {
+		t.Fatalf("Expected 6 items, got %d: %v", len(items), items)
+	}
+	for i, v := range items {
+		if v != i+1 {
+			t.Errorf("Item %d: expected %d, got %d", i, i+1, v)
+		}
+	}
+
+	if p.Pages() != 3 {
+		t.Errorf("Expected 3 pages loaded, got %d", p.Pages())
+	}
+	if p.HasMore() {
+		t.Error("Expected HasMore to be false after last page")
+	}
+	if err := p.LoadNext(); !errors.Is(err, ErrNoMorePages) {
+		t.Errorf("Expected ErrNoMorePages, got %v", err)
+	}
+
+	// The update channel holds the latest full list
+	select {
+	case latest := <-p.Updates():
+		if len(latest) != 6 {
+			t.Errorf("Expected update with 6 items, got %d", len(latest))
+		}
+	default:
+		t.Error("Expected an update to be emitted")
+	}
+}
+
+func TestPaginatorLoadingFlag(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	p := NewPaginator(func(page int) ([]string, bool, error) {
+		close(started)
+		<-release
+		return []string{"a"}, true, nil
+	})
+
+	if p.Loading() {
+		t.Fatal("Expected Loading to be false before LoadNext")
+	}
+
+	done := make(chan error)
+	go func() { done <- p.LoadNext() }()
+
+	<-started
+	if !p.Loading() {
+		t.Error("Expected Loading to be true while fetching")
+	}
+
+	// A concurrent call while loading is a no-op
+	if err := p.LoadNext(); err != nil {
+		t.Errorf("Expected concurrent LoadNext to be ignored, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("LoadNext failed: %v", err)
+	}
+
+	if p.Loading() {
+		t.Error("Expected Loading to be false after fetch completes")
+	}
+	if got := len(p.Items()); got != 1 {
+		t.Errorf("Expected 1 item, got %d", got)
+	}
+}
+
+func TestPaginatorFetchError(t *testing.T) {
+	fetchErr := errors.New("network down")
+	p := NewPaginator(func(page int) ([]int, bool, error) {
+		return nil, false, fetchErr
+	})
+
+	if err := p.LoadNext(); !errors.Is(err, fetchErr) {
+		t.Fatalf("Expected fetch error, got %v", err)
+	}
+	if p.Loading() {
+		t.Error("Expected Loading to be reset after an error")
+	}
+	if p.Pages() != 0 {
+		t.Errorf("Expected no pages after an error, got %d", p.Pages())
+	}
+	if !p.HasMore() {
+		t.Error("Expected a failed page to be retryable")
+	}
+}
+
+func TestPaginatorResetDuringFetch(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	calls := 0
+	p := NewPaginator(func(page int) ([]string, bool, error) {
+		calls++
+		if calls == 1 {
+			started <- struct{}{}
+			<-release
+			return []string{"stale"}, true, nil
+		}
+		return []string{"fresh"}, true, nil
+	})
+
+	done := make(chan error)
+	go func() { done <- p.LoadNext() }()
+	<-started
+
+	p.Reset()
+
+	// Subscribers see the emptied list
+	select {
+	case items := <-p.Updates():
+		if len(items) != 0 {
+			t.Errorf("Expected an empty update after Reset, got %v", items)
+		}
+	default:
+		t.Error("Expected Reset to emit an update")
+	}
+	if p.Loading() {
+		t.Error("Expected Reset to clear Loading")
+	}
+
+	// The page fetched before the reset is dropped
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("LoadNext failed: %v", err)
+	}
+	if items := p.Items(); len(items) != 0 || p.Pages() != 0 {
+		t.Errorf("Expected the stale page to be dropped, got %v after %d pages", items, p.Pages())
+	}
+	select {
+	case items := <-p.Updates():
+		t.Errorf("Expected no update for the stale page, got %v", items)
+	default:
+	}
+
+	if err := p.LoadNext(); err != nil {
+		t.Fatalf("LoadNext failed: %v", err)
+	}
+	if items := p.Items(); len(items) != 1 || items[0] != "fresh" || p.Pages() != 1 {
+		t.Errorf("Expected loading to start again from page 0, got %v after %d pages", items, p.Pages())
+	}
+}
@@ -0,0 +1,61 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// Portal renders its children into the element matching selector instead
+// of its parent, e.g. a modal into the document body so no ancestor's
+// overflow or z-index clips it:
+//
+//	const modalRoot = "body"
+//	Div(Class("page")) {
+//	    if showDialog {
+//	        Portal(modalRoot) {
+//	            Div(Class("modal")) { "Saved" }
+//	        }
+//	    }
+//	}
+//
+// The portal keeps its place among its siblings with a comment. Its
+// children are diffed and unmounted like any others, removing them from
+// the target along with their event handlers. DOM events from them bubble
+// through the target's ancestors, not the portal's.
+func Portal(selector string, children ...*VNode) *VNode {
+	node := &VNode{
+		Type:   PortalNode,
+		Target: selector,
+	}
+	for _, child := range children {
+		node.Children = appendChild(node.Children, child)
+	}
+	return node
+}
+
+// createPortal mounts the children of a portal under its target and
+// returns the placeholder comment marking its position
+func createPortal(vnode *VNode) (js.Value, error) {
+	target := portalTarget(vnode)
+	if target.IsNull() || target.IsUndefined() {
+		return js.Undefined(), fmt.Errorf("portal target %q not found", vnode.Target)
+	}
+
+	for _, child := range vnode.Children {
+		childNode, err := createDOMNode(child)
+		if err != nil {
+			return js.Undefined(), err
+		}
+		child.DOMNode = childNode
+		target.Call("appendChild", childNode)
+	}
+
+	return js.Global().Get("document").Call("createComment", "portal"), nil
+}
+
+// portalTarget returns the element the children of a portal mount into
+func portalTarget(vnode *VNode) js.Value {
+	return js.Global().Get("document").Call("querySelector", vnode.Target)
+}
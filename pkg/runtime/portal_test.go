@@ -0,0 +1,108 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// installPortalTarget adds a body element to the fake document, returned
+// by querySelector("body"), holding an element of its own
+func installPortalTarget(t *testing.T) js.Value {
+	t.Helper()
+	installFakeDocument(t)
+
+	doc := js.Global().Get("document")
+	body := doc.Call("createElement", "body")
+	body.Call("appendChild", doc.Call("createElement", "main"))
+
+	querySelector := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if args[0].String() == "body" {
+			return body
+		}
+		return js.Null()
+	})
+	t.Cleanup(querySelector.Release)
+	doc.Set("querySelector", querySelector)
+
+	return body
+}
+
+func modalPage(messages ...string) *VNode {
+	items := make([]*VNode, len(messages))
+	for i, message := range messages {
+		items[i] = P(Text(message))
+	}
+	return Div(
+		Span(Text("page")),
+		Portal("body", Div(Class("modal"), OnClick(func(e Event) {})), Fragment(items...)),
+	)
+}
+
+func TestPortalMountsIntoTarget(t *testing.T) {
+	body := installPortalTarget(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	tree := modalPage("Saved")
+	if err := Mount(tree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	// The portal holds its place with a comment; its children are in body
+	inline := tree.DOMNode.Get("childNodes")
+	if inline.Length() != 2 || inline.Index(1).Get("nodeType").Int() != 8 {
+		t.Fatalf("Expected a span and a placeholder comment inline, got %d nodes", inline.Length())
+	}
+	bodyNodes := body.Get("childNodes")
+	if bodyNodes.Length() != 3 {
+		t.Fatalf("Expected main, the modal and a paragraph in body, got %d nodes", bodyNodes.Length())
+	}
+	if got := bodyNodes.Index(1).Get("attributes").Get("class").String(); got != "modal" {
+		t.Errorf("Expected the modal after the existing content, got class %q", got)
+	}
+	if _, ok := tree.Children[1].Children[0].Events["click"]; !ok {
+		t.Error("Expected the click handler to be attached across the portal")
+	}
+}
+
+func TestPortalPatchesAndUnmountsInTarget(t *testing.T) {
+	body := installPortalTarget(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	oldTree := modalPage("Saved")
+	if err := Mount(oldTree, root); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	newTree := modalPage("Saved", "Synced")
+	if err := ApplyPatches(Diff(oldTree, newTree)); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+	CopyDOMRefs(oldTree, newTree)
+
+	bodyNodes := body.Get("childNodes")
+	if bodyNodes.Length() != 4 {
+		t.Fatalf("Expected the new paragraph to be added in body, got %d nodes", bodyNodes.Length())
+	}
+	if got := bodyNodes.Index(3).Get("childNodes").Index(0).Get("textContent").String(); got != "Synced" {
+		t.Errorf("Expected the new paragraph last, got %q", got)
+	}
+	if got := newTree.DOMNode.Get("childNodes").Length(); got != 2 {
+		t.Errorf("Expected nothing added inline, got %d nodes", got)
+	}
+
+	Unmount(newTree)
+	if bodyNodes.Length() != 1 || bodyNodes.Index(0).Get("tagName").String() != "main" {
+		t.Errorf("Expected only the existing content left in body, got %d nodes", bodyNodes.Length())
+	}
+}
+
+func TestPortalMissingTarget(t *testing.T) {
+	installPortalTarget(t)
+
+	root := js.Global().Get("document").Call("createElement", "div")
+	if err := Mount(Portal("#missing", Div()), root); err == nil {
+		t.Error("Expected an error for a missing portal target")
+	}
+}
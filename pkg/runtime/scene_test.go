@@ -198,6 +198,21 @@ func TestMeshWithProps(t *testing.T) {
 	}
 }
 
+func TestMeshWithMaterialOf(t *testing.T) {
+	red := Mesh(MaterialOf(Color(1, 0, 0, 1), Roughness(0.2)))
+	if red.Material == nil {
+		t.Fatal("Expected material from props")
+	}
+	if red.Material.Color != (Vec4{1, 0, 0, 1}) || red.Material.Roughness != 0.2 {
+		t.Errorf("Expected red material with roughness 0.2, got %+v", red.Material)
+	}
+
+	mat := StandardMaterial(Metalness(1))
+	if shared := Mesh(MaterialOf(mat)); shared.Material != mat {
+		t.Error("Expected the given material to be used as is")
+	}
+}
+
 func TestPerspectiveCameraBuilder(t *testing.T) {
 	node := PerspectiveCamera()
 
@@ -214,6 +229,29 @@ func TestPerspectiveCameraBuilder(t *testing.T) {
 	}
 }
 
+func TestOrthographicCameraBuilder(t *testing.T) {
+	node := OrthographicCamera(-8, 8, -4.5, 4.5, 0.1, 100, Position(0, 0, 10), Far(50))
+
+	if node.Type != CameraNodeType {
+		t.Errorf("Expected CameraNodeType, got %d", node.Type)
+	}
+
+	camera := node.Camera
+	if camera.Projection != OrthographicProjection {
+		t.Errorf("Expected OrthographicProjection, got %d", camera.Projection)
+	}
+	if camera.Left != -8 || camera.Right != 8 || camera.Bottom != -4.5 || camera.Top != 4.5 {
+		t.Errorf("Expected bounds (-8, 8, -4.5, 4.5), got (%v, %v, %v, %v)",
+			camera.Left, camera.Right, camera.Bottom, camera.Top)
+	}
+	if camera.Position != (Vec3{0, 0, 10}) {
+		t.Errorf("Expected position (0, 0, 10), got %v", camera.Position)
+	}
+	if camera.Near != 0.1 || camera.Far != 50 {
+		t.Errorf("Expected near 0.1 and far 50, got %v and %v", camera.Near, camera.Far)
+	}
+}
+
 func TestAmbientLightBuilder(t *testing.T) {
 	node := AmbientLight()
 
@@ -246,6 +284,20 @@ func TestDirectionalLightBuilder(t *testing.T) {
 	}
 }
 
+func TestSpotLightBuilder(t *testing.T) {
+	node := SpotLight(Position(0, 4, 0), Direction(0, -1, 0), ConeAngle(0.5), Intensity(2))
+
+	if node.Type != LightNodeType || node.Light.Type != "spot" {
+		t.Fatalf("Expected a spot light node, got type %d light %q", node.Type, node.Light.Type)
+	}
+	if node.Light.Position != (Vec3{0, 4, 0}) || node.Light.Direction != (Vec3{0, -1, 0}) {
+		t.Errorf("Expected position and direction to be set, got %v and %v", node.Light.Position, node.Light.Direction)
+	}
+	if node.Light.ConeAngle != 0.5 || node.Light.Intensity != 2 {
+		t.Errorf("Expected cone angle 0.5 and intensity 2, got %v and %v", node.Light.ConeAngle, node.Light.Intensity)
+	}
+}
+
 func TestComplexSceneGraph(t *testing.T) {
 	// Build a complex scene graph like the cube example
 	scene := SceneNode(
@@ -303,3 +355,75 @@ func TestComplexSceneGraph(t *testing.T) {
 		t.Error("Expected fourth child to be Light")
 	}
 }
+
+func TestMeshBindPropReadsLatestChannelValue(t *testing.T) {
+	angle := make(chan float32, 2)
+	latest := Latest(angle)
+
+	mesh := Mesh(BindProp(func() GPUProp {
+		return Rotation(0, latest.Value(), 0)
+	}))
+
+	bindings, ok := mesh.Properties["bindProps"].([]func() GPUProp)
+	if !ok || len(bindings) != 1 {
+		t.Fatalf("Expected 1 prop binding, got %v", mesh.Properties["bindProps"])
+	}
+
+	// No value received yet: the zero value is applied
+	if mesh.Transform.Rotation.Y != 0 {
+		t.Errorf("Expected initial rotation 0, got %f", mesh.Transform.Rotation.Y)
+	}
+
+	// Only the most recent value is used
+	angle <- 1.5
+	angle <- 2.5
+	applyTransformProp(&mesh.Transform, bindings[0]())
+	if mesh.Transform.Rotation.Y != 2.5 {
+		t.Errorf("Expected rotation 2.5, got %f", mesh.Transform.Rotation.Y)
+	}
+
+	// The value is kept when nothing new arrives
+	applyTransformProp(&mesh.Transform, bindings[0]())
+	if mesh.Transform.Rotation.Y != 2.5 {
+		t.Errorf("Expected rotation to stay 2.5, got %f", mesh.Transform.Rotation.Y)
+	}
+}
+
+func TestDrawOrderSortsTransparentBackToFront(t *testing.T) {
+	names := make(map[*MeshInstance]string)
+	mesh := func(name string, z float32, material *Material) *MeshInstance {
+		m := &MeshInstance{Transform: NewTransform(), Material: material}
+		m.Transform.Position = Vec3{0, 0, z}
+		names[m] = name
+		return m
+	}
+	glass := StandardMaterial(Color(1, 1, 1, 0.5))
+	water := StandardMaterial(Transparent(true))
+	solid := StandardMaterial()
+
+	meshes := []*MeshInstance{
+		mesh("near", 3, glass),
+		mesh("solid", 0, solid),
+		mesh("far", -4, water),
+		mesh("middle", 0, glass),
+		mesh("default", 1, nil),
+	}
+
+	// Camera in front of the meshes, looking down -Z
+	got := drawOrder(meshes, Vec3{0, 0, 5})
+	want := []string{"solid", "default", "far", "middle", "near"}
+	for i, m := range got {
+		if names[m] != want[i] {
+			t.Fatalf("Expected draw order %v, got mesh %q at %d", want, names[m], i)
+		}
+	}
+
+	// Moving the camera behind the meshes reverses the transparent ones
+	got = drawOrder(meshes, Vec3{0, 0, -10})
+	want = []string{"solid", "default", "near", "middle", "far"}
+	for i, m := range got {
+		if names[m] != want[i] {
+			t.Fatalf("Expected draw order %v, got mesh %q at %d", want, names[m], i)
+		}
+	}
+}
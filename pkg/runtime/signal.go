@@ -0,0 +1,73 @@
+//go:build js && wasm
+
+package runtime
+
+import "sync"
+
+// Signal holds a value shared by components. Every component taking a
+// *Signal[T] parameter subscribes to it in BindApp and re-renders when it
+// is set, so state can be shared without wiring a channel to each of them:
+//
+//	cart := runtime.NewSignal(0)
+//	header := NewHeader(WithCount(cart))
+//	list := NewProductList(WithCount(cart))
+//	cart.Set(cart.Get() + 1) // both re-render
+//
+// A Signal is safe for use by multiple goroutines.
+type Signal[T any] struct {
+	mu          sync.Mutex
+	value       T
+	subscribers []*subscriber[T]
+}
+
+// subscriber is a callback registered with Signal.Subscribe
+type subscriber[T any] struct {
+	fn func(T)
+}
+
+// NewSignal creates a signal holding initial
+func NewSignal[T any](initial T) *Signal[T] {
+	return &Signal[T]{value: initial}
+}
+
+// Get returns the current value
+func (s *Signal[T]) Get() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value
+}
+
+// Set stores v and calls the subscribers with it, in the order they
+// subscribed. Setting a value equal to the current one notifies them too.
+func (s *Signal[T]) Set(v T) {
+	s.mu.Lock()
+	s.value = v
+	subscribers := make([]*subscriber[T], len(s.subscribers))
+	copy(subscribers, s.subscribers)
+	s.mu.Unlock()
+
+	// Called without the lock, so subscribers may Get, Set or unsubscribe
+	for _, sub := range subscribers {
+		sub.fn(v)
+	}
+}
+
+// Subscribe calls fn with every value set from now on. It returns a
+// function that unsubscribes fn; calling it more than once has no effect.
+func (s *Signal[T]) Subscribe(fn func(T)) func() {
+	sub := &subscriber[T]{fn: fn}
+	s.mu.Lock()
+	s.subscribers = append(s.subscribers, sub)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, other := range s.subscribers {
+			if other == sub {
+				s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestSignalGetSet(t *testing.T) {
+	s := NewSignal("draft")
+	if got := s.Get(); got != "draft" {
+		t.Fatalf("Expected initial value draft, got %q", got)
+	}
+
+	s.Set("sent")
+	if got := s.Get(); got != "sent" {
+		t.Errorf("Expected sent after Set, got %q", got)
+	}
+}
+
+func TestSignalSubscribers(t *testing.T) {
+	s := NewSignal(0)
+
+	var first, second []int
+	unsubscribe := s.Subscribe(func(v int) { first = append(first, v) })
+	s.Subscribe(func(v int) { second = append(second, v) })
+
+	s.Set(1)
+	unsubscribe()
+	unsubscribe() // no effect the second time
+	s.Set(2)
+
+	if len(first) != 1 || first[0] != 1 {
+		t.Errorf("Expected the unsubscribed callback to see only 1, got %v", first)
+	}
+	if len(second) != 2 || second[0] != 1 || second[1] != 2 {
+		t.Errorf("Expected the second callback to see 1 and 2, got %v", second)
+	}
+}
+
+func TestSignalSubscriberMaySet(t *testing.T) {
+	s := NewSignal(0)
+
+	// A subscriber clamping the value sets it again from within Set
+	s.Subscribe(func(v int) {
+		if v > 10 {
+			s.Set(10)
+		}
+	})
+
+	s.Set(42)
+	if got := s.Get(); got != 10 {
+		t.Errorf("Expected the subscriber to clamp the value to 10, got %d", got)
+	}
+}
@@ -0,0 +1,225 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// SortableOption configures a Sortable
+type SortableOption[T any] func(*sortableConfig[T])
+
+type sortableConfig[T any] struct {
+	items     []T
+	order     chan []T
+	onReorder func([]T)
+}
+
+// SortableItems sets the items of a Sortable in their current order and the
+// channel that receives the new order after each move, so the component
+// re-renders with it. Items are labelled with fmt.Sprint.
+func SortableItems[T any](items []T, order chan []T) SortableOption[T] {
+	return func(cfg *sortableConfig[T]) {
+		cfg.items = items
+		cfg.order = order
+	}
+}
+
+// OnReorder sets a function called with the new order after each move
+func OnReorder[T any](fn func([]T)) SortableOption[T] {
+	return func(cfg *sortableConfig[T]) {
+		cfg.onReorder = fn
+	}
+}
+
+// sortableState is the order and the drag in progress of a Sortable. Items
+// are tracked by key, since their handlers outlive the render they were
+// created in when the keyed diff moves their nodes.
+type sortableState[T any] struct {
+	items    []T
+	keys     []string
+	dragging string // Key of the dragged item, or ""
+	over     string // Key of the item the dragged item would be dropped on
+	mu       sync.Mutex
+}
+
+var (
+	sortableStates   = make(map[interface{}]interface{})
+	sortableStatesMu sync.Mutex
+)
+
+// sortableStateFor returns the state of the Sortable feeding the given order
+// channel. A Sortable without a channel starts fresh every render.
+func sortableStateFor[T any](order chan []T) *sortableState[T] {
+	if order == nil {
+		return &sortableState[T]{}
+	}
+
+	sortableStatesMu.Lock()
+	defer sortableStatesMu.Unlock()
+	state, ok := sortableStates[order].(*sortableState[T])
+	if !ok {
+		state = &sortableState[T]{}
+		sortableStates[order] = state
+	}
+	return state
+}
+
+// indexOf returns the index of the item with the given key, or -1
+func (s *sortableState[T]) indexOf(key string) int {
+	for i, k := range s.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveItem returns a copy of items with the item at from moved to index to,
+// shifting the items in between. Out of range indices return an unchanged
+// copy.
+func moveItem[T any](items []T, from, to int) []T {
+	moved := make([]T, 0, len(items))
+	if from < 0 || from >= len(items) || to < 0 || to >= len(items) {
+		return append(moved, items...)
+	}
+
+	for i, item := range items {
+		if i == from {
+			continue
+		}
+		if len(moved) == to {
+			moved = append(moved, items[from])
+		}
+		moved = append(moved, item)
+	}
+	if len(moved) == to {
+		moved = append(moved, items[from])
+	}
+	return moved
+}
+
+// sortableKeys returns a stable key for each item, numbering repeated labels
+func sortableKeys[T any](items []T) []string {
+	keys := make([]string, len(items))
+	seen := make(map[string]int)
+	for i, item := range items {
+		label := fmt.Sprint(item)
+		seen[label]++
+		keys[i] = label
+		if n := seen[label]; n > 1 {
+			keys[i] = label + "#" + strconv.Itoa(n)
+		}
+	}
+	return keys
+}
+
+// isSortableMoveKey returns true for Alt+ArrowUp and Alt+ArrowDown, which
+// move a Sortable item instead of scrolling the page
+func isSortableMoveKey(e Event) bool {
+	return e.AltKey && (e.Key == "ArrowUp" || e.Key == "ArrowDown")
+}
+
+// Sortable creates a list whose items can be dragged to reorder them:
+//
+//	Sortable(SortableItems(<-order, order), OnReorder(save))
+//
+// Dragging an item with the pointer and releasing it over another item moves
+// it to that item's position; Alt+ArrowUp and Alt+ArrowDown move the focused
+// item by one. The new order is sent to the SortableItems channel and passed
+// to OnReorder. Items are keyed, so their DOM nodes are moved rather than
+// rebuilt when the component re-renders.
+func Sortable[T any](options ...SortableOption[T]) *VNode {
+	cfg := &sortableConfig[T]{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	state := sortableStateFor(cfg.order)
+	keys := sortableKeys(cfg.items)
+	state.mu.Lock()
+	state.items = cfg.items
+	state.keys = keys
+	state.mu.Unlock()
+
+	move := func(from, to int) {
+		state.mu.Lock()
+		if from < 0 || to < 0 || from == to {
+			state.mu.Unlock()
+			return
+		}
+		order := moveItem(state.items, from, to)
+		state.items = order
+		state.keys = sortableKeys(order)
+		state.mu.Unlock()
+
+		if cfg.order != nil {
+			// Event handlers must not block the JS event loop
+			go func() { cfg.order <- order }()
+		}
+		if cfg.onReorder != nil {
+			cfg.onReorder(order)
+		}
+	}
+
+	drop := func(Event) {
+		state.mu.Lock()
+		from, to := state.indexOf(state.dragging), state.indexOf(state.over)
+		state.dragging, state.over = "", ""
+		state.mu.Unlock()
+		move(from, to)
+	}
+	cancel := func(Event) {
+		state.mu.Lock()
+		state.dragging, state.over = "", ""
+		state.mu.Unlock()
+	}
+
+	opts := []interface{}{
+		Class("sortable"),
+		EventHandler{Name: "pointerup", Handler: drop},
+		EventHandler{Name: "pointerleave", Handler: cancel},
+	}
+
+	for i, item := range cfg.items {
+		key := keys[i]
+		opts = append(opts, Li(
+			WithKey(key),
+			TabIndex(0),
+			Attr{Key: "aria-roledescription", Value: "sortable item"},
+			EventHandler{Name: "pointerdown", Handler: func(Event) {
+				state.mu.Lock()
+				state.dragging, state.over = key, key
+				state.mu.Unlock()
+			}},
+			EventHandler{Name: "pointerenter", Handler: func(Event) {
+				state.mu.Lock()
+				if state.dragging != "" {
+					state.over = key
+				}
+				state.mu.Unlock()
+			}},
+			PreventDefaultIf(OnKeyDown(func(e Event) {
+				if !isSortableMoveKey(e) {
+					return
+				}
+				state.mu.Lock()
+				from := state.indexOf(key)
+				to := from - 1
+				if e.Key == "ArrowDown" {
+					to = from + 1
+				}
+				if to >= len(state.keys) {
+					to = -1
+				}
+				state.mu.Unlock()
+				move(from, to)
+			}), isSortableMoveKey),
+			Text(fmt.Sprint(item)),
+		))
+	}
+
+	return Ul(opts...)
+}
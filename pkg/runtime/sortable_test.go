@@ -0,0 +1,122 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMoveItem(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	tests := []struct {
+		from, to int
+		want     []string
+	}{
+		{0, 2, []string{"b", "c", "a", "d"}},
+		{3, 0, []string{"d", "a", "b", "c"}},
+		{1, 3, []string{"a", "c", "d", "b"}},
+		{2, 2, []string{"a", "b", "c", "d"}},
+		{4, 0, []string{"a", "b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		got := moveItem(items, tt.from, tt.to)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("moveItem(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+	if !reflect.DeepEqual(items, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected the input to be unchanged, got %v", items)
+	}
+}
+
+func TestSortableKeys(t *testing.T) {
+	list := Sortable(SortableItems([]string{"a", "b", "a"}, nil))
+	want := []interface{}{"a", "b", "a#2"}
+	for i, item := range list.Children {
+		if item.Key != want[i] {
+			t.Errorf("Item %d: expected key %v, got %v", i, want[i], item.Key)
+		}
+	}
+}
+
+func TestSortableDropReorders(t *testing.T) {
+	order := make(chan []string)
+	var reordered []string
+
+	list := Sortable(
+		SortableItems([]string{"a", "b", "c"}, order),
+		OnReorder(func(items []string) { reordered = items }),
+	)
+
+	// Drag the first item and drop it on the last
+	list.Children[0].Events["pointerdown"].Handler(Event{})
+	list.Children[2].Events["pointerenter"].Handler(Event{})
+	list.Events["pointerup"].Handler(Event{})
+
+	want := []string{"b", "c", "a"}
+	if !reflect.DeepEqual(reordered, want) {
+		t.Errorf("Expected OnReorder with %v, got %v", want, reordered)
+	}
+	select {
+	case got := <-order:
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v on the order channel, got %v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the new order")
+	}
+
+	// Handlers of the first render look items up by key, as the keyed diff
+	// keeps them on the moved nodes: "a" is now last and moves up one
+	list.Children[0].Events["keydown"].Handler(Event{Key: "ArrowUp", AltKey: true})
+	want = []string{"b", "a", "c"}
+	if !reflect.DeepEqual(reordered, want) {
+		t.Errorf("Expected OnReorder with %v, got %v", want, reordered)
+	}
+	<-order
+}
+
+func TestSortableCancelledDrag(t *testing.T) {
+	called := false
+	list := Sortable(
+		SortableItems([]string{"a", "b"}, nil),
+		OnReorder(func([]string) { called = true }),
+	)
+
+	// Leaving the list cancels the drag
+	list.Children[0].Events["pointerdown"].Handler(Event{})
+	list.Children[1].Events["pointerenter"].Handler(Event{})
+	list.Events["pointerleave"].Handler(Event{})
+	list.Events["pointerup"].Handler(Event{})
+
+	// Entering items without a drag doesn't start one
+	list.Children[1].Events["pointerenter"].Handler(Event{})
+	list.Events["pointerup"].Handler(Event{})
+
+	if called {
+		t.Error("Expected no reorder")
+	}
+}
+
+func TestSortableCancelsMoveKeys(t *testing.T) {
+	list := Sortable(SortableItems([]string{"a", "b"}, nil))
+	keydown := list.Children[0].Events["keydown"]
+
+	// Only Alt+Arrow moves an item; a plain arrow still scrolls the page
+	for _, tc := range []struct {
+		event Event
+		want  bool
+	}{
+		{Event{Key: "ArrowUp", AltKey: true}, true},
+		{Event{Key: "ArrowDown", AltKey: true}, true},
+		{Event{Key: "ArrowDown"}, false},
+		{Event{Key: "Home", AltKey: true}, false},
+	} {
+		if got := keydown.PreventDefaultIf(tc.event); got != tc.want {
+			t.Errorf("Key %q with Alt %v: expected cancel %v, got %v", tc.event.Key, tc.event.AltKey, tc.want, got)
+		}
+	}
+}
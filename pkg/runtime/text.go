@@ -0,0 +1,160 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// defaultFont is the font of a 2D canvas context that was not given one
+	defaultFont = "10px sans-serif"
+	// estimatedCharWidth and estimatedLineHeight are the fraction of the font
+	// size an average character and a line take when text can't be measured
+	estimatedCharWidth  = 0.6
+	estimatedLineHeight = 1.2
+)
+
+var (
+	textContextOnce sync.Once
+	// textContext is the 2D context text is measured with, or undefined when
+	// the browser can't create one
+	textContext js.Value
+)
+
+// measureContext returns the 2D context of an offscreen canvas, creating it
+// on first use
+func measureContext() js.Value {
+	textContextOnce.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logError("Text: Failed to create a 2D context:", r)
+				textContext = js.Undefined()
+			}
+		}()
+
+		var canvas js.Value
+		if offscreen := js.Global().Get("OffscreenCanvas"); isElement(offscreen) {
+			canvas = offscreen.New(1, 1)
+		} else if document := js.Global().Get("document"); isElement(document) {
+			canvas = document.Call("createElement", "canvas")
+		} else {
+			textContext = js.Undefined()
+			return
+		}
+		textContext = canvas.Call("getContext", "2d")
+	})
+	return textContext
+}
+
+// MeasureText returns the width and line height in pixels of text drawn in a
+// CSS font, e.g. "11px sans-serif", to lay out labels on a canvas:
+//
+//	w, h := MeasureText("Volume", "12px sans-serif")
+//
+// When the browser has no 2D canvas context the size is estimated from the
+// font size.
+func MeasureText(text, font string) (w, h float64) {
+	if font == "" {
+		font = defaultFont
+	}
+	ctx := measureContext()
+	if !isElement(ctx) {
+		return estimateTextSize(text, font)
+	}
+
+	ctx.Set("font", font)
+	metrics := ctx.Call("measureText", text)
+	w = metrics.Get("width").Float()
+
+	// The font's bounding box is the same for every string, so lines of
+	// different text line up; older browsers only have the text's own box
+	ascent, descent := metrics.Get("fontBoundingBoxAscent"), metrics.Get("fontBoundingBoxDescent")
+	if ascent.Type() != js.TypeNumber || descent.Type() != js.TypeNumber {
+		ascent, descent = metrics.Get("actualBoundingBoxAscent"), metrics.Get("actualBoundingBoxDescent")
+	}
+	if ascent.Type() != js.TypeNumber || descent.Type() != js.TypeNumber {
+		_, h = estimateTextSize(text, font)
+		return w, h
+	}
+	return w, ascent.Float() + descent.Float()
+}
+
+// WrapText breaks text into lines no wider than maxWidth pixels in a CSS
+// font, breaking between words where it can. Newlines in text always start
+// a new line.
+func WrapText(text string, maxWidth float64, font string) []string {
+	return wrapText(text, maxWidth, func(s string) float64 {
+		w, _ := MeasureText(s, font)
+		return w
+	})
+}
+
+// wrapText greedily fills lines with the words of text, splitting words
+// wider than maxWidth between characters
+func wrapText(text string, maxWidth float64, width func(string) float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if width(candidate) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = word
+			for utf8.RuneCountInString(line) > 1 && width(line) > maxWidth {
+				head, tail := splitToWidth(line, maxWidth, width)
+				lines = append(lines, head)
+				line = tail
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitToWidth splits a word of two or more characters after the most
+// characters that fit in maxWidth, keeping at least one on each side
+func splitToWidth(word string, maxWidth float64, width func(string) float64) (string, string) {
+	runes := []rune(word)
+	n := 1
+	for n < len(runes)-1 && width(string(runes[:n+1])) <= maxWidth {
+		n++
+	}
+	return string(runes[:n]), string(runes[n:])
+}
+
+// estimateTextSize approximates the size of text from the font size, for
+// when there is no context to measure it with
+func estimateTextSize(text, font string) (w, h float64) {
+	size := fontSize(font)
+	return float64(utf8.RuneCountInString(text)) * size * estimatedCharWidth, size * estimatedLineHeight
+}
+
+// fontSize returns the pixel size in a CSS font such as "bold 12px serif",
+// or the size of the default font if it has none
+func fontSize(font string) float64 {
+	for _, field := range strings.Fields(font) {
+		// "12px/1.5" sets a line height after the size
+		field, _, _ = strings.Cut(field, "/")
+		if field == "" || !strings.HasSuffix(field, "px") || !unicode.IsDigit(rune(field[0])) {
+			continue
+		}
+		if size, err := strconv.ParseFloat(strings.TrimSuffix(field, "px"), 64); err == nil && size > 0 {
+			return size
+		}
+	}
+	return 10
+}
@@ -0,0 +1,71 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+// charWidth measures text as 10 pixels per character
+func charWidth(s string) float64 {
+	return float64(utf8.RuneCountInString(s) * 10)
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     []string
+	}{
+		{"fits", "Open price", 100, []string{"Open price"}},
+		{"breaks between words", "the quick brown fox", 100, []string{"the quick", "brown fox"}},
+		{"collapses spaces", "a   b", 100, []string{"a b"}},
+		{"keeps newlines", "High\n\nLow", 100, []string{"High", "", "Low"}},
+		{"splits long words", "abcdefghij kl", 40, []string{"abcd", "efgh", "ij", "kl"}},
+		{"keeps a character per line", "abc", 5, []string{"a", "b", "c"}},
+		{"empty", "", 100, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.maxWidth, charWidth); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFontSize(t *testing.T) {
+	tests := map[string]float64{
+		"11px sans-serif":                  11,
+		"bold 12.5px serif":                12.5,
+		"italic 14px/1.5 Helvetica, Arial": 14,
+		"":                                 10,
+		"1em serif":                        10,
+		"small-caps 600 16px 'Fira 20px'":  16,
+	}
+	for font, want := range tests {
+		if got := fontSize(font); got != want {
+			t.Errorf("fontSize(%q): expected %v, got %v", font, want, got)
+		}
+	}
+}
+
+func TestMeasureTextWithoutContext(t *testing.T) {
+	// The test environment has no 2D canvas, so the size is estimated
+	if isElement(measureContext()) {
+		t.Skip("2D canvas context available")
+	}
+
+	w, h := MeasureText("Volume", "20px sans-serif")
+	if w != 6*20*estimatedCharWidth || h != 20*estimatedLineHeight {
+		t.Errorf("Expected estimated size %vx%v, got %vx%v", 6*20*estimatedCharWidth, 20*estimatedLineHeight, w, h)
+	}
+
+	if lines := WrapText("one two three", 100, "20px sans-serif"); len(lines) != 2 {
+		t.Errorf("Expected 2 lines of about 100px, got %q", lines)
+	}
+}
@@ -0,0 +1,212 @@
+//go:build js && wasm
+
+package runtime
+
+import (
+	"strconv"
+	"sync"
+	"syscall/js"
+)
+
+const (
+	// tooltipDelay is how long the pointer or focus must rest on the anchor
+	// before the tooltip shows, in milliseconds
+	tooltipDelay = 400
+
+	// tooltipGap is the distance between the anchor and the tooltip in pixels
+	tooltipGap = 8
+)
+
+// Tooltip placements relative to the anchor
+const (
+	PlacementTop    = "top"
+	PlacementBottom = "bottom"
+	PlacementLeft   = "left"
+	PlacementRight  = "right"
+)
+
+// TooltipPlacement sets the side of the anchor a Tooltip shows on
+type TooltipPlacement struct {
+	Side string
+}
+
+// Placement sets the side of the anchor a Tooltip shows on: "top" (the
+// default), "bottom", "left" or "right"
+func Placement(side string) TooltipPlacement {
+	return TooltipPlacement{Side: side}
+}
+
+// rect is a box in viewport coordinates, as returned by getBoundingClientRect
+type rect struct {
+	X, Y, Width, Height float64
+}
+
+// tooltipPosition returns the viewport coordinates of the top left corner
+// of a tooltip of the given size, centered on the placement side of the
+// anchor. Unknown placements fall back to top.
+func tooltipPosition(placement string, anchor rect, width, height float64) (x, y float64) {
+	switch placement {
+	case PlacementBottom:
+		return anchor.X + (anchor.Width-width)/2, anchor.Y + anchor.Height + tooltipGap
+	case PlacementLeft:
+		return anchor.X - width - tooltipGap, anchor.Y + (anchor.Height-height)/2
+	case PlacementRight:
+		return anchor.X + anchor.Width + tooltipGap, anchor.Y + (anchor.Height-height)/2
+	default:
+		return anchor.X + (anchor.Width-width)/2, anchor.Y - height - tooltipGap
+	}
+}
+
+// boundingRect returns the bounding rect of a DOM element
+func boundingRect(elem js.Value) rect {
+	r := elem.Call("getBoundingClientRect")
+	return rect{
+		X:      r.Get("left").Float(),
+		Y:      r.Get("top").Float(),
+		Width:  r.Get("width").Float(),
+		Height: r.Get("height").Float(),
+	}
+}
+
+var tooltipCount int
+
+// tooltip is the portal element of a Tooltip while it is scheduled or shown
+type tooltip struct {
+	text      string
+	placement string
+
+	mu      sync.Mutex
+	timeout js.Value
+	pending js.Func
+	elem    js.Value
+}
+
+// schedule shows the tooltip for the anchor after tooltipDelay
+func (t *tooltip) schedule(anchor js.Value) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.pending.IsUndefined() || isElement(t.elem) {
+		return
+	}
+
+	var fire js.Func
+	fire = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !t.pending.Equal(fire.Value) {
+			return nil
+		}
+		t.pending = js.Func{}
+		fire.Release()
+		t.show(anchor)
+		return nil
+	})
+	t.pending = fire
+	t.timeout = js.Global().Call("setTimeout", fire, tooltipDelay)
+}
+
+// show appends the tooltip to the document body, outside the component
+// tree so overflow and stacking of the anchor's ancestors don't clip it,
+// and positions it next to the anchor
+func (t *tooltip) show(anchor js.Value) {
+	doc := js.Global().Get("document")
+	body := doc.Get("body")
+	if body.IsUndefined() || body.IsNull() {
+		return
+	}
+
+	tooltipCount++
+	id := "tooltip-" + strconv.Itoa(tooltipCount)
+
+	elem := doc.Call("createElement", "div")
+	elem.Set("id", id)
+	elem.Set("className", "tooltip")
+	elem.Call("setAttribute", "role", "tooltip")
+	elem.Set("textContent", t.text)
+	// Measure hidden first, then place
+	elem.Call("setAttribute", "style", "position: fixed; left: 0; top: 0; visibility: hidden; pointer-events: none; z-index: 1000")
+	body.Call("appendChild", elem)
+
+	size := boundingRect(elem)
+	x, y := tooltipPosition(t.placement, boundingRect(anchor), size.Width, size.Height)
+	elem.Call("setAttribute", "style", "position: fixed; left: "+strconv.FormatFloat(x, 'f', -1, 64)+
+		"px; top: "+strconv.FormatFloat(y, 'f', -1, 64)+"px; pointer-events: none; z-index: 1000")
+
+	anchor.Call("setAttribute", "aria-describedby", id)
+	t.elem = elem
+}
+
+// hide cancels a scheduled tooltip and removes a shown one
+func (t *tooltip) hide(anchor js.Value) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.pending.IsUndefined() {
+		js.Global().Call("clearTimeout", t.timeout)
+		t.pending.Release()
+		t.pending = js.Func{}
+	}
+	if isElement(t.elem) {
+		t.elem.Call("remove")
+		t.elem = js.Undefined()
+		anchor.Call("removeAttribute", "aria-describedby")
+	}
+}
+
+// Tooltip wraps an anchor and shows a text next to it while the pointer
+// rests on it or it holds the focus:
+//
+//	Tooltip(Text("Copy to clipboard"), Placement("top"), Button(...))
+//
+// The first Text is the tooltip; the other children form the anchor. The
+// tooltip shows after a short delay, is rendered into the document body and
+// is positioned from the anchor's bounding rect. The anchor references it
+// with aria-describedby while it is shown.
+func Tooltip(options ...interface{}) *VNode {
+	t := &tooltip{placement: PlacementTop}
+	hasText := false
+	opts := []interface{}{Class("tooltip-anchor")}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case TooltipPlacement:
+			t.placement = o.Side
+		case *VNode:
+			if !hasText && o != nil && o.Type == TextNode {
+				t.text = o.Text
+				hasText = true
+			} else {
+				opts = append(opts, o)
+			}
+		default:
+			opts = append(opts, o)
+		}
+	}
+
+	// Handlers run after the event is dispatched, so the anchor is taken
+	// from the mounted node rather than the event's currentTarget
+	var anchor *VNode
+	show := func(Event) {
+		if isElement(anchor.DOMNode) {
+			t.schedule(anchor.DOMNode)
+		}
+	}
+	hide := func(Event) {
+		if isElement(anchor.DOMNode) {
+			t.hide(anchor.DOMNode)
+		}
+	}
+	opts = append(opts,
+		EventHandler{Name: "mouseenter", Handler: show},
+		EventHandler{Name: "mouseleave", Handler: hide},
+		EventHandler{Name: "focusin", Handler: show},
+		EventHandler{Name: "focusout", Handler: hide},
+	)
+
+	anchor = Span(opts...)
+	return anchor
+}
+
+// isElement checks if a value is a mounted DOM node
+func isElement(v js.Value) bool {
+	return !v.IsUndefined() && !v.IsNull()
+}
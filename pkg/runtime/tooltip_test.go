@@ -0,0 +1,48 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestTooltipPosition(t *testing.T) {
+	// A 100x40 anchor at (200, 300) and an 80x20 tooltip
+	anchor := rect{X: 200, Y: 300, Width: 100, Height: 40}
+
+	tests := []struct {
+		placement string
+		x, y      float64
+	}{
+		{PlacementTop, 210, 300 - 20 - tooltipGap},
+		{PlacementBottom, 210, 340 + tooltipGap},
+		{PlacementLeft, 200 - 80 - tooltipGap, 310},
+		{PlacementRight, 300 + tooltipGap, 310},
+		// Unknown placements fall back to top
+		{"diagonal", 210, 300 - 20 - tooltipGap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.placement, func(t *testing.T) {
+			x, y := tooltipPosition(tt.placement, anchor, 80, 20)
+			if x != tt.x || y != tt.y {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.x, tt.y, x, y)
+			}
+		})
+	}
+}
+
+func TestTooltipStructure(t *testing.T) {
+	node := Tooltip(Text("Copy"), Placement(PlacementBottom), Button(Text("Copy")))
+
+	if node.Tag != "span" || node.Attributes["class"] != "tooltip-anchor" {
+		t.Fatalf("Expected span.tooltip-anchor, got %s.%s", node.Tag, node.Attributes["class"])
+	}
+	// The tooltip text is not part of the anchor
+	if len(node.Children) != 1 || node.Children[0].Tag != "button" {
+		t.Fatalf("Expected the button as the only child, got %d children", len(node.Children))
+	}
+	for _, name := range []string{"mouseenter", "mouseleave", "focusin", "focusout"} {
+		if _, ok := node.Events[name]; !ok {
+			t.Errorf("Expected a %s handler on the anchor", name)
+		}
+	}
+}
@@ -2,7 +2,10 @@
 package runtime
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall/js"
 )
 
@@ -18,6 +21,8 @@ const (
 	ComponentNode
 	// FragmentNode represents a fragment (multiple children without wrapper)
 	FragmentNode
+	// PortalNode renders its children into another element (see Portal)
+	PortalNode
 )
 
 // VNode represents a virtual DOM node
@@ -25,6 +30,7 @@ type VNode struct {
 	Type       VNodeType
 	Tag        string                 // For ElementNode (div, button, etc.)
 	Text       string                 // For TextNode
+	Target     string                 // For PortalNode: selector of the element its children mount into
 	Key        interface{}            // For keyed reconciliation
 	Attributes map[string]string      // HTML attributes
 	Properties map[string]interface{} // DOM properties
@@ -32,26 +38,56 @@ type VNode struct {
 	Children   []*VNode
 	DOMNode    js.Value // Reference to actual DOM node after mount
 	Component  Component
+
+	OnMount         MountHook   // Called once the element's DOM node is inserted
+	OnBeforeUnmount UnmountHook // Called before the element's DOM node is removed
 }
 
-// EventHandler wraps a Go function for DOM event handling
+// EventHandler wraps a Go function for DOM event handling. Listeners are
+// active by default, so PreventDefault also works for wheel and touch
+// events, which browsers may otherwise register as passive.
 type EventHandler struct {
-	Name    string
-	Handler func(Event)
-	jsFunc  js.Func // Stored for cleanup
+	Name             string
+	Handler          func(Event)
+	PreventDefault   bool             // Cancel the browser's default action before Handler runs
+	PreventDefaultIf func(Event) bool // Cancel the default action before Handler runs when it returns true
+	StopPropagation  bool             // Stop the event reaching ancestors before Handler runs
+	Passive          bool             // Register a passive listener, which can't cancel the event; ignored with PreventDefault or PreventDefaultIf
+	jsFunc           js.Func          // Stored for cleanup
 }
 
 // Event wraps JavaScript event objects
 type Event struct {
-	Native   js.Value
-	Target   EventTarget
-	Type     string
-	Key      string // For keyboard events: the key value
-	Code     string // For keyboard events: the physical key code
-	CtrlKey  bool   // For keyboard events: ctrl key pressed
-	ShiftKey bool   // For keyboard events: shift key pressed
-	AltKey   bool   // For keyboard events: alt key pressed
-	MetaKey  bool   // For keyboard events: meta/command key pressed
+	Native    js.Value
+	Target    EventTarget
+	Type      string
+	Key       string  // For keyboard events: the key value
+	Code      string  // For keyboard events: the physical key code
+	CtrlKey   bool    // For keyboard events: ctrl key pressed
+	ShiftKey  bool    // For keyboard events: shift key pressed
+	AltKey    bool    // For keyboard events: alt key pressed
+	MetaKey   bool    // For keyboard events: meta/command key pressed
+	DeltaX    float64 // For wheel events: horizontal scroll amount
+	DeltaY    float64 // For wheel events: vertical scroll amount
+	DeltaMode int     // For wheel events: unit of the deltas (0 pixels, 1 lines, 2 pages)
+}
+
+// PreventDefault cancels the browser's default action for the event.
+// Handlers run in a goroutine once the event is dispatched, so the action
+// may already have happened; wrap the handler with PreventDefault or
+// PreventDefaultIf to cancel it reliably.
+func (e Event) PreventDefault() {
+	if !e.Native.IsUndefined() && !e.Native.IsNull() {
+		e.Native.Call("preventDefault")
+	}
+}
+
+// StopPropagation stops the event reaching ancestors of its target. Like
+// PreventDefault, it is only reliable through the StopPropagation wrapper.
+func (e Event) StopPropagation() {
+	if !e.Native.IsUndefined() && !e.Native.IsNull() {
+		e.Native.Call("stopPropagation")
+	}
 }
 
 // EventTarget represents an event target
@@ -84,7 +120,7 @@ func El(tag string, options ...interface{}) *VNode {
 	for _, opt := range options {
 		switch o := opt.(type) {
 		case *VNode:
-			node.Children = append(node.Children, o)
+			node.Children = appendChild(node.Children, o)
 		case Attr:
 			node.Attributes[o.Key] = o.Value
 		case Prop:
@@ -92,17 +128,39 @@ func El(tag string, options ...interface{}) *VNode {
 		case EventHandler:
 			node.Events[o.Name] = o
 		case Class:
-			node.Attributes["class"] = string(o)
+			appendClass(node, string(o))
+		case ClassToggle:
+			if o.On {
+				appendClass(node, o.Name)
+			}
 		case Style:
 			node.Attributes["style"] = string(o)
+		case CSS:
+			node.Attributes["style"] = o.String()
 		case Key:
 			node.Key = o.Value
+		case MountHook:
+			node.OnMount = o
+		case UnmountHook:
+			node.OnBeforeUnmount = o
 		}
 	}
 
 	return node
 }
 
+// appendClass adds a class name to the node's class list
+func appendClass(node *VNode, name string) {
+	if name == "" {
+		return
+	}
+	if existing := node.Attributes["class"]; existing != "" {
+		node.Attributes["class"] = existing + " " + name
+		return
+	}
+	node.Attributes["class"] = name
+}
+
 // Text creates a text VNode
 func Text(content string) *VNode {
 	return &VNode{
@@ -111,12 +169,44 @@ func Text(content string) *VNode {
 	}
 }
 
-// Fragment creates a fragment node
+// Fragment creates a fragment node. Nested fragments are flattened into
+// its children.
 func Fragment(children ...*VNode) *VNode {
+	flat := make([]*VNode, 0, len(children))
+	for _, child := range children {
+		flat = appendChild(flat, child)
+	}
 	return &VNode{
 		Type:     FragmentNode,
-		Children: children,
+		Children: flat,
+	}
+}
+
+// KeyedFragment creates a fragment with a reconciliation key, e.g. a table
+// row followed by its detail row in a list:
+//
+//	KeyedFragment(row.ID, Tr(...), Tr(Class("detail"), ...))
+//
+// Unlike other fragments it is kept as one child of its parent, so a keyed
+// diff keeps, moves or removes its children as a group.
+func KeyedFragment(key interface{}, children ...*VNode) *VNode {
+	fragment := Fragment(children...)
+	fragment.Key = key
+	return fragment
+}
+
+// appendChild appends a child node, splicing in the children of a non-empty
+// fragment so the diff and DOM layers see a flat child list. Empty fragments
+// are kept: they mount as a placeholder comment holding their position.
+// Keyed fragments are kept too, to be matched by their key.
+func appendChild(children []*VNode, child *VNode) []*VNode {
+	if child == nil || child.Type != FragmentNode || child.Key != nil || len(child.Children) == 0 {
+		return append(children, child)
+	}
+	for _, grandchild := range child.Children {
+		children = appendChild(children, grandchild)
 	}
+	return children
 }
 
 // Attribute types for builder pattern
@@ -136,9 +226,86 @@ type Prop struct {
 // Class represents a class attribute
 type Class string
 
+// ClassToggle represents a class name that is applied only when On is true
+type ClassToggle struct {
+	Name string
+	On   bool
+}
+
+// ToggleClass adds the named class when on is true, e.g. bound to the
+// current value of a boolean channel
+func ToggleClass(name string, on bool) ClassToggle {
+	return ClassToggle{Name: name, On: on}
+}
+
+// ClassCond is a class name applied only when When is true, passed to Classes
+type ClassCond struct {
+	Name string
+	When bool
+}
+
+// ClassIf returns a class name for Classes that is applied only when
+// when is true
+func ClassIf(name string, when bool) ClassCond {
+	return ClassCond{Name: name, When: when}
+}
+
+// Classes joins class names into a single class list, skipping empty names
+// and conditions that don't hold:
+//
+//	Div(Classes("card", ClassIf("active", isActive), ClassIf("disabled", !enabled)))
+//
+// Each entry is a string, a ClassCond from ClassIf or a ClassToggle. Like
+// Class, the result is added to the classes the node already has.
+func Classes(classes ...interface{}) Class {
+	var names []string
+	for _, c := range classes {
+		switch c := c.(type) {
+		case string:
+			names = append(names, c)
+		case Class:
+			names = append(names, string(c))
+		case ClassCond:
+			if c.When {
+				names = append(names, c.Name)
+			}
+		case ClassToggle:
+			if c.On {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	return Class(strings.Join(strings.Fields(strings.Join(names, " ")), " "))
+}
+
 // Style represents a style attribute
 type Style string
 
+// CSS is an inline style built from property names and values, passed to
+// an element in place of a Style string:
+//
+//	Div(CSS{"color": "red", "margin-top": "4px"})
+//
+// Values are strings, so lengths carry their unit. Maps can be built up
+// and merged before rendering, unlike a style string.
+type CSS map[string]string
+
+// String returns the declarations as a style attribute, sorted by property
+// name so the same map always renders the same attribute
+func (c CSS) String() string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	declarations := make([]string, len(names))
+	for i, name := range names {
+		declarations[i] = name + ": " + c[name]
+	}
+	return strings.Join(declarations, "; ")
+}
+
 // Key represents a keyed node for reconciliation
 type Key struct {
 	Value interface{}
@@ -186,11 +353,62 @@ func A(options ...interface{}) *VNode {
 	return El("a", options...)
 }
 
+// Ul creates a ul element
+func Ul(options ...interface{}) *VNode {
+	return El("ul", options...)
+}
+
+// Li creates an li element
+func Li(options ...interface{}) *VNode {
+	return El("li", options...)
+}
+
 // Img creates an img element
 func Img(options ...interface{}) *VNode {
 	return El("img", options...)
 }
 
+// Form creates a form element
+func Form(options ...interface{}) *VNode {
+	return El("form", options...)
+}
+
+// Select creates a select element
+func Select(options ...interface{}) *VNode {
+	return El("select", options...)
+}
+
+// Option creates an option element
+func Option(options ...interface{}) *VNode {
+	return El("option", options...)
+}
+
+// Output creates an output element
+func Output(options ...interface{}) *VNode {
+	return El("output", options...)
+}
+
+// Progress creates a progress element
+func Progress(options ...interface{}) *VNode {
+	return El("progress", options...)
+}
+
+// Meter creates a meter element
+func Meter(options ...interface{}) *VNode {
+	return El("meter", options...)
+}
+
+// ErrorText creates an alert element displaying an error message
+// The element uses role="alert" so screen readers announce it
+func ErrorText(message string, options ...interface{}) *VNode {
+	opts := append([]interface{}{
+		Attr{Key: "role", Value: "alert"},
+		Class("error-text"),
+	}, options...)
+	opts = append(opts, Text(message))
+	return El("div", opts...)
+}
+
 // Canvas creates a canvas element (for WebGPU)
 func Canvas(options ...interface{}) *VNode {
 	return El("canvas", options...)
@@ -218,6 +436,16 @@ func Src(value string) Attr {
 	return Attr{Key: "src", Value: value}
 }
 
+// Alt sets the alt attribute (alternative text of an image)
+func Alt(value string) Attr {
+	return Attr{Key: "alt", Value: value}
+}
+
+// Title sets the title attribute (also the header label of an accordion Panel)
+func Title(value string) Attr {
+	return Attr{Key: "title", Value: value}
+}
+
 // Type sets the type attribute
 func Type(value string) Attr {
 	return Attr{Key: "type", Value: value}
@@ -228,26 +456,50 @@ func Placeholder(value string) Attr {
 	return Attr{Key: "placeholder", Value: value}
 }
 
-// Min sets the min attribute (for input elements)
-func Min(value string) Attr {
-	return Attr{Key: "min", Value: value}
+// Min sets the min attribute (for input and meter elements)
+// Accepts a string or a number
+func Min(value interface{}) Attr {
+	return Attr{Key: "min", Value: fmt.Sprint(value)}
 }
 
-// Max sets the max attribute (for input elements)
-func Max(value string) Attr {
-	return Attr{Key: "max", Value: value}
+// Max sets the max attribute (for input, progress and meter elements)
+// Accepts a string or a number
+func Max(value interface{}) Attr {
+	return Attr{Key: "max", Value: fmt.Sprint(value)}
 }
 
 // Step sets the step attribute (for input elements)
-func Step(value string) Attr {
-	return Attr{Key: "step", Value: value}
+// Accepts a string or a number
+func Step(value interface{}) Attr {
+	return Attr{Key: "step", Value: fmt.Sprint(value)}
 }
 
 // Value sets the value property
-func Value(value string) Prop {
+// Accepts a string for inputs or a number for progress and meter elements
+func Value(value interface{}) Prop {
 	return Prop{Key: "value", Value: value}
 }
 
+// Low sets the low attribute (for meter elements)
+func Low(value interface{}) Attr {
+	return Attr{Key: "low", Value: fmt.Sprint(value)}
+}
+
+// High sets the high attribute (for meter elements)
+func High(value interface{}) Attr {
+	return Attr{Key: "high", Value: fmt.Sprint(value)}
+}
+
+// Optimum sets the optimum attribute (for meter elements)
+func Optimum(value interface{}) Attr {
+	return Attr{Key: "optimum", Value: fmt.Sprint(value)}
+}
+
+// Checked sets the checked property (for checkbox and radio inputs)
+func Checked(value bool) Prop {
+	return Prop{Key: "checked", Value: value}
+}
+
 // Disabled sets the disabled property
 func Disabled(value bool) Prop {
 	return Prop{Key: "disabled", Value: value}
@@ -258,6 +510,16 @@ func TabIndex(value int) Attr {
 	return Attr{Key: "tabindex", Value: strconv.Itoa(value)}
 }
 
+// Role sets the ARIA role attribute
+func Role(value string) Attr {
+	return Attr{Key: "role", Value: value}
+}
+
+// Name sets the name attribute (also the key of a field in FormValues)
+func Name(value string) Attr {
+	return Attr{Key: "name", Value: value}
+}
+
 // GPUScene creates a special VNode wrapper for WebGPU Scene components
 // This allows Scene components to be used as children of Canvas elements
 func GPUScene(scene Scene) *VNode {
@@ -332,6 +594,21 @@ func OnSubmit(handler func(Event)) EventHandler {
 	}
 }
 
+// FormValues holds the values of a form's bound fields keyed by field name
+type FormValues map[string]interface{}
+
+// OnFormSubmit creates a submit handler that prevents the browser's default
+// navigation and passes the values returned by collect to handler
+func OnFormSubmit(collect func() FormValues, handler func(FormValues)) EventHandler {
+	return EventHandler{
+		Name: "submit",
+		Handler: func(e Event) {
+			handler(collect())
+		},
+		PreventDefault: true,
+	}
+}
+
 // OnMouseOver creates a mouseover event handler
 func OnMouseOver(handler func(Event)) EventHandler {
 	return EventHandler{
@@ -348,6 +625,65 @@ func OnMouseOut(handler func(Event)) EventHandler {
 	}
 }
 
+// OnWheel creates a wheel event handler
+func OnWheel(handler func(Event)) EventHandler {
+	return EventHandler{
+		Name:    "wheel",
+		Handler: handler,
+	}
+}
+
+// OnScroll creates a scroll event handler
+func OnScroll(handler func(Event)) EventHandler {
+	return EventHandler{
+		Name:    "scroll",
+		Handler: handler,
+	}
+}
+
+// OnContextMenu creates a contextmenu event handler
+func OnContextMenu(handler func(Event)) EventHandler {
+	return EventHandler{
+		Name:    "contextmenu",
+		Handler: handler,
+	}
+}
+
+// PreventDefault makes a handler cancel the browser's default action
+// before it runs, e.g. a wheel zoom that mustn't scroll the page:
+//
+//	Canvas(PreventDefault(OnWheel(zoom)))
+func PreventDefault(handler EventHandler) EventHandler {
+	handler.PreventDefault = true
+	return handler
+}
+
+// PreventDefaultIf makes a handler cancel the browser's default action
+// before it runs for the events cancel accepts, e.g. keys that move focus
+// instead of scrolling the page. cancel runs while the event is dispatched
+// and must not block.
+//
+//	Ul(PreventDefaultIf(OnKeyDown(move), func(e Event) bool { return e.Key == "ArrowDown" }))
+func PreventDefaultIf(handler EventHandler, cancel func(Event) bool) EventHandler {
+	handler.PreventDefaultIf = cancel
+	return handler
+}
+
+// StopPropagation makes a handler stop the event reaching ancestors of its
+// element before it runs, e.g. a button inside a clickable card
+func StopPropagation(handler EventHandler) EventHandler {
+	handler.StopPropagation = true
+	return handler
+}
+
+// Passive registers a handler as a passive listener, so the browser can
+// scroll without waiting for it, e.g. for touchmove tracking. A passive
+// handler can't prevent the default action.
+func Passive(handler EventHandler) EventHandler {
+	handler.Passive = true
+	return handler
+}
+
 // WithKey sets a key for reconciliation
 func WithKey(key interface{}) Key {
 	return Key{Value: key}
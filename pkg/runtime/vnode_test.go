@@ -0,0 +1,65 @@
+//go:build js && wasm
+
+package runtime
+
+import "testing"
+
+func TestClassesJoin(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []interface{}
+		want    Class
+	}{
+		{"plain names", []interface{}{"card", "wide"}, "card wide"},
+		{"true condition", []interface{}{ClassIf("active", true), "card"}, "active card"},
+		{"false condition skipped", []interface{}{"card", ClassIf("active", false), "wide"}, "card wide"},
+		{"all false", []interface{}{ClassIf("active", false), ClassIf("open", false)}, ""},
+		{"empty names skipped", []interface{}{"", "card", ClassIf("", true), " "}, "card"},
+		{"class and toggle", []interface{}{Class("card"), ToggleClass("open", true), ToggleClass("shut", false)}, "card open"},
+		{"no classes", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classes(tt.classes...); got != tt.want {
+				t.Errorf("Classes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassesOnElement(t *testing.T) {
+	node := Div(Class("panel"), Classes("card", ClassIf("active", true), ClassIf("hidden", false)))
+	if got := node.Attributes["class"]; got != "panel card active" {
+		t.Errorf("Expected class %q, got %q", "panel card active", got)
+	}
+
+	// No class attribute when every condition is false
+	node = Div(Classes(ClassIf("active", false)))
+	if _, ok := node.Attributes["class"]; ok {
+		t.Errorf("Expected no class attribute, got %q", node.Attributes["class"])
+	}
+}
+
+func TestCSSString(t *testing.T) {
+	css := CSS{"margin-top": "4px", "color": "red", "background": "white"}
+
+	// Map iteration order is random, so render many times
+	want := "background: white; color: red; margin-top: 4px"
+	for i := 0; i < 20; i++ {
+		if got := css.String(); got != want {
+			t.Fatalf("CSS.String() = %q, want %q", got, want)
+		}
+	}
+
+	if got := (CSS{}).String(); got != "" {
+		t.Errorf("Expected empty style, got %q", got)
+	}
+}
+
+func TestCSSOnElement(t *testing.T) {
+	node := Div(CSS{"color": "red", "display": "flex"})
+	if got := node.Attributes["style"]; got != "color: red; display: flex" {
+		t.Errorf("Expected style %q, got %q", "color: red; display: flex", got)
+	}
+}
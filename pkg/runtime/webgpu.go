@@ -4,6 +4,7 @@ package runtime
 
 import (
 	"fmt"
+	"sync"
 	"syscall/js"
 )
 
@@ -13,6 +14,8 @@ type GPUContext struct {
 	Adapter js.Value // GPUAdapter
 	Device  js.Value // GPUDevice
 	Queue   js.Value // GPUQueue
+
+	mipmapPipelines map[string]*RenderPipeline // GenerateMipmaps pipelines by texture format
 }
 
 // WebGPUFeatures represents optional WebGPU features
@@ -57,8 +60,11 @@ type GPULimits struct {
 }
 
 var (
-	// GlobalGPUContext is the shared GPU context for the application
+	// GlobalGPUContext is the GPU context shared by every canvas on the page
 	GlobalGPUContext *GPUContext
+	// gpuContextMu serializes creating GlobalGPUContext, so canvases mounted
+	// at the same time wait for one device instead of each requesting one
+	gpuContextMu sync.Mutex
 )
 
 // IsWebGPUSupported checks if WebGPU is available in the browser
@@ -71,9 +77,23 @@ func IsWebGPUSupported() bool {
 	return gpu.Truthy() && !gpu.IsUndefined() && !gpu.IsNull()
 }
 
-// InitWebGPU initializes the WebGPU context
-// This must be called before any GPU operations
+// InitWebGPU initializes a new WebGPU context and makes it the global one.
+// Canvases share GlobalGPUContext through GetOrInitGPUContext; resources
+// created on one device can't be used with another.
 func InitWebGPU() (*GPUContext, error) {
+	gpuContextMu.Lock()
+	defer gpuContextMu.Unlock()
+
+	ctx, err := initWebGPU()
+	if err != nil {
+		return nil, err
+	}
+	GlobalGPUContext = ctx
+	return ctx, nil
+}
+
+// initWebGPU requests an adapter and device for a new context
+func initWebGPU() (*GPUContext, error) {
 	log("[WebGPU] Starting initialization")
 
 	if !IsWebGPUSupported() {
@@ -145,19 +165,52 @@ func InitWebGPU() (*GPUContext, error) {
 		return nil
 	}))
 
-	// Store global context
-	GlobalGPUContext = ctx
 	log("[WebGPU] Initialization complete")
 
 	return ctx, nil
 }
 
-// GetOrInitGPUContext returns the global GPU context or initializes it if needed
+// GetOrInitGPUContext returns the global GPU context or initializes it if
+// needed. Every canvas, scene and chart on the page shares it, including
+// those of separate apps; concurrent callers wait for the first to finish
+// initializing.
 func GetOrInitGPUContext() (*GPUContext, error) {
+	gpuContextMu.Lock()
+	defer gpuContextMu.Unlock()
+
 	if GlobalGPUContext != nil {
 		return GlobalGPUContext, nil
 	}
-	return InitWebGPU()
+	ctx, err := initWebGPU()
+	if err != nil {
+		return nil, err
+	}
+	GlobalGPUContext = ctx
+	return ctx, nil
+}
+
+// WithErrorScope runs fn in a GPU validation error scope and returns the
+// validation error the device reports for the commands fn issued. WebGPU
+// returns invalid objects, like a pipeline with a bad shader, without an
+// error and reports it later, so this waits for the scope to be popped;
+// call it from a goroutine, not a JS callback. An error returned by fn
+// takes precedence.
+func (ctx *GPUContext) WithErrorScope(fn func() error) error {
+	ctx.Device.Call("pushErrorScope", "validation")
+	fnErr := fn()
+
+	// Pop the scope even when fn failed, so scopes stay balanced
+	gpuErr, err := awaitPromise(ctx.Device.Call("popErrorScope"))
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to pop error scope: %w", err)
+	}
+	if gpuErr.Truthy() {
+		return fmt.Errorf("GPU validation error: %s", gpuErr.Get("message").String())
+	}
+	return nil
 }
 
 // awaitPromise waits for a JavaScript Promise to resolve and returns the result
@@ -187,6 +240,10 @@ func awaitPromise(promise js.Value) (js.Value, error) {
 		var err error
 		if len(args) > 0 {
 			errMsg := args[0].String()
+			// Rejections with an Error, e.g. a TypeError of fetch, carry a message
+			if args[0].Type() == js.TypeObject && args[0].Get("message").Type() == js.TypeString {
+				errMsg = args[0].Get("message").String()
+			}
 			err = fmt.Errorf("%s", errMsg)
 		} else {
 			err = fmt.Errorf("promise rejected with no reason")
@@ -280,8 +337,10 @@ func (ctx *GPUContext) CreateBuffer(size int, usage int, label string) (js.Value
 	return buffer, nil
 }
 
-// CreateTexture creates a GPU texture with the specified parameters
-func (ctx *GPUContext) CreateTexture(width, height int, format string, usage int, label string) (js.Value, error) {
+// CreateTexture creates a GPU texture with the specified parameters.
+// mipLevelCount is the number of mip levels, 1 for none; MipLevelCount
+// returns the full chain for a size.
+func (ctx *GPUContext) CreateTexture(width, height int, format string, usage int, mipLevelCount int, label string) (js.Value, error) {
 	if ctx.Device.IsUndefined() {
 		return js.Undefined(), fmt.Errorf("GPU device not initialized")
 	}
@@ -292,8 +351,9 @@ func (ctx *GPUContext) CreateTexture(width, height int, format string, usage int
 			"height":             height,
 			"depthOrArrayLayers": 1,
 		},
-		"format": format,
-		"usage":  usage,
+		"format":        format,
+		"usage":         usage,
+		"mipLevelCount": max(mipLevelCount, 1),
 	}
 	if label != "" {
 		descriptor["label"] = label
@@ -381,10 +441,16 @@ func (ctx *GPUContext) CreateCommandEncoder(label string) (js.Value, error) {
 	return encoder, nil
 }
 
-// Destroy releases GPU resources (call on cleanup)
+// Destroy releases GPU resources (call on cleanup). Destroying the global
+// context loses the device of every canvas using it.
 func (ctx *GPUContext) Destroy() {
 	if ctx.Device.Truthy() {
 		ctx.Device.Call("destroy")
 	}
-	GlobalGPUContext = nil
+
+	gpuContextMu.Lock()
+	defer gpuContextMu.Unlock()
+	if GlobalGPUContext == ctx {
+		GlobalGPUContext = nil
+	}
 }
@@ -0,0 +1,57 @@
+package visitors
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gaarutyunov/guix/pkg/parser"
+)
+
+// Severity is the severity of a Diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is an error or warning reported by the semantic analyzer
+type Diagnostic struct {
+	Severity Severity
+	Position string // line:column in the source
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Position, d.Severity, d.Message)
+}
+
+// Analyze parses a Guix source and runs the semantic analyzer on it without
+// generating code. It returns the DebugPrinter dump of the AST and the
+// analyzer's errors followed by its warnings. A syntax error is returned as
+// err, the *parser.ParseError, with no dump or diagnostics.
+func Analyze(r io.Reader) (string, []Diagnostic, error) {
+	p, err := parser.New()
+	if err != nil {
+		return "", nil, err
+	}
+	file, err := p.Parse(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	file.Accept(analyzer)
+
+	var diags []Diagnostic
+	for _, e := range analyzer.Errors {
+		diags = append(diags, Diagnostic{Severity: SeverityError, Position: e.Position, Message: e.Message})
+	}
+	for _, w := range analyzer.Warnings {
+		diags = append(diags, Diagnostic{Severity: SeverityWarning, Position: w.Position, Message: w.Message})
+	}
+
+	printer := NewDebugPrinter()
+	file.Accept(printer)
+	return printer.String(), diags, nil
+}
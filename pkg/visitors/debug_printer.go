@@ -76,10 +76,10 @@ func (d *DebugPrinter) VisitFile(node *ast.File) interface{} {
 
 // VisitImport prints an import node
 func (d *DebugPrinter) VisitImport(node *ast.Import) interface{} {
-	if len(node.Paths) == 1 {
-		d.print("Import: %s", node.Paths[0])
+	if node.Alias != "" {
+		d.print("Import: %s %s", node.Alias, node.Path)
 	} else {
-		d.print("Import: %v", node.Paths)
+		d.print("Import: %s", node.Path)
 	}
 	return nil
 }
@@ -108,7 +108,7 @@ func (d *DebugPrinter) VisitStructType(node *ast.StructType) interface{} {
 
 // VisitStructField prints a struct field
 func (d *DebugPrinter) VisitStructField(node *ast.StructField) interface{} {
-	d.print("Field: %s %s", node.Name, d.typeString(node.Type))
+	d.print("Field: %s %s", strings.Join(node.Names(), ", "), d.typeString(node.Type))
 	return nil
 }
 
@@ -141,6 +141,15 @@ func (d *DebugPrinter) VisitComponent(node *ast.Component) interface{} {
 
 // VisitBody prints a component body
 func (d *DebugPrinter) VisitBody(node *ast.Body) interface{} {
+	if len(node.ConstDecls) > 0 {
+		d.print("Constants:")
+		d.indent++
+		for _, constDecl := range node.ConstDecls {
+			constDecl.Accept(d)
+		}
+		d.indent--
+	}
+
 	if len(node.VarDecls) > 0 {
 		d.print("Variables:")
 		d.indent++
@@ -171,6 +180,19 @@ func (d *DebugPrinter) VisitBody(node *ast.Body) interface{} {
 	return nil
 }
 
+// VisitConstDecl prints a constant declaration
+func (d *DebugPrinter) VisitConstDecl(node *ast.ConstDecl) interface{} {
+	for _, spec := range node.Specs {
+		d.print("ConstDecl: %s = ...", spec.Name)
+		d.indent++
+		if spec.Value != nil {
+			spec.Value.Accept(d)
+		}
+		d.indent--
+	}
+	return nil
+}
+
 // VisitVarDecl prints a variable declaration
 func (d *DebugPrinter) VisitVarDecl(node *ast.VarDecl) interface{} {
 	d.print("VarDecl: %s := ...", strings.Join(node.Names, ", "))
@@ -208,6 +230,12 @@ func (d *DebugPrinter) VisitReturn(node *ast.Return) interface{} {
 	return nil
 }
 
+// VisitBranchStmt prints a break or continue statement
+func (d *DebugPrinter) VisitBranchStmt(node *ast.BranchStmt) interface{} {
+	d.print("Branch: %s", node.Tok)
+	return nil
+}
+
 // VisitIfStmt prints an if statement
 func (d *DebugPrinter) VisitIfStmt(node *ast.IfStmt) interface{} {
 	d.print("If:")
@@ -249,7 +277,9 @@ func (d *DebugPrinter) VisitElse(node *ast.Else) interface{} {
 
 // VisitForLoop prints a for loop
 func (d *DebugPrinter) VisitForLoop(node *ast.ForLoop) interface{} {
-	if node.Key != "" {
+	if node.Range == nil {
+		d.print("For: %s; ...; ...", strings.Join(node.Init.Names, ", "))
+	} else if node.Key != "" {
 		d.print("For: %s, %s in ...", node.Key, node.Val)
 	} else {
 		d.print("For: %s in ...", node.Val)
@@ -267,6 +297,12 @@ func (d *DebugPrinter) VisitForLoop(node *ast.ForLoop) interface{} {
 		node.Body.Accept(d)
 		d.indent--
 	}
+	if node.CBody != nil {
+		d.print("Body:")
+		d.indent++
+		node.CBody.Accept(d)
+		d.indent--
+	}
 	d.indent--
 	return nil
 }
@@ -325,6 +361,9 @@ func (d *DebugPrinter) VisitNode(node *ast.Node) interface{} {
 	if node.ChannelRecv != nil {
 		node.ChannelRecv.Accept(d)
 	}
+	if node.Slot != nil {
+		node.Slot.Accept(d)
+	}
 	if node.Element != nil {
 		node.Element.Accept(d)
 	}
@@ -362,6 +401,9 @@ func (d *DebugPrinter) VisitFragment(node *ast.Fragment) interface{} {
 		node.Expr.Accept(d)
 		d.indent--
 	}
+	if node.Format != "" {
+		d.print("Format: %s", node.Format)
+	}
 	return nil
 }
 
@@ -375,6 +417,26 @@ func (d *DebugPrinter) VisitExpr(node *ast.Expr) interface{} {
 	for _, binOp := range node.BinOps {
 		binOp.Accept(d)
 	}
+	if node.Cond != nil {
+		node.Cond.Accept(d)
+	}
+	d.indent--
+	return nil
+}
+
+// VisitCondExpr prints the branches of a conditional expression
+func (d *DebugPrinter) VisitCondExpr(node *ast.CondExpr) interface{} {
+	d.print("Then:")
+	d.indent++
+	if node.Then != nil {
+		node.Then.Accept(d)
+	}
+	d.indent--
+	d.print("Else:")
+	d.indent++
+	if node.Else != nil {
+		node.Else.Accept(d)
+	}
 	d.indent--
 	return nil
 }
@@ -531,6 +593,9 @@ func (d *DebugPrinter) VisitFuncBody(node *ast.FuncBody) interface{} {
 
 // VisitStatement prints a statement
 func (d *DebugPrinter) VisitStatement(node *ast.Statement) interface{} {
+	if node.ConstDecl != nil {
+		node.ConstDecl.Accept(d)
+	}
 	if node.VarDecl != nil {
 		node.VarDecl.Accept(d)
 	}
@@ -554,8 +619,18 @@ func (d *DebugPrinter) VisitStatement(node *ast.Statement) interface{} {
 
 // VisitCompositeLit prints a composite literal
 func (d *DebugPrinter) VisitCompositeLit(node *ast.CompositeLit) interface{} {
-	d.print("CompositeLit: %s{...}", node.Type)
+	switch {
+	case node.SliceOf != nil:
+		d.print("CompositeLit: []%s{...}", node.SliceOf.Name)
+	case node.Map != nil:
+		d.print("CompositeLit: map{...}")
+	default:
+		d.print("CompositeLit: %s{...}", node.Type)
+	}
 	d.indent++
+	for _, elem := range node.Values {
+		elem.Accept(d)
+	}
 	for _, elem := range node.Elements {
 		elem.Accept(d)
 	}
@@ -567,6 +642,30 @@ func (d *DebugPrinter) VisitCompositeLit(node *ast.CompositeLit) interface{} {
 func (d *DebugPrinter) VisitKeyValue(node *ast.KeyValue) interface{} {
 	d.print("Field: %s", node.Key)
 	d.indent++
+	if node.LitKey != nil {
+		node.LitKey.Accept(d)
+	}
+	if node.Value != nil {
+		node.Value.Accept(d)
+	}
+	d.indent--
+	return nil
+}
+
+// VisitLitElem prints an element of a slice or map literal
+func (d *DebugPrinter) VisitLitElem(node *ast.LitElem) interface{} {
+	if node.Key != "" {
+		d.print("Element: %s", node.Key)
+	} else {
+		d.print("Element")
+	}
+	d.indent++
+	if node.LitKey != nil {
+		node.LitKey.Accept(d)
+	}
+	for _, elem := range node.Elements {
+		elem.Accept(d)
+	}
 	if node.Value != nil {
 		node.Value.Accept(d)
 	}
@@ -586,6 +685,12 @@ func (d *DebugPrinter) VisitChannelRecv(node *ast.ChannelRecv) interface{} {
 	return nil
 }
 
+// VisitSlot prints a children slot
+func (d *DebugPrinter) VisitSlot(node *ast.Slot) interface{} {
+	d.print("Slot: {%s}", node.Name)
+	return nil
+}
+
 // VisitIfExpr prints an if expression
 func (d *DebugPrinter) VisitIfExpr(node *ast.IfExpr) interface{} {
 	d.print("IfExpr:")
@@ -602,6 +707,12 @@ func (d *DebugPrinter) VisitIfExpr(node *ast.IfExpr) interface{} {
 		node.TrueBody.Accept(d)
 	}
 	d.indent--
+	if node.ElseIf != nil {
+		d.print("ElseIf:")
+		d.indent++
+		node.ElseIf.Accept(d)
+		d.indent--
+	}
 	if node.FalseBody != nil {
 		d.print("False:")
 		d.indent++
@@ -628,6 +739,16 @@ func (d *DebugPrinter) typeString(t *ast.Type) string {
 		prefix += "*"
 	}
 	name := t.Name
+	if t.Struct != nil {
+		fields := make([]string, len(t.Struct.Fields))
+		for i, field := range t.Struct.Fields {
+			fields[i] = strings.Join(field.Names(), ", ") + " " + d.typeString(field.Type)
+		}
+		name = "struct{" + strings.Join(fields, "; ") + "}"
+	}
+	if t.Map != nil {
+		name = "map[" + d.typeString(t.Map.Key) + "]" + d.typeString(t.Map.Value)
+	}
 	if t.Generic != nil {
 		name = fmt.Sprintf("%s[%s]", name, d.typeString(t.Generic))
 	}
@@ -4,6 +4,7 @@ package visitors
 import (
 	"fmt"
 
+	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/gaarutyunov/guix/pkg/ast"
 )
 
@@ -35,19 +36,50 @@ type SemanticAnalyzer struct {
 	// Current scope for variable tracking
 	scopes []map[string]bool
 
+	// Constants declared in each scope, parallel to scopes
+	constScopes []map[string]bool
+
 	// Component parameters for current component
 	componentParams map[string]bool
 
 	// Hoisted variables for current component
 	hoistedVars map[string]bool
+
+	// Hoisted variables referenced in the current component
+	usedVars map[string]bool
+
+	// Index in scopes of the current component's scope
+	componentScope int
+
+	// Whether the current function is a UI component, whose top-level
+	// variables become struct fields
+	uiComponent bool
+
+	// Channels the generated component listens on: channel parameters and
+	// hoisted make(chan ...) variables
+	reactiveChannels map[string]bool
+
+	// Parameters of the current component receiving its children
+	childrenParams map[string]bool
+
+	// Depth of UI tree nodes being analyzed, reset inside function literals
+	templateDepth int
+
+	// Depth of for loops being analyzed, reset inside function literals
+	loopDepth int
+
+	// Depth of switch and select statements being analyzed, which a break
+	// may leave, reset inside function literals
+	switchDepth int
 }
 
 // NewSemanticAnalyzer creates a new semantic analyzer
 func NewSemanticAnalyzer() *SemanticAnalyzer {
 	return &SemanticAnalyzer{
-		Errors:   make([]*SemanticError, 0),
-		Warnings: make([]*SemanticError, 0),
-		scopes:   []map[string]bool{make(map[string]bool)},
+		Errors:      make([]*SemanticError, 0),
+		Warnings:    make([]*SemanticError, 0),
+		scopes:      []map[string]bool{make(map[string]bool)},
+		constScopes: []map[string]bool{make(map[string]bool)},
 	}
 }
 
@@ -72,15 +104,28 @@ func (s *SemanticAnalyzer) addWarning(pos, message string) {
 	})
 }
 
+// checkShadowsRuntime warns when a declared name collides with a runtime
+// component or prop helper, e.g. a parameter named Class
+func (s *SemanticAnalyzer) checkShadowsRuntime(pos lexer.Position, kind, name string) {
+	if ast.RuntimeComponents[name] {
+		s.addWarning(
+			fmt.Sprintf("%d:%d", pos.Line, pos.Column),
+			fmt.Sprintf("%s %s shadows runtime helper %s", kind, name, name),
+		)
+	}
+}
+
 // pushScope creates a new variable scope
 func (s *SemanticAnalyzer) pushScope() {
 	s.scopes = append(s.scopes, make(map[string]bool))
+	s.constScopes = append(s.constScopes, make(map[string]bool))
 }
 
 // popScope removes the current scope
 func (s *SemanticAnalyzer) popScope() {
 	if len(s.scopes) > 1 {
 		s.scopes = s.scopes[:len(s.scopes)-1]
+		s.constScopes = s.constScopes[:len(s.constScopes)-1]
 	}
 }
 
@@ -88,6 +133,103 @@ func (s *SemanticAnalyzer) popScope() {
 func (s *SemanticAnalyzer) declareVar(name string) {
 	if len(s.scopes) > 0 {
 		s.scopes[len(s.scopes)-1][name] = true
+		delete(s.constScopes[len(s.constScopes)-1], name)
+	}
+}
+
+// declareConst declares a constant in the current scope
+func (s *SemanticAnalyzer) declareConst(name string) {
+	s.declareVar(name)
+	s.constScopes[len(s.constScopes)-1][name] = true
+}
+
+// declareShort declares the names of a := or range clause in the current
+// scope. At the top level of a UI component each name becomes a field of the
+// generated component, so none may be declared twice; in a block or a helper
+// function, as in Go, at least one name must be new.
+func (s *SemanticAnalyzer) declareShort(pos lexer.Position, kind string, names ...string) {
+	position := fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	scope := s.scopes[len(s.scopes)-1]
+	// Top-level variables of a UI component become struct fields, so none
+	// may be redeclared; elsewhere Go's rule applies
+	componentLevel := s.uiComponent && len(s.scopes)-1 == s.componentScope
+
+	redeclared := 0
+	for _, name := range names {
+		if name == "_" || !scope[name] {
+			continue
+		}
+		redeclared++
+		if componentLevel {
+			s.addError(position, fmt.Sprintf("%s redeclared in this block", name))
+		}
+	}
+	if !componentLevel && redeclared > 0 && redeclared == countNamed(names) {
+		s.addError(position, "no new variables on left side of :=")
+	}
+
+	for _, name := range names {
+		if name == "_" {
+			continue
+		}
+		if !scope[name] {
+			s.checkShadowsComponent(position, kind, name)
+		}
+		s.checkShadowsRuntime(pos, kind, name)
+		s.declareVar(name)
+	}
+}
+
+// checkShadowsComponent warns when a block declares a name of a component
+// parameter or hoisted variable. The generated code reads the component's
+// field for that name everywhere, so the block's value would be ignored.
+func (s *SemanticAnalyzer) checkShadowsComponent(position, kind, name string) {
+	if !s.uiComponent || len(s.scopes)-1 == s.componentScope {
+		return
+	}
+	// An enclosing block already shadowing the name was reported there
+	for i := len(s.scopes) - 2; i > s.componentScope; i-- {
+		if s.scopes[i][name] {
+			return
+		}
+	}
+	switch {
+	case s.hoistedVars[name]:
+		s.addWarning(position, fmt.Sprintf("%s %s shadows component variable %s", kind, name, name))
+	case s.componentParams[name]:
+		s.addWarning(position, fmt.Sprintf("%s %s shadows component parameter %s", kind, name, name))
+	}
+}
+
+// countNamed counts the names that aren't the blank identifier
+func countNamed(names []string) int {
+	n := 0
+	for _, name := range names {
+		if name != "_" {
+			n++
+		}
+	}
+	return n
+}
+
+// isConst checks if a name resolves to a constant. The innermost scope
+// declaring the name decides, so a variable can shadow a constant.
+func (s *SemanticAnalyzer) isConst(name string) bool {
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if s.scopes[i][name] {
+			return s.constScopes[i][name]
+		}
+	}
+	return false
+}
+
+// checkConstAssign reports an assignment to a constant
+func (s *SemanticAnalyzer) checkConstAssign(pos lexer.Position, name string) {
+	if s.isConst(name) {
+		s.addError(
+			fmt.Sprintf("%d:%d", pos.Line, pos.Column),
+			fmt.Sprintf("cannot assign to constant: %s", name),
+		)
 	}
 }
 
@@ -110,6 +252,40 @@ func (s *SemanticAnalyzer) isDeclared(name string) bool {
 	return false
 }
 
+// markUsed records a reference to a name, unless a scope inside the
+// component declares it and shadows the hoisted variable
+func (s *SemanticAnalyzer) markUsed(name string) {
+	if s.usedVars == nil || !s.hoistedVars[name] {
+		return
+	}
+	for i := len(s.scopes) - 1; i > s.componentScope; i-- {
+		if s.scopes[i][name] {
+			return
+		}
+	}
+	s.usedVars[name] = true
+}
+
+// checkUnusedVars warns about hoisted variables never referenced in the
+// body. Each becomes a field of the generated component that nothing reads.
+func (s *SemanticAnalyzer) checkUnusedVars(body *ast.Body) {
+	for _, varDecl := range body.VarDecls {
+		for _, name := range varDecl.Names {
+			if name == "_" || s.usedVars[name] {
+				continue
+			}
+			kind := "variable"
+			if s.reactiveChannels[name] {
+				kind = "channel"
+			}
+			s.addWarning(
+				fmt.Sprintf("%d:%d", varDecl.Pos.Line, varDecl.Pos.Column),
+				fmt.Sprintf("%s %s is declared but never used", kind, name),
+			)
+		}
+	}
+}
+
 // VisitFile analyzes a file
 func (s *SemanticAnalyzer) VisitFile(node *ast.File) interface{} {
 	for _, imp := range node.Imports {
@@ -132,8 +308,16 @@ func (s *SemanticAnalyzer) VisitComponent(node *ast.Component) interface{} {
 
 	// Track component parameters
 	s.componentParams = make(map[string]bool)
+	s.reactiveChannels = make(map[string]bool)
+	s.childrenParams = make(map[string]bool)
 	for _, param := range node.Params {
 		s.componentParams[param.Name] = true
+		if param.IsChildren() {
+			s.childrenParams[param.Name] = true
+		}
+		if param.Type != nil && (param.Type.IsChannel || param.Type.IsChan) {
+			s.reactiveChannels[param.Name] = true
+		}
 		param.Accept(s)
 	}
 
@@ -141,25 +325,53 @@ func (s *SemanticAnalyzer) VisitComponent(node *ast.Component) interface{} {
 	if node.Body != nil {
 		// First pass: collect hoisted variables (channels and state)
 		s.hoistedVars = make(map[string]bool)
+		s.usedVars = make(map[string]bool)
+		s.componentScope = len(s.scopes) - 1
+		s.uiComponent = isComponentFunc(node)
 		for _, varDecl := range node.Body.VarDecls {
-			for _, name := range varDecl.Names {
+			for i, name := range varDecl.Names {
 				s.hoistedVars[name] = true
+				if i < len(varDecl.Values) && isMakeChan(varDecl.Values[i]) {
+					s.reactiveChannels[name] = true
+				}
 			}
 		}
 
 		// Second pass: analyze the body
 		node.Body.Accept(s)
+
+		s.checkUnusedVars(node.Body)
 	}
 
 	// Clear component context
 	s.componentParams = nil
 	s.hoistedVars = nil
+	s.usedVars = nil
+	s.reactiveChannels = nil
+	s.childrenParams = nil
+	s.uiComponent = false
 
 	return nil
 }
 
+// isComponentFunc checks if a function is a UI component, returning the
+// Component interface, rather than a helper function
+func isComponentFunc(comp *ast.Component) bool {
+	for _, result := range comp.Results {
+		if result.Name == "Component" {
+			return true
+		}
+	}
+	return false
+}
+
 // VisitBody analyzes a component body
 func (s *SemanticAnalyzer) VisitBody(node *ast.Body) interface{} {
+	// Analyze constant declarations
+	for _, constDecl := range node.ConstDecls {
+		constDecl.Accept(s)
+	}
+
 	// Analyze variable declarations
 	for _, varDecl := range node.VarDecls {
 		varDecl.Accept(s)
@@ -171,25 +383,24 @@ func (s *SemanticAnalyzer) VisitBody(node *ast.Body) interface{} {
 	}
 
 	// Analyze UI tree
+	s.templateDepth++
 	for _, child := range node.Children {
 		child.Accept(s)
 	}
+	s.templateDepth--
 
 	return nil
 }
 
 // VisitVarDecl analyzes a variable declaration
 func (s *SemanticAnalyzer) VisitVarDecl(node *ast.VarDecl) interface{} {
-	// Declare all variables
-	for _, name := range node.Names {
-		s.declareVar(name)
-	}
-
-	// Analyze values
+	// Analyze values before declaring, so they see any outer names
 	for _, val := range node.Values {
 		val.Accept(s)
 	}
 
+	s.declareShort(node.Pos, "variable", node.Names...)
+
 	// Validate: number of names should match number of values (or values is 1 for multi-return)
 	if len(node.Values) != 1 && len(node.Names) != len(node.Values) {
 		s.addError(
@@ -201,8 +412,24 @@ func (s *SemanticAnalyzer) VisitVarDecl(node *ast.VarDecl) interface{} {
 	return nil
 }
 
+// VisitConstDecl analyzes a constant declaration
+func (s *SemanticAnalyzer) VisitConstDecl(node *ast.ConstDecl) interface{} {
+	for _, spec := range node.Specs {
+		if spec.Value != nil {
+			spec.Value.Accept(s)
+		}
+		s.checkShadowsRuntime(spec.Pos, "constant", spec.Name)
+		s.declareConst(spec.Name)
+	}
+	return nil
+}
+
 // VisitAssignment analyzes an assignment
 func (s *SemanticAnalyzer) VisitAssignment(node *ast.Assignment) interface{} {
+	if node.Op != ":=" {
+		s.markUsed(node.Left)
+	}
+
 	// Check if variable is declared (for regular assignments, not :=)
 	if node.Op == "=" || node.Op == "+=" || node.Op == "-=" || node.Op == "*=" || node.Op == "/=" {
 		if !s.isDeclared(node.Left) {
@@ -210,10 +437,12 @@ func (s *SemanticAnalyzer) VisitAssignment(node *ast.Assignment) interface{} {
 				fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column),
 				fmt.Sprintf("undefined variable: %s", node.Left),
 			)
+		} else if len(node.LeftSelector) == 0 {
+			s.checkConstAssign(node.Pos, node.Left)
 		}
 	} else if node.Op == ":=" {
 		// Short declaration - declare the variable
-		s.declareVar(node.Left)
+		s.declareShort(node.Pos, "variable", node.Left)
 	}
 
 	// Analyze right side
@@ -224,23 +453,61 @@ func (s *SemanticAnalyzer) VisitAssignment(node *ast.Assignment) interface{} {
 	return nil
 }
 
+// VisitAssignmentStmt analyzes an assignment statement
+func (s *SemanticAnalyzer) VisitAssignmentStmt(node *ast.AssignmentStmt) interface{} {
+	if node.Op != ":=" {
+		s.markUsed(node.Base)
+	}
+	if node.Op != ":=" && node.Op != "<-" && len(node.Fields) == 0 && node.Index == nil {
+		s.checkConstAssign(node.Pos, node.Base)
+	}
+	if node.Index != nil {
+		node.Index.Accept(s)
+	}
+	if node.Right != nil {
+		node.Right.Accept(s)
+	}
+	return nil
+}
+
 // VisitStatement analyzes a statement
 func (s *SemanticAnalyzer) VisitStatement(node *ast.Statement) interface{} {
+	if node.ConstDecl != nil {
+		node.ConstDecl.Accept(s)
+	}
 	if node.VarDecl != nil {
 		node.VarDecl.Accept(s)
 	}
+	if node.AssignStmt != nil {
+		node.AssignStmt.Accept(s)
+	}
 	if node.Assignment != nil {
 		node.Assignment.Accept(s)
 	}
 	if node.Return != nil {
 		node.Return.Accept(s)
 	}
+	if node.Branch != nil {
+		node.Branch.Accept(s)
+	}
 	if node.If != nil {
 		node.If.Accept(s)
 	}
 	if node.For != nil {
 		node.For.Accept(s)
 	}
+	if node.Switch != nil {
+		node.Switch.Accept(s)
+	}
+	if node.Select != nil {
+		node.Select.Accept(s)
+	}
+	if node.GoStmt != nil {
+		node.GoStmt.Accept(s)
+	}
+	if node.CallStmt != nil {
+		node.CallStmt.Accept(s)
+	}
 	if node.Expr != nil {
 		node.Expr.Accept(s)
 	}
@@ -291,20 +558,48 @@ func (s *SemanticAnalyzer) VisitForLoop(node *ast.ForLoop) interface{} {
 
 	// Analyze body in new scope with loop variables
 	s.pushScope()
-	if node.Key != "" {
-		s.declareVar(node.Key)
-	}
 	if node.Val != "" {
-		s.declareVar(node.Val)
+		names := []string{node.Val}
+		if node.Key != "" {
+			names = []string{node.Key, node.Val}
+		}
+		s.declareShort(node.Pos, "loop variable", names...)
+	}
+	if node.Init != nil {
+		node.Init.Accept(s)
+	}
+	if node.Cond != nil {
+		node.Cond.Accept(s)
 	}
+	if node.Post != nil {
+		node.Post.Accept(s)
+	}
+	s.loopDepth++
 	if node.Body != nil {
 		node.Body.Accept(s)
 	}
+	if node.CBody != nil {
+		node.CBody.Accept(s)
+	}
+	s.loopDepth--
 	s.popScope()
 
 	return nil
 }
 
+// VisitBranchStmt reports a continue statement outside a for loop and a
+// break statement outside a for loop, switch or select
+func (s *SemanticAnalyzer) VisitBranchStmt(node *ast.BranchStmt) interface{} {
+	position := fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column)
+	switch {
+	case node.Tok == "continue" && s.loopDepth == 0:
+		s.addError(position, "continue is not in a loop")
+	case node.Tok == "break" && s.loopDepth == 0 && s.switchDepth == 0:
+		s.addError(position, "break is not in a loop, switch, or select")
+	}
+	return nil
+}
+
 // VisitExpr analyzes an expression
 func (s *SemanticAnalyzer) VisitExpr(node *ast.Expr) interface{} {
 	if node.Left != nil {
@@ -313,6 +608,21 @@ func (s *SemanticAnalyzer) VisitExpr(node *ast.Expr) interface{} {
 	for _, binOp := range node.BinOps {
 		binOp.Accept(s)
 	}
+	if node.Cond != nil {
+		node.Cond.Accept(s)
+	}
+	return nil
+}
+
+// VisitCondExpr analyzes both branches of a conditional expression; the
+// condition is analyzed as the rest of its expression
+func (s *SemanticAnalyzer) VisitCondExpr(node *ast.CondExpr) interface{} {
+	if node.Then != nil {
+		node.Then.Accept(s)
+	}
+	if node.Else != nil {
+		node.Else.Accept(s)
+	}
 	return nil
 }
 
@@ -320,6 +630,8 @@ func (s *SemanticAnalyzer) VisitExpr(node *ast.Expr) interface{} {
 func (s *SemanticAnalyzer) VisitPrimary(node *ast.Primary) interface{} {
 	// Check identifier usage
 	if node.Ident != "" {
+		s.markUsed(node.Ident)
+
 		// Check if it's a known identifier (variable, parameter, etc.)
 		// We'll be lenient here and not error on unknown identifiers
 		// as they might be component names or Go built-ins
@@ -339,6 +651,9 @@ func (s *SemanticAnalyzer) VisitPrimary(node *ast.Primary) interface{} {
 	if node.CompositeLit != nil {
 		node.CompositeLit.Accept(s)
 	}
+	if node.IndexExpr != nil {
+		node.IndexExpr.Accept(s)
+	}
 	if node.MakeCall != nil {
 		node.MakeCall.Accept(s)
 	}
@@ -361,6 +676,7 @@ func (s *SemanticAnalyzer) VisitPrimary(node *ast.Primary) interface{} {
 // VisitCallOrSelect analyzes a call or selector
 func (s *SemanticAnalyzer) VisitCallOrSelect(node *ast.CallOrSelect) interface{} {
 	// Check base identifier
+	s.markUsed(node.Base)
 	if node.Base != "" && !s.isDeclared(node.Base) {
 		// Might be a component, built-in, or imported identifier
 		// Don't error
@@ -380,6 +696,11 @@ func (s *SemanticAnalyzer) VisitFuncLit(node *ast.FuncLit) interface{} {
 	s.pushScope()
 	defer s.popScope()
 
+	// A handler body runs on events, not as part of the template
+	templateDepth, loopDepth, switchDepth := s.templateDepth, s.loopDepth, s.switchDepth
+	s.templateDepth, s.loopDepth, s.switchDepth = 0, 0, 0
+	defer func() { s.templateDepth, s.loopDepth, s.switchDepth = templateDepth, loopDepth, switchDepth }()
+
 	// Declare parameters
 	for _, param := range node.Params {
 		s.declareVar(param.Name)
@@ -404,16 +725,39 @@ func (s *SemanticAnalyzer) VisitFuncBody(node *ast.FuncBody) interface{} {
 
 // VisitChannelOp analyzes a channel operation
 func (s *SemanticAnalyzer) VisitChannelOp(node *ast.ChannelOp) interface{} {
+	s.markUsed(node.Channel)
+
 	// Check if channel variable is declared
 	if !s.isDeclared(node.Channel) {
 		s.addError(
 			fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column),
 			fmt.Sprintf("undefined channel: %s", node.Channel),
 		)
+		return nil
 	}
+	s.checkReactiveChannel(node.Pos, node.Channel)
 	return nil
 }
 
+// checkReactiveChannel warns when a template receives from a channel the
+// component doesn't listen on. The receive then renders a zero value that
+// never updates, which is usually a typo in the channel name.
+func (s *SemanticAnalyzer) checkReactiveChannel(pos lexer.Position, name string) {
+	if s.templateDepth == 0 || s.reactiveChannels == nil || s.reactiveChannels[name] {
+		return
+	}
+	s.addWarning(
+		fmt.Sprintf("%d:%d", pos.Line, pos.Column),
+		fmt.Sprintf("receive from %s in template is not reactive: it is not a channel parameter or make(chan) variable", name),
+	)
+}
+
+// isMakeChan reports whether an expression is a make(chan T) call
+func isMakeChan(expr *ast.Expr) bool {
+	return expr != nil && len(expr.BinOps) == 0 && expr.Left != nil &&
+		expr.Left.MakeCall != nil && expr.Left.MakeCall.ChanType != nil
+}
+
 // Remaining visitor methods delegate to BaseVisitor for traversal
 func (s *SemanticAnalyzer) VisitBinaryOp(node *ast.BinaryOp) interface{} {
 	if node.Right != nil {
@@ -430,9 +774,22 @@ func (s *SemanticAnalyzer) VisitUnaryExpr(node *ast.UnaryExpr) interface{} {
 }
 
 func (s *SemanticAnalyzer) VisitCompositeLit(node *ast.CompositeLit) interface{} {
+	for _, elem := range node.Values {
+		elem.Accept(s)
+	}
+	for _, elem := range node.Elements {
+		elem.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitLitElem(node *ast.LitElem) interface{} {
 	for _, elem := range node.Elements {
 		elem.Accept(s)
 	}
+	if node.Value != nil {
+		node.Value.Accept(s)
+	}
 	return nil
 }
 
@@ -454,6 +811,28 @@ func (s *SemanticAnalyzer) VisitElement(node *ast.Element) interface{} {
 }
 
 func (s *SemanticAnalyzer) VisitProp(node *ast.Prop) interface{} {
+	// Bind(field) must target a declared variable or parameter
+	if target := node.BindTarget(); target != nil {
+		var base string
+		if target.Left != nil && target.Left.CallOrSel != nil && !target.Left.CallOrSel.HasParens {
+			base = target.Left.CallOrSel.Base
+		} else if target.Left != nil && target.Left.Ident != "" {
+			base = target.Left.Ident
+		}
+
+		if base == "" {
+			s.addError(
+				fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column),
+				"Bind target must be a variable or field",
+			)
+		} else if !s.isDeclared(base) {
+			s.addError(
+				fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column),
+				fmt.Sprintf("undefined bind target: %s", base),
+			)
+		}
+	}
+
 	for _, arg := range node.Args {
 		if arg != nil {
 			arg.Accept(s)
@@ -478,6 +857,9 @@ func (s *SemanticAnalyzer) VisitNode(node *ast.Node) interface{} {
 	if node.ChannelRecv != nil {
 		node.ChannelRecv.Accept(s)
 	}
+	if node.Slot != nil {
+		node.Slot.Accept(s)
+	}
 	if node.Element != nil {
 		node.Element.Accept(s)
 	}
@@ -498,6 +880,12 @@ func (s *SemanticAnalyzer) VisitFragment(node *ast.Fragment) interface{} {
 	if node.Expr != nil {
 		node.Expr.Accept(s)
 	}
+	if !node.Format.Valid() {
+		s.addError(
+			fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column),
+			fmt.Sprintf("unknown format %q: expected a fmt verb like .2f or 05d, time or date", node.Format),
+		)
+	}
 	return nil
 }
 
@@ -510,6 +898,9 @@ func (s *SemanticAnalyzer) VisitIfExpr(node *ast.IfExpr) interface{} {
 		node.TrueBody.Accept(s)
 		s.popScope()
 	}
+	if node.ElseIf != nil {
+		node.ElseIf.Accept(s)
+	}
 	if node.FalseBody != nil {
 		s.pushScope()
 		node.FalseBody.Accept(s)
@@ -529,31 +920,183 @@ func (s *SemanticAnalyzer) VisitBodyStatement(node *ast.BodyStatement) interface
 	if node.VarDecl != nil {
 		node.VarDecl.Accept(s)
 	}
+	if node.AssignStmt != nil {
+		node.AssignStmt.Accept(s)
+	}
 	if node.Assignment != nil {
 		node.Assignment.Accept(s)
 	}
 	if node.Return != nil {
 		node.Return.Accept(s)
 	}
+	if node.Branch != nil {
+		node.Branch.Accept(s)
+	}
 	if node.If != nil {
 		node.If.Accept(s)
 	}
 	if node.For != nil {
 		node.For.Accept(s)
 	}
+	if node.Switch != nil {
+		node.Switch.Accept(s)
+	}
+	if node.Select != nil {
+		node.Select.Accept(s)
+	}
+	if node.GoStmt != nil {
+		node.GoStmt.Accept(s)
+	}
+	if node.CallStmt != nil {
+		node.CallStmt.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitCallStmt(node *ast.CallStmt) interface{} {
+	s.markUsed(string(node.Base))
+	for _, arg := range node.Args {
+		arg.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitGoStmt(node *ast.GoStmt) interface{} {
+	if node.Func != nil {
+		node.Func.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitSwitchStmt(node *ast.SwitchStmt) interface{} {
+	if node.Expr != nil {
+		node.Expr.Accept(s)
+	}
+	s.switchDepth++
+	for _, caseClause := range node.Cases {
+		caseClause.Accept(s)
+	}
+	s.switchDepth--
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitCaseClause(node *ast.CaseClause) interface{} {
+	for _, val := range node.Values {
+		val.Accept(s)
+	}
+	s.pushScope()
+	defer s.popScope()
+	for _, stmt := range node.Statements {
+		stmt.Accept(s)
+	}
+	for _, stmt := range node.DefStmts {
+		stmt.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitSelectStmt(node *ast.SelectStmt) interface{} {
+	s.switchDepth++
+	for _, commClause := range node.Cases {
+		commClause.Accept(s)
+	}
+	s.switchDepth--
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitCommClause(node *ast.CommClause) interface{} {
+	s.pushScope()
+	defer s.popScope()
+	if node.Comm != nil {
+		node.Comm.Accept(s)
+	}
+	for _, stmt := range node.Statements {
+		stmt.Accept(s)
+	}
+	for _, stmt := range node.DefStmts {
+		stmt.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitCommCase(node *ast.CommCase) interface{} {
+	if node.Send != nil {
+		node.Send.Accept(s)
+	}
+	if node.Recv != nil {
+		node.Recv.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitSendStmt(node *ast.SendStmt) interface{} {
+	s.markUsed(node.Channel)
+	if node.Value != nil {
+		node.Value.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitRecvStmt(node *ast.RecvStmt) interface{} {
+	s.markUsed(node.Channel)
+	for _, name := range node.Names {
+		s.declareVar(name)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitIndexExpr(node *ast.IndexExpr) interface{} {
+	s.markUsed(node.Base)
+	if node.Index != nil {
+		node.Index.Accept(s)
+	}
+	if node.Slice != nil {
+		node.Slice.Accept(s)
+	}
+	return nil
+}
+
+func (s *SemanticAnalyzer) VisitSliceExpr(node *ast.SliceExpr) interface{} {
+	if node.Low != nil {
+		node.Low.Accept(s)
+	}
+	if node.High != nil {
+		node.High.Accept(s)
+	}
 	return nil
 }
 
 func (s *SemanticAnalyzer) VisitMakeCall(node *ast.MakeCall) interface{} {
-	return s.BaseVisitor.VisitMakeCall(node)
+	for _, size := range []*ast.Expr{node.ChanSize, node.SliceLen, node.SliceCap} {
+		if size != nil {
+			size.Accept(s)
+		}
+	}
+	return nil
 }
 
 func (s *SemanticAnalyzer) VisitChannelRecv(node *ast.ChannelRecv) interface{} {
-	return s.BaseVisitor.VisitChannelRecv(node)
+	s.markUsed(node.Channel)
+	s.checkReactiveChannel(node.Pos, node.Channel)
+	return nil
+}
+
+// VisitSlot checks that a slot names the children parameter of the component
+func (s *SemanticAnalyzer) VisitSlot(node *ast.Slot) interface{} {
+	if !s.childrenParams[node.Name] {
+		s.addError(
+			fmt.Sprintf("%d:%d", node.Pos.Line, node.Pos.Column),
+			fmt.Sprintf("{%s} is not a Children parameter of the component", node.Name),
+		)
+	}
+	return nil
 }
 
 func (s *SemanticAnalyzer) VisitExprStmt(node *ast.ExprStmt) interface{} {
-	return s.BaseVisitor.VisitExprStmt(node)
+	if node.Expr != nil {
+		node.Expr.Accept(s)
+	}
+	return nil
 }
 
 func (s *SemanticAnalyzer) VisitImport(node *ast.Import) interface{} {
@@ -561,18 +1104,28 @@ func (s *SemanticAnalyzer) VisitImport(node *ast.Import) interface{} {
 }
 
 func (s *SemanticAnalyzer) VisitTypeDef(node *ast.TypeDef) interface{} {
-	return s.BaseVisitor.VisitTypeDef(node)
+	if node.Struct != nil {
+		node.Struct.Accept(s)
+	}
+	return nil
 }
 
 func (s *SemanticAnalyzer) VisitStructType(node *ast.StructType) interface{} {
-	return s.BaseVisitor.VisitStructType(node)
+	for _, field := range node.Fields {
+		field.Accept(s)
+	}
+	return nil
 }
 
 func (s *SemanticAnalyzer) VisitStructField(node *ast.StructField) interface{} {
+	for _, name := range node.Names() {
+		s.checkShadowsRuntime(node.Pos, "struct field", name)
+	}
 	return s.BaseVisitor.VisitStructField(node)
 }
 
 func (s *SemanticAnalyzer) VisitParameter(node *ast.Parameter) interface{} {
+	s.checkShadowsRuntime(node.Pos, "parameter", node.Name)
 	return s.BaseVisitor.VisitParameter(node)
 }
 
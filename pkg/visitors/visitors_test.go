@@ -180,6 +180,185 @@ func TestSemanticAnalyzer_Scoping(t *testing.T) {
 	}
 }
 
+func TestSemanticAnalyzer_ConstReassignment(t *testing.T) {
+	numVal := "1"
+	one := &ast.Expr{Left: &ast.Primary{Literal: &ast.Literal{Number: &numVal}}}
+	comp := &ast.Component{
+		Name: "Test",
+		Body: &ast.Body{
+			ConstDecls: []*ast.ConstDecl{
+				{Specs: []*ast.ConstSpec{{Name: "limit", Value: one}}},
+			},
+			Statements: []*ast.BodyStatement{
+				{
+					// Reading the constant is fine
+					VarDecl: &ast.VarDecl{
+						Names:  []string{"x"},
+						Op:     ":=",
+						Values: []*ast.Expr{{Left: &ast.Primary{Ident: "limit"}}},
+					},
+				},
+				{
+					// This should error - limit is a constant
+					AssignStmt: &ast.AssignmentStmt{
+						Base:  "limit",
+						Op:    "+=",
+						Right: one,
+					},
+				},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	if len(analyzer.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+
+	if !strings.Contains(analyzer.Errors[0].Message, "cannot assign to constant: limit") {
+		t.Errorf("Expected 'cannot assign to constant: limit', got '%s'", analyzer.Errors[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_ParamShadowsRuntimeHelper(t *testing.T) {
+	comp := &ast.Component{
+		Name: "Test",
+		Params: []*ast.Parameter{
+			{Name: "Class", Type: &ast.Type{Name: "string"}},
+			{Name: "label", Type: &ast.Type{Name: "string"}},
+		},
+		Body: &ast.Body{},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	if analyzer.HasErrors() {
+		t.Errorf("Expected no errors, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+
+	if len(analyzer.Warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %v", len(analyzer.Warnings), analyzer.Warnings)
+	}
+
+	if !strings.Contains(analyzer.Warnings[0].Message, "parameter Class shadows runtime helper Class") {
+		t.Errorf("Expected shadowing warning, got '%s'", analyzer.Warnings[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_StructFieldShadowsRuntimeHelper(t *testing.T) {
+	file := &ast.File{
+		Types: []*ast.TypeDef{
+			{
+				Name: "Item",
+				Struct: &ast.StructType{
+					Fields: []*ast.StructField{
+						{Name: "OnClick", Type: &ast.Type{Name: "string"}},
+					},
+				},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	file.Accept(analyzer)
+
+	if len(analyzer.Warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %v", len(analyzer.Warnings), analyzer.Warnings)
+	}
+
+	if !strings.Contains(analyzer.Warnings[0].Message, "struct field OnClick") {
+		t.Errorf("Expected struct field warning, got '%s'", analyzer.Warnings[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_BindUndeclaredTarget(t *testing.T) {
+	// Input(Bind(state.Display)) where state is never declared
+	comp := &ast.Component{
+		Name: "Test",
+		Body: &ast.Body{
+			Children: []*ast.Node{
+				{
+					Element: &ast.Element{
+						Tag: "Input",
+						Props: []*ast.Prop{
+							{
+								Name:      "Bind",
+								HasParens: true,
+								Args: []*ast.Expr{
+									{
+										Left: &ast.Primary{
+											CallOrSel: &ast.CallOrSelect{
+												Base:   "state",
+												Fields: []string{"Display"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	if len(analyzer.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+
+	if !strings.Contains(analyzer.Errors[0].Message, "undefined bind target: state") {
+		t.Errorf("Expected 'undefined bind target: state', got '%s'", analyzer.Errors[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_BindParameter(t *testing.T) {
+	// Input(Bind(&name)) where name is a component parameter
+	comp := &ast.Component{
+		Name: "Test",
+		Params: []*ast.Parameter{
+			{Name: "name", Type: &ast.Type{Name: "string"}},
+		},
+		Body: &ast.Body{
+			Children: []*ast.Node{
+				{
+					Element: &ast.Element{
+						Tag: "Input",
+						Props: []*ast.Prop{
+							{
+								Name:      "Bind",
+								HasParens: true,
+								Args: []*ast.Expr{
+									{
+										Left: &ast.Primary{
+											Unary: &ast.UnaryExpr{
+												Op:    "&",
+												Right: &ast.Primary{Ident: "name"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	if analyzer.HasErrors() {
+		t.Errorf("Expected no errors, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+}
+
 func TestDebugPrinter_SimpleComponent(t *testing.T) {
 	// Create a simple component with Component return type
 	comp := &ast.Component{
@@ -282,3 +461,431 @@ func TestDebugPrinter_Expression(t *testing.T) {
 		t.Errorf("Expected 'Number: 3' in output, got:\n%s", output)
 	}
 }
+
+func TestDebugPrinter_ElseIfChain(t *testing.T) {
+	// if a { Span } else if b { Button } else { }
+	ifExpr := &ast.IfExpr{
+		Cond:     &ast.Expr{Left: &ast.Primary{Ident: "a"}},
+		TrueBody: &ast.Body{Children: []*ast.Node{{Element: &ast.Element{Tag: "Span"}}}},
+		ElseIf: &ast.IfExpr{
+			Cond:      &ast.Expr{Left: &ast.Primary{Ident: "b"}},
+			TrueBody:  &ast.Body{Children: []*ast.Node{{Element: &ast.Element{Tag: "Button"}}}},
+			FalseBody: &ast.Body{},
+		},
+	}
+
+	printer := NewDebugPrinter()
+	ifExpr.Accept(printer)
+
+	output := printer.String()
+
+	for _, expected := range []string{"ElseIf:", "Element: Span", "Element: Button", "False:"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected '%s' in output, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestSemanticAnalyzer_NonReactiveChannelReceive(t *testing.T) {
+	recv := func(channel string) *ast.Expr {
+		return &ast.Expr{Left: &ast.Primary{ChannelOp: &ast.ChannelOp{Op: "<-", Channel: channel}}}
+	}
+	comp := &ast.Component{
+		Name: "Test",
+		Params: []*ast.Parameter{
+			{Name: "counter", Type: &ast.Type{Name: "int", IsChan: true}},
+			{Name: "label", Type: &ast.Type{Name: "string"}},
+		},
+		Body: &ast.Body{
+			VarDecls: []*ast.VarDecl{
+				{
+					Names:  []string{"ticks"},
+					Op:     ":=",
+					Values: []*ast.Expr{{Left: &ast.Primary{MakeCall: &ast.MakeCall{Func: "make", ChanType: &ast.Type{Name: "int"}}}}},
+				},
+			},
+			Children: []*ast.Node{
+				// Channel parameters and make(chan) variables are listened on
+				{ChannelRecv: &ast.ChannelRecv{Channel: "counter"}},
+				{Template: &ast.Template{Fragments: []*ast.Fragment{{Expr: recv("ticks")}}}},
+				// A typo'd channel name would render a zero value
+				{ChannelRecv: &ast.ChannelRecv{Channel: "countr"}},
+				// label is declared, but not a channel
+				{Template: &ast.Template{Fragments: []*ast.Fragment{{Expr: recv("label")}}}},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	if len(analyzer.Errors) != 0 {
+		t.Fatalf("Expected no errors, got %v", analyzer.Errors)
+	}
+	if len(analyzer.Warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %v", len(analyzer.Warnings), analyzer.Warnings)
+	}
+	for i, name := range []string{"countr", "label"} {
+		if !strings.Contains(analyzer.Warnings[i].Message, "receive from "+name+" in template is not reactive") {
+			t.Errorf("Expected non-reactive warning for %s, got '%s'", name, analyzer.Warnings[i].Message)
+		}
+	}
+}
+
+func TestSemanticAnalyzer_CondExpr(t *testing.T) {
+	recv := func(name string) *ast.Expr {
+		return &ast.Expr{Left: &ast.Primary{ChannelOp: &ast.ChannelOp{Op: "<-", Channel: name}}}
+	}
+	comp := &ast.Component{
+		Name: "Test",
+		Params: []*ast.Parameter{
+			{Name: "ready", Type: &ast.Type{Name: "bool", IsChan: true}},
+		},
+		Body: &ast.Body{
+			Children: []*ast.Node{
+				{Template: &ast.Template{Fragments: []*ast.Fragment{{Expr: &ast.Expr{
+					Left: &ast.Primary{ChannelOp: &ast.ChannelOp{Op: "<-", Channel: "ready"}},
+					Cond: &ast.CondExpr{Then: recv("yes"), Else: recv("no")},
+				}}}}},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	// The condition is valid; both branches are analyzed
+	if len(analyzer.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+	for i, name := range []string{"yes", "no"} {
+		if !strings.Contains(analyzer.Errors[i].Message, "undefined channel: "+name) {
+			t.Errorf("Expected undefined channel error for %s, got '%s'", name, analyzer.Errors[i].Message)
+		}
+	}
+}
+
+func TestSemanticAnalyzer_BranchOutsideLoop(t *testing.T) {
+	zero := "0"
+	branch := func(tok string) *ast.BodyStatement {
+		return &ast.BodyStatement{Branch: &ast.BranchStmt{Tok: tok}}
+	}
+	comp := &ast.Component{
+		Name: "Test",
+		Body: &ast.Body{
+			Statements: []*ast.BodyStatement{
+				branch("break"),
+				{For: &ast.ForLoop{
+					Init: &ast.VarDecl{Names: []string{"i"}, Op: ":=", Values: []*ast.Expr{
+						{Left: &ast.Primary{Literal: &ast.Literal{Number: &zero}}},
+					}},
+					CBody: &ast.Body{Statements: []*ast.BodyStatement{branch("continue")}},
+				}},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	// Only the break outside the loop is rejected
+	if len(analyzer.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+	if !strings.Contains(analyzer.Errors[0].Message, "break is not in a loop") {
+		t.Errorf("Expected 'break is not in a loop', got '%s'", analyzer.Errors[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_BreakInSwitchAndSelect(t *testing.T) {
+	source := `package main
+
+func pick(n int, done chan bool) (int) {
+	switch n {
+	case 1:
+		break
+	default:
+		continue
+	}
+	select {
+	case <-done:
+		break
+	default:
+	}
+	break
+	return n
+}`
+
+	_, diags, err := Analyze(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A break may leave a switch or select; a continue needs a loop
+	want := []Diagnostic{
+		{Severity: SeverityError, Position: "8:3", Message: "continue is not in a loop"},
+		{Severity: SeverityError, Position: "15:2", Message: "break is not in a loop, switch, or select"},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(want), len(diags), diags)
+	}
+	for i := range want {
+		if diags[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], diags[i])
+		}
+	}
+}
+
+func TestSemanticAnalyzer_SlotWithoutChildrenParam(t *testing.T) {
+	slot := func(name string) *ast.Node {
+		return &ast.Node{Slot: &ast.Slot{Name: name}}
+	}
+	comp := &ast.Component{
+		Name: "Card",
+		Params: []*ast.Parameter{
+			{Name: "title", Type: &ast.Type{Name: "string"}},
+			{Name: "children", Type: &ast.Type{Name: "Children"}},
+		},
+		Body: &ast.Body{
+			Children: []*ast.Node{slot("children"), slot("title")},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	// Only the slot naming a string parameter is rejected
+	if len(analyzer.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+	if !strings.Contains(analyzer.Errors[0].Message, "{title} is not a Children parameter") {
+		t.Errorf("Expected '{title} is not a Children parameter', got '%s'", analyzer.Errors[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_TemplateFormatSpec(t *testing.T) {
+	fragment := func(format ast.FormatSpec) *ast.Fragment {
+		return &ast.Fragment{Expr: &ast.Expr{Left: &ast.Primary{Ident: "price"}}, Format: format}
+	}
+	comp := &ast.Component{
+		Name: "Receipt",
+		Body: &ast.Body{
+			Children: []*ast.Node{{Template: &ast.Template{Fragments: []*ast.Fragment{
+				fragment(".2f"), fragment("05d"), fragment("time"), fragment(""), fragment("money"),
+			}}}},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	// Only the unknown format is rejected
+	if len(analyzer.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(analyzer.Errors), analyzer.Errors)
+	}
+	if !strings.Contains(analyzer.Errors[0].Message, `unknown format "money"`) {
+		t.Errorf("Expected 'unknown format \"money\"', got '%s'", analyzer.Errors[0].Message)
+	}
+}
+
+func TestSemanticAnalyzer_UnusedVariables(t *testing.T) {
+	makeChan := &ast.Expr{Left: &ast.Primary{MakeCall: &ast.MakeCall{Func: "make", ChanType: &ast.Type{Name: "int"}}}}
+	comp := &ast.Component{
+		Name: "Counter",
+		Body: &ast.Body{
+			VarDecls: []*ast.VarDecl{
+				{Names: []string{"clicks"}, Op: ":=", Values: []*ast.Expr{makeChan}},
+				{Names: []string{"resets"}, Op: ":=", Values: []*ast.Expr{makeChan}},
+				{Names: []string{"_"}, Op: "=", Values: []*ast.Expr{makeChan}},
+			},
+			Children: []*ast.Node{
+				{ChannelRecv: &ast.ChannelRecv{Channel: "clicks"}},
+				// The handler's parameter shadows resets, so it doesn't use it
+				{Element: &ast.Element{Tag: "Button", Props: []*ast.Prop{{
+					Name:      "OnClick",
+					HasParens: true,
+					Args: []*ast.Expr{{Left: &ast.Primary{FuncLit: &ast.FuncLit{
+						Params: []*ast.Parameter{{Name: "resets", Type: &ast.Type{Name: "int"}}},
+						Body: &ast.FuncBody{Statements: []*ast.Statement{
+							{Expr: &ast.Expr{Left: &ast.Primary{Ident: "resets"}}},
+						}},
+					}}}},
+				}}}},
+			},
+		},
+	}
+
+	analyzer := NewSemanticAnalyzer()
+	comp.Accept(analyzer)
+
+	if len(analyzer.Errors) != 0 {
+		t.Fatalf("Expected no errors, got %v", analyzer.Errors)
+	}
+	if len(analyzer.Warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %v", len(analyzer.Warnings), analyzer.Warnings)
+	}
+	if !strings.Contains(analyzer.Warnings[0].Message, "channel resets is declared but never used") {
+		t.Errorf("Expected unused channel warning for resets, got '%s'", analyzer.Warnings[0].Message)
+	}
+}
+
+func TestAnalyze_UndefinedChannel(t *testing.T) {
+	source := `package main
+
+func Counter() (Component) {
+	Div {
+		` + "`{<-clicks}`" + `
+	}
+}`
+
+	dump, diags, err := Analyze(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(dump, "Component: Counter") {
+		t.Errorf("Expected the AST dump to contain the component, got:\n%s", dump)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	want := Diagnostic{Severity: SeverityError, Position: "5:5", Message: "undefined channel: clicks"}
+	if diags[0] != want {
+		t.Errorf("Expected %v, got %v", want, diags[0])
+	}
+}
+
+func TestAnalyze_SyntaxError(t *testing.T) {
+	dump, diags, err := Analyze(strings.NewReader("package main\n\nfunc {"))
+	if err == nil {
+		t.Fatal("Expected a syntax error, got none")
+	}
+	if dump != "" || diags != nil {
+		t.Errorf("Expected no dump or diagnostics, got %q, %v", dump, diags)
+	}
+}
+
+func TestSemanticAnalyzer_ShadowingInIfBlock(t *testing.T) {
+	source := `package main
+
+func Greeting(show bool) (Component) {
+	name := "Ada"
+	Div {
+		if show {
+			name := "Grace"
+			Span { ` + "`{name}`" + ` }
+		}
+	}
+}`
+
+	_, diags, err := Analyze(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := Diagnostic{Severity: SeverityWarning, Position: "7:4", Message: "variable name shadows component variable name"}
+	if len(diags) == 0 || diags[0] != want {
+		t.Fatalf("Expected %v first, got %v", want, diags)
+	}
+	for _, diag := range diags {
+		if diag.Severity == SeverityError {
+			t.Errorf("Expected shadowing in a block not to be an error, got %v", diag)
+		}
+	}
+}
+
+func TestSemanticAnalyzer_DuplicateShortDecl(t *testing.T) {
+	source := `package main
+
+func Form(show bool) (Component) {
+	count := 0
+	count := 1
+	Div {
+		if show {
+			a, b := 1, 2
+			a, b := 3, 4
+			a, c := 5, 6
+			Span { ` + "`{a} {b} {c} {count}`" + ` }
+		}
+	}
+}`
+
+	_, diags, err := Analyze(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Redeclaring a, b is an error; a, c declares the new name c
+	want := []Diagnostic{
+		{Severity: SeverityError, Position: "5:2", Message: "count redeclared in this block"},
+		{Severity: SeverityError, Position: "9:4", Message: "no new variables on left side of :="},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(want), len(diags), diags)
+	}
+	for i := range want {
+		if diags[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], diags[i])
+		}
+	}
+}
+
+func TestSemanticAnalyzer_HelperShortDecl(t *testing.T) {
+	source := `package main
+
+import "strconv"
+
+func sum(a string, b string) (int) {
+	x, err := strconv.Atoi(a)
+	y, err := strconv.Atoi(b)
+	if err != nil {
+		return 0
+	}
+	x, y := 1, 2
+	return x + y
+}`
+
+	_, diags, err := Analyze(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A helper function's variables aren't fields, so y, err declares y
+	want := []Diagnostic{
+		{Severity: SeverityError, Position: "11:2", Message: "no new variables on left side of :="},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(want), len(diags), diags)
+	}
+	for i := range want {
+		if diags[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], diags[i])
+		}
+	}
+}
+
+func TestSemanticAnalyzer_LoopVariableShadowing(t *testing.T) {
+	source := `package main
+
+func List(items []string, label string) (Component) {
+	Ul {
+		` + "`{label}`" + `
+		for i, label := range items {
+			Li { ` + "`{i} {label}`" + ` }
+		}
+		for _, item := range items {
+			Li { ` + "`{item}`" + ` }
+		}
+	}
+}`
+
+	_, diags, err := Analyze(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := Diagnostic{Severity: SeverityWarning, Position: "6:3", Message: "loop variable label shadows component parameter label"}
+	if len(diags) != 1 || diags[0] != want {
+		t.Errorf("Expected only %v, got %v", want, diags)
+	}
+}